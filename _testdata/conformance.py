@@ -0,0 +1,134 @@
+#!/usr/bin/env python3
+
+# Generates conformance.json, a fixed set of test cases for the division,
+# integer division, remainder, quantization, and comparison operations
+# computed by CPython's decimal module. The output is checked in so the tests
+# do not depend on Python.
+#
+# Usage: ./conformance.py > conformance.json
+
+from decimal import *
+import json
+import random
+import sys
+
+random.seed(206)
+
+modes = {
+    "=0": ROUND_HALF_EVEN,
+    "=^": ROUND_HALF_UP,
+    "0": ROUND_DOWN,
+    "<": ROUND_FLOOR,
+    ">": ROUND_CEILING,
+    "^": ROUND_UP,
+}
+
+flags = {
+    Clamped: "c",
+    DivisionByZero: "z",
+    Inexact: "x",
+    InvalidOperation: "i",
+    Overflow: "o",
+    Rounded: "r",
+    Subnormal: "s",
+    Underflow: "u",
+}
+
+ops = {
+    "quo": lambda ctx, x, y: ctx.divide(x, y),
+    "quoint": lambda ctx, x, y: ctx.divide_int(x, y),
+    "rem": lambda ctx, x, y: ctx.remainder(x, y),
+    "quant": lambda ctx, x, y: ctx.quantize(x, Decimal((0, (1,), -y))),
+    "cmp": lambda ctx, x, y: ctx.compare(x, y),
+}
+
+
+def rand_digits(n):
+    s = str(random.randint(1, 9))
+    for _ in range(n - 1):
+        s += str(random.randint(0, 9))
+    return s
+
+
+def rand_dec(prec):
+    """Returns a random finite decimal whose length is close to prec."""
+    r = random.randint(0, 30)
+    if r == 0:
+        return random.choice(["0", "-0", "0E+3", "0.000"])
+    if r == 1:
+        return random.choice(["Inf", "-Inf", "NaN", "sNaN"])
+    n = max(1, prec + random.randint(-2, 2))
+    s = rand_digits(n)
+    if random.randint(0, 1) == 0:
+        s = "-" + s
+    return "{}E{}".format(s, random.randint(-n - 3, 3))
+
+
+def near_limit(prec):
+    """Returns a pair of decimals whose integer quotient is close to prec
+    digits long."""
+    y = rand_digits(random.randint(1, 4))
+    q = rand_digits(max(1, prec + random.randint(-1, 1)))
+    r = random.randint(0, int(y) - 1)
+    x = int(q) * int(y) + r
+    xe = random.randint(-2, 2)
+    ye = random.randint(-2, 2)
+    xs = "{}E{}".format(x, xe)
+    ys = "{}E{}".format(y, ye)
+    if random.randint(0, 3) == 0:
+        xs = "-" + xs
+    if random.randint(0, 3) == 0:
+        ys = "-" + ys
+    return xs, ys
+
+
+def conv(r):
+    if r.is_infinite():
+        return "-Inf" if r.is_signed() else "Inf"
+    return str(r)
+
+
+def make_case(op, prec, mode, x, y):
+    ctx = Context(prec=prec, rounding=modes[mode], Emax=MAX_EMAX,
+                  Emin=MIN_EMIN, traps=[], flags=[])
+    if op == "quant":
+        r = ops[op](ctx, Decimal(x), y)
+    else:
+        r = ops[op](ctx, Decimal(x), Decimal(y))
+    conds = ""
+    for key, value in ctx.flags.items():
+        if value and key in flags:
+            conds += flags[key]
+    return {
+        "op": op,
+        "prec": prec,
+        "mode": mode,
+        "x": x,
+        "y": str(y),
+        "r": conv(r),
+        "exp": r.as_tuple().exponent if r.is_finite() else 0,
+        "conds": conds,
+    }
+
+
+cases = []
+for op in ops:
+    for i in range(300):
+        prec = random.randint(1, 40)
+        mode = random.choice(list(modes.keys()))
+        if op == "quant":
+            x = rand_dec(prec)
+            y = random.randint(-prec - 3, prec + 3)
+        elif op in ("quoint", "rem") and random.randint(0, 1) == 0:
+            x, y = near_limit(prec)
+        else:
+            x = rand_dec(prec)
+            y = rand_dec(prec)
+        cases.append(make_case(op, prec, mode, x, y))
+
+# One case per line keeps diffs of regenerated fixtures readable.
+sys.stdout.write("[\n")
+for i, c in enumerate(cases):
+    sep = "," if i < len(cases) - 1 else ""
+    sys.stdout.write(json.dumps(c, separators=(",", ":")) + sep + "\n")
+sys.stdout.write("]\n")
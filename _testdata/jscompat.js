@@ -0,0 +1,184 @@
+#!/usr/bin/env node
+// Generates jscompat.json, the expected outputs of JavaScript's Number
+// formatting methods for the inputs below, for TestJSCompat. Run from this
+// directory with:
+//
+//	node jscompat.js > jscompat.json
+//
+// The Go methods format a decimal's exact value, while Number formats its
+// nearest float64, so a case is only recorded if the float64 is exactly the
+// decimal. toString and toExponential with no argument format the shortest
+// decimal that rounds to the float64 instead, so their cases are recorded if
+// that decimal is the input, whether or not the float64 is exact. Arithmetic
+// cases, which are formatted with toString, require both.
+//
+// marshalJSON cases record the JSON a JavaScript client writes after decoding
+// the input as a JSON number, which TestJSCompat checks against the number
+// policy of MarshalJSON under QuoteUnsafeJSON. They are recorded whether or
+// not the input survives the round trip.
+'use strict';
+
+const inputs = [
+	// Specials and zeros.
+	'NaN', 'Infinity', '-Infinity', '0', '-0', '0.000', '0e+5',
+	// Ties for toFixed, toExponential, and toPrecision.
+	'0.5', '-0.5', '1.5', '2.5', '-2.5', '3.5', '9.5', '99.5', '999.5',
+	'0.25', '1.25', '0.125', '1.125', '-1.125', '0.375', '0.0625', '-0.0625',
+	'0.001953125', '0.00048828125', '1.00048828125', '4503599627370495.5',
+	// Trailing zeros.
+	'1.0', '1.50', '1.000', '1000', '1200',
+	// The thresholds of positional notation: 1e-7 and 1e+21.
+	'0.000001', '0.0000001', '1e-6', '1e-7', '1.5e-7', '2.5e-7',
+	'0.000003814697265625', '0.00000095367431640625',
+	'100000000000000000000', '999999999999999900000', '1e21', '1.5e21',
+	'1e22', '1180591620717411303424', '9007199254740992', '-9007199254740992',
+	// Neither exact nor short enough for toString.
+	'0.1', '0.3', '123.456', '-1234.5678', '1e-10', '1e100', '1e+300',
+	'5e-324', '12345678901234567890', '9007199254740993',
+];
+
+const binaryInputs = [
+	['0.5', '0.25'], ['1.5', '2.25'], ['1e21', '1'], ['1e20', '1'], ['0.1', '0.2'],
+	['1.125', '8'], ['3', '0.5'], ['1', '3'], ['-2.5', '2.5'], ['1e-7', '1'],
+	['65536', '65536'], ['0.0625', '0.0625'], ['9007199254740992', '1'],
+	['1e-6', '2'], ['12345.5', '-0.25'], ['1e22', '1e22'],
+];
+
+// Inputs for marshalJSON, in addition to those of inputs that are JSON
+// numbers: the limits of QuoteUnsafeJSON, 15 significant digits and adjusted
+// exponents of -21 and 21.
+const jsonInputs = [
+	'123456789012345', '1234567890123456', '-123456789012345.6',
+	'0.30000000000000004', '0.1000000000000001', '99999999999999.99',
+	'999999999999999', '9999999999999999', '1e-21', '1.5e-21', '1e-22',
+	'9.99e21', '12.50', '1.20e+3',
+];
+
+const fixed = [0, 1, 2, 3, 10, 20];
+const exponential = [null, 0, 1, 2, 5, 20];
+const precision = [1, 2, 3, 5, 21];
+
+// rat returns the decimal literal s as an exact fraction [num, den], or null
+// if s is not finite.
+function rat(s) {
+	const m = /^([+-]?)(\d*)(?:\.(\d*))?(?:e([+-]?\d+))?$/i.exec(s);
+	if (!m) {
+		return null;
+	}
+	const frac = m[3] || '';
+	let num = BigInt((m[2] || '0') + frac);
+	let den = 1n;
+	const exp = Number(m[4] || 0) - frac.length;
+	if (exp >= 0) {
+		num *= 10n ** BigInt(exp);
+	} else {
+		den = 10n ** BigInt(-exp);
+	}
+	return [m[1] === '-' ? -num : num, den];
+}
+
+// floatRat returns the finite float64 f as an exact fraction [num, den].
+function floatRat(f) {
+	const v = new DataView(new ArrayBuffer(8));
+	v.setFloat64(0, f);
+	const bits = v.getBigUint64(0);
+	const neg = bits >> 63n;
+	let e = Number((bits >> 52n) & 0x7ffn);
+	let mant = bits & ((1n << 52n) - 1n);
+	if (e === 0) {
+		e = 1;
+	} else {
+		mant |= 1n << 52n;
+	}
+	e -= 1075;
+	let num = neg ? -mant : mant;
+	let den = 1n;
+	if (e >= 0) {
+		num <<= BigInt(e);
+	} else {
+		den <<= BigInt(-e);
+	}
+	return [num, den];
+}
+
+function eq(a, b) {
+	return a[0] * b[1] === b[0] * a[1];
+}
+
+// exact reports whether the Number f is exactly the fraction r.
+function exact(f, r) {
+	if (!Number.isFinite(f)) {
+		return r === null;
+	}
+	return r !== null && eq(floatRat(f), r);
+}
+
+// shortest reports whether the shortest decimal that rounds to the Number f,
+// which toString and toExponential produce, is exactly the fraction r.
+function shortest(f, r) {
+	if (!Number.isFinite(f)) {
+		return r === null;
+	}
+	return r !== null && eq(rat(f.toString()), r);
+}
+
+const cases = [];
+for (const s of inputs) {
+	const f = Number(s);
+	const r = rat(s);
+	const short = shortest(f, r);
+	if (short) {
+		cases.push({op: 'toString', x: s, want: f.toString()});
+	}
+	if (!exact(f, r)) {
+		continue;
+	}
+	for (const d of fixed) {
+		// toFixed uses toString for magnitudes of at least 1e21.
+		if (short || Math.abs(f) < 1e21) {
+			cases.push({op: 'toFixed', x: s, arg: d, want: f.toFixed(d)});
+		}
+	}
+	for (const d of exponential) {
+		if (d === null && short) {
+			cases.push({op: 'toExponential', x: s, arg: d, want: f.toExponential()});
+		} else if (d !== null) {
+			cases.push({op: 'toExponential', x: s, arg: d, want: f.toExponential(d)});
+		}
+	}
+	for (const p of precision) {
+		cases.push({op: 'toPrecision', x: s, arg: p, want: f.toPrecision(p)});
+	}
+}
+
+const jsonNumber = /^-?(0|[1-9]\d*)(\.\d+)?([eE][+-]?\d+)?$/;
+for (const s of inputs.concat(jsonInputs)) {
+	if (jsonNumber.test(s)) {
+		cases.push({op: 'marshalJSON', x: s, want: JSON.stringify(JSON.parse(s))});
+	}
+}
+
+const ops = {
+	add: [(a, b) => a + b, (a, b) => [a[0] * b[1] + b[0] * a[1], a[1] * b[1]]],
+	sub: [(a, b) => a - b, (a, b) => [a[0] * b[1] - b[0] * a[1], a[1] * b[1]]],
+	mul: [(a, b) => a * b, (a, b) => [a[0] * b[0], a[1] * b[1]]],
+	div: [(a, b) => a / b, (a, b) => [a[0] * b[1], a[1] * b[0]]],
+};
+for (const [x, y] of binaryInputs) {
+	const a = rat(x);
+	const b = rat(y);
+	if (!exact(Number(x), a) || !exact(Number(y), b)) {
+		continue;
+	}
+	for (const [op, [fn, ratFn]] of Object.entries(ops)) {
+		const f = fn(Number(x), Number(y));
+		const r = ratFn(a, b);
+		if (exact(f, r) && shortest(f, r)) {
+			cases.push({op, x, y, want: f.toString()});
+		}
+	}
+}
+
+// One case per line keeps diffs of regenerated fixtures readable.
+process.stdout.write('{\n\t"node": ' + JSON.stringify(process.version) + ',\n\t"cases": [\n' +
+	cases.map((c) => '\t\t' + JSON.stringify(c)).join(',\n') + '\n\t]\n}\n');
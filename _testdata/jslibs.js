@@ -0,0 +1,77 @@
+#!/usr/bin/env node
+// Generates jslibs.json, the expected outputs of big.js's and decimal.js's
+// formatting methods for the inputs below, for TestJSLibs. Run from this
+// directory with:
+//
+//	npm install --no-save big.js decimal.js
+//	node jslibs.js > jslibs.json
+//
+// Unlike Number, both libraries format a decimal's exact value, so every case
+// is recorded, including those with more digits than a float64 holds. Both
+// use ROUND_HALF_UP, the default of big.js, which ToFixed and ToExponential
+// use and ToPrecision uses with ToNearestAway. Neither uses exponential
+// notation in toFixed, while Number, which ToFixed follows, does for
+// magnitudes of at least 1e21, so those cases are skipped.
+//
+// jslibs.py models both libraries for machines without npm; the two must
+// agree.
+'use strict';
+
+const Big = require('big.js');
+const Decimal = require('decimal.js');
+
+Big.RM = Big.roundHalfUp;
+Decimal.set({rounding: Decimal.ROUND_HALF_UP, toExpNeg: -7, toExpPos: 21});
+
+const inputs = [
+	// Zeros.
+	'0', '0.000', '0e+5',
+	// Ties, including those Number cannot represent.
+	'0.5', '-0.5', '1.5', '2.5', '-2.5', '9.5', '99.5', '999.5', '0.25',
+	'1.005', '-1.005', '1.0005', '0.0005', '0.00000095', '4503599627370495.5',
+	'12345678901234567890.5', '-12345678901234567890.5',
+	// Carries.
+	'0.9999', '9.9999', '-9.9999', '0.000999999', '99999999999999999999.5',
+	// Trailing zeros.
+	'1.0', '1.50', '1.000', '1000', '1200',
+	// The thresholds of positional notation: 1e-7 and 1e+21.
+	'0.000001', '0.0000001', '1e-6', '1e-7', '1.5e-7', '9.99999e-7',
+	'100000000000000000000', '999999999999999999999', '1e21', '1.5e21',
+	// More digits than a float64 holds.
+	'0.1', '0.3', '123.456', '-1234.5678', '1e-10', '1e100',
+	'3.14159265358979323846264338327950288', '9007199254740993',
+	'123456789012345678901234567890',
+];
+
+const fixed = [0, 1, 2, 3, 10, 20];
+const exponential = [null, 0, 1, 2, 5, 20];
+const precision = [1, 2, 3, 5, 21];
+
+const libs = [['big.js', Big], ['decimal.js', Decimal]];
+
+const cases = [];
+for (const [lib, New] of libs) {
+	for (const s of inputs) {
+		const x = new New(s);
+		for (const d of fixed) {
+			if (x.abs().gte('1e21')) {
+				continue;
+			}
+			cases.push({lib, op: 'toFixed', x: s, arg: d, want: x.toFixed(d)});
+		}
+		for (const d of exponential) {
+			const want = d === null ? x.toExponential() : x.toExponential(d);
+			cases.push({lib, op: 'toExponential', x: s, arg: d, want});
+		}
+		for (const p of precision) {
+			cases.push({lib, op: 'toPrecision', x: s, arg: p, want: x.toPrecision(p)});
+		}
+	}
+}
+
+// One case per line keeps diffs of regenerated fixtures readable.
+process.stdout.write('{\n' +
+	'\t"big.js": ' + JSON.stringify(require('big.js/package.json').version) + ',\n' +
+	'\t"decimal.js": ' + JSON.stringify(require('decimal.js/package.json').version) + ',\n' +
+	'\t"cases": [\n' +
+	cases.map((c) => '\t\t' + JSON.stringify(c)).join(',\n') + '\n\t]\n}\n');
@@ -0,0 +1,126 @@
+#!/usr/bin/env python3
+
+# Generates jslibs.json, the expected outputs of big.js's and decimal.js's
+# formatting methods for TestJSLibs, from a model of the two libraries written
+# with CPython's decimal module. jslibs.js records the libraries' own outputs
+# for the same cases and is the generator of record; this script is for
+# machines without npm, and regenerating with either must give the same cases.
+#
+# The model follows the libraries' rules for the inputs below, which have no
+# negative zeros:
+#
+#   - Every method formats the exact value and rounds it with ROUND_HALF_UP.
+#   - Trailing zeros are not significant: both libraries drop them on parse.
+#   - toFixed never uses exponential notation. Number, which ToFixed follows,
+#     does for magnitudes of at least 1e21, so those cases are skipped.
+#   - toExponential with no argument uses every significant digit.
+#   - toPrecision(p) uses exponential notation if the rounded value's exponent
+#     e satisfies p <= e or e <= -7.
+#   - A zero has the exponent 0, and a result is negative if the input is
+#     negative and nonzero, even if the result rounds to zero.
+#
+# Usage: ./jslibs.py > jslibs.json
+
+from decimal import *
+import json
+
+getcontext().prec = 1000
+getcontext().rounding = ROUND_HALF_UP
+
+inputs = [
+    # Zeros.
+    '0', '0.000', '0e+5',
+    # Ties, including those Number cannot represent.
+    '0.5', '-0.5', '1.5', '2.5', '-2.5', '9.5', '99.5', '999.5', '0.25',
+    '1.005', '-1.005', '1.0005', '0.0005', '0.00000095', '4503599627370495.5',
+    '12345678901234567890.5', '-12345678901234567890.5',
+    # Carries.
+    '0.9999', '9.9999', '-9.9999', '0.000999999', '99999999999999999999.5',
+    # Trailing zeros.
+    '1.0', '1.50', '1.000', '1000', '1200',
+    # The thresholds of positional notation: 1e-7 and 1e+21.
+    '0.000001', '0.0000001', '1e-6', '1e-7', '1.5e-7', '9.99999e-7',
+    '100000000000000000000', '999999999999999999999', '1e21', '1.5e21',
+    # More digits than a float64 holds.
+    '0.1', '0.3', '123.456', '-1234.5678', '1e-10', '1e100',
+    '3.14159265358979323846264338327950288', '9007199254740993',
+    '123456789012345678901234567890',
+]
+
+fixed = [0, 1, 2, 3, 10, 20]
+exponential = [None, 0, 1, 2, 5, 20]
+precision = [1, 2, 3, 5, 21]
+
+
+def digits(x, n):
+    """Returns the n significant digits of x, rounded, and the exponent of the
+    first."""
+    if x == 0:
+        return '0' * n, 0
+    e = x.adjusted()
+    q = abs(x).quantize(Decimal(1).scaleb(e - n + 1))
+    if q.adjusted() != e:
+        # The rounding carried into a new digit.
+        e += 1
+        q = abs(x).quantize(Decimal(1).scaleb(e - n + 1))
+    return str(q.scaleb(n - 1 - e).to_integral_exact()), e
+
+
+def sign(x, s):
+    return '-' + s if x < 0 else s
+
+
+def exp(d, e):
+    s = d[0]
+    if len(d) > 1:
+        s += '.' + d[1:]
+    return s + ('e+' if e >= 0 else 'e') + str(e)
+
+
+def to_fixed(x, dp):
+    q = abs(x).quantize(Decimal(1).scaleb(-dp))
+    return sign(x, '{:f}'.format(q))
+
+
+def to_exponential(x, dp):
+    if dp is None:
+        dp = len(x.normalize().as_tuple().digits) - 1 if x != 0 else 0
+    return sign(x, exp(*digits(x, dp + 1)))
+
+
+def to_precision(x, p):
+    d, e = digits(x, p)
+    if p <= e or e <= -7:
+        return sign(x, exp(d, e))
+    if e < 0:
+        return sign(x, '0.' + '0' * (-e - 1) + d)
+    if e + 1 < p:
+        return sign(x, d[:e + 1] + '.' + d[e + 1:])
+    return sign(x, d)
+
+
+cases = []
+for lib in ['big.js', 'decimal.js']:
+    for s in inputs:
+        x = Decimal(s)
+        for d in fixed:
+            if abs(x) < Decimal('1e21'):
+                cases.append({'lib': lib, 'op': 'toFixed', 'x': s, 'arg': d,
+                              'want': to_fixed(x, d)})
+        for d in exponential:
+            cases.append({'lib': lib, 'op': 'toExponential', 'x': s, 'arg': d,
+                          'want': to_exponential(x, d)})
+        for p in precision:
+            cases.append({'lib': lib, 'op': 'toPrecision', 'x': s, 'arg': p,
+                          'want': to_precision(x, p)})
+
+# One case per line, as written by jslibs.js. The versions name the model, not
+# a release of either library.
+compact = {'separators': (',', ':')}
+print('{')
+print('\t"big.js": ' + json.dumps('model (jslibs.py)') + ',')
+print('\t"decimal.js": ' + json.dumps('model (jslibs.py)') + ',')
+print('\t"cases": [')
+print(',\n'.join('\t\t' + json.dumps(c, **compact) for c in cases))
+print('\t]')
+print('}')
@@ -0,0 +1,85 @@
+#!/usr/bin/env python3
+
+# Generates squareroot.json, the square root test cases, from the GDA test
+# vectors in squareroot.decTest (distributed with CPython in
+# Lib/test/decimaltestdata). Each operand is tested with the vector's own
+# context and again at precision 34 with the exponent limits of Decimal128.
+# The results are computed by CPython's decimal module, which passes the
+# vectors. Operands with NaN payloads, vectors that require clamping, and the
+# "#" (null reference) operand are skipped, since this package supports none
+# of them. So are subnormal and clamped results in the vectors' own contexts,
+# since the smallest exponent of a subnormal in this package depends on the
+# package's MinScale rather than the Context's.
+#
+# Usage: ./squareroot.py path/to/squareroot.decTest > squareroot.json
+
+from decimal import *
+import json
+import sys
+
+flags = {
+    Clamped: "c",
+    DivisionByZero: "z",
+    Inexact: "x",
+    InvalidOperation: "i",
+    Overflow: "o",
+    Rounded: "r",
+    Subnormal: "s",
+    Underflow: "u",
+}
+
+
+def make_case(name, prec, emax, emin, x):
+    ctx = Context(prec=prec, rounding=ROUND_HALF_EVEN, Emax=emax, Emin=emin,
+                  clamp=0, traps=[], flags=[])
+    r = ctx.sqrt(Decimal(x))
+    conds = ""
+    for key, value in ctx.flags.items():
+        if value and key in flags:
+            conds += flags[key]
+    return {
+        "id": name,
+        "prec": prec,
+        "emax": emax,
+        "emin": emin,
+        "x": x,
+        "r": str(r),
+        "conds": conds,
+    }
+
+
+settings = {"precision": 9, "maxexponent": 384, "minexponent": -383,
+            "clamp": 0}
+cases = []
+seen = set()
+with open(sys.argv[1]) as f:
+    for line in f:
+        line = line.split("--")[0].strip()
+        if not line:
+            continue
+        if ":" in line:
+            key, value = line.split(":", 1)
+            if key.strip().lower() in settings:
+                settings[key.strip().lower()] = int(value)
+            continue
+        fields = line.split()
+        if len(fields) < 5 or fields[1].lower() != "squareroot":
+            continue
+        name, x = fields[0], fields[2].strip("'")
+        if x == "#" or "nan" in x.lower() and x.lower().lstrip("+-s") != "nan":
+            continue
+        if settings["clamp"] == 0:
+            c = make_case(name, settings["precision"],
+                          settings["maxexponent"], settings["minexponent"], x)
+            if "s" not in c["conds"] and "c" not in c["conds"]:
+                cases.append(c)
+        if x not in seen:
+            seen.add(x)
+            cases.append(make_case(name, 34, 6144, -6143, x))
+
+# One case per line keeps diffs of regenerated fixtures readable.
+sys.stdout.write("[\n")
+for i, c in enumerate(cases):
+    sep = "," if i < len(cases) - 1 else ""
+    sys.stdout.write(json.dumps(c, separators=(",", ":")) + sep + "\n")
+sys.stdout.write("]\n")
@@ -0,0 +1,330 @@
+package decimal
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strconv"
+
+	"github.com/ericlagergren/decimal/internal/arith"
+	"github.com/ericlagergren/decimal/internal/arith/checked"
+)
+
+// Amount is an immutable decimal value optimized for amounts of money with two
+// decimal places, such as 12.34. The zero value is 0.00.
+//
+// An Amount that is a whole number of hundredths in the range of an int64 is
+// stored as an int64, and operations on such Amounts use integer arithmetic.
+// Any other value, such as 1.234, 10^30, or NaN, is transparently stored as a
+// Big, and an operation whose result no longer fits an int64 promotes it to a
+// Big without loss. Either way, an operation computes exactly what the
+// equivalent operation on Bigs computes, and raises the same Conditions.
+//
+// An Amount holds a value, not a representation: the Amounts of 1.5, 1.50,
+// and 1.500 are identical, and are all formatted as 1.50. Likewise, the sign
+// of a zero is not preserved.
+type Amount struct {
+	cents int64
+	x     *Big // if non-nil, the value, which does not fit in cents
+}
+
+// NewAmount returns the Amount cents × 10^-2.
+func NewAmount(cents int64) Amount {
+	return Amount{cents: cents}
+}
+
+// AmountOf returns the Amount of x. x is not modified.
+func AmountOf(x *Big) Amount {
+	mustNotNil("AmountOf", x, x)
+	return amountOf(new(Big).Copy(x))
+}
+
+// amountOf returns the Amount of z, which must not be modified afterward.
+func amountOf(z *Big) Amount {
+	if z.IsFinite() && z.isCompact() {
+		mag, ok := z.compact, true
+		if z.exp >= -2 {
+			mag, ok = checked.MulPow10(mag, uint64(z.exp+2))
+		} else if p, ok2 := arith.Pow10(uint64(-2 - z.exp)); !ok2 || mag%p != 0 {
+			ok = false
+		} else {
+			mag /= p
+		}
+		if ok && mag <= math.MaxInt64 {
+			if z.Signbit() {
+				return Amount{cents: -int64(mag)}
+			}
+			return Amount{cents: int64(mag)}
+		}
+		if ok && mag == 1<<63 && z.Signbit() {
+			return Amount{cents: math.MinInt64}
+		}
+	}
+	z.Context = Context{}
+	return Amount{x: z}
+}
+
+// Big sets z to the value of a and returns z. If z is nil a new Big is
+// allocated. The result is exact; it is not rounded to z's Context.
+func (a Amount) Big(z *Big) *Big {
+	if z == nil {
+		z = new(Big)
+	}
+	if a.x != nil {
+		return z.Copy(a.x)
+	}
+	return z.SetMantScale(a.cents, 2)
+}
+
+// Cents returns a as a number of hundredths. The returned boolean is false if
+// a is not a whole number of hundredths in the range of an int64.
+func (a Amount) Cents() (int64, bool) {
+	return a.cents, a.x == nil
+}
+
+// IsPromoted reports whether a is stored as a Big.
+func (a Amount) IsPromoted() bool {
+	return a.x != nil
+}
+
+// Add returns a + b computed using ctx, along with the Conditions raised. The
+// result is the Amount of ctx.Add(z, x, y), where x and y are the Bigs of a
+// and b.
+func (a Amount) Add(b Amount, ctx Context) (Amount, Condition) {
+	if a.x == nil && b.x == nil && ctx.amountFast() {
+		if v := a.cents + b.cents; (v > a.cents) == (b.cents > 0) && ctx.amountFits(v) {
+			return Amount{cents: v}, 0
+		}
+	}
+	var x, y Big
+	x.Context.OperatingMode = ctx.OperatingMode
+	y.Context.OperatingMode = ctx.OperatingMode
+	z := ctx.Add(new(Big), a.Big(&x), b.Big(&y))
+	cond := z.Context.Conditions
+	return amountOf(z), cond
+}
+
+// Sub returns a - b computed using ctx, along with the Conditions raised. The
+// result is the Amount of ctx.Sub(z, x, y), where x and y are the Bigs of a
+// and b.
+func (a Amount) Sub(b Amount, ctx Context) (Amount, Condition) {
+	if a.x == nil && b.x == nil && ctx.amountFast() {
+		if v := a.cents - b.cents; (v < a.cents) == (b.cents > 0) && ctx.amountFits(v) {
+			return Amount{cents: v}, 0
+		}
+	}
+	var x, y Big
+	x.Context.OperatingMode = ctx.OperatingMode
+	y.Context.OperatingMode = ctx.OperatingMode
+	z := ctx.Sub(new(Big), a.Big(&x), b.Big(&y))
+	cond := z.Context.Conditions
+	return amountOf(z), cond
+}
+
+// Mul returns a × rate rounded to two decimal places using ctx, along with the
+// Conditions raised. The product is computed exactly and rounded once: the
+// result is the Amount of ctx.Quantize(z, 2), where z is the exact product of
+// the Big of a and rate. As with Quantize, the result is NaN if it would have
+// more than ctx.Precision digits.
+func (a Amount) Mul(rate *Big, ctx Context) (Amount, Condition) {
+	if a.x == nil && rate != nil && rate.IsFinite() && rate.isCompact() && ctx.amountFast() {
+		if v, cond, ok := ctx.mulCents(a.cents, rate); ok {
+			return Amount{cents: v}, cond
+		}
+	}
+	var x Big
+	z := Context{Precision: UnlimitedPrecision}.Mul(new(Big), a.Big(&x), rate)
+	cond := ctx.Quantize(z, 2).Context.Conditions
+	return amountOf(z), cond
+}
+
+// mulCents implements Mul for an Amount of cents and a compact rate. It
+// reports false if the result must be computed by Quantize.
+func (c Context) mulCents(cents int64, rate *Big) (int64, Condition, bool) {
+	neg := cents < 0 != rate.Signbit()
+	mag := uint64(cents)
+	if cents < 0 {
+		mag = -mag
+	}
+	p, ok := checked.Mul(mag, rate.compact)
+	if !ok || (p == 0 && neg) || c.maxScale() < -2 {
+		return 0, 0, false
+	}
+	if p == 0 {
+		return 0, 0, true
+	}
+	if arith.Length(p)+rate.exp >= precision(c) {
+		// Leave the result's precision, including a possible carry, to
+		// Quantize.
+		return 0, 0, false
+	}
+
+	var cond Condition
+	switch e := rate.exp; {
+	case e > 0:
+		if p, ok = checked.MulPow10(p, uint64(e)); !ok {
+			return 0, 0, false
+		}
+	case e < 0:
+		d, ok := arith.Pow10(uint64(-e))
+		if !ok {
+			return 0, 0, false
+		}
+		q, r := p/d, p%d
+		cond = Rounded
+		if r != 0 {
+			cond |= Inexact
+			rc := 1
+			if r2, ok := checked.Mul(r, 2); ok {
+				rc = arith.Cmp(r2, d)
+			}
+			if c.roundingMode().needsInc(q&1 != 0, rc, !neg) {
+				q++
+			}
+		}
+		p = q
+	}
+	if p > math.MaxInt64 {
+		return 0, 0, false
+	}
+	if neg {
+		return -int64(p), cond, true
+	}
+	return int64(p), cond, true
+}
+
+// amountFast reports whether operations on Amounts using c can be computed
+// with integer arithmetic.
+func (c Context) amountFast() bool {
+	if c.ExactOnly || c.hooks != nil {
+		return false
+	}
+	var z Big
+	return !z.invalidContext(c)
+}
+
+// amountFits reports whether the sum or difference cents of two Amounts can be
+// computed with integer arithmetic, i.e., the equivalent Big is neither
+// rounded nor clamped by c.
+func (c Context) amountFits(cents int64) bool {
+	mag := uint64(cents)
+	if cents < 0 {
+		mag = -mag
+	}
+	n := arith.Length(mag)
+	adj := n - 3
+	return n <= precision(c) && adj <= c.maxScale() && adj >= c.minScale()
+}
+
+// Cmp compares a and b and returns:
+//
+//	-1 if a <  b
+//	 0 if a == b
+//	+1 if a >  b
+//
+// If a or b is promoted, the result is as for Big.Cmp.
+func (a Amount) Cmp(b Amount) int {
+	if a.x == nil && b.x == nil {
+		switch {
+		case a.cents < b.cents:
+			return -1
+		case a.cents > b.cents:
+			return +1
+		default:
+			return 0
+		}
+	}
+	var x, y Big
+	return a.Big(&x).Cmp(b.Big(&y))
+}
+
+// Sign returns:
+//
+//	-1 if a <  0
+//	 0 if a == 0
+//	+1 if a >  0
+func (a Amount) Sign() int {
+	if a.x != nil {
+		return a.x.Sign()
+	}
+	switch {
+	case a.cents < 0:
+		return -1
+	case a.cents > 0:
+		return +1
+	default:
+		return 0
+	}
+}
+
+// String returns a formatted like Big.String. Amounts that are not promoted
+// always have two decimal places; e.g., 1.50 and -0.05.
+func (a Amount) String() string {
+	if a.x != nil {
+		return a.x.String()
+	}
+	var buf [24]byte
+	return string(a.appendCents(buf[:0]))
+}
+
+// appendCents appends the unpromoted a to dst.
+func (a Amount) appendCents(dst []byte) []byte {
+	mag := uint64(a.cents)
+	if a.cents < 0 {
+		dst = append(dst, '-')
+		mag = -mag
+	}
+	if mag < 100 {
+		return append(dst, '0', '.', byte('0'+mag/10), byte('0'+mag%10))
+	}
+	dst = strconv.AppendUint(dst, mag/100, 10)
+	return append(dst, '.', byte('0'+mag/10%10), byte('0'+mag%10))
+}
+
+// MarshalText implements encoding.TextMarshaler. a is encoded as by
+// Big.MarshalText.
+func (a Amount) MarshalText() ([]byte, error) {
+	if a.x != nil {
+		return a.x.MarshalText()
+	}
+	return a.appendCents(make([]byte, 0, 24)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts any of the
+// formats accepted by SetString.
+func (a *Amount) UnmarshalText(data []byte) error {
+	var x Big
+	if err := x.scanBytes(data, x.Context, new(bytes.Reader)); err != nil {
+		return err
+	}
+	if x.Context.Conditions&ConversionSyntax != 0 {
+		return errors.New("decimal: invalid Amount " + string(data))
+	}
+	*a = amountOf(&x)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. a is encoded as by Big.MarshalJSON.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	if a.x != nil {
+		return a.x.MarshalJSON()
+	}
+	return a.appendCents(make([]byte, 0, 24)), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts the same input as
+// Big.UnmarshalJSON. null leaves a unchanged.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	var x Big
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if err := x.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	if x.Context.Conditions&ConversionSyntax != 0 {
+		return errors.New("decimal: invalid Amount " + string(data))
+	}
+	*a = amountOf(&x)
+	return nil
+}
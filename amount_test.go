@@ -0,0 +1,254 @@
+package decimal_test
+
+import (
+	"encoding/json"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestAmountOf(t *testing.T) {
+	for i, test := range [...]struct {
+		x        string
+		want     string
+		promoted bool
+	}{
+		{"0", "0.00", false},
+		{"-0", "0.00", false},
+		{"1.5", "1.50", false},
+		{"1.500", "1.50", false},
+		{"-0.05", "-0.05", false},
+		{"12E+3", "12000.00", false},
+		{"92233720368547758.07", "92233720368547758.07", false},
+		{"-92233720368547758.08", "-92233720368547758.08", false},
+		{"92233720368547758.08", "92233720368547758.08", true},
+		{"1.234", "1.234", true},
+		{"1E+30", "1E+30", true},
+		{"-Infinity", "-Infinity", true},
+		{"NaN", "NaN", true},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		orig := x.String()
+		a := decimal.AmountOf(x)
+		if a.String() != test.want || a.IsPromoted() != test.promoted {
+			t.Fatalf("#%d: AmountOf(%s): wanted %s (promoted: %t), got %s (promoted: %t)",
+				i, test.x, test.want, test.promoted, a, a.IsPromoted())
+		}
+		if x.IsFinite() && a.Big(nil).Cmp(x) != 0 {
+			t.Fatalf("#%d: AmountOf(%s).Big(): wanted %s, got %s", i, test.x, x, a.Big(nil))
+		}
+		if x.String() != orig {
+			t.Fatalf("#%d: AmountOf modified x: %s", i, x)
+		}
+	}
+}
+
+func TestAmount_Promotion(t *testing.T) {
+	ctx := decimal.ContextUnlimited
+	max := decimal.NewAmount(math.MaxInt64)
+
+	sum, cond := max.Add(decimal.NewAmount(1), ctx)
+	if sum.String() != "92233720368547758.08" || !sum.IsPromoted() || cond != 0 {
+		t.Fatalf("wanted promoted 92233720368547758.08, got %s (promoted: %t, %s)",
+			sum, sum.IsPromoted(), cond)
+	}
+	diff, _ := sum.Sub(decimal.NewAmount(1), ctx)
+	if c, ok := diff.Cents(); !ok || c != math.MaxInt64 {
+		t.Fatalf("wanted demoted %d, got %s (promoted: %t)", int64(math.MaxInt64), diff, diff.IsPromoted())
+	}
+
+	p, cond := decimal.NewAmount(100).Mul(decimal.New(1234, 3), ctx)
+	if p.String() != "1.23" || p.IsPromoted() || cond != decimal.Inexact|decimal.Rounded {
+		t.Fatalf("wanted 1.23 (inexact, rounded), got %s (%s)", p, cond)
+	}
+
+	// The default precision is too small for the exact sum.
+	sum, cond = max.Add(decimal.NewAmount(0), decimal.Context{})
+	if want := "9.223372036854776E+16"; sum.String() != want ||
+		cond != decimal.Inexact|decimal.Rounded {
+		t.Fatalf("wanted %s (inexact, rounded), got %s (%s)", want, sum, cond)
+	}
+}
+
+// TestAmount_Big checks that operations on Amounts are identical to the
+// equivalent operations on Bigs, including the Conditions they raise.
+func TestAmount_Big(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	cents := func() int64 {
+		switch rng.Intn(4) {
+		case 0:
+			return rng.Int63n(2000) - 1000
+		case 1:
+			return rng.Int63n(2e15) - 1e15
+		case 2:
+			return rng.Int63() - rng.Int63()
+		default:
+			if rng.Intn(2) == 0 {
+				return math.MaxInt64 - rng.Int63n(100)
+			}
+			return math.MinInt64 + rng.Int63n(100)
+		}
+	}
+	rate := func() *decimal.Big {
+		r := decimal.New(rng.Int63n(1e7), rng.Intn(10)-2)
+		if rng.Intn(4) == 0 {
+			r.Neg(r)
+		}
+		return r
+	}
+	check := func(op string, ctx decimal.Context, a decimal.Amount, acond decimal.Condition, z *decimal.Big) {
+		t.Helper()
+		want := decimal.AmountOf(z)
+		if a.String() != want.String() || a.IsPromoted() != want.IsPromoted() ||
+			acond != z.Context.Conditions {
+			t.Fatalf("%s (%+v): wanted %s (%s), got %s (%s)",
+				op, ctx, want, z.Context.Conditions, a, acond)
+		}
+	}
+
+	n := 20000
+	if testing.Short() {
+		n = 2000
+	}
+	for _, ctx := range []decimal.Context{
+		{},
+		{Precision: 5},
+		{Precision: 19, RoundingMode: decimal.ToNegativeInf},
+		{Precision: 25, RoundingMode: decimal.AwayFromZero},
+		{OperatingMode: decimal.Go},
+		{MinScale: -1, MaxScale: 10, Precision: 20},
+		decimal.Context32,
+		decimal.ContextUnlimited,
+	} {
+		for i := 0; i < n; i++ {
+			a, b := decimal.NewAmount(cents()), decimal.NewAmount(cents())
+			if rng.Intn(8) == 0 {
+				a = decimal.AmountOf(rate())
+			}
+			r := rate()
+
+			s, cond := a.Add(b, ctx)
+			check(a.String()+" + "+b.String(), ctx, s, cond,
+				ctx.Add(new(decimal.Big), a.Big(nil), b.Big(nil)))
+
+			d, cond := a.Sub(b, ctx)
+			check(a.String()+" - "+b.String(), ctx, d, cond,
+				ctx.Sub(new(decimal.Big), a.Big(nil), b.Big(nil)))
+
+			p, cond := a.Mul(r, ctx)
+			z := decimal.ContextUnlimited.Mul(new(decimal.Big), a.Big(nil), r)
+			check(a.String()+" × "+r.String(), ctx, p, cond, ctx.Quantize(z, 2))
+		}
+	}
+}
+
+func TestAmount_Cmp(t *testing.T) {
+	for i, test := range [...]struct {
+		a, b decimal.Amount
+		want int
+	}{
+		{decimal.NewAmount(1), decimal.NewAmount(2), -1},
+		{decimal.NewAmount(-1), decimal.NewAmount(-1), 0},
+		{decimal.AmountOf(decimal.New(1234, 3)), decimal.NewAmount(123), +1},
+		{decimal.NewAmount(123), decimal.AmountOf(decimal.New(1230, 3)), 0},
+		{decimal.NewAmount(math.MaxInt64), decimal.AmountOf(decimal.New(1, -30)), -1},
+	} {
+		if got := test.a.Cmp(test.b); got != test.want {
+			t.Fatalf("#%d: Cmp(%s, %s): wanted %d, got %d", i, test.a, test.b, test.want, got)
+		}
+		if got := test.a.Sign(); got != test.a.Big(nil).Sign() {
+			t.Fatalf("#%d: Sign(%s): wanted %d, got %d", i, test.a, test.a.Big(nil).Sign(), got)
+		}
+	}
+}
+
+func TestAmount_JSON(t *testing.T) {
+	type order struct {
+		Total decimal.Amount  `json:"total"`
+		Tax   *decimal.Amount `json:"tax"`
+	}
+	for i, test := range [...]struct {
+		in, out string
+	}{
+		{`{"total":"12.5","tax":null}`, `{"total":12.50,"tax":null}`},
+		{`{"total":-0.05,"tax":"1.005"}`, `{"total":-0.05,"tax":1.005}`},
+		{`{"total":"1e30","tax":"0.00"}`, `{"total":1000000000000000000000000000000,"tax":0.00}`},
+		{`{"total":"-92233720368547758.08","tax":"Infinity"}`,
+			`{"total":-92233720368547758.08,"tax":"Infinity"}`},
+	} {
+		var o order
+		if err := json.Unmarshal([]byte(test.in), &o); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		b, err := json.Marshal(o)
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if string(b) != test.out {
+			t.Fatalf("#%d: wanted %s, got %s", i, test.out, b)
+		}
+	}
+
+	var a decimal.Amount
+	if err := a.UnmarshalText([]byte("12.3.4")); err == nil {
+		t.Fatalf("wanted an error, got %s", a)
+	}
+}
+
+// The order-totaling benchmarks sum the lines of an order, each a price
+// multiplied by a quantity, and then compute tax on the total.
+
+var (
+	benchPrices = []int64{1999, 250, 4, 129900, 75, 1000, 3333, 49}
+	benchQtys   = []*decimal.Big{
+		decimal.New(3, 0), decimal.New(12, 0), decimal.New(1000, 0), decimal.New(1, 0),
+		decimal.New(25, 1), decimal.New(7, 0), decimal.New(3, 0), decimal.New(125, 3),
+	}
+	benchTax = decimal.New(825, 4)
+)
+
+func BenchmarkAmount_OrderTotal(b *testing.B) {
+	b.ReportAllocs()
+	ctx := decimal.Context{Precision: 19}
+	prices := make([]decimal.Amount, len(benchPrices))
+	for i, p := range benchPrices {
+		prices[i] = decimal.NewAmount(p)
+	}
+	var total decimal.Amount
+	for i := 0; i < b.N; i++ {
+		total = decimal.Amount{}
+		for j, p := range prices {
+			line, _ := p.Mul(benchQtys[j], ctx)
+			total, _ = total.Add(line, ctx)
+		}
+		tax, _ := total.Mul(benchTax, ctx)
+		total, _ = total.Add(tax, ctx)
+	}
+	if total.String() != "1732.97" {
+		b.Fatalf("wanted 1732.97, got %s", total)
+	}
+}
+
+func BenchmarkBig_OrderTotal(b *testing.B) {
+	b.ReportAllocs()
+	ctx := decimal.Context{Precision: 19}
+	prices := make([]*decimal.Big, len(benchPrices))
+	for i, p := range benchPrices {
+		prices[i] = decimal.New(p, 2)
+	}
+	var total, line, tax decimal.Big
+	for i := 0; i < b.N; i++ {
+		total.SetUint64(0)
+		for j, p := range prices {
+			ctx.Quantize(ctx.Mul(&line, p, benchQtys[j]), 2)
+			ctx.Add(&total, &total, &line)
+		}
+		ctx.Quantize(ctx.Mul(&tax, &total, benchTax), 2)
+		ctx.Add(&total, &total, &tax)
+	}
+	if total.String() != "1732.97" {
+		b.Fatalf("wanted 1732.97, got %s", &total)
+	}
+}
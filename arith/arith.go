@@ -0,0 +1,41 @@
+// Package arith provides the unsigned integer building blocks decimal uses for
+// operations on compact (uint64) coefficients, for packages that implement
+// their own compact-path operations.
+//
+// The functions are thin wrappers around the implementations used by package
+// decimal itself, so their results always match decimal's.
+package arith
+
+import (
+	"math/bits"
+
+	"github.com/ericlagergren/decimal/internal/arith"
+)
+
+// MaxPow10Uint64 is the largest n for which Pow10Uint64(n) succeeds: 10^19 is
+// the largest power of ten that fits in a uint64.
+const MaxPow10Uint64 = arith.PowTabLen - 1
+
+// Mul64To128 returns the 128-bit product of x and y, split into its high and
+// low 64 bits.
+func Mul64To128(x, y uint64) (hi, lo uint64) {
+	return arith.Mul128(x, y)
+}
+
+// Div128By64 returns the quotient and remainder of the 128-bit integer
+// (hi, lo) divided by y. It panics if y is zero or if the quotient does not fit
+// in a uint64, that is, if y <= hi.
+func Div128By64(hi, lo, y uint64) (quo, rem uint64) {
+	return bits.Div64(hi, lo, y)
+}
+
+// Len10 returns the number of decimal digits in x. Len10(0) is 1.
+func Len10(x uint64) int {
+	return arith.Length(x)
+}
+
+// Pow10Uint64 returns 10^n and true if the result fits in a uint64, that is, if
+// n <= MaxPow10Uint64. Otherwise, it returns 0 and false.
+func Pow10Uint64(n uint64) (uint64, bool) {
+	return arith.Pow10(n)
+}
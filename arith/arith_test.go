@@ -0,0 +1,126 @@
+package arith
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+func TestMul64To128(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	mask := new(big.Int).SetUint64(math.MaxUint64)
+	check := func(x, y uint64) {
+		hi, lo := Mul64To128(x, y)
+		z := new(big.Int).Mul(new(big.Int).SetUint64(x), new(big.Int).SetUint64(y))
+		whi := new(big.Int).Rsh(z, 64).Uint64()
+		wlo := new(big.Int).And(z, mask).Uint64()
+		if hi != whi || lo != wlo {
+			t.Fatalf("Mul64To128(%d, %d): wanted (%d, %d), got (%d, %d)", x, y, whi, wlo, hi, lo)
+		}
+	}
+	edges := []uint64{0, 1, 2, 10, math.MaxUint32, math.MaxUint32 + 1, 1 << 63, math.MaxUint64}
+	for _, x := range edges {
+		for _, y := range edges {
+			check(x, y)
+		}
+	}
+	for i := 0; i < 10000; i++ {
+		check(r.Uint64(), r.Uint64())
+	}
+}
+
+func TestDiv128By64(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	check := func(hi, lo, y uint64) {
+		quo, rem := Div128By64(hi, lo, y)
+		// Check quo*y + rem == (hi, lo).
+		phi, plo := Mul64To128(quo, y)
+		plo2 := plo + rem
+		if plo2 < plo {
+			phi++
+		}
+		if phi != hi || plo2 != lo || rem >= y {
+			t.Fatalf("Div128By64(%d, %d, %d): got (%d, %d)", hi, lo, y, quo, rem)
+		}
+	}
+	for _, test := range [...][3]uint64{
+		{0, 0, 1},
+		{0, math.MaxUint64, 1},
+		{0, math.MaxUint64, 10},
+		{9, math.MaxUint64, 10},
+		{math.MaxUint64 - 1, math.MaxUint64, math.MaxUint64},
+		{1<<63 - 1, 0, 1 << 63},
+	} {
+		check(test[0], test[1], test[2])
+	}
+	for i := 0; i < 10000; i++ {
+		y := r.Uint64()
+		if y == 0 {
+			y = 1
+		}
+		check(r.Uint64()%y, r.Uint64(), y)
+	}
+
+	for _, test := range [...][3]uint64{
+		{0, 1, 0},
+		{1, 0, 1},
+		{10, 0, 10},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("Div128By64(%d, %d, %d): wanted a panic", test[0], test[1], test[2])
+				}
+			}()
+			Div128By64(test[0], test[1], test[2])
+		}()
+	}
+}
+
+func TestLen10(t *testing.T) {
+	for _, test := range [...]struct {
+		x uint64
+		n int
+	}{
+		{0, 1},
+		{math.MaxUint64, 20},
+	} {
+		if n := Len10(test.x); n != test.n {
+			t.Fatalf("Len10(%d): wanted %d, got %d", test.x, test.n, n)
+		}
+	}
+	// Check both sides of every power of ten.
+	p := uint64(1)
+	for i := 0; i <= MaxPow10Uint64; i++ {
+		for _, x := range [...]uint64{p - 1, p, p + 1} {
+			if x == 0 {
+				continue
+			}
+			if n, want := Len10(x), len(strconv.FormatUint(x, 10)); n != want {
+				t.Fatalf("Len10(%d): wanted %d, got %d", x, want, n)
+			}
+		}
+		p *= 10
+	}
+}
+
+func TestPow10Uint64(t *testing.T) {
+	p := uint64(1)
+	for n := uint64(0); n <= MaxPow10Uint64; n++ {
+		if x, ok := Pow10Uint64(n); !ok || x != p {
+			t.Fatalf("Pow10Uint64(%d): wanted (%d, true), got (%d, %t)", n, p, x, ok)
+		}
+		p *= 10
+	}
+	for _, n := range [...]uint64{MaxPow10Uint64 + 1, MaxPow10Uint64 + 2, math.MaxUint64} {
+		if x, ok := Pow10Uint64(n); ok || x != 0 {
+			t.Fatalf("Pow10Uint64(%d): wanted (0, false), got (%d, %t)", n, x, ok)
+		}
+	}
+	// The table must end exactly where uint64 does.
+	if x, _ := Pow10Uint64(MaxPow10Uint64); x <= math.MaxUint64/10 {
+		t.Fatalf("10^%d fits in a uint64", MaxPow10Uint64+1)
+	}
+}
@@ -0,0 +1,133 @@
+package decimal
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// ArrowDecimal128 returns x as the value of an Apache Arrow Decimal128 with
+// the scale scale: the integer x × 10**scale, in 128-bit two's complement and
+// little-endian byte order. For example, with a scale of 2, 1.5 is the integer
+// 150 and -1.5 is -150.
+//
+// If x has more digits after the decimal point than scale, the integer is
+// rounded using x's RoundingMode and cond is Rounded, or Inexact|Rounded if
+// any of the discarded digits were nonzero. x is never modified, so the
+// conditions are returned rather than raised in x's Context. ArrowDecimal128 returns an
+// error that wraps Overflow if the integer does not fit in 128 bits, or one
+// that wraps InvalidOperation if x is a NaN or infinite value, which Arrow
+// decimals cannot represent.
+//
+// Arrow also limits a Decimal128 to a precision of 38 digits, which is not
+// checked.
+func (x *Big) ArrowDecimal128(scale int32) (b [16]byte, cond Condition, err error) {
+	cond, err = x.arrowDecimal("ArrowDecimal128", b[:], scale)
+	return b, cond, err
+}
+
+// ArrowDecimal256 is like ArrowDecimal128, but returns the value of an Arrow
+// Decimal256, which is 256 bits and has a precision of up to 76 digits.
+func (x *Big) ArrowDecimal256(scale int32) (b [32]byte, cond Condition, err error) {
+	cond, err = x.arrowDecimal("ArrowDecimal256", b[:], scale)
+	return b, cond, err
+}
+
+// arrowDecimal stores x × 10**scale in b as a little-endian two's complement
+// integer, and returns the conditions raised by rounding it.
+func (x *Big) arrowDecimal(op string, b []byte, scale int32) (Condition, error) {
+	if x == nil {
+		return 0, ErrNilOperand{Op: op}
+	}
+	if debug {
+		x.validate()
+	}
+	if !x.IsFinite() {
+		return 0, fmt.Errorf("decimal: %s: cannot represent %s: %w", op, x.form, InvalidOperation)
+	}
+	if x.compact == 0 {
+		return 0, nil
+	}
+
+	// A two's complement integer of n bits has at most n×log10(2) + 1
+	// digits, which is 39 for 128 bits and 78 for 256 bits.
+	nbits := 8 * len(b)
+	maxDigits := nbits*30103/100000 + 1
+
+	// t = x×10**scale, rounded to an integer by quantizing it. Because only
+	// whether the discarded digits are zero, less than half, half, or more
+	// than half matters, a t of less than 0.1 may be replaced by any other,
+	// which keeps the exponent in range.
+	p := int64(x.Precision())
+	e := int64(x.exp) + int64(scale)
+	if e+p > int64(maxDigits) {
+		return 0, arrowOverflow(op, x, scale, nbits)
+	}
+	if e < -(p + 1) {
+		e = -(p + 1)
+	}
+	t := Big{Context: Context{
+		Precision:    maxDigits + 1,
+		RoundingMode: x.Context.roundingMode(),
+	}}
+	t.Copy(x)
+	t.exp = int(e)
+	t.Quantize(0)
+
+	var v big.Int
+	if t.isCompact() {
+		v.SetUint64(t.compact)
+	} else {
+		v.Set(&t.unscaled)
+	}
+	// -2**(nbits-1) <= v < 2**(nbits-1).
+	if n := v.BitLen(); n > nbits || n == nbits && (!t.Signbit() || v.TrailingZeroBits() != uint(n-1)) {
+		return 0, arrowOverflow(op, x, scale, nbits)
+	}
+	if t.Signbit() && v.Sign() != 0 {
+		var m big.Int
+		v.Sub(m.Lsh(m.SetUint64(1), uint(nbits)), &v)
+	}
+	v.FillBytes(b)
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return t.Context.Conditions, nil
+}
+
+// arrowOverflow returns an error for an x×10**scale that does not fit in nbits
+// bits.
+func arrowOverflow(op string, x *Big, scale int32, nbits int) error {
+	return fmt.Errorf("decimal: %s: %s with a scale of %d does not fit in %d bits: %w",
+		op, x, scale, nbits, Overflow)
+}
+
+// SetArrowDecimal128 sets z to the value of an Apache Arrow Decimal128 with
+// the scale scale, as returned by ArrowDecimal128, and returns z. z has the
+// scale scale, so 150 with a scale of 2 is 1.50. z is not rounded.
+func (z *Big) SetArrowDecimal128(b [16]byte, scale int32) *Big {
+	mustNotNil("SetArrowDecimal128", z, z)
+	return z.setArrowDecimal(b[:], scale)
+}
+
+// SetArrowDecimal256 is like SetArrowDecimal128, but sets z to the value of an
+// Arrow Decimal256.
+func (z *Big) SetArrowDecimal256(b [32]byte, scale int32) *Big {
+	mustNotNil("SetArrowDecimal256", z, z)
+	return z.setArrowDecimal(b[:], scale)
+}
+
+// setArrowDecimal sets z to the little-endian two's complement integer b
+// times 10**-scale.
+func (z *Big) setArrowDecimal(b []byte, scale int32) *Big {
+	var be [32]byte
+	for i, c := range b {
+		be[len(b)-1-i] = c
+	}
+	var v big.Int
+	v.SetBytes(be[:len(b)])
+	if b[len(b)-1]&0x80 != 0 {
+		var m big.Int
+		v.Sub(&v, m.Lsh(m.SetUint64(1), uint(8*len(b))))
+	}
+	return z.SetBigMantScale(&v, int(scale))
+}
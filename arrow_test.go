@@ -0,0 +1,130 @@
+package decimal_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestBig_ArrowDecimal128(t *testing.T) {
+	for i, test := range [...]struct {
+		in    string
+		scale int32
+		mode  decimal.RoundingMode
+		enc   string // little-endian
+		out   string
+		cond  decimal.Condition
+	}{
+		{"0", 2, decimal.ToNearestEven, "00000000000000000000000000000000", "0.00", 0},
+		{"-0", 0, decimal.ToNearestEven, "00000000000000000000000000000000", "0", 0},
+		{"1.5", 2, decimal.ToNearestEven, "96000000000000000000000000000000", "1.50", 0},
+		{"-1.5", 2, decimal.ToNearestEven, "6affffffffffffffffffffffffffffff", "-1.50", 0},
+		{"1.5E+3", 0, decimal.ToNearestEven, "dc050000000000000000000000000000", "1500", 0},
+		{"1500", -2, decimal.ToNearestEven, "0f000000000000000000000000000000", "1.5E+3", decimal.Rounded},
+		{"2.5", 0, decimal.ToNearestEven, "02000000000000000000000000000000", "2", decimal.Inexact | decimal.Rounded},
+		{"2.5", 0, decimal.AwayFromZero, "03000000000000000000000000000000", "3", decimal.Inexact | decimal.Rounded},
+		{"-2.5", 0, decimal.ToNegativeInf, "fdffffffffffffffffffffffffffffff", "-3", decimal.Inexact | decimal.Rounded},
+		{"1.20", 1, decimal.ToNearestEven, "0c000000000000000000000000000000", "1.2", decimal.Rounded},
+		{"1E-100", 2, decimal.ToNearestEven, "00000000000000000000000000000000", "0.00", decimal.Inexact | decimal.Rounded},
+		{"1E-100", 2, decimal.ToPositiveInf, "01000000000000000000000000000000", "0.01", decimal.Inexact | decimal.Rounded},
+		{"-9.999", 2, decimal.ToNearestEven, "18fcffffffffffffffffffffffffffff", "-10.00", decimal.Inexact | decimal.Rounded},
+		// The largest and smallest 128-bit integers.
+		{"170141183460469231731687303715884105727", 0, decimal.ToNearestEven, "ffffffffffffffffffffffffffffff7f", "170141183460469231731687303715884105727", 0},
+		{"-170141183460469231731687303715884105728", 0, decimal.ToNearestEven, "00000000000000000000000000000080", "-170141183460469231731687303715884105728", 0},
+		{"-1701411834604692317316873037158841057.28", 2, decimal.ToNearestEven, "00000000000000000000000000000080", "-1701411834604692317316873037158841057.28", 0},
+	} {
+		x, _ := new(decimal.Big).SetString(test.in)
+		x.Context.RoundingMode = test.mode
+		b, cond, err := x.ArrowDecimal128(test.scale)
+		if err != nil || hex.EncodeToString(b[:]) != test.enc || cond != test.cond {
+			t.Fatalf("#%d: %s.ArrowDecimal128(%d): wanted (%s, %s), got (%x, %s, %v)",
+				i, test.in, test.scale, test.enc, test.cond, b, cond, err)
+		}
+		if x.Context.Conditions != 0 {
+			t.Fatalf("#%d: %s.ArrowDecimal128(%d): raised %s", i, test.in, test.scale, x.Context.Conditions)
+		}
+		z := new(decimal.Big).SetArrowDecimal128(b, test.scale)
+		if z.String() != test.out {
+			t.Fatalf("#%d: SetArrowDecimal128(%x, %d): wanted %s, got %s", i, b, test.scale, test.out, z)
+		}
+	}
+
+	for i, test := range [...]struct {
+		in    string
+		scale int32
+		err   decimal.Condition
+	}{
+		{"170141183460469231731687303715884105728", 0, decimal.Overflow},
+		{"-170141183460469231731687303715884105729", 0, decimal.Overflow},
+		{"1", 39, decimal.Overflow},
+		{"1E+1000000", 0, decimal.Overflow},
+		{"1", 2147483647, decimal.Overflow},
+		{"170141183460469231731687303715884105727.5", 0, decimal.Overflow},
+		{"NaN", 0, decimal.InvalidOperation},
+		{"-Infinity", 0, decimal.InvalidOperation},
+	} {
+		x, _ := new(decimal.Big).SetString(test.in)
+		b, _, err := x.ArrowDecimal128(test.scale)
+		if !errors.Is(err, test.err) {
+			t.Fatalf("#%d: %s.ArrowDecimal128(%d): wanted %s, got %x (%v)", i, test.in, test.scale, test.err, b, err)
+		}
+		if x.Context.Conditions != 0 {
+			t.Fatalf("#%d: %s.ArrowDecimal128(%d): raised %s", i, test.in, test.scale, x.Context.Conditions)
+		}
+	}
+}
+
+func TestBig_ArrowDecimal256(t *testing.T) {
+	max := new(big.Int).Lsh(big.NewInt(1), 255)
+	min := new(big.Int).Neg(max)
+	max.Sub(max, big.NewInt(1))
+
+	x := new(decimal.Big).SetBigMantScale(min, 10)
+	b, cond, err := x.ArrowDecimal256(10)
+	if err != nil || cond != 0 || b[31] != 0x80 {
+		t.Fatalf("%s.ArrowDecimal256(10): got %x (%s, %v)", x, b, cond, err)
+	}
+	if z := new(decimal.Big).SetArrowDecimal256(b, 10); z.Cmp(x) != 0 {
+		t.Fatalf("SetArrowDecimal256(%x, 10): wanted %s, got %s", b, x, z)
+	}
+	if _, _, err := x.ArrowDecimal256(11); !errors.Is(err, decimal.Overflow) {
+		t.Fatalf("%s.ArrowDecimal256(11): wanted Overflow, got %v", x, err)
+	}
+	x.SetBigMantScale(max, 0)
+	if _, _, err := x.ArrowDecimal256(0); err != nil {
+		t.Fatalf("%s.ArrowDecimal256(0): %v", x, err)
+	}
+	if _, _, err := x.Add(x, decimal.New(1, 0)).ArrowDecimal256(0); !errors.Is(err, decimal.Overflow) {
+		t.Fatalf("%s.ArrowDecimal256(0): wanted Overflow, got %v", x, err)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		var c big.Int
+		c.Rand(rng, new(big.Int).Lsh(big.NewInt(1), uint(rng.Intn(256))))
+		if rng.Intn(2) == 0 {
+			c.Neg(&c)
+		}
+		scale := int32(rng.Intn(100) - 50)
+		x := new(decimal.Big).SetBigMantScale(&c, int(scale))
+		b, cond, err := x.ArrowDecimal256(scale)
+		if err != nil || cond != 0 {
+			t.Fatalf("%s.ArrowDecimal256(%d): %s, %v", x, scale, cond, err)
+		}
+		z := new(decimal.Big).SetArrowDecimal256(b, scale)
+		if z.Cmp(x) != 0 || z.Scale() != x.Scale() {
+			t.Fatalf("SetArrowDecimal256(%x, %d): wanted %s, got %s", b, scale, x, z)
+		}
+	}
+}
+
+func BenchmarkBig_ArrowDecimal128(b *testing.B) {
+	x, _ := new(decimal.Big).SetString("12345678901234.5678")
+	for i := 0; i < b.N; i++ {
+		_, _, _ = x.ArrowDecimal128(2)
+	}
+}
@@ -31,6 +31,12 @@ import (
 //
 // Additionally, each Big value has a contextual object which governs arithmetic
 // operations.
+//
+// The zero value for a Big is +0 with a scale of 0 and is ready to use, both as
+// a receiver and as an operand. Its zero Context is interpreted as GDA mode with
+// DefaultPrecision and ToNearestEven rounding. Every exported method treats the
+// zero value identically to a Big explicitly set to zero; e.g., its String
+// method returns "0", it compares equal to New(0, 0), and it may be marshaled.
 type Big struct {
 	// Context is the decimal's unique contextual object.
 	Context Context
@@ -143,6 +149,43 @@ const (
 	reduction
 	quointprec
 	remprec
+	nilop
+	inexactop
+	invctxcompat
+	invctxguard
+	invctxpgtp
+	roundsig
+	roundsiglt1
+	wrapping
+	wrapinf
+	wraprange
+	expnan
+	expunlim
+	modemix
+	lognan
+	logneg
+	logunlim
+	pownan
+	pow00
+	powneg
+	powunlim
+	sqrtnan
+	sqrtneg
+	sqrtunlim
+	hypotnan
+	hypotunlim
+	trignan
+	triginf
+	trighuge
+	trigunlim
+	atannan
+	atanunlim
+	constunlim
+	simulated
+	moneysign
+	invctxsltz
+	invctxsgtz
+	toolong
 )
 
 var payloads = [...]string{
@@ -175,6 +218,43 @@ var payloads = [...]string{
 	reduction:      "reduction with NaN as an operand",
 	quointprec:     "result of integer division was larger than the desired precision",
 	remprec:        "result of remainder operation was larger than the desired precision",
+	nilop:          "operation with a nil operand",
+	inexactop:      "inexact result with ExactOnly set",
+	invctxcompat:   "operation with an invalid CompatLevel",
+	invctxguard:    "operation with negative GuardDigits",
+	invctxpgtp:     "operation with a precision greater than MaxPracticalPrecision",
+	roundsig:       "rounding with NaN as an operand",
+	roundsiglt1:    "rounding to fewer than one significant digit",
+	wrapping:       "wrapping with NaN as an operand",
+	wrapinf:        "wrapping of an infinity",
+	wraprange:      "wrapping into an empty or infinite range",
+	expnan:         "exponential with NaN as an operand",
+	expunlim:       "exponential with unlimited precision",
+	modemix:        "operation with operands in a different OperatingMode",
+	lognan:         "logarithm with NaN as an operand",
+	logneg:         "logarithm of a negative number",
+	logunlim:       "logarithm with unlimited precision",
+	pownan:         "power with NaN as an operand",
+	pow00:          "zero to the power of zero",
+	powneg:         "negative number to a non-integral power",
+	powunlim:       "inexact power with unlimited precision",
+	sqrtnan:        "square root with NaN as an operand",
+	sqrtneg:        "square root of a negative number",
+	sqrtunlim:      "inexact square root with unlimited precision",
+	hypotnan:       "hypotenuse with NaN as an operand",
+	hypotunlim:     "inexact hypotenuse with unlimited precision",
+	trignan:        "trigonometric function with NaN as an operand",
+	triginf:        "trigonometric function of an infinity",
+	trighuge:       "trigonometric function of a huge argument",
+	trigunlim:      "inexact trigonometric function with unlimited precision",
+	atannan:        "arctangent with NaN as an operand",
+	atanunlim:      "inexact arctangent with unlimited precision",
+	constunlim:     "mathematical constant with unlimited precision",
+	simulated:      "simulated condition",
+	moneysign:      "money with invalid nanos or units and nanos of opposing signs",
+	invctxsltz:     "operation with a MaxScale less than zero",
+	invctxsgtz:     "operation with a MinScale greater than zero",
+	toolong:        "exact result with more than MaxPracticalPrecision digits",
 }
 
 func (p Payload) String() string {
@@ -192,10 +272,153 @@ func (e ErrNaN) Error() string { return e.Msg }
 
 var _ error = ErrNaN{}
 
+// An ErrNotExact is used when an operation cannot be computed exactly and its
+// Context has ExactOnly set. It is returned by Context.Err in GDA mode and used
+// as a panic value in Go mode.
+type ErrNotExact struct {
+	Op   string // the failed operation, e.g. "Quo"
+	Lost int    // the number of digits that would have been lost, or -1 if unbounded
+}
+
+func (e ErrNotExact) Error() string {
+	if e.Lost < 0 {
+		return "decimal: " + e.Op + " has a non-terminating result"
+	}
+	return fmt.Sprintf("decimal: %s would lose %d digit(s)", e.Op, e.Lost)
+}
+
+// Unwrap returns Inexact.
+func (e ErrNotExact) Unwrap() error { return Inexact }
+
+var _ error = ErrNotExact{}
+
+// A ConditionError is returned by Context.Err when a trapped Condition has been
+// raised. Cond holds every trapped flag raised. Op and Operands describe the
+// most recent operation that raised one, if it was performed by a Context
+// method, or a method of Big that uses one, with a Context that traps it; e.g.,
+// after Context128.Quo(z, New(1, 0), New(0, 0)), z.Context.Err() returns a
+// ConditionError with the Op "Quo" and the Operands "1" and "0".
+type ConditionError struct {
+	Cond     Condition // the trapped Conditions
+	Op       string    // the operation, e.g. "Quo", or "" if unknown
+	Operands []string  // Op's operands, as formatted by String beforehand
+}
+
+func (e ConditionError) Error() string {
+	if e.Op == "" {
+		return "decimal: " + e.Cond.String()
+	}
+	return "decimal: " + e.Op + "(" + strings.Join(e.Operands, ", ") + "): " + e.Cond.String()
+}
+
+// Unwrap returns e.Cond, so that, e.g., errors.Is(err, ErrOverflow) reports
+// whether err contains Overflow.
+func (e ConditionError) Unwrap() error { return e.Cond }
+
+var _ error = ConditionError{}
+
+// setInexact sets z to NaN because op, performed by c with ExactOnly set,
+// would lose lost digits, and records the failure in z's Context so that Err
+// reports it. It panics if c's OperatingMode is Go.
+func (c Context) setInexact(z *Big, op string, lost int) *Big {
+	err := ErrNotExact{Op: op, Lost: lost}
+	if c.OperatingMode == Go {
+		panic(err)
+	}
+	z.form = qnan
+	z.compact = uint64(inexactop)
+	z.Context.Conditions |= InvalidOperation | Inexact | Rounded
+	z.Context.hooks = z.Context.hooks.update(func(h *hooks) { h.inexact = &err })
+	return z
+}
+
+// An ErrNilOperand is used when a nil *Big is passed to a method. Methods which
+// set a non-nil result raise InvalidOperation and set the result to NaN (with
+// the payload "operation with a nil operand") when its OperatingMode is GDA, or
+// panic with an ErrNilOperand when its OperatingMode is Go. Methods without a
+// result to set—including those called with a nil receiver—always panic with
+// an ErrNilOperand, except for those returning an error (e.g., MarshalText),
+// which return one instead.
+type ErrNilOperand struct{ Op string }
+
+func (e ErrNilOperand) Error() string { return "decimal: nil operand passed to " + e.Op }
+
+var _ error = ErrNilOperand{}
+
+// An ErrModeMix is used when CheckModeMix is set and a method without a result
+// to set, such as Cmp, is passed decimals with different OperatingModes.
+type ErrModeMix struct{ Op string }
+
+func (e ErrModeMix) Error() string {
+	return "decimal: operands with different OperatingModes passed to " + e.Op
+}
+
+var _ error = ErrModeMix{}
+
+// An ErrInvalidSetting describes an invalid setting of a Context. It is
+// returned by Context.Validate and used as a panic value in Go mode by
+// operations performed with an invalid Context.
+type ErrInvalidSetting struct {
+	Field string // the invalid field, e.g. "Precision"
+	Value int    // the field's value
+	Msg   string // why the value is invalid
+}
+
+func (e ErrInvalidSetting) Error() string {
+	return fmt.Sprintf("decimal: invalid Context: %s is %d: %s", e.Field, e.Value, e.Msg)
+}
+
+// Unwrap returns InvalidContext.
+func (e ErrInvalidSetting) Unwrap() error { return InvalidContext }
+
+var _ error = ErrInvalidSetting{}
+
+// An ErrRange is returned by a checked conversion, such as Int64Checked, if the
+// converted value is infinite or does not fit in the destination type.
+type ErrRange struct{ Op string }
+
+func (e ErrRange) Error() string { return "decimal: " + e.Op + ": value out of range" }
+
+var _ error = ErrRange{}
+
+// checkNil reports whether z, x, or y is nil. If x or y is nil, z is set to NaN
+// and InvalidOperation is raised. It panics if z is nil or if z's OperatingMode
+// is Go.
+func (z *Big) checkNil(op string, x, y *Big) bool {
+	if z != nil && x != nil && y != nil {
+		return false
+	}
+	if z == nil || z.Context.OperatingMode == Go {
+		panic(ErrNilOperand{Op: op})
+	}
+	z.form = qnan
+	z.compact = uint64(nilop)
+	z.Context.Conditions |= InvalidOperation
+	return true
+}
+
+// mustNotNil panics with an ErrNilOperand if either x or y is nil.
+func mustNotNil(op string, x, y *Big) {
+	if x == nil || y == nil {
+		panic(ErrNilOperand{Op: op})
+	}
+}
+
+// context returns z's Context. It panics with an ErrNilOperand if z is nil.
+func (z *Big) context(op string) Context {
+	if z == nil {
+		panic(ErrNilOperand{Op: op})
+	}
+	return z.Context
+}
+
 // CheckNaNs checks if either x or y is NaN. If so, it follows the rules of NaN
 // handling set forth in the GDA specification. The second argument, y, may be
 // nil. It returns true if either condition is a NaN.
 func (z *Big) CheckNaNs(x, y *Big) bool {
+	if z.checkNil("CheckNaNs", x, x) {
+		return true
+	}
 	return z.invalidContext(z.Context) || z.checkNaNs(x, y, 0)
 }
 
@@ -265,21 +488,88 @@ func (x *Big) isInflated() bool { return !x.isCompact() }
 func (x *Big) isSpecial() bool  { return x.form&(inf|nan) != 0 }
 
 func (x *Big) adjusted() int { return (x.exp + x.Precision()) - 1 }
-func (c Context) etiny() int { return MinScale - (precision(c) - 1) }
+
+// etiny returns the smallest exponent of a subnormal in c, Etiny. At
+// compatibility level 1, it ignores c's MinScale.
+func (c Context) etiny() int {
+	if c.Compat() < 2 {
+		return MinScale - (precision(c) - 1)
+	}
+	return c.minScale() - (precision(c) - 1)
+}
 
 // Abs sets z to the absolute value of x and returns z.
 func (z *Big) Abs(x *Big) *Big {
+	if z.checkNil("Abs", x, x) {
+		return z
+	}
 	if debug {
 		x.validate()
 	}
-	if !z.invalidContext(z.Context) && !z.checkNaNs(x, x, absvalue) {
+	if !z.invalidContext(z.Context) && !z.mixedModes(z.Context, x) && !z.checkNaNs(x, x, absvalue) {
 		z.Context.round(z.copyAbs(x))
 	}
 	return z
 }
 
 // Add sets z to x + y and returns z.
-func (z *Big) Add(x, y *Big) *Big { return z.Context.Add(z, x, y) }
+func (z *Big) Add(x, y *Big) *Big { return z.context("Add").Add(z, x, y) }
+
+// AddFloat64 sets z to x + v and returns z. v is first converted by SetFloat64
+// to the shortest decimal that converts back to exactly v (e.g., 0.1 is
+// treated as 0.1, not
+// 0.1000000000000000055511151231257827021181583404541015625). The result is
+// identical to z.Add(x, y) where y is v formatted by strconv.FormatFloat(v, 'g',
+// -1, 64) and parsed with SetString.
+func (z *Big) AddFloat64(x *Big, v float64) *Big {
+	var y Big
+	return z.context("AddFloat64").Add(z, x, y.SetFloat64(v))
+}
+
+// AddInt64 sets z to x + v and returns z. It is identical to z.Add(x, New(v,
+// 0)), but does not allocate a Big for v.
+func (z *Big) AddInt64(x *Big, v int64) *Big {
+	var y Big
+	return z.context("AddInt64").Add(z, x, y.SetMantScale(v, 0))
+}
+
+// Append appends the string form of x, as generated by x.Format with the verb
+// fmt and precision prec, to buf and returns the extended buffer. It mirrors
+// strconv.AppendFloat: fmt is one of 'e', 'E', 'f', 'F', 'g', or 'G', and a
+// negative prec means no precision, so that x.Append(buf, 'f', -1) is
+// equivalent to fmt.Appendf(buf, "%f", x) and x.Append(buf, 'e', 3) to
+// fmt.Appendf(buf, "%.3e", x). Like strconv.AppendFloat, an invalid fmt
+// appends '%' followed by fmt.
+//
+// Append does not allocate if buf has sufficient capacity and x is compact.
+func (x *Big) Append(buf []byte, fmt byte, prec int) []byte {
+	if x == nil {
+		return append(buf, "<nil>"...)
+	}
+	if debug {
+		x.validate()
+	}
+
+	f := formatter{b: buf, prec: prec, width: noWidth}
+	hasPrec := prec >= 0
+	if !hasPrec {
+		f.prec = x.Precision()
+	}
+	switch fmt {
+	case 'e', 'E', 'f', 'F', 'g', 'G':
+		f.formatFloat(x, fmt, hasPrec)
+	default:
+		return append(buf, '%', fmt)
+	}
+	return f.b
+}
+
+// Atan sets z to the arctangent of x, in radians, and returns z.
+func (z *Big) Atan(x *Big) *Big { return z.context("Atan").Atan(z, x) }
+
+// Atan2 sets z to the arctangent of y/x, in radians, in the quadrant
+// determined by the signs of y and x, and returns z. See Context.Atan2.
+func (z *Big) Atan2(y, x *Big) *Big { return z.context("Atan2").Atan2(z, y, x) }
 
 // Class returns the ``class'' of x, which is one of the following:
 //
@@ -295,6 +585,7 @@ func (z *Big) Add(x, y *Big) *Big { return z.Context.Add(z, x, y) }
 //  +Infinity
 //
 func (x *Big) Class() string {
+	mustNotNil("Class", x, x)
 	if x.IsNaN(0) {
 		if x.IsNaN(+1) {
 			return "NaN"
@@ -333,7 +624,10 @@ func (x *Big) Class() string {
 //
 // It does not modify x or y. The result is undefined if either x or y are NaN.
 // For an abstract comparison with NaN values, see misc.CmpTotal.
-func (x *Big) Cmp(y *Big) int { return cmp(x, y, false) }
+func (x *Big) Cmp(y *Big) int {
+	mustNotMix("Cmp", x, y)
+	return cmp(x, y, false)
+}
 
 // CmpAbs compares |x| and |y| and returns:
 //
@@ -343,10 +637,24 @@ func (x *Big) Cmp(y *Big) int { return cmp(x, y, false) }
 //
 // It does not modify x or y. The result is undefined if either x or y are NaN.
 // For an abstract comparison with NaN values, see misc.CmpTotalAbs.
-func (x *Big) CmpAbs(y *Big) int { return cmp(x, y, true) }
+func (x *Big) CmpAbs(y *Big) int {
+	mustNotMix("CmpAbs", x, y)
+	return cmp(x, y, true)
+}
+
+// CmpInt64 compares x and v. It is identical to x.Cmp(New(v, 0)).
+func (x *Big) CmpInt64(v int64) int {
+	var y Big
+	return cmp(x, y.SetMantScale(v, 0), false)
+}
 
 // cmp is the implementation for both Cmp and CmpAbs.
 func cmp(x, y *Big, abs bool) int {
+	if abs {
+		mustNotNil("CmpAbs", x, y)
+	} else {
+		mustNotNil("Cmp", x, y)
+	}
 	if debug {
 		x.validate()
 		y.validate()
@@ -440,8 +748,12 @@ func cmpabs(x, y *Big) int {
 	return arith.CmpBits(xw, yw)
 }
 
-// Copy sets z to a copy of x and returns z.
+// Copy sets z to a copy of x, including its tag, and returns z. z's Context is
+// not modified; see Clone.
 func (z *Big) Copy(x *Big) *Big {
+	if z.checkNil("Copy", x, x) {
+		return z
+	}
 	if debug {
 		x.validate()
 	}
@@ -453,6 +765,34 @@ func (z *Big) Copy(x *Big) *Big {
 	return z
 }
 
+// Clone returns a new Big with the same value, tag, and Context as x. Unlike
+// assigning *x to another Big, which shares x's coefficient when it does not
+// fit in a uint64, the clone owns a copy of the coefficient, so neither x nor
+// the clone is affected by later changes to the other. The clone's Context is
+// x's Context, except that its Conditions are cleared and Err reports nil.
+//
+// Clone differs from Copy and Set in which Context the result has: z.Copy(x)
+// and z.Set(x) keep z's Context. Copy and Clone never round. In GDA mode, Set
+// rounds the result to z's Precision; in Go and JS mode, Set does not round,
+// but the result may still overflow or underflow z's MaxScale or MinScale.
+func (x *Big) Clone() *Big {
+	mustNotNil("Clone", x, x)
+	return x.CloneWithContext(x.Context)
+}
+
+// CloneWithContext is like Clone, but the clone uses the Context ctx. The
+// value is not rounded to ctx; use ctx.Round to do so.
+func (x *Big) CloneWithContext(ctx Context) *Big {
+	mustNotNil("CloneWithContext", x, x)
+	ctx.Conditions = 0
+	ctx.hooks = ctx.hooks.settings()
+	z := &Big{Context: ctx}
+	sign := x.form & signbit
+	z.copyAbs(x)
+	z.form |= sign
+	return z
+}
+
 // copyAbs sets z to a copy of |x| and returns z.
 func (z *Big) copyAbs(x *Big) *Big {
 	if z != x {
@@ -462,6 +802,9 @@ func (z *Big) copyAbs(x *Big) *Big {
 		if x.IsFinite() && x.isInflated() {
 			z.unscaled.Set(&x.unscaled)
 		}
+		if x.Context.hooks != nil || z.Context.hooks != nil {
+			z.setTag(x.tag())
+		}
 	}
 	z.form = x.form & ^signbit
 	return z
@@ -469,6 +812,9 @@ func (z *Big) copyAbs(x *Big) *Big {
 
 // CopySign sets z to x with the sign of y and returns z. It accepts NaN values.
 func (z *Big) CopySign(x, y *Big) *Big {
+	if z.checkNil("CopySign", x, y) {
+		return z
+	}
 	if debug {
 		x.validate()
 		y.validate()
@@ -480,11 +826,19 @@ func (z *Big) CopySign(x, y *Big) *Big {
 	return z
 }
 
-// Float64 returns x as a float64 and a bool indicating whether x can fit into
-// a float64 without truncation, overflow, or underflow. Special values are
-// considered exact; however, special values that occur because the magnitude of
-// x is too large to be represented as a float64 are not.
-func (x *Big) Float64() (f float64, ok bool) {
+// Cos sets z to the cosine of x, in radians, and returns z.
+func (z *Big) Cos(x *Big) *Big { return z.context("Cos").Cos(z, x) }
+
+// Float64 returns x as a float64, rounded to nearest with ties to even, and
+// reports whether the conversion is exact: 0.5, 3, and 1.25 are exact, while
+// 0.1 is not. A magnitude too large to be represented is converted to ±Inf and
+// one too small is converted to ±0, neither of which is exact.
+//
+// Infinities are converted exactly. NaN values are converted to a NaN with
+// the same sign and are also considered exact; unlike most methods, Float64
+// never panics for a NaN, even in the Go OperatingMode.
+func (x *Big) Float64() (f float64, exact bool) {
+	mustNotNil("Float64", x, x)
 	if debug {
 		x.validate()
 	}
@@ -492,7 +846,7 @@ func (x *Big) Float64() (f float64, ok bool) {
 	if !x.IsFinite() {
 		switch x.form {
 		case pinf, ninf:
-			return math.Inf(int(x.form & signbit)), true
+			return math.Inf(x.Sign()), true
 		case snan, qnan:
 			return math.NaN(), true
 		case ssnan, sqnan:
@@ -501,31 +855,213 @@ func (x *Big) Float64() (f float64, ok bool) {
 	}
 
 	const maxPow10 = 22         // largest exact power of 10
-	const maxMantissa = 1 << 52 // largest exact mantissa
-	switch {
-	case !x.isCompact():
-		f, _ = strconv.ParseFloat(x.String(), 64)
-		ok = !math.IsInf(f, 0) && !math.IsNaN(f)
-	case x.compact == 0:
-		ok = true
-	case x.exp == 0:
-		f, ok = float64(x.compact), true
-	case x.exp > 0:
-		f = float64(x.compact) * math.Pow10(x.exp)
-		ok = x.compact < maxMantissa && x.exp < maxPow10
-	case x.exp < 0:
-		f = float64(x.compact) / math.Pow10(-x.exp)
-		ok = x.compact < maxMantissa && x.exp > -maxPow10
+	const maxMantissa = 1 << 53 // largest exact mantissa
+	// When both the mantissa and the power of 10 are exact, a single
+	// multiplication or division is correctly rounded, and FMA computes its
+	// error without rounding.
+	switch m := float64(x.compact); {
+	case x.Sign() == 0:
+		exact = true
+	case !x.isCompact() || x.compact > maxMantissa || x.exp > maxPow10 || x.exp < -maxPow10:
+		f, acc := x.Float64Round(ToNearestEven)
+		return f, acc == big.Exact
+	case x.exp >= 0:
+		p := math.Pow10(x.exp)
+		f = m * p
+		exact = math.FMA(m, p, -f) == 0
+	default:
+		p := math.Pow10(-x.exp)
+		f = m / p
+		exact = math.FMA(f, p, -m) == 0
 	}
 	if x.form&signbit != 0 {
 		f = math.Copysign(f, -1)
 	}
-	return f, ok
+	return f, exact
+}
+
+// Float64Round returns x as a float64 rounded using mode, along with the
+// accuracy of the result: Below if the result is less than x, Above if it is
+// greater, and Exact if it is equal. For example, ToNegativeInf returns the
+// largest float64 less than or equal to x and ToPositiveInf returns the
+// smallest float64 greater than or equal to x.
+//
+// The result is always correctly rounded, including when it is subnormal, and
+// Float64 is equivalent to Float64Round(ToNearestEven). As in IEEE 754
+// arithmetic, a magnitude too large to be represented rounds to
+// ±math.MaxFloat64 when rounding toward zero and to ±Inf otherwise.
+// Infinities and NaN values are converted as by Float64 and are Exact.
+func (x *Big) Float64Round(mode RoundingMode) (float64, big.Accuracy) {
+	mustNotNil("Float64Round", x, x)
+	if debug {
+		x.validate()
+	}
+	return x.roundFloat(mode, &float64Format)
+}
+
+// Float32 returns x as a float32, rounded to nearest with ties to even, and
+// reports whether the conversion is exact. x is rounded directly to a float32,
+// not to a float64 first, so the result is never double rounded. Like Float64,
+// a magnitude too large to be represented is converted to ±Inf and one too
+// small is converted to ±0, neither of which is exact, and NaN values are
+// converted without panicking.
+func (x *Big) Float32() (f float32, exact bool) {
+	mustNotNil("Float32", x, x)
+	if debug {
+		x.validate()
+	}
+	f64, acc := x.roundFloat(ToNearestEven, &float32Format)
+	return float32(f64), acc == big.Exact
+}
+
+// floatFormat describes an IEEE 754 binary floating-point format.
+type floatFormat struct {
+	mantBits int     // bits in the mantissa, including the implicit bit
+	maxShift int     // fractional bits in the smallest nonzero value
+	minShift int     // -log2 of the ulp of the largest finite value
+	maxAdj   int     // magnitudes with larger adjusted exponents overflow
+	minAdj   int     // magnitudes with smaller adjusted exponents are < min/2
+	max      float64 // largest finite value
+	min      float64 // smallest nonzero value
+}
+
+var (
+	float64Format = floatFormat{
+		mantBits: 53,
+		maxShift: 1074,
+		minShift: -971,
+		maxAdj:   308, // |x| >= 1E+309 > math.MaxFloat64
+		minAdj:   -324,
+		max:      math.MaxFloat64,
+		min:      math.SmallestNonzeroFloat64,
+	}
+	float32Format = floatFormat{
+		mantBits: 24,
+		maxShift: 149,
+		minShift: -104,
+		maxAdj:   38, // |x| >= 1E+39 > math.MaxFloat32
+		minAdj:   -46,
+		max:      math.MaxFloat32,
+		min:      math.SmallestNonzeroFloat32,
+	}
+)
+
+// roundFloat returns x rounded to the format ff using mode. The result is
+// returned as a float64, which represents every value of ff exactly.
+func (x *Big) roundFloat(mode RoundingMode, ff *floatFormat) (float64, big.Accuracy) {
+	if !x.IsFinite() || x.Sign() == 0 {
+		f, _ := x.Float64()
+		return f, big.Exact
+	}
+
+	// Round the magnitude of x, converting the directed modes to the
+	// equivalent modes for the magnitude.
+	neg := x.Signbit()
+	m := mode
+	switch mode {
+	case ToNegativeInf:
+		m = ToZero
+		if neg {
+			m = AwayFromZero
+		}
+	case ToPositiveInf:
+		m = AwayFromZero
+		if neg {
+			m = ToZero
+		}
+	}
+
+	var f float64
+	var acc big.Accuracy
+	switch adj := x.adjusted(); {
+	case adj > ff.maxAdj:
+		f, acc = ff.overflow(m)
+	case adj < ff.minAdj:
+		// Less than half of the smallest nonzero value.
+		f, acc = 0, big.Below
+		if m == AwayFromZero {
+			f, acc = ff.min, big.Above
+		}
+	default:
+		f, acc = ff.ratRound(x.Rat(nil), m)
+	}
+	if neg {
+		return -f, -acc
+	}
+	return f, acc
+}
+
+// ratRound returns the positive, finite x rounded to ff using m, which must
+// not be ToNegativeInf or ToPositiveInf.
+func (ff *floatFormat) ratRound(x *big.Rat, m RoundingMode) (float64, big.Accuracy) {
+	// Find the shift s such that q = ⌊x·2**s⌋ has mantBits bits, or fewer if
+	// the result is subnormal. x·2**s is in (2**(mantBits-1), 2**(mantBits+1))
+	// for the first guess.
+	num, den := new(big.Int).Abs(x.Num()), x.Denom()
+	s := ff.mantBits - (num.BitLen() - den.BitLen())
+	var q, r, n, d big.Int
+	for {
+		if s > ff.maxShift {
+			s = ff.maxShift
+		}
+		n.Set(num)
+		d.Set(den)
+		if s >= 0 {
+			n.Lsh(&n, uint(s))
+		} else {
+			d.Lsh(&d, uint(-s))
+		}
+		q.QuoRem(&n, &d, &r)
+		if q.BitLen() <= ff.mantBits {
+			break
+		}
+		s--
+	}
+	if s < ff.minShift {
+		// x >= 2**(mantBits-1) · 2**(1-minShift), which is past the largest
+		// finite value.
+		return ff.overflow(m)
+	}
+
+	mant := q.Uint64()
+	acc := big.Exact
+	if r.Sign() != 0 {
+		acc = big.Below
+		var inc bool
+		switch m {
+		case ToZero:
+		case AwayFromZero:
+			inc = true
+		default:
+			c := r.Lsh(&r, 1).Cmp(&d)
+			inc = c > 0 || c == 0 && (m == ToNearestAway || mant&1 != 0)
+		}
+		if inc {
+			mant++
+			acc = big.Above
+		}
+	}
+	f := math.Ldexp(float64(mant), -s)
+	if f > ff.max {
+		// Rounding the largest finite value up overflows to +Inf.
+		return math.Inf(+1), big.Above
+	}
+	return f, acc
+}
+
+// overflow returns the result of rounding a positive value larger than ff.max
+// using m.
+func (ff *floatFormat) overflow(m RoundingMode) (float64, big.Accuracy) {
+	if m == ToZero {
+		return ff.max, big.Below
+	}
+	return math.Inf(+1), big.Above
 }
 
 // Float sets z to x and returns z. z is allowed to be nil. The result is
 // undefined if z is a NaN value.
 func (x *Big) Float(z *big.Float) *big.Float {
+	mustNotNil("Float", x, x)
 	if debug {
 		x.validate()
 	}
@@ -553,26 +1089,40 @@ func (x *Big) Float(z *big.Float) *big.Float {
 // supported:
 //
 // 	%s: -dddd.dd or -d.dddd±edd, depending on x
-// 	%d: same as %s
+// 	%d: -dddd, if x is an integer
 // 	%v: same as %s
 // 	%e: -d.dddd±edd
 // 	%E: -d.dddd±Edd
 // 	%f: -dddd.dd
-// 	%g: same as %f
+// 	%g: %e if the adjusted exponent is less than -4 or at least the
+// 	    precision (21 if none is given), %f otherwise
+// 	%G: same as %g, but using %E
 //
 // While width is honored in the same manner as the fmt package (the minimum
 // width of the formatted number), precision is the number of significant digits
 // in the decimal number. Given %f, however, precision is the number of digits
-// following the radix.
+// following the radix. %d ignores precision.
+//
+// %d formats an integer x, such as 12.00 or 1.2E+3, without a fraction or
+// exponent. Rather than drop the fractional digits of an x that is not an
+// integer, it reports the error in the output as the fmt package does for bad
+// verbs: e.g., %d of 1.5 is %!d(decimal=1.5). Like every verb, it does not
+// modify x, so no conditions are raised.
 //
 // Format honors all flags (such as '+' and ' ') in the same manner as the fmt
 // package, except for '#'. Unless used in conjunction with %v, %q, or %p, the
 // '#' flag will be ignored; decimals have no defined hexadeximal or octal
-// representation.
+// representation. The '0' flag pads with zeros after the sign, but NaN and
+// infinities, which are formatted according to x's OperatingMode, are always
+// padded with spaces.
 //
 // %+v, %#v, %T, %#p, and %p all honor the formats specified in the fmt
 // package's documentation.
 func (x *Big) Format(s fmt.State, c rune) {
+	if x == nil {
+		io.WriteString(s, "<nil>")
+		return
+	}
 	if debug {
 		x.validate()
 	}
@@ -587,39 +1137,31 @@ func (x *Big) Format(s fmt.State, c rune) {
 	}
 
 	var (
-		hash    = s.Flag('#')
-		dash    = s.Flag('-')
-		lpZero  = s.Flag('0')
-		lpSpace = width != noWidth && !dash && !lpZero
-		plus    = s.Flag('+')
-		space   = s.Flag(' ')
-		f       = formatter{prec: prec, width: width}
-		e       = sciE[x.Context.OperatingMode]
+		hash   = s.Flag('#')
+		dash   = s.Flag('-')
+		lpZero = s.Flag('0') && !dash
+		plus   = s.Flag('+')
+		space  = s.Flag(' ')
+		buf    [64]byte
+		f      = formatter{b: buf[:0], prec: prec, width: width}
+		e      = sciE[x.Context.OperatingMode]
 	)
 
-	// If we need to left pad then we need to first write our string into an
-	// empty buffer.
-	tmpbuf := lpZero || lpSpace
-	if tmpbuf {
-		b := new(strings.Builder)
-		b.Grow(x.Precision())
-		f.w = b
-	} else {
-		f.w = stateWrapper{s}
-	}
-
 	if plus {
 		f.sign = '+'
 	} else if space {
 		f.sign = ' '
 	}
 
-	// noE is a placeholder for formats that do not use scientific notation
-	// and don't require 'e' or 'E'
-	const noE = 0
 	switch c {
-	case 's', 'd':
+	case 's':
 		f.format(x, normal, e)
+	case 'd':
+		if x.IsFinite() && !x.IsInt() {
+			fmt.Fprintf(s, "%%!d(decimal=%s)", x.String())
+			return
+		}
+		f.formatInt(x)
 	case 'q':
 		// The fmt package's docs specify that the '+' flag
 		// "guarantee[s] ASCII-only output for %q (%+q)"
@@ -634,29 +1176,8 @@ func (x *Big) Format(s fmt.State, c rune) {
 		f.WriteByte(quote)
 		f.format(x, normal, e)
 		f.WriteByte(quote)
-	case 'e', 'E':
-		f.format(x, sci, byte(c))
-	case 'f', 'F':
-		if !hasPrec {
-			prec = 0
-		} else {
-
-			// %f's precision means "number of digits after the radix"
-			if x.exp > 0 {
-				f.prec += x.Precision()
-			} else {
-				if adj := x.exp + x.Precision(); adj > -f.prec {
-					f.prec += adj
-				} else {
-					f.prec = -f.prec
-				}
-			}
-		}
-
-		f.format(x, plain, noE)
-	case 'g', 'G':
-		// %g's precision means "number of significant digits"
-		f.format(x, plain, noE)
+	case 'e', 'E', 'f', 'F', 'g', 'G':
+		f.formatFloat(x, byte(c), hasPrec)
 
 	// Make sure we return from the following two cases.
 	case 'v':
@@ -698,32 +1219,56 @@ func (x *Big) Format(s fmt.State, c rune) {
 	}
 
 	// Need padding out to width.
-	if f.n < int64(width) {
-		switch pad := int64(width) - f.n; {
+	b := f.b
+	if pad := width - len(b); pad > 0 {
+		p := formatter{b: make([]byte, 0, width)}
+		switch {
 		case dash:
-			io.CopyN(s, spaceReader{}, pad)
-		case lpZero:
-			io.CopyN(s, zeroReader{}, pad)
-		case lpSpace:
-			io.CopyN(s, spaceReader{}, pad)
+			p.Write(b)
+			p.pad(' ', pad)
+		case lpZero && x.IsFinite():
+			// Like the fmt package, zeros go between the sign and the digits.
+			if b[0] == '-' || b[0] == '+' || b[0] == ' ' {
+				p.WriteByte(b[0])
+				b = b[1:]
+			}
+			p.pad('0', pad)
+			p.Write(b)
+		default:
+			// NaN and infinities are padded with spaces, even with the '0'
+			// flag.
+			p.pad(' ', pad)
+			p.Write(b)
 		}
+		b = p.b
 	}
-
-	if tmpbuf {
-		// fmt's internal state type implements stringWriter I think.
-		io.WriteString(s, f.w.(*strings.Builder).String())
-	}
+	s.Write(b)
 }
 
 var _ fmt.Formatter = (*Big)(nil)
 
-// FMA sets z to (x * y) + u without any intermediate rounding.
-func (z *Big) FMA(x, y, u *Big) *Big { return z.Context.FMA(z, x, y, u) }
+// Dot sets z to the dot product of x and y and returns z. See Context.Dot.
+func (z *Big) Dot(x, y []*Big) *Big { return z.context("Dot").Dot(z, x, y) }
 
-// Int sets z to x, truncating the fractional portion (if any) and returns z. z
-// is allowed to be nil. If x is an infinity or a NaN value the result is
-// undefined.
-func (x *Big) Int(z *big.Int) *big.Int {
+// Exp sets z to e**x and returns z. See Context.Exp.
+func (z *Big) Exp(x *Big) *Big { return z.context("Exp").Exp(z, x) }
+
+// Expm1 sets z to e**x - 1 and returns z. See Context.Expm1.
+func (z *Big) Expm1(x *Big) *Big { return z.context("Expm1").Expm1(z, x) }
+
+// FMA sets z to (x * y) + u without any intermediate rounding.
+func (z *Big) FMA(x, y, u *Big) *Big { return z.context("FMA").FMA(z, x, y, u) }
+
+// Hypot sets z to sqrt(p*p + q*q) and returns z. See Context.Hypot.
+func (z *Big) Hypot(p, q *Big) *Big { return z.context("Hypot").Hypot(z, p, q) }
+
+// Int sets z to x, truncating the fractional portion (if any), and returns z
+// and whether the conversion was exact; that is, whether x had no non-zero
+// fractional digits. z is allowed to be nil. The integer part of x can be
+// arbitrarily large; for example, 1E+40 is converted exactly. If x is an
+// infinity or a NaN value, z is set to zero and the conversion is not exact.
+func (x *Big) Int(z *big.Int) (*big.Int, bool) {
+	mustNotNil("Int", x, x)
 	if debug {
 		x.validate()
 	}
@@ -733,7 +1278,7 @@ func (x *Big) Int(z *big.Int) *big.Int {
 	}
 
 	if !x.IsFinite() {
-		return z
+		return z.SetUint64(0), false
 	}
 
 	if x.isCompact() {
@@ -745,81 +1290,300 @@ func (x *Big) Int(z *big.Int) *big.Int {
 		z.Neg(z)
 	}
 	if x.exp == 0 {
-		return z
+		return z, true
+	}
+	if x.exp > 0 {
+		return bigScalex(z, z, x.exp), true
+	}
+	if -x.exp > x.Precision() {
+		// Every digit is fractional.
+		exact := z.Sign() == 0
+		return z.SetUint64(0), exact
 	}
-	return bigScalex(z, z, x.exp)
+	var r big.Int
+	z.QuoRem(z, arith.BigPow10(uint64(-x.exp)), &r)
+	return z, r.Sign() == 0
 }
 
-// Int64 returns x as an int64, truncating towards zero. The returned boolean
-// indicates whether the conversion to an int64 was successful.
+// Int64 returns x as an int64 and a bool indicating whether the conversion was
+// successful. It is unsuccessful, and returns 0, if x has a non-zero
+// fractional part, is outside the range of an int64, or is an infinity or NaN
+// value. For example, 9223372036854775807 and -1.00 are converted, but
+// 9223372036854775808 and 1.5 are not. Use Int64Saturating to round or clamp
+// x instead.
 func (x *Big) Int64() (int64, bool) {
+	mustNotNil("Int64", x, x)
+	if debug {
+		x.validate()
+	}
+
+	u, ok := x.uint64Abs()
+	switch {
+	case !ok:
+		return 0, false
+	case x.Signbit():
+		if u > 1<<63 {
+			return 0, false
+		}
+		// -int64(1<<63) wraps to math.MinInt64, as desired.
+		return -int64(u), true
+	case u > math.MaxInt64:
+		return 0, false
+	default:
+		return int64(u), true
+	}
+}
+
+// Uint64 returns x as a uint64 and a bool indicating whether the conversion was
+// successful. Like Int64, it is unsuccessful, and returns 0, if x has a
+// non-zero fractional part, is outside the range of a uint64, or is an
+// infinity or NaN value. For example, 18446744073709551615 and 1E+19 are
+// converted, but -1 and 0.5 are not.
+func (x *Big) Uint64() (uint64, bool) {
+	mustNotNil("Uint64", x, x)
 	if debug {
 		x.validate()
 	}
 
+	u, ok := x.uint64Abs()
+	if !ok || x.Signbit() && u != 0 {
+		return 0, false
+	}
+	return u, true
+}
+
+// uint64Abs returns |x| as a uint64 and whether x is a finite integer whose
+// magnitude fits into a uint64.
+func (x *Big) uint64Abs() (uint64, bool) {
 	if !x.IsFinite() {
 		return 0, false
 	}
 
-	// x might be too large to fit into an int64 *now*, but rescaling x might
+	// x might be too large to fit into a uint64 *now*, but rescaling x might
 	// shrink it enough. See issue #20.
 	if !x.isCompact() {
-		xb := x.Int(nil)
-		return xb.Int64(), xb.IsInt64()
+		xb, exact := x.Int(nil)
+		xb.Abs(xb)
+		return xb.Uint64(), exact && xb.IsUint64()
 	}
 
 	u := x.compact
-	if x.exp != 0 {
-		var ok bool
-		if u, ok = scalex(u, x.exp); !ok {
-			return 0, false
+	switch {
+	case x.exp > 0:
+		return checked.MulPow10(u, uint64(x.exp))
+	case x.exp < 0:
+		p, ok := arith.Pow10(uint64(-x.exp))
+		if !ok {
+			// 10**-x.exp > u, so x is a fraction unless it is zero.
+			return 0, u == 0
 		}
+		return u / p, u%p == 0
+	default:
+		return u, true
 	}
-	su := int64(u)
-	if su >= 0 || x.Signbit() && su == -su {
-		if x.Signbit() {
-			su = -su
+}
+
+// Int64Saturating returns x × 10^scale rounded to an integer using x's
+// RoundingMode. For example, if x is 1.2345, x.Int64Saturating(6) returns
+// 1234500, the number of millionths in x. If the result does not fit in an
+// int64, it is clamped to math.MinInt64 or math.MaxInt64.
+//
+// Discarding non-zero digits raises Inexact and Rounded, and clamping raises
+// Clamped and Inexact, in x's Context. If x is NaN, Int64Saturating returns 0
+// and raises InvalidOperation.
+//
+// The result is computed without materializing x × 10^scale, so values whose
+// result is far outside the range of an int64 are clamped cheaply.
+func (x *Big) Int64Saturating(scale int) int64 {
+	mustNotNil("Int64Saturating", x, x)
+	v, fits, cond := x.scaledInt64(scale)
+	if !fits {
+		cond |= Clamped | Inexact
+	}
+	x.Context.Conditions |= cond
+	return v
+}
+
+// Int64Checked is like Int64Saturating, but returns an ErrRange instead of
+// clamping if x is infinite or the result does not fit in an int64, or an
+// ErrNaN if x is NaN. Only Inexact and Rounded are raised in x's Context, and
+// only if the conversion succeeds.
+func (x *Big) Int64Checked(scale int) (int64, error) {
+	mustNotNil("Int64Checked", x, x)
+	if x.IsNaN(0) {
+		return 0, ErrNaN{Msg: "Int64Checked of NaN"}
+	}
+	v, fits, cond := x.scaledInt64(scale)
+	if !fits {
+		return 0, ErrRange{Op: "Int64Checked"}
+	}
+	x.Context.Conditions |= cond
+	return v, nil
+}
+
+// Uint64Saturating is like Int64Saturating, but converts to a uint64: results
+// less than zero are clamped to 0 and results greater than math.MaxUint64 are
+// clamped to math.MaxUint64. A negative x that rounds to zero is not clamped.
+func (x *Big) Uint64Saturating(scale int) uint64 {
+	mustNotNil("Uint64Saturating", x, x)
+	v, fits, cond := x.scaledUint64(scale)
+	if !fits {
+		cond |= Clamped | Inexact
+	}
+	x.Context.Conditions |= cond
+	return v
+}
+
+// Uint64Checked is like Int64Checked, but converts to a uint64.
+func (x *Big) Uint64Checked(scale int) (uint64, error) {
+	mustNotNil("Uint64Checked", x, x)
+	if x.IsNaN(0) {
+		return 0, ErrNaN{Msg: "Uint64Checked of NaN"}
+	}
+	v, fits, cond := x.scaledUint64(scale)
+	if !fits {
+		return 0, ErrRange{Op: "Uint64Checked"}
+	}
+	x.Context.Conditions |= cond
+	return v, nil
+}
+
+// scaledInt64 returns x × 10^scale rounded to an int64, whether it fit, and the
+// conditions the conversion raised. If it did not fit, the result is clamped.
+func (x *Big) scaledInt64(scale int) (v int64, fits bool, cond Condition) {
+	if x.IsNaN(0) {
+		return 0, true, InvalidOperation
+	}
+	neg := x.Signbit()
+	mag, inexact, ok := x.scaledAbs(scale)
+	if inexact {
+		cond = Inexact | Rounded
+	}
+	if neg {
+		if !ok || mag > 1<<63 {
+			return math.MinInt64, false, 0
 		}
-		return su, true
+		return -int64(mag), true, cond
+	}
+	if !ok || mag > math.MaxInt64 {
+		return math.MaxInt64, false, 0
 	}
-	return 0, false
+	return int64(mag), true, cond
 }
 
-// Uint64 returns x as an int64, truncating towards zero. The returned boolean
-// indicates whether the conversion to a uint64 was successful.
-func (x *Big) Uint64() (uint64, bool) {
-	if debug {
-		x.validate()
+// scaledUint64 is like scaledInt64, but for a uint64.
+func (x *Big) scaledUint64(scale int) (v uint64, fits bool, cond Condition) {
+	if x.IsNaN(0) {
+		return 0, true, InvalidOperation
 	}
+	mag, inexact, ok := x.scaledAbs(scale)
+	if inexact {
+		cond = Inexact | Rounded
+	}
+	switch {
+	case x.Signbit() && (!ok || mag != 0):
+		return 0, false, 0
+	case !ok:
+		return math.MaxUint64, false, 0
+	default:
+		return mag, true, cond
+	}
+}
 
-	if !x.IsFinite() || x.Signbit() {
-		return 0, false
+// scaledAbs returns |x| × 10^scale rounded to an integer using x's
+// RoundingMode and whether the rounding discarded any non-zero digits. ok is
+// false if x is infinite or the result does not fit in a uint64. The result is
+// computed in a single step, without materializing more digits than a uint64
+// can hold.
+func (x *Big) scaledAbs(scale int) (mag uint64, inexact, ok bool) {
+	if x.IsInf(0) {
+		return 0, false, false
+	}
+	if x.compact == 0 {
+		return 0, false, true
 	}
 
-	// x might be too large to fit into an uint64 *now*, but rescaling x might
-	// shrink it enough. See issue #20.
-	if !x.isCompact() {
-		xb := x.Int(nil)
-		return xb.Uint64(), xb.IsUint64()
+	// Guard against overflow of x.exp + scale. Either bound is far enough
+	// beyond the range of a uint64 to be handled below.
+	e := x.exp + scale
+	if scale > 0 && e < x.exp {
+		e = math.MaxInt32
+	} else if scale < 0 && e > x.exp {
+		e = math.MinInt32
 	}
 
-	b := x.compact
-	if x.exp == 0 {
-		return b, true
+	// |x| × 10^scale has p + e digits before rounding, and a uint64 holds at
+	// most 20.
+	p := x.Precision()
+	if e > 20-p {
+		return 0, false, false
+	}
+
+	if e >= 0 {
+		if x.isCompact() {
+			mag, ok = checked.MulPow10(x.compact, uint64(e))
+			return mag, false, ok
+		}
+		var t big.Int
+		checked.MulBigPow10(&t, &x.unscaled, uint64(e))
+		return t.Uint64(), false, t.IsUint64()
+	}
+
+	// r compares the discarded digits with one half: r > 0 if they're greater,
+	// r == 0 if equal, and r < 0 if less.
+	var r int
+	if e < -p {
+		// 0 < |x| × 10^scale < 0.1
+		mag, r = 0, -1
+	} else if n := uint64(-e); x.isCompact() && n < arith.PowTabLen {
+		pow, _ := arith.Pow10(n)
+		rem := x.compact % pow
+		if rem == 0 {
+			return x.compact / pow, false, true
+		}
+		mag, r = x.compact/pow, arith.Cmp(rem, pow-rem)
+	} else {
+		var q, rem big.Int
+		var t *big.Int
+		if x.isCompact() {
+			t = new(big.Int).SetUint64(x.compact)
+		} else {
+			t = &x.unscaled
+		}
+		pow := arith.BigPow10(n)
+		q.QuoRem(t, pow, &rem)
+		if !q.IsUint64() {
+			return 0, false, false
+		}
+		if rem.Sign() == 0 {
+			return q.Uint64(), false, true
+		}
+		mag, r = q.Uint64(), rem.Lsh(&rem, 1).Cmp(pow)
 	}
-	return scalex(b, x.exp)
+	if x.Context.roundingMode().needsInc(mag&1 != 0, r, !x.Signbit()) {
+		if mag == math.MaxUint64 {
+			return 0, true, false
+		}
+		mag++
+	}
+	return mag, true, true
 }
 
 // IsFinite returns true if x is finite.
-func (x *Big) IsFinite() bool { return x.form & ^signbit == 0 }
+func (x *Big) IsFinite() bool {
+	mustNotNil("IsFinite", x, x)
+	return x.form & ^signbit == 0
+}
 
 // IsNormal returns true if x is normal.
 func (x *Big) IsNormal() bool {
+	mustNotNil("IsNormal", x, x)
 	return x.IsFinite() && x.adjusted() >= x.Context.minScale()
 }
 
 // IsSubnormal returns true if x is subnormal.
 func (x *Big) IsSubnormal() bool {
+	mustNotNil("IsSubnormal", x, x)
 	return x.IsFinite() && x.adjusted() < x.Context.minScale()
 }
 
@@ -828,6 +1592,7 @@ func (x *Big) IsSubnormal() bool {
 // If sign <  0, IsInf reports whether x is negative infinity.
 // If sign == 0, IsInf reports whether x is either infinity.
 func (x *Big) IsInf(sign int) bool {
+	mustNotNil("IsInf", x, x)
 	return sign >= 0 && x.form == pinf || sign <= 0 && x.form == ninf
 }
 
@@ -836,12 +1601,14 @@ func (x *Big) IsInf(sign int) bool {
 // If sign <  0, IsNaN reports whether x is signaling NaN.
 // If sign == 0, IsNaN reports whether x is either NaN.
 func (x *Big) IsNaN(quiet int) bool {
+	mustNotNil("IsNaN", x, x)
 	return quiet >= 0 && x.form&qnan == qnan || quiet <= 0 && x.form&snan == snan
 }
 
 // IsInt reports whether x is an integer. Infinity and NaN values are not
 // integers.
 func (x *Big) IsInt() bool {
+	mustNotNil("IsInt", x, x)
 	if debug {
 		x.validate()
 	}
@@ -886,32 +1653,131 @@ func (x *Big) IsInt() bool {
 	return exp >= 0
 }
 
-// MarshalText implements encoding.TextMarshaler.
+// Log sets z to the natural logarithm of x and returns z. See Context.Log.
+func (z *Big) Log(x *Big) *Big { return z.context("Log").Log(z, x) }
+
+// Log10 sets z to the base-10 logarithm of x and returns z. See Context.Log10.
+func (z *Big) Log10(x *Big) *Big { return z.context("Log10").Log10(z, x) }
+
+// Log1p sets z to ln(1 + x) and returns z. See Context.Log1p.
+func (z *Big) Log1p(x *Big) *Big { return z.context("Log1p").Log1p(z, x) }
+
+// MantScale returns x's coefficient and scale, such that x = mant × 10^-scale.
+// Unlike Int64, the coefficient is not rescaled: 1.50 has a mantissa of 150 and
+// a scale of 2. The returned boolean is false if x is not finite or its
+// coefficient does not fit into an int64. The sign of a negative zero is lost.
+//
+// NewMantScale(x.MantScale()) has the same value and scale as x.
+func (x *Big) MantScale() (mant int64, scale int, ok bool) {
+	mustNotNil("MantScale", x, x)
+	if debug {
+		x.validate()
+	}
+
+	if !x.IsFinite() {
+		return 0, 0, false
+	}
+
+	u := x.compact
+	if !x.isCompact() {
+		if !x.unscaled.IsUint64() {
+			return 0, 0, false
+		}
+		u = x.unscaled.Uint64()
+	}
+	if x.Signbit() {
+		if u > 1<<63 {
+			return 0, 0, false
+		}
+		// -int64(1<<63) == math.MinInt64
+		return -int64(u), -x.exp, true
+	}
+	if u > math.MaxInt64 {
+		return 0, 0, false
+	}
+	return int64(u), -x.exp, true
+}
+
+// MantScaleBig sets z to x's coefficient and returns x's scale, such that x =
+// z × 10^-scale. It is like MantScale, but handles coefficients of any size. If
+// x is not finite, z is set to zero and the returned scale is zero. z must not
+// be nil.
+func (x *Big) MantScaleBig(z *big.Int) (scale int) {
+	mustNotNil("MantScaleBig", x, x)
+	if debug {
+		x.validate()
+	}
+
+	if !x.IsFinite() {
+		z.SetUint64(0)
+		return 0
+	}
+
+	if x.isCompact() {
+		z.SetUint64(x.compact)
+	} else {
+		z.Set(&x.unscaled)
+	}
+	if x.Signbit() {
+		z.Neg(z)
+	}
+	return -x.exp
+}
+
+// MarshalText implements encoding.TextMarshaler. A finite x is encoded as by
+// String, which depends on x's OperatingMode. NaN and infinite values are
+// encoded according to x's SpecialsPolicy. A nil x returns an error.
 func (x *Big) MarshalText() ([]byte, error) {
+	if x == nil {
+		return nil, ErrNilOperand{Op: "MarshalText"}
+	}
 	if debug {
 		x.validate()
 	}
-	var (
-		b = new(bytes.Buffer)
-		f = formatter{w: b, prec: x.Precision(), width: noWidth}
-		e = sciE[x.Context.OperatingMode]
-	)
-	b.Grow(x.Precision())
-	f.format(x, normal, e)
-	return b.Bytes(), nil
+	if x.isSpecial() {
+		s, null, err := x.marshalSpecial("MarshalText")
+		if err != nil {
+			return nil, err
+		}
+		if null {
+			return nil, fmt.Errorf("decimal: MarshalText: cannot encode %s as null", x.form)
+		}
+		return []byte(s), nil
+	}
+	f := formatter{b: make([]byte, 0, x.Precision()+8), prec: x.Precision(), width: noWidth}
+	f.format(x, normal, sciE[x.Context.OperatingMode])
+	return f.b, nil
 }
 
+var _ encoding.TextMarshaler = (*Big)(nil)
+
 // Mul sets z to x * y and returns z.
-func (z *Big) Mul(x, y *Big) *Big { return z.Context.Mul(z, x, y) }
+func (z *Big) Mul(x, y *Big) *Big { return z.context("Mul").Mul(z, x, y) }
+
+// MulBig sets z to x * y and returns z, multiplying very large coefficients in
+// chunks. See Context.MulBig.
+func (z *Big) MulBig(x, y *Big, opts MulOptions) (*Big, error) {
+	return z.context("MulBig").MulBig(z, x, y, opts)
+}
+
+// MulInt64 sets z to x * v and returns z. It is identical to z.Mul(x, New(v,
+// 0)), but does not allocate a Big for v.
+func (z *Big) MulInt64(x *Big, v int64) *Big {
+	var y Big
+	return z.context("MulInt64").Mul(z, x, y.SetMantScale(v, 0))
+}
 
 // Neg sets z to -x and returns z. If x is positive infinity, z will be set to
 // negative infinity and visa versa. If x == 0, z will be set to zero as well.
 // NaN will result in an error.
 func (z *Big) Neg(x *Big) *Big {
+	if z.checkNil("Neg", x, x) {
+		return z
+	}
 	if debug {
 		x.validate()
 	}
-	if !z.invalidContext(z.Context) && !z.checkNaNs(x, x, negation) {
+	if !z.invalidContext(z.Context) && !z.mixedModes(z.Context, x) && !z.checkNaNs(x, x, negation) {
 		xform := x.form // copy in case z == x
 		z.copyAbs(x)
 		if !z.IsFinite() || z.compact != 0 || z.Context.RoundingMode == ToNegativeInf {
@@ -933,20 +1799,36 @@ func New(value int64, scale int) *Big {
 	return new(Big).SetMantScale(value, scale)
 }
 
+// NewMantScale creates a new Big decimal with the given mantissa and scale. It
+// is identical to New and is the inverse of MantScale.
+func NewMantScale(mant int64, scale int) *Big {
+	return new(Big).SetMantScale(mant, scale)
+}
+
 // Payload returns the payload of x, provided x is a NaN value. If x is not a
 // NaN value, the result is undefined.
 func (x *Big) Payload() Payload {
+	mustNotNil("Payload", x, x)
 	if !x.IsNaN(0) {
 		return 0
 	}
 	return Payload(x.compact)
 }
 
+// Pow sets z to x**y and returns z. See Context.Pow.
+func (z *Big) Pow(x, y *Big) *Big { return z.context("Pow").Pow(z, x, y) }
+
 // Precision returns the precision of x. That is, it returns the number of
 // digits in the unscaled form of x. x == 0 has a precision of 1. The result is
 // undefined if x is not finite.
+//
+// The number of digits is maintained by every operation that modifies x, so
+// Precision runs in constant time and does not allocate, even if x's
+// coefficient does not fit in a uint64. The same is true of Scale, Sign, and
+// Signbit.
 func (x *Big) Precision() int {
 	// Cannot call validate since validate calls this method.
+	mustNotNil("Precision", x, x)
 	if !x.IsFinite() {
 		return 0
 	}
@@ -957,34 +1839,60 @@ func (x *Big) Precision() int {
 }
 
 // Quantize sets z to the number equal in value and sign to z with the scale, n.
-func (z *Big) Quantize(n int) *Big { return z.Context.Quantize(z, n) }
+func (z *Big) Quantize(n int) *Big { return z.context("Quantize").Quantize(z, n) }
 
 // Quo sets z to x / y and returns z.
-func (z *Big) Quo(x, y *Big) *Big { return z.Context.Quo(z, x, y) }
+func (z *Big) Quo(x, y *Big) *Big { return z.context("Quo").Quo(z, x, y) }
+
+// QuoOrDefault sets z to x / y and returns z, or sets z to def if y is zero.
+// See Context.QuoOrDefault for more details.
+func (z *Big) QuoOrDefault(x, y, def *Big) *Big {
+	return z.context("QuoOrDefault").QuoOrDefault(z, x, y, def)
+}
+
+// QuoOrZero sets z to x / y and returns z, or sets z to 0 if y is zero. See
+// Context.QuoOrZero for more details.
+func (z *Big) QuoOrZero(x, y *Big) *Big { return z.context("QuoOrZero").QuoOrZero(z, x, y) }
 
 // QuoInt sets z to x / y with the remainder truncated. See QuoRem for more
 // details.
-func (z *Big) QuoInt(x, y *Big) *Big { return z.Context.QuoInt(z, x, y) }
+func (z *Big) QuoInt(x, y *Big) *Big { return z.context("QuoInt").QuoInt(z, x, y) }
+
+// QuoInt64 sets z to x / v and returns z. It is identical to z.Quo(x, New(v,
+// 0)), but does not allocate a Big for v. Unlike QuoInt, the quotient is not
+// truncated.
+func (z *Big) QuoInt64(x *Big, v int64) *Big {
+	var y Big
+	return z.context("QuoInt64").Quo(z, x, y.SetMantScale(v, 0))
+}
 
 // QuoRem sets z to the quotient x / y and r to the remainder x % y, such that
-// x = z * y + r, and returns the pair (z, r).
+// x = z * y + r, and returns the pair (z, r). z and r, including the conditions
+// raised on each, are identical to the results of z.QuoInt(x, y) and
+// r.Rem(x, y), respectively.
 func (z *Big) QuoRem(x, y, r *Big) (*Big, *Big) {
-	return z.Context.QuoRem(z, x, r, y)
+	return z.context("QuoRem").QuoRem(z, x, y, r)
 }
 
-// Rat sets z to x returns z. z is allowed to be nil. The result is undefined if
-// x is an infinity or NaN value.
+// Rat sets z to the exact value of x and returns z. z is allowed to be nil.
+//
+// If x is an infinity or NaN value, which have no rational value, Rat raises
+// InvalidOperation in x's Context, so that Context.Err reports it if it is
+// trapped, and returns nil. Like Int64Saturating, it does not panic if x's
+// OperatingMode is Go. z is not modified.
 func (x *Big) Rat(z *big.Rat) *big.Rat {
+	mustNotNil("Rat", x, x)
 	if debug {
 		x.validate()
 	}
 
-	if z == nil {
-		z = new(big.Rat)
+	if !x.IsFinite() {
+		x.Context.Conditions |= InvalidOperation
+		return nil
 	}
 
-	if !x.IsFinite() {
-		return z.SetInt64(0)
+	if z == nil {
+		z = new(big.Rat)
 	}
 
 	// Fast path for decimals <= math.MaxInt64.
@@ -1030,37 +1938,85 @@ func (x *Big) Rat(z *big.Rat) *big.Rat {
 func Raw(x *Big) (*uint64, *big.Int) { return &x.compact, &x.unscaled }
 
 // Reduce reduces a finite z to its most simplest form.
-func (z *Big) Reduce() *Big { return z.Context.Reduce(z) }
+func (z *Big) Reduce() *Big { return z.context("Reduce").Reduce(z) }
 
 // Rem sets z to the remainder x % y. See QuoRem for more details.
-func (z *Big) Rem(x, y *Big) *Big { return z.Context.Rem(z, x, y) }
+func (z *Big) Rem(x, y *Big) *Big { return z.context("Rem").Rem(z, x, y) }
 
 // Round rounds z down to n digits of precision and returns z. The result is
 // undefined if z is not finite. No rounding will occur if n <= 0. The result of
 // Round will always be within the interval [⌊10**x⌋, z] where x = the precision
 // of z.
 func (z *Big) Round(n int) *Big {
-	ctx := z.Context
+	zc := z.context("Round")
+	ctx := zc
 	ctx.Precision = n
-	return ctx.Round(z)
+	ctx.Round(z)
+	zc.adopt(z)
+	return z
 }
 
+// RoundSig sets z to x rounded to sig significant digits and returns z. See
+// Context.RoundSig for more details.
+func (z *Big) RoundSig(x *Big, sig int) *Big { return z.context("RoundSig").RoundSig(z, x, sig) }
+
 // RoundToInt rounds z down to an integral value.
-func (z *Big) RoundToInt() *Big { return z.Context.RoundToInt(z) }
+func (z *Big) RoundToInt() *Big { return z.context("RoundToInt").RoundToInt(z) }
 
-// Scale returns x's scale.
-func (x *Big) Scale() int { return -x.exp }
+// Scale returns x's scale. It runs in constant time; see Precision.
+func (x *Big) Scale() int {
+	mustNotNil("Scale", x, x)
+	return -x.exp
+}
 
-// Scan implements fmt.Scanner.
+// Scan implements fmt.Scanner, so that, e.g., fmt.Sscan("1.23e4", z) sets z to
+// 1.23E+4. It supports the %e, %E, %f, %F, %g, %G, %s, and %v verbs.
+//
+// After skipping leading space, Scan reads the longest prefix of the input that
+// could be a decimal in any of the formats accepted by SetString and leaves
+// the rest unread, so scanning "1.5kg" sets z to 1.5 and leaves "kg" for the
+// next operand. Infinities and NaN values may be written as by either
+// OperatingMode ("Infinity", "Inf", "+Inf", "NaN", "sNaN123", and so on),
+// ignoring case.
+//
+// If the token is invalid, Scan returns an ErrInput and leaves z unchanged. If
+// it exceeds the limits of z's Context, Scan sets z to NaN, raises the
+// appropriate Condition, and returns an ErrParseLimit, as SetString does.
 func (z *Big) Scan(state fmt.ScanState, verb rune) error {
-	return z.scan(byteReader{state})
+	if z == nil {
+		return ErrNilOperand{Op: "Scan"}
+	}
+	switch verb {
+	case 'e', 'E', 'f', 'F', 'g', 'G', 's', 'v':
+	default:
+		return fmt.Errorf("decimal: Scan: invalid verb %%%c", verb)
+	}
+
+	nb, _ := z.Context.parseLimits()
+	tok, err := scanToken(state, nb)
+	if err != nil {
+		return err
+	}
+	err = z.UnmarshalText(tok)
+	if e, ok := err.(ErrParseLimit); ok {
+		return z.setParseLimit(e)
+	}
+	return err
 }
 
 var _ fmt.Scanner = (*Big)(nil)
 
 // Set sets z to x and returns z. The result might be rounded depending on z's
 // Context, and even if z == x.
-func (z *Big) Set(x *Big) *Big { return z.Context.round(z.Copy(x)) }
+func (z *Big) Set(x *Big) *Big {
+	if z.checkNil("Set", x, x) || z.mixedModes(z.Context, x) {
+		return z
+	}
+	if z.Context.OperatingMode == JS {
+		return z.Context.fix(z.Copy(x))
+	}
+	return z.Context.round(z.Copy(x))
+}
 
 // setShared sets z to x, but does not copy—z may possibly alias x.
 func (z *Big) setShared(x *Big) *Big {
@@ -1078,8 +2034,35 @@ func (z *Big) setShared(x *Big) *Big {
 	return z
 }
 
+// SetBigMantExp sets z to mant × 10**exp and returns z. mant may be negative.
+// Unlike SetBigMantScale, the exponent is checked against z's Context: if z
+// is outside the range allowed by the Context's MaxScale and MinScale, it is
+// rounded using the Context, so it overflows to ±Infinity (or clamps, if mant
+// is zero) and underflows to a subnormal or zero, raising the appropriate
+// conditions. Otherwise, z is not rounded.
+func (z *Big) SetBigMantExp(mant *big.Int, exp int) *Big {
+	if mant == nil {
+		z.checkNil("SetBigMantExp", nil, nil)
+		return z
+	}
+	mustNotNil("SetBigMantExp", z, z)
+	z.SetBigMantScale(mant, 0)
+	if z.clampExp(int64(exp)); !z.IsFinite() {
+		return z
+	}
+	if adj := z.adjusted(); adj > z.Context.maxScale() || adj < z.Context.minScale() {
+		return z.Context.round(z)
+	}
+	return z
+}
+
 // SetBigMantScale sets z to the given value and scale.
 func (z *Big) SetBigMantScale(value *big.Int, scale int) *Big {
+	if value == nil {
+		z.checkNil("SetBigMantScale", nil, nil)
+		return z
+	}
+	mustNotNil("SetBigMantScale", z, z)
 	// Do this first in case value == z.unscaled. Don't want to clobber the sign.
 	z.form = finite
 	if value.Sign() < 0 {
@@ -1100,8 +2083,57 @@ func (z *Big) SetBigMantScale(value *big.Int, scale int) *Big {
 	return z
 }
 
+// SetBytes is like SetString, but parses b directly instead of first converting
+// it to a string, so it does not copy b. It accepts exactly the same inputs as
+// SetString and, like SetString, raises ConversionSyntax on failure. b is not
+// retained.
+func (z *Big) SetBytes(b []byte) (*Big, bool) {
+	mustNotNil("SetBytes", z, z)
+	if err := z.scanBytes(b, z.Context, new(bytes.Reader)); err != nil {
+		return nil, false
+	}
+	return z, true
+}
+
+// SetScaleFloor sets z to x with its scale increased to at least minScale and
+// returns z. If x's scale is less than minScale, its coefficient is padded with
+// trailing zeros, so 12E+3 with a minScale of 0 becomes 12000 and 1.5 with a
+// minScale of 2 becomes 1.50; otherwise, z is set to a copy of x. The value of
+// z is always that of x: SetScaleFloor never rounds, so z's precision might
+// exceed that of its Context. NaN and infinite values are copied unchanged.
+func (z *Big) SetScaleFloor(x *Big, minScale int) *Big {
+	if z.checkNil("SetScaleFloor", x, x) {
+		return z
+	}
+	z.Copy(x)
+	if !z.IsFinite() || -z.exp >= minScale {
+		return z
+	}
+	shift := z.exp + minScale
+	if z.compact == 0 {
+		z.exp = -minScale
+		return z
+	}
+	if z.isCompact() {
+		if zc, ok := checked.MulPow10(z.compact, uint64(shift)); ok {
+			return z.setTriple(zc, z.form&signbit, -minScale)
+		}
+		z.unscaled.SetUint64(z.compact)
+		z.compact = c.Inflated
+	}
+	checked.MulBigPow10(&z.unscaled, &z.unscaled, uint64(shift))
+	z.precision = arith.BigLength(&z.unscaled)
+	z.exp = -minScale
+	return z
+}
+
 // SetFloat sets z to x and returns z.
 func (z *Big) SetFloat(x *big.Float) *Big {
+	if x == nil {
+		z.checkNil("SetFloat", nil, nil)
+		return z
+	}
+	mustNotNil("SetFloat", z, z)
 	if x.IsInf() {
 		if x.Signbit() {
 			z.form = ninf
@@ -1113,12 +2145,11 @@ func (z *Big) SetFloat(x *big.Float) *Big {
 
 	neg := x.Signbit()
 	if x.Sign() == 0 {
+		var sign form
 		if neg {
-			z.form |= signbit
+			sign = signbit
 		}
-		z.compact = 0
-		z.precision = 1
-		return z
+		return z.setZero(sign, 0)
 	}
 
 	z.exp = 0
@@ -1146,8 +2177,81 @@ func (z *Big) SetFloat(x *big.Float) *Big {
 	return z
 }
 
-// SetFloat64 sets z to exactly x.
+// SetFloat64 sets z to the shortest decimal that converts back to exactly x
+// and returns z. The result matches what strconv.FormatFloat(x, 'g', -1, 64)
+// and JavaScript's Number.prototype.toString print; for example, 0.1 is set to
+// 0.1. Use SetFloat64Exact for the exact binary value of x.
+//
+// Like SetFloat64Exact, SetFloat64 sets z to a signed zero, an infinity, or a
+// quiet NaN if x is one. z is not rounded.
 func (z *Big) SetFloat64(x float64) *Big {
+	mustNotNil("SetFloat64", z, z)
+	return z.setShortestFloat(x, 64)
+}
+
+// SetFloat32 sets z to the shortest decimal that converts back to exactly x,
+// as a float32, and returns z. For example, float32(0.1) is set to 0.1. Use
+// SetFloat32Exact for the exact binary value of x. z is not rounded.
+func (z *Big) SetFloat32(x float32) *Big {
+	mustNotNil("SetFloat32", z, z)
+	return z.setShortestFloat(float64(x), 32)
+}
+
+// SetFloat32Exact sets z to exactly x, its full binary expansion, and returns
+// z. For example, float32(0.1) is set to 0.100000001490116119384765625. z is
+// not rounded.
+func (z *Big) SetFloat32Exact(x float32) *Big {
+	mustNotNil("SetFloat32Exact", z, z)
+	// Every float32 is exactly representable as a float64.
+	return z.SetFloat64Exact(float64(x))
+}
+
+// setShortestFloat sets z to the shortest decimal that converts back to
+// exactly x, which has bitSize bits, and returns z.
+func (z *Big) setShortestFloat(x float64, bitSize int) *Big {
+	if x == 0 || math.IsNaN(x) || math.IsInf(x, 0) {
+		return z.SetFloat64Exact(x)
+	}
+
+	// strconv formats x as [-]d[.ddd]e±dd with at most 17 significant digits,
+	// so the coefficient always fits into a uint64.
+	var buf [32]byte
+	b := strconv.AppendFloat(buf[:0], x, 'e', -1, bitSize)
+
+	var sign form
+	if b[0] == '-' {
+		sign = signbit
+		b = b[1:]
+	}
+	var (
+		mant uint64
+		exp  int
+		i    int
+	)
+	for ; b[i] != 'e'; i++ {
+		if b[i] == '.' {
+			continue
+		}
+		mant = mant*10 + uint64(b[i]-'0')
+		exp--
+	}
+	// Exponent is always signed.
+	e := 0
+	for _, c := range b[i+2:] {
+		e = e*10 + int(c-'0')
+	}
+	if b[i+1] == '-' {
+		e = -e
+	}
+	return z.setTriple(mant, sign, exp+e+1)
+}
+
+// SetFloat64Exact sets z to exactly x, its full binary expansion, and returns
+// z. For example, 0.1 is set to
+// 0.1000000000000000055511151231257827021181583404541015625. Subnormal values
+// are converted exactly as well. z is not rounded.
+func (z *Big) SetFloat64Exact(x float64) *Big {
+	mustNotNil("SetFloat64Exact", z, z)
 	if x == 0 {
 		var sign form
 		if math.Signbit(x) {
@@ -1217,9 +2321,37 @@ func (z *Big) SetFloat64(x float64) *Big {
 	return z.norm()
 }
 
+// SetFrac sets z to num/den, rounded to z's Context, and returns z. Like Quo,
+// it raises DivisionByZero if den is zero and num is not, and
+// DivisionUndefined if both are zero.
+//
+// A fraction with a terminating decimal expansion (i.e., one whose reduced
+// denominator has no prime factors other than 2 and 5) is exact, and does not
+// raise Inexact, if its expansion fits in z's precision. For example, 7/8 is
+// 0.875 and 3/40 is 0.075.
+func (z *Big) SetFrac(num, den int64) *Big {
+	mustNotNil("SetFrac", z, z)
+	var x, y Big
+	x.Context.OperatingMode = z.Context.OperatingMode
+	y.Context.OperatingMode = z.Context.OperatingMode
+	x.SetMantScale(num, 0)
+	y.SetMantScale(den, 0)
+	return z.Quo(&x, &y)
+}
+
+// SetFracBig is like SetFrac, but num and den are decimals. It is equivalent
+// to z.Quo(num, den): the result is governed by z's Context alone.
+func (z *Big) SetFracBig(num, den *Big) *Big {
+	if z.checkNil("SetFracBig", num, den) {
+		return z
+	}
+	return z.Quo(num, den)
+}
+
 // SetInf sets z to -Inf if signbit is set or +Inf is signbit is not set, and
 // returns z.
 func (z *Big) SetInf(signbit bool) *Big {
+	mustNotNil("SetInf", z, z)
 	if signbit {
 		z.form = ninf
 	} else {
@@ -1230,6 +2362,7 @@ func (z *Big) SetInf(signbit bool) *Big {
 
 // SetMantScale sets z to the given value and scale.
 func (z *Big) SetMantScale(value int64, scale int) *Big {
+	mustNotNil("SetMantScale", z, z)
 	z.SetUint64(arith.Abs(value))
 	z.exp = -scale // compiler should optimize out z.exp = 0 in SetUint64
 	if value < 0 {
@@ -1253,6 +2386,7 @@ func (z *Big) setNaN(c Condition, f form, p Payload) *Big {
 // SetNaN sets z to a signaling NaN if signal is true or quiet NaN otherwise and
 // returns z. No conditions are raised.
 func (z *Big) SetNaN(signal bool) *Big {
+	mustNotNil("SetNaN", z, z)
 	if signal {
 		z.form = snan
 	} else {
@@ -1262,12 +2396,24 @@ func (z *Big) SetNaN(signal bool) *Big {
 	return z
 }
 
-// SetRat sets z to to the possibly rounded value of x and return z.
+// SetRat sets z to x, rounded using z's Context, and returns z. Like SetFrac,
+// the result is exact, and does not raise Inexact, if x's denominator has no
+// prime factors other than 2 and 5 and its decimal expansion fits in z's
+// precision. Otherwise, Inexact and Rounded are raised. For example, 7/8 is set
+// to 0.875 and 1/3 is set to 0.3333333333333333 (inexact) under the default
+// precision.
 func (z *Big) SetRat(x *big.Rat) *Big {
+	if x == nil {
+		z.checkNil("SetRat", nil, nil)
+		return z
+	}
+	mustNotNil("SetRat", z, z)
 	if x.IsInt() {
 		return z.Context.round(z.SetBigMantScale(x.Num(), 0))
 	}
 	var num, denom Big
+	num.Context.OperatingMode = z.Context.OperatingMode
+	denom.Context.OperatingMode = z.Context.OperatingMode
 	num.SetBigMantScale(x.Num(), 0)
 	denom.SetBigMantScale(x.Denom(), 0)
 	return z.Quo(&num, &denom)
@@ -1275,6 +2421,7 @@ func (z *Big) SetRat(x *big.Rat) *Big {
 
 // SetScale sets z's scale to scale and returns z.
 func (z *Big) SetScale(scale int) *Big {
+	mustNotNil("SetScale", z, z)
 	z.exp = -scale
 	return z
 }
@@ -1301,8 +2448,16 @@ var Regexp = regexp.MustCompile(`(?i)(([+-]?(\d+\.\d*|\.?\d+)([eE][+-]?\d+)?)|(i
 // optional diagnostic information, represented as trailing digits; for example,
 // ``NaN123''. These digits are otherwise ignored but are included for
 // robustness.
+//
+// If z.Context.Underscores is set, the digits of s may be separated by
+// underscores, as in 1_000_000.000_1. An underscore anywhere else raises
+// ConversionSyntax.
+//
+// SetString fails if s exceeds the limits set by z.Context.MaxParseBytes or
+// z.Context.MaxParseDigits.
 func (z *Big) SetString(s string) (*Big, bool) {
-	if err := z.scan(strings.NewReader(s)); err != nil {
+	mustNotNil("SetString", z, z)
+	if err := z.scanString(s, z.Context); err != nil {
 		return nil, false
 	}
 	return z, true
@@ -1326,7 +2481,11 @@ func (z *Big) setZero(sign form, exp int) *Big {
 
 // SetUint64 is shorthand for SetMantScale(x, 0) for an unsigned integer.
 func (z *Big) SetUint64(x uint64) *Big {
+	mustNotNil("SetUint64", z, z)
 	z.compact = x
+	if x == c.Inflated {
+		z.unscaled.SetUint64(x)
+	}
 	z.precision = arith.Length(x)
 	z.exp = 0
 	z.form = finite
@@ -1356,6 +2515,7 @@ func (x *Big) ord(abs bool) int {
 //
 // The result is undefined if x is a NaN value.
 func (x *Big) Sign() int {
+	mustNotNil("Sign", x, x)
 	if debug {
 		x.validate()
 	}
@@ -1372,6 +2532,7 @@ func (x *Big) Sign() int {
 // Signbit returns true if x is negative, negative infinity, negative zero, or
 // negative NaN.
 func (x *Big) Signbit() bool {
+	mustNotNil("Signbit", x, x)
 	if debug {
 		x.validate()
 	}
@@ -1382,28 +2543,85 @@ func (x *Big) Signbit() bool {
 // discussed in the Format method's documentation. Special cases depend on the
 // OperatingMode.
 func (x *Big) String() string {
-	var (
-		b = new(strings.Builder)
-		f = formatter{w: b, prec: x.Precision(), width: noWidth}
-		e = sciE[x.Context.OperatingMode]
-	)
-	b.Grow(x.Precision())
-	f.format(x, normal, e)
-	return b.String()
+	if x == nil {
+		return "<nil>"
+	}
+	var buf [64]byte
+	f := formatter{b: buf[:0], prec: x.Precision(), width: noWidth}
+	f.format(x, normal, sciE[x.Context.OperatingMode])
+	return string(f.b)
 }
 
 var _ fmt.Stringer = (*Big)(nil)
 
+// Sin sets z to the sine of x, in radians, and returns z.
+func (z *Big) Sin(x *Big) *Big { return z.context("Sin").Sin(z, x) }
+
+// Sqrt sets z to the square root of x and returns z.
+func (z *Big) Sqrt(x *Big) *Big { return z.context("Sqrt").Sqrt(z, x) }
+
 // Sub sets z to x - y and returns z.
-func (z *Big) Sub(x, y *Big) *Big { return z.Context.Sub(z, x, y) }
+func (z *Big) Sub(x, y *Big) *Big { return z.context("Sub").Sub(z, x, y) }
+
+// Sum sets z to the sum of xs and returns z. See Context.Sum.
+func (z *Big) Sum(xs ...*Big) *Big { return z.context("Sum").Sum(z, xs...) }
+
+// Tan sets z to the tangent of x, in radians, and returns z.
+func (z *Big) Tan(x *Big) *Big { return z.context("Tan").Tan(z, x) }
 
-// UnmarshalText implements encoding.TextUnmarshaler.
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts any of the
+// formats accepted by SetString, subject to the limits of z's Context.
+//
+// If data is invalid, UnmarshalText returns an error and leaves z unchanged.
+// The error is an ErrInput describing the offending input, or an
+// ErrParseLimit if data exceeds the Context's limits; both wrap the Condition
+// that SetString would raise. A nil z returns an error.
 func (z *Big) UnmarshalText(data []byte) error {
-	return z.scan(bytes.NewReader(data))
+	if z == nil {
+		return ErrNilOperand{Op: "UnmarshalText"}
+	}
+	x := Big{Context: z.Context}
+	x.Context.Conditions = 0
+	r := new(bytes.Reader)
+	err := x.scanBytes(data, x.Context, r)
+	if _, ok := err.(ErrParseLimit); ok {
+		// data is too large to include in an error.
+		return err
+	}
+	if err != nil || x.Context.Conditions&ConversionSyntax != 0 {
+		return textInputErr(data, r, err)
+	}
+	x.Context.Conditions |= z.Context.Conditions
+	*z = x
+	return nil
+}
+
+// textInputErr returns the ErrInput for the invalid data, which was read from
+// r until scanning failed with err.
+func textInputErr(data []byte, r *bytes.Reader, err error) error {
+	e := ErrInput{Input: string(data), Offset: len(data) - r.Len() - 1}
+	switch {
+	case len(data) == 0:
+		e.Offset = 0
+		e.Msg = "empty input"
+	case err == io.EOF || err == io.ErrUnexpectedEOF || e.Offset >= len(data):
+		e.Offset = len(data)
+		e.Msg = "unexpected end of input"
+	default:
+		if e.Offset < 0 {
+			e.Offset = 0
+		}
+		e.Msg = fmt.Sprintf("unexpected %q", data[e.Offset])
+	}
+	return e
 }
 
 var _ encoding.TextUnmarshaler = (*Big)(nil)
 
+// Wrap sets z to x wrapped into the range [lo, hi) and returns z. See
+// Context.Wrap for more details.
+func (z *Big) Wrap(x, lo, hi *Big) *Big { return z.context("Wrap").Wrap(z, x, lo, hi) }
+
 // validate ensures x's internal state is correct. There's no need for it to
 // have good performance since it's for debug == true only.
 func (x *Big) validate() {
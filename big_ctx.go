@@ -3,6 +3,7 @@ package decimal
 import (
 	"math"
 	"math/big"
+	"strconv"
 
 	"github.com/ericlagergren/decimal/internal/arith"
 	"github.com/ericlagergren/decimal/internal/arith/checked"
@@ -11,15 +12,35 @@ import (
 
 // Add sets z to x + y and returns z.
 func (c Context) Add(z, x, y *Big) *Big {
+	defer c.adopt(z)
+	if c.hookedOp(z, x, y) {
+		return c.hooked(z, hookOp{name: "+", infix: true, tagged: true}, func(c Context) *Big {
+			return c.Add(z, x, y)
+		}, x, y)
+	}
+	if c.Traps != 0 && z != nil {
+		return c.trapped(z, "Add", func(c Context) *Big {
+			return c.Add(z, x, y)
+		}, x, y)
+	}
+	if z.checkNil("Add", x, y) {
+		return z
+	}
 	if debug {
 		x.validate()
 		y.validate()
 	}
-	if z.invalidContext(c) {
+	if z.invalidContext(c) || z.mixedModes(c, x, y) {
 		return z
 	}
+	if c.ExactOnly {
+		c.Precision = UnlimitedPrecision
+	}
 
 	if x.IsFinite() && y.IsFinite() {
+		if c.alignsTooFar(x, y) {
+			return z.setNaN(InsufficientStorage, qnan, toolong)
+		}
 		z.form = finite | c.add(z, x, x.form, y, y.form)
 		return c.round(z)
 	}
@@ -40,10 +61,10 @@ func (c Context) Add(z, x, y *Big) *Big {
 		// ±Inf + y
 		// +Inf + +Inf
 		// -Inf + -Inf
-		return z.Set(x)
+		return z.Copy(x)
 	}
 	// x + ±Inf
-	return z.Set(y)
+	return z.Copy(y)
 }
 
 func (c Context) add(z *Big, x *Big, xn form, y *Big, yn form) (sign form) {
@@ -73,6 +94,23 @@ func (c Context) add(z *Big, x *Big, xn form, y *Big, yn form) (sign form) {
 	return sign
 }
 
+// alignsTooFar reports whether the exact sum of the finite x and y, which has
+// the smaller of their exponents, would have more than MaxPracticalPrecision
+// digits with unlimited precision. With limited precision, tryTinyAdd keeps
+// the alignment within the precision.
+func (c Context) alignsTooFar(x, y *Big) bool {
+	if precision(c) != UnlimitedPrecision {
+		return false
+	}
+	hi, lo := x, y
+	if hi.exp < lo.exp {
+		hi, lo = lo, hi
+	}
+	// The exponents are at most MaxScale - (MinScale - MaxPrecision) apart,
+	// which does not overflow an int.
+	return hi.compact != 0 && hi.Precision()+(hi.exp-lo.exp) > MaxPracticalPrecision
+}
+
 // tryTinyAdd returns true if hi + lo requires a huge shift that will produce
 // the same results as a smaller shift. E.g., 3 + 0e+9999999999999999 with a
 // precision of 5 doesn't need to be shifted by a large number.
@@ -221,9 +259,395 @@ func (c Context) addBig(z *Big, hi *big.Int, hineg form, lo *big.Int, loneg form
 	return sign
 }
 
+// Dot sets z to the dot product of x and y, the sum of x[i] * y[i], and returns
+// z. It panics if x and y have different lengths. Each product is exact and each
+// partial sum carries c.Precision+c.GuardDigits digits; only the final result
+// is rounded to c.Precision.
+func (c Context) Dot(z *Big, x, y []*Big) *Big {
+	defer c.adopt(z)
+	if len(x) != len(y) {
+		panic("decimal: Dot: len(x) != len(y)")
+	}
+	if c.hookedOps(z, x) || c.hookedOps(z, y) {
+		return c.hooked(z, hookOp{name: "dot", tagged: true}, func(c Context) *Big {
+			return c.Dot(z, x, y)
+		}, append(x[:len(x):len(x)], y...)...)
+	}
+	if c.Traps != 0 && z != nil {
+		return c.trapped(z, "Dot", func(c Context) *Big {
+			return c.Dot(z, x, y)
+		}, append(x[:len(x):len(x)], y...)...)
+	}
+	for i := range x {
+		if z.checkNil("Dot", x[i], y[i]) {
+			return z
+		}
+	}
+	if z.invalidContext(c) || z.mixedModes(c, x...) || z.mixedModes(c, y...) {
+		return z
+	}
+	// Alternate between two accumulators so the partial sum is never both the
+	// addend and the receiver of FMA. Neither may be z, which might alias an
+	// element of x or y.
+	wc := c.guarded()
+	acc, t := WithContext(wc), WithContext(wc)
+	for i := range x {
+		wc.FMA(t, x[i], y[i], acc)
+		acc, t = t, acc
+	}
+	z.Context.Conditions |= acc.Context.Conditions | t.Context.Conditions
+	return c.round(z.setShared(acc))
+}
+
+// Exp sets z to e**x, the natural exponential of x, correctly rounded using
+// c's precision and RoundingMode, and returns z. Since e**x is irrational for
+// every non-zero x, Inexact and Rounded are always raised unless x is zero, in
+// which case z is set to exactly 1.
+//
+// Exp(+Inf) is +Inf and Exp(-Inf) is 0. If the result overflows, z is set to
+// +Inf, regardless of the RoundingMode, and Overflow is raised; if it
+// underflows, z is rounded to a subnormal or zero and Underflow is raised. A
+// huge |x| raises one of the two without computing any digits of the result.
+//
+// If c.Precision is UnlimitedPrecision and x is finite and non-zero, z is set to
+// NaN and InvalidOperation is raised, since the result cannot be represented
+// exactly.
+func (c Context) Exp(z, x *Big) *Big {
+	defer c.adopt(z)
+	if c.hookedOp(z, x, nil) {
+		return c.hooked(z, hookOp{name: "exp", tagged: true}, func(c Context) *Big {
+			return c.Exp(z, x)
+		}, x)
+	}
+	if c.Traps != 0 && z != nil {
+		return c.trapped(z, "Exp", func(c Context) *Big {
+			return c.Exp(z, x)
+		}, x)
+	}
+	if z.checkNil("Exp", x, x) {
+		return z
+	}
+	if z.invalidContext(c) || z.mixedModes(c, x) {
+		return z
+	}
+	if z.checkNaNs(x, x, expnan) {
+		return z
+	}
+	switch {
+	case x.IsInf(+1):
+		return z.SetInf(false)
+	case x.IsInf(-1):
+		return z.setZero(0, 0)
+	case x.compact == 0:
+		return z.SetMantScale(1, 0)
+	}
+
+	prec := precision(c)
+	if prec == UnlimitedPrecision {
+		return z.setNaN(InvalidOperation, qnan, expunlim)
+	}
+	// e**20 > 10**8, so if |x| >= 1E+20, e**x is far beyond 10**MaxScale or
+	// 10**MinScale.
+	if x.adjusted() >= 20 {
+		if x.Signbit() {
+			z.Context.Conditions |= Clamped
+			return z.xflow(c.etiny(), false, false)
+		}
+		return z.xflow(c.minScale(), true, false)
+	}
+
+	var lo, hi Big
+	done := false
+	if x.adjusted() < -(prec + 3) {
+		// e**x is within |x| < 10**-(prec+3) of 1, too close for any other
+		// value to round differently from it.
+		v := new(big.Int).Set(arith.BigPow10(uint64(prec + 3)))
+		if x.Signbit() {
+			v.Sub(v, cst.OneInt)
+		} else {
+			v.Add(v, cst.OneInt)
+		}
+		c.Round(lo.SetBigMantScale(v, prec+3))
+		done = true
+	}
+
+	// Compute e**x with increasing precision until both ends of an interval
+	// containing it round to the same result. e**x cannot be a rounding
+	// boundary, so this always terminates.
+	for wp := prec + 5; !done; wp += wp / 2 {
+		y, d, n, f := expApprox(x, wp)
+		if n.Cmp(big.NewInt(int64(c.maxScale())+1)) > 0 {
+			return z.xflow(c.minScale(), true, false)
+		}
+		if n.Cmp(big.NewInt(int64(c.etiny())-2)) < 0 {
+			z.Context.Conditions |= Clamped
+			return z.xflow(c.etiny(), false, false)
+		}
+		e := int(n.Int64()) - f
+		lo.Context.Conditions = 0
+		c.Round(lo.SetBigMantScale(new(big.Int).Sub(y, d), -e))
+		c.Round(hi.SetBigMantScale(y.Add(y, d), -e))
+		if lo.Context.Conditions&Overflow != 0 {
+			return z.xflow(c.minScale(), true, false)
+		}
+		done = lo.Cmp(&hi) == 0
+	}
+	z.Copy(&lo)
+	z.Context.Conditions |= lo.Context.Conditions | Inexact | Rounded
+	return z
+}
+
+// expApprox approximates e**x for a finite, non-zero x with |x| < 1E+20 using
+// wp digits of working precision. It returns y, d, n, and f such that e**x is
+// in the open interval ((y-d) × 10**(n-f), (y+d) × 10**(n-f)), where d is about
+// y × 10**-wp.
+func expApprox(x *Big, wp int) (y, d, n *big.Int, f int) {
+	// With x = n×ln(10) + r and |r| <= ln(10)/2, e**x = e**r × 10**n. The
+	// Taylor series of e**(r / 2**k) converges quickly and its sum is squared
+	// k times. Each step truncates to f fractional digits, so f includes
+	// enough guard digits to cover the error from n×ln(10), the series, and
+	// the squaring, which doubles the relative error.
+	k := 1
+	for k*k < wp {
+		k++
+	}
+	adj := x.adjusted()
+	if adj < 0 {
+		adj = 0
+	}
+	f = wp + (k+2)/3 + adj + arith.Length(uint64(wp)) + 8
+	one := arith.BigPow10(uint64(f))
+
+	r := scaledMod(x, x.exp, nil)
+	if s := x.exp + f; s >= 0 {
+		r.Mul(r, arith.BigPow10(uint64(s)))
+	} else {
+		r.Quo(r, arith.BigPow10(uint64(-s)))
+	}
+	n = new(big.Int)
+	if x.adjusted() >= 0 {
+		ln10 := ln10Fixed(f)
+		t := new(big.Int).Lsh(r, 1)
+		t.Add(t, ln10)
+		n.Div(t, new(big.Int).Lsh(ln10, 1))
+		r.Sub(r, t.Mul(n, ln10))
+	}
+	r.Quo(r, new(big.Int).Lsh(cst.OneInt, uint(k)))
+
+	y = new(big.Int).Set(one)
+	t := new(big.Int).Set(one)
+	var q big.Int
+	for i := int64(1); ; i++ {
+		t.Mul(t, r)
+		t.Quo(t, q.Mul(one, q.SetInt64(i)))
+		if t.Sign() == 0 {
+			break
+		}
+		y.Add(y, t)
+	}
+	for i := 0; i < k; i++ {
+		y.Mul(y, y)
+		y.Quo(y, one)
+	}
+	d = new(big.Int).Quo(y, arith.BigPow10(uint64(wp)))
+	return y, d.Add(d, cst.OneInt), n, f
+}
+
+// ln10Fixed returns ln(10) × 10**f, truncated, using
+//
+//	ln(10) = 3×ln(2) + ln(5/4) = 6×atanh(1/3) + 2×atanh(1/9)
+func ln10Fixed(f int) *big.Int {
+	g := f + arith.Length(uint64(f)) + 3
+	one := arith.BigPow10(uint64(g))
+	s := atanhInv(3, one)
+	s.Mul(s, big.NewInt(6))
+	t := atanhInv(9, one)
+	s.Add(s, t.Lsh(t, 1))
+	return s.Quo(s, arith.BigPow10(uint64(g-f)))
+}
+
+// atanhInvFixed returns atanh(1/q) × 10**f with an error of at most one unit
+// in the last place. For example, ln(2) = 2×atanhInvFixed(3, f) × 10**-f.
+func atanhInvFixed(q int64, f int) *big.Int {
+	g := f + arith.Length(uint64(f)) + 3
+	s := atanhInv(q, arith.BigPow10(uint64(g)))
+	return s.Quo(s, arith.BigPow10(uint64(g-f)))
+}
+
+// atanhInv returns atanh(1/q) × one, truncated, using the series
+//
+//	atanh(1/q) = Σ 1 / ((2i+1) × q**(2i+1))
+func atanhInv(q int64, one *big.Int) *big.Int {
+	p := new(big.Int).Quo(one, big.NewInt(q))
+	q2 := big.NewInt(q * q)
+	var s, t big.Int
+	for i := int64(1); p.Sign() != 0; i += 2 {
+		s.Add(&s, t.Quo(p, t.SetInt64(i)))
+		p.Quo(p, q2)
+	}
+	return &s
+}
+
+// Expm1 sets z to e**x - 1, correctly rounded using c's precision and
+// RoundingMode, and returns z. Unlike Exp followed by Sub, it keeps full
+// precision when x is near zero: Expm1(1E-20) at 16 digits is
+// 1.000000000000000E-20 rather than 0. Inexact and Rounded are always raised
+// unless x is zero, in which case z is set to x.
+//
+// Expm1(+Inf) is +Inf and Expm1(-Inf) is exactly -1. Overflow and unlimited
+// precision are handled as by Exp.
+func (c Context) Expm1(z, x *Big) *Big {
+	defer c.adopt(z)
+	if c.hookedOp(z, x, nil) {
+		return c.hooked(z, hookOp{name: "expm1", tagged: true}, func(c Context) *Big {
+			return c.Expm1(z, x)
+		}, x)
+	}
+	if c.Traps != 0 && z != nil {
+		return c.trapped(z, "Expm1", func(c Context) *Big {
+			return c.Expm1(z, x)
+		}, x)
+	}
+	if z.checkNil("Expm1", x, x) {
+		return z
+	}
+	if z.invalidContext(c) || z.mixedModes(c, x) {
+		return z
+	}
+	if z.checkNaNs(x, x, expnan) {
+		return z
+	}
+	switch {
+	case x.IsInf(+1):
+		return z.SetInf(false)
+	case x.IsInf(-1):
+		return z.SetMantScale(-1, 0)
+	case x.compact == 0:
+		return c.fix(z.setZero(x.form&signbit, x.exp))
+	}
+
+	prec := precision(c)
+	if prec == UnlimitedPrecision {
+		return z.setNaN(InvalidOperation, qnan, expunlim)
+	}
+	// e**x - 1 is x + x**2/2 + ..., which is in (x, x + x**2) for a small x.
+	if v, e, ok := tinyNudge(x, prec, 2, true); ok {
+		c.Round(z.SetBigMantScale(v, -e))
+		z.Context.Conditions |= Inexact | Rounded
+		return z
+	}
+	if x.Signbit() && x.CmpAbs(New(231*int64(prec+4), 2)) > 0 {
+		// Since 2.31 > ln(10), e**x < 10**-(prec+4), so e**x - 1 is too close
+		// to -1 for any other value to round differently from it.
+		v := new(big.Int).Set(arith.BigPow10(uint64(prec + 4)))
+		v.Sub(v, cst.OneInt)
+		c.Round(z.SetBigMantScale(v.Neg(v), prec+4))
+		z.Context.Conditions |= Inexact | Rounded
+		return z
+	}
+	if !x.Signbit() && x.adjusted() >= 0 {
+		if x.adjusted() >= 20 {
+			return z.xflow(c.minScale(), true, false)
+		}
+		if _, _, n, _ := expApprox(x, prec+5); n.Cmp(big.NewInt(int64(c.maxScale())+1)) > 0 {
+			return z.xflow(c.minScale(), true, false)
+		}
+	}
+
+	c.ziv(z, func(wp int) (*big.Int, *big.Int, int) {
+		return expm1Approx(x, wp)
+	})
+	if z.Context.Conditions&Overflow != 0 {
+		return z.xflow(c.minScale(), true, false)
+	}
+	return z
+}
+
+// expm1Approx approximates e**x - 1 for a finite, non-zero x with
+// -2.31×(wp+4) < x < 1E+20 that does not overflow, using wp digits of working
+// precision. It returns y, d, and f such that e**x - 1 is within d × 10**-f of
+// y × 10**-f.
+func expm1Approx(x *Big, wp int) (y, d *big.Int, f int) {
+	adj := x.adjusted()
+	if adj >= 0 {
+		// |e**x - 1| > 1/2, so subtracting one from e**x loses nothing.
+		y, d, n, ef := expApprox(x, wp)
+		f = ef - int(n.Int64())
+		if f < 0 {
+			// One is less than a unit of y.
+			return y, d.Add(d, cst.OneInt), f
+		}
+		return y.Sub(y, arith.BigPow10(uint64(f))), d, f
+	}
+
+	// Sum the Taylor series x + x**2/2! + x**3/3! + ... with f fractional
+	// digits, enough for wp significant digits since |e**x - 1| > |x|/2.
+	// Each term is truncated, and x itself contributes at most three units of
+	// error since the slope of e**x is at most e.
+	f = wp - adj + arith.Length(uint64(wp)) + 5
+	one := arith.BigPow10(uint64(f))
+	r := scaledMod(x, x.exp, nil)
+	if s := x.exp + f; s >= 0 {
+		r.Mul(r, arith.BigPow10(uint64(s)))
+	} else {
+		r.Quo(r, arith.BigPow10(uint64(-s)))
+	}
+	y = new(big.Int).Set(r)
+	t := new(big.Int).Set(r)
+	var q big.Int
+	i := int64(2)
+	for ; t.Sign() != 0; i++ {
+		t.Mul(t, r)
+		t.Quo(t, q.Mul(one, q.SetInt64(i)))
+		y.Add(y, t)
+	}
+	return y, big.NewInt(i + 4), f
+}
+
+// tinyNudge reports whether x is close enough to zero that a value within
+// 10**(k×(adj+1)) of x, where adj is x's adjusted exponent and k > 1, rounds to
+// prec digits the same way as any other value on the same side of x. If so,
+// it returns v and e such that v × 10**e is such a value, greater than x if up
+// is true and less than x otherwise.
+func tinyNudge(x *Big, prec, k int, up bool) (v *big.Int, e int, ok bool) {
+	// 10**u is less than a ten thousandth of a unit in the last of prec
+	// digits, so no rounding boundary other than x itself lies between x and
+	// x ± 10**u.
+	adj := int64(x.adjusted())
+	u := int64(x.exp)
+	if t := adj - int64(prec) - 3; t < u {
+		u = t
+	}
+	u--
+	if int64(k)*(adj+1) > u {
+		return nil, 0, false
+	}
+	v = scaledMod(x, int(u), nil)
+	if up {
+		v.Add(v, cst.OneInt)
+	} else {
+		v.Sub(v, cst.OneInt)
+	}
+	return v, int(u), true
+}
+
 // FMA sets z to (x * y) + u without any intermediate rounding.
 func (c Context) FMA(z, x, y, u *Big) *Big {
-	if z.invalidContext(c) {
+	defer c.adopt(z)
+	if c.hookedOp(z, x, y) || c.hookedOp(nil, u, nil) {
+		return c.hooked(z, hookOp{name: "fma", tagged: true}, func(c Context) *Big {
+			return c.FMA(z, x, y, u)
+		}, x, y, u)
+	}
+	if c.Traps != 0 && z != nil {
+		return c.trapped(z, "FMA", func(c Context) *Big {
+			return c.FMA(z, x, y, u)
+		}, x, y, u)
+	}
+	if z.checkNil("FMA", x, y) || z.checkNil("FMA", u, u) {
+		return z
+	}
+	if z.invalidContext(c) || z.mixedModes(c, x, y, u) {
 		return z
 	}
 	// Create a temporary receiver if z == u so we handle the z.FMA(x, y, z)
@@ -240,11 +664,436 @@ func (c Context) FMA(z, x, y, u *Big) *Big {
 	return z.setShared(z0)
 }
 
+// Hypot sets z to sqrt(p*p + q*q) and returns z. Unlike computing the squares,
+// it does not raise Overflow or Underflow unless the result itself overflows
+// or underflows. The result is correctly rounded using c's precision and
+// RoundingMode, and is exact if possible, with an exponent as close to the
+// smaller exponent of p and q as c's precision allows; e.g., Hypot(3.0, 4) is
+// 5.0.
+//
+// Special cases are, like math.Hypot:
+//
+//	Hypot(±Inf, q) = +Inf
+//	Hypot(p, ±Inf) = +Inf
+//	Hypot(NaN, q) = NaN
+//	Hypot(p, NaN) = NaN
+//
+// If c.Precision is UnlimitedPrecision and the result is not exact, z is set
+// to NaN and InvalidOperation is raised.
+func (c Context) Hypot(z, p, q *Big) *Big {
+	defer c.adopt(z)
+	if c.hookedOp(z, p, q) {
+		return c.hooked(z, hookOp{name: "hypot", tagged: true}, func(c Context) *Big {
+			return c.Hypot(z, p, q)
+		}, p, q)
+	}
+	if c.Traps != 0 && z != nil {
+		return c.trapped(z, "Hypot", func(c Context) *Big {
+			return c.Hypot(z, p, q)
+		}, p, q)
+	}
+	if z.checkNil("Hypot", p, q) {
+		return z
+	}
+	if z.invalidContext(c) || z.mixedModes(c, p, q) {
+		return z
+	}
+	if p.IsInf(0) || q.IsInf(0) {
+		// Even if the other operand is NaN.
+		return z.SetInf(false)
+	}
+	if z.checkNaNs(p, q, hypotnan) {
+		return z
+	}
+
+	// Let |p| be the larger of the two, or the non-zero one.
+	if p.compact == 0 || (q.compact != 0 && q.adjusted() > p.adjusted()) {
+		p, q = q, p
+	}
+	switch {
+	case p.compact == 0:
+		e := p.exp
+		if q.exp < e {
+			e = q.exp
+		}
+		return c.fix(z.setZero(0, e))
+	case q.compact == 0:
+		return c.Round(z.copyAbs(p))
+	}
+
+	prec := precision(c)
+	gap := int64(p.adjusted()) - int64(q.adjusted())
+	if prec == UnlimitedPrecision {
+		// An exact result has at least 2×gap digits.
+		if 2*gap > MaxPracticalPrecision {
+			return z.setNaN(InvalidOperation, qnan, hypotunlim)
+		}
+	} else {
+		// With |p| padded to w digits, q*q/(2|p|), which is less than
+		// 10**(2×q.adjusted() - p.adjusted() + 2), is less than a unit in
+		// its last place if 2×gap > w. The result then rounds as |p| plus a
+		// tenth of a unit does.
+		d := p.Precision()
+		w := d
+		if w < prec+1 {
+			w = prec + 1
+		}
+		if 2*gap > int64(w) {
+			e := p.exp - (w - d) - 1
+			a := scaledMod(p, e, nil)
+			a.Abs(a)
+			return c.Round(z.SetBigMantScale(a.Add(a, cst.OneInt), -e))
+		}
+	}
+
+	// The gap is small enough to compute p*p + q*q exactly.
+	e := p.exp
+	if q.exp < e {
+		e = q.exp
+	}
+	a := scaledMod(p, e, nil)
+	b := scaledMod(q, e, nil)
+	a.Mul(a, a)
+	return c.sqrt(z, a.Add(a, b.Mul(b, b)), e, hypotunlim)
+}
+
+// Log sets z to the natural logarithm of x, correctly rounded using c's
+// precision and RoundingMode, and returns z. Since ln(x) is irrational for
+// every positive x other than 1, Inexact and Rounded are always raised unless x
+// is 1, in which case z is set to exactly 0.
+//
+// Log(±0) is -Inf and Log(+Inf) is +Inf. If x is less than zero, z is set to
+// NaN and InvalidOperation is raised. As with Exp, if c.Precision is
+// UnlimitedPrecision and x is finite, positive, and not 1, z is set to NaN and
+// InvalidOperation is raised.
+func (c Context) Log(z, x *Big) *Big {
+	defer c.adopt(z)
+	if c.hookedOp(z, x, nil) {
+		return c.hooked(z, hookOp{name: "ln", tagged: true}, func(c Context) *Big {
+			return c.Log(z, x)
+		}, x)
+	}
+	if c.Traps != 0 && z != nil {
+		return c.trapped(z, "Log", func(c Context) *Big {
+			return c.Log(z, x)
+		}, x)
+	}
+	return c.log(z, x, false)
+}
+
+// Log10 sets z to the base-10 logarithm of x, correctly rounded using c's
+// precision and RoundingMode, and returns z. If x is an exact power of ten,
+// such as 0.001 or 1E+50, z is set to its exponent, which is an integer, and
+// Inexact is not raised unless c's precision is too small to hold it.
+// Otherwise, log10(x) is irrational, and Inexact and Rounded are always
+// raised.
+//
+// Special values and unlimited precision are handled as by Log: Log10(±0) is
+// -Inf, Log10(+Inf) is +Inf, and a negative x raises InvalidOperation.
+func (c Context) Log10(z, x *Big) *Big {
+	defer c.adopt(z)
+	if c.hookedOp(z, x, nil) {
+		return c.hooked(z, hookOp{name: "log10", tagged: true}, func(c Context) *Big {
+			return c.Log10(z, x)
+		}, x)
+	}
+	if c.Traps != 0 && z != nil {
+		return c.trapped(z, "Log10", func(c Context) *Big {
+			return c.Log10(z, x)
+		}, x)
+	}
+	return c.log(z, x, true)
+}
+
+// log is the implementation of Log and, if ten is true, Log10.
+func (c Context) log(z, x *Big, ten bool) *Big {
+	op := "Log"
+	if ten {
+		op = "Log10"
+	}
+	if z.checkNil(op, x, x) {
+		return z
+	}
+	if z.invalidContext(c) || z.mixedModes(c, x) {
+		return z
+	}
+	if z.checkNaNs(x, x, lognan) {
+		return z
+	}
+	switch {
+	case x.IsFinite() && x.compact == 0:
+		return z.SetInf(true)
+	case x.Signbit():
+		return z.setNaN(InvalidOperation, qnan, logneg)
+	case x.IsInf(+1):
+		return z.SetInf(false)
+	}
+
+	if ten {
+		var pow10 bool
+		if x.isCompact() {
+			pow10 = arith.PowOfTen(x.compact)
+		} else {
+			pow10 = arith.PowOfTenBig(&x.unscaled)
+		}
+		if pow10 {
+			// The result is exact, but might not fit in c's precision.
+			return c.Round(z.SetMantScale(int64(x.adjusted()), 0))
+		}
+	}
+
+	prec := precision(c)
+	var lo, hi Big
+	done := false
+	extra := 0
+	if adj := x.adjusted(); adj == 0 || adj == -1 {
+		// x is in [0.1, 10), so ln(x) might be close to zero. With δ = x - 1,
+		// ln(x) might be as small as δ/2, so δ's magnitude is added to the
+		// working precision.
+		var delta Big
+		Context{Precision: UnlimitedPrecision}.Sub(&delta, x, New(1, 0))
+		if delta.compact == 0 {
+			return z.setZero(0, 0)
+		}
+		if prec == UnlimitedPrecision {
+			return z.setNaN(InvalidOperation, qnan, logunlim)
+		}
+		if adj := delta.adjusted(); adj < 0 {
+			extra = -adj
+		}
+		if !ten && delta.adjusted() < -(prec+3) {
+			// ln(1+δ) = δ - δ**2/2 + δ**3/3 - ..., which, for a small δ, is in
+			// the interval (δ - δ**2, δ - δ**2/4). Unless a rounding boundary
+			// lies within it, the interval is narrow enough to round without
+			// computing any series.
+			u := Context{Precision: UnlimitedPrecision}
+			var d2 Big
+			u.Mul(&d2, &delta, &delta)
+			u.Sub(&lo, &delta, &d2)
+			u.Sub(&hi, &delta, u.Mul(&d2, &d2, New(25, 2)))
+			c.Round(&lo)
+			c.Round(&hi)
+			done = lo.Cmp(&hi) == 0
+		}
+	} else if prec == UnlimitedPrecision {
+		return z.setNaN(InvalidOperation, qnan, logunlim)
+	}
+
+	// As with Exp, compute ln(x) with increasing precision until both ends of
+	// an interval containing it round to the same result.
+	for wp := prec + 5; !done; wp += wp / 2 {
+		y, d, f := logApprox(x, wp+extra)
+		if ten {
+			y, d = log10Approx(y, d, f)
+		}
+		lo.Context.Conditions = 0
+		c.Round(lo.SetBigMantScale(new(big.Int).Sub(y, d), f))
+		c.Round(hi.SetBigMantScale(y.Add(y, d), f))
+		done = lo.Cmp(&hi) == 0
+	}
+	z.Copy(&lo)
+	z.Context.Conditions |= lo.Context.Conditions | Inexact | Rounded
+	return z
+}
+
+// logApprox approximates ln(x) for a finite, positive x using at least wp
+// fractional digits. It returns y, d, and f such that ln(x) is in the open
+// interval ((y-d) × 10**-f, (y+d) × 10**-f).
+func logApprox(x *Big, wp int) (y, d *big.Int, f int) {
+	// With x = a × 10**adj, a in [1, 10), and a = b × 2**j, b in [0.75, 1.5),
+	//
+	//    ln(x) = ln(b) + j×ln(2) + adj×ln(10)
+	//
+	// and ln(b) = 2×atanh((b-1)/(b+1)), where |(b-1)/(b+1)| < 0.2. Each step
+	// truncates to f fractional digits; d bounds the accumulated error, which
+	// is at most a few units per term of the series and per multiple of ln(10).
+	f = wp + arith.Length(uint64(wp)) + 3
+	one := arith.BigPow10(uint64(f))
+
+	adj := x.adjusted()
+	b := scaledMod(x, x.exp, nil)
+	if s := f - (x.Precision() - 1); s >= 0 {
+		b.Mul(b, arith.BigPow10(uint64(s)))
+	} else {
+		b.Quo(b, arith.BigPow10(uint64(-s)))
+	}
+	j := int64(0)
+	lim := new(big.Int).Mul(arith.BigPow10(uint64(f-1)), big.NewInt(15))
+	for b.Cmp(lim) >= 0 {
+		b.Rsh(b, 1)
+		j++
+	}
+
+	t := new(big.Int).Sub(b, one)
+	t.Mul(t, one)
+	t.Quo(t, b.Add(b, one))
+	t2 := new(big.Int).Mul(t, t)
+	t2.Quo(t2, one)
+	y = new(big.Int)
+	var q big.Int
+	for i := int64(1); t.Sign() != 0; i += 2 {
+		y.Add(y, q.Quo(t, q.SetInt64(i)))
+		t.Mul(t, t2)
+		t.Quo(t, one)
+	}
+	y.Lsh(y, 1)
+
+	if j != 0 {
+		ln2 := atanhInvFixed(3, f)
+		y.Add(y, ln2.Mul(ln2, big.NewInt(2*j)))
+	}
+	if adj != 0 {
+		ln10 := ln10Fixed(f)
+		y.Add(y, ln10.Mul(ln10, big.NewInt(int64(adj))))
+	}
+	if adj < 0 {
+		adj = -adj
+	}
+	d = big.NewInt(int64(adj))
+	d.Mul(d, big.NewInt(4))
+	return y, d.Add(d, big.NewInt(10*int64(f)+100)), f
+}
+
+// log10Approx converts the approximation of ln(x) returned by logApprox into
+// one of log10(x) = ln(x) / ln(10) with the same number of fractional digits.
+func log10Approx(y, d *big.Int, f int) (*big.Int, *big.Int) {
+	// With ln(10) = L ± 1 and L > 2 × 10**f, the quotient y × 10**f / L is
+	// within d + |y|/L + 1 of log10(x) × 10**f, plus 1 for truncating.
+	ln10 := ln10Fixed(f)
+	q := new(big.Int).Mul(y, arith.BigPow10(uint64(f)))
+	q.Quo(q, ln10)
+	e := new(big.Int).Abs(y)
+	e.Quo(e, ln10)
+	e.Add(e, d)
+	return q, e.Add(e, cst.TwoInt)
+}
+
+// Log1p sets z to ln(1 + x), correctly rounded using c's precision and
+// RoundingMode, and returns z. Unlike Log of 1 + x, it keeps full precision
+// when x is near zero. Inexact and Rounded are always raised unless x is zero,
+// in which case z is set to x.
+//
+// Log1p(-1) is -Inf and Log1p(+Inf) is +Inf. If x is less than -1, z is set to
+// NaN and InvalidOperation is raised. Unlimited precision is handled as by Log.
+func (c Context) Log1p(z, x *Big) *Big {
+	defer c.adopt(z)
+	if c.hookedOp(z, x, nil) {
+		return c.hooked(z, hookOp{name: "log1p", tagged: true}, func(c Context) *Big {
+			return c.Log1p(z, x)
+		}, x)
+	}
+	if c.Traps != 0 && z != nil {
+		return c.trapped(z, "Log1p", func(c Context) *Big {
+			return c.Log1p(z, x)
+		}, x)
+	}
+	if z.checkNil("Log1p", x, x) {
+		return z
+	}
+	if z.invalidContext(c) || z.mixedModes(c, x) {
+		return z
+	}
+	if z.checkNaNs(x, x, lognan) {
+		return z
+	}
+	switch {
+	case x.IsInf(+1):
+		return z.SetInf(false)
+	case x.IsInf(-1):
+		return z.setNaN(InvalidOperation, qnan, logneg)
+	case x.compact == 0:
+		return c.fix(z.setZero(x.form&signbit, x.exp))
+	}
+	if x.Signbit() {
+		switch x.CmpAbs(New(1, 0)) {
+		case 0:
+			return z.SetInf(true)
+		case +1:
+			return z.setNaN(InvalidOperation, qnan, logneg)
+		}
+	}
+
+	prec := precision(c)
+	if prec == UnlimitedPrecision {
+		return z.setNaN(InvalidOperation, qnan, logunlim)
+	}
+	// ln(1+x) is x - x**2/2 + ..., which is in (x - x**2, x) for a small x.
+	if v, e, ok := tinyNudge(x, prec, 2, false); ok {
+		c.Round(z.SetBigMantScale(v, -e))
+		z.Context.Conditions |= Inexact | Rounded
+		return z
+	}
+	return c.ziv(z, func(wp int) (*big.Int, *big.Int, int) {
+		return log1pApprox(x, wp)
+	})
+}
+
+// log1pApprox approximates ln(1 + x) for a finite, non-zero x > -1 using wp
+// digits of working precision. It returns y, d, and f such that ln(1 + x) is
+// within d × 10**-f of y × 10**-f.
+func log1pApprox(x *Big, wp int) (y, d *big.Int, f int) {
+	adj := x.adjusted()
+	if adj >= 0 || adj == -1 {
+		// |ln(1 + x)| > 0.09, so wp+2 fractional digits of it are enough.
+		if adj > wp+20 {
+			// ln(1 + x) - ln(x) = ln(1 + 1/x) < 1/x, which is less than a
+			// unit.
+			y, d, f = logApprox(x, wp+2)
+			return y, d.Add(d, cst.OneInt), f
+		}
+		var s Big
+		Context{Precision: UnlimitedPrecision}.Add(&s, x, New(1, 0))
+		return logApprox(&s, wp+2)
+	}
+
+	// With |x| < 0.1, ln(1 + x) = 2×atanh(t) where t = x / (2 + x), and
+	// |t| < 0.06. The series has f fractional digits, enough for wp
+	// significant digits since |ln(1 + x)| > |x|/2. Each term and t itself
+	// are truncated, and the sum is doubled.
+	f = wp - adj + arith.Length(uint64(wp)) + 5
+	one := arith.BigPow10(uint64(f))
+	var den Big
+	Context{Precision: UnlimitedPrecision}.Add(&den, x, New(2, 0))
+	t, _ := fixedQuo(x, &den, f)
+	if x.Signbit() {
+		t.Neg(t)
+	}
+	t2 := new(big.Int).Mul(t, t)
+	t2.Quo(t2, one)
+	y = new(big.Int)
+	var q big.Int
+	i := int64(1)
+	for ; t.Sign() != 0; i += 2 {
+		y.Add(y, q.Quo(t, q.SetInt64(i)))
+		t.Mul(t, t2)
+		t.Quo(t, one)
+	}
+	y.Lsh(y, 1)
+	return y, big.NewInt(2*i + 6), f
+}
+
 // Mul sets z to x * y and returns z.
 func (c Context) Mul(z, x, y *Big) *Big {
-	if z.invalidContext(c) {
+	defer c.adopt(z)
+	if c.hookedOp(z, x, y) {
+		return c.hooked(z, hookOp{name: "×", infix: true, tagged: true}, func(c Context) *Big {
+			return c.Mul(z, x, y)
+		}, x, y)
+	}
+	if c.Traps != 0 && z != nil {
+		return c.trapped(z, "Mul", func(c Context) *Big {
+			return c.Mul(z, x, y)
+		}, x, y)
+	}
+	if z.checkNil("Mul", x, y) {
+		return z
+	}
+	if z.invalidContext(c) || z.mixedModes(c, x, y) {
 		return z
 	}
+	if c.ExactOnly {
+		c.Precision = UnlimitedPrecision
+	}
 	return c.round(c.mul(z, x, y))
 }
 
@@ -306,8 +1155,405 @@ func (c Context) mul(z, x, y *Big) *Big {
 	return z.setNaN(InvalidOperation, qnan, mul0inf)
 }
 
+// Pow sets z to x**y, correctly rounded using c's precision and RoundingMode,
+// and returns z.
+//
+// If y is an integer, x**y is computed exactly, when its coefficient is short
+// enough to be rounded to c's precision, and rounded once. As with Mul, its
+// ideal exponent is x's exponent times y, so 1.50**2 is 2.2500 and 2**-2 is
+// 0.25. Otherwise, x**y = e**(y×ln(x)) is computed with as many guard digits as
+// are needed to round it correctly and, as the GDA specification requires, the
+// result is padded to c's precision and Inexact and Rounded are raised even
+// if it is exact; e.g., with a precision of 16, 4**0.5 is 2.000000000000000.
+//
+// If x and y are both zero, or x is less than zero and y is not an integer,
+// z is set to NaN and InvalidOperation is raised. As with Exp, so is it if
+// c.Precision is UnlimitedPrecision and y is not an integer. Zeros and
+// infinities follow the GDA specification: 0**y is 0 for y > 0 and +Inf for
+// y < 0, Inf**y is the reverse, and either is negative only if x is negative
+// and y is an odd integer. x**±Inf is 0 or +Inf for x > 0 unless x is 1, in
+// which case it is an inexact 1. x**0 is exactly 1.
+func (c Context) Pow(z, x, y *Big) *Big {
+	defer c.adopt(z)
+	if c.hookedOp(z, x, y) {
+		return c.hooked(z, hookOp{name: "pow", tagged: true}, func(c Context) *Big {
+			return c.Pow(z, x, y)
+		}, x, y)
+	}
+	if c.Traps != 0 && z != nil {
+		return c.trapped(z, "Pow", func(c Context) *Big {
+			return c.Pow(z, x, y)
+		}, x, y)
+	}
+	if z.checkNil("Pow", x, y) {
+		return z
+	}
+	if z.invalidContext(c) || z.mixedModes(c, x, y) {
+		return z
+	}
+	if z.checkNaNs(x, y, pownan) {
+		return z
+	}
+
+	yint := y.IsFinite() && y.IsInt()
+	// sign is the sign of the result, which is negative only if x is
+	// negative and y is an odd integer.
+	var sign form
+	if x.Signbit() && yint && isOddInt(y) {
+		sign = signbit
+	}
+	switch {
+	case x.Sign() < 0 && !yint:
+		return z.setNaN(InvalidOperation, qnan, powneg)
+	case x.IsFinite() && x.compact == 0:
+		switch y.Sign() {
+		case 0:
+			return z.setNaN(InvalidOperation, qnan, pow00)
+		case +1:
+			return z.setZero(sign, 0)
+		default:
+			return z.SetInf(sign != 0)
+		}
+	case x.IsInf(0):
+		switch y.Sign() {
+		case 0:
+			return z.SetMantScale(1, 0)
+		case +1:
+			return z.SetInf(sign != 0)
+		default:
+			return z.setZero(sign, 0)
+		}
+	case y.IsInf(0):
+		var one Big
+		switch r := cmp(x, one.SetMantScale(1, 0), false); {
+		case r == 0:
+			z.SetMantScale(1, 0)
+			return c.powPad(z)
+		case (r > 0) == y.IsInf(+1):
+			return z.SetInf(false)
+		default:
+			return z.setZero(0, 0)
+		}
+	case y.compact == 0:
+		return z.SetMantScale(1, 0)
+	}
+
+	// r is |x| with its coefficient reduced.
+	var r Big
+	Context{Precision: UnlimitedPrecision}.simpleReduce(r.copyAbs(x))
+
+	prec := precision(c)
+	if yint {
+		if c.powInt(z, &r, y, x.exp, sign) {
+			return z
+		}
+		if prec == UnlimitedPrecision {
+			return z.setNaN(InvalidOperation, qnan, powunlim)
+		}
+	} else {
+		if prec == UnlimitedPrecision {
+			return z.setNaN(InvalidOperation, qnan, powunlim)
+		}
+		// If x**y is exact, or is halfway between two results, computing
+		// e**(y×ln(x)) with increasing precision would never end. It can only
+		// be either if x**y = v**m for a rational v and an integer m.
+		if v, m, ok := powRoot(&r, y); ok && c.powInt(z, v, m, v.exp, 0) {
+			return c.powPad(z)
+		}
+	}
+	return c.powApprox(z, &r, y, sign)
+}
+
+// powPad pads the coefficient of z, the inexact result of Pow, to c's
+// precision and raises Inexact and Rounded.
+func (c Context) powPad(z *Big) *Big {
+	if z.IsFinite() && z.compact != 0 {
+		n := precision(c) - z.Precision()
+		if m := z.exp - c.etiny(); n > m {
+			n = m
+		}
+		if n > 0 {
+			z.SetScaleFloor(z, n-z.exp)
+		}
+	}
+	z.Context.Conditions |= Inexact | Rounded
+	return z
+}
+
+// powInt sets z to x**y with the given sign, where x is positive and has a
+// reduced coefficient, y is a non-zero integer, and the ideal exponent of the
+// result is xexp×y. It reports false, leaving z unmodified, if the exact
+// coefficient of x**y is too long to compute.
+//
+// It computes every x**y that could be exactly representable with two more
+// digits than c's precision, which Pow relies on so that powApprox, which
+// cannot round such results, never has to.
+func (c Context) powInt(z, x, y *Big, xexp int, sign form) bool {
+	prec := precision(c)
+	if prec == UnlimitedPrecision {
+		prec = MaxPracticalPrecision
+	}
+	a := scaledMod(x, x.exp, nil)
+	pow10 := a.Cmp(cst.OneInt) == 0
+	if y.adjusted() > 18 {
+		// Since |y| >= 1E+19, x**y is far out of range unless x is 1.
+		switch {
+		case !pow10 || (x.exp == 0 && precision(c) == UnlimitedPrecision):
+			return false
+		case x.exp == 0:
+			z.SetMantScale(1, 0)
+			if xexp < 0 && !y.Signbit() {
+				// The ideal exponent is far below zero.
+				z.SetScaleFloor(z, prec-1)
+			}
+			z.form |= sign
+			return true
+		case x.exp > 0 == y.Signbit():
+			z.Context.Conditions |= Clamped
+			z.xflow(c.etiny(), false, sign != 0)
+		default:
+			z.xflow(c.minScale(), true, sign != 0)
+		}
+		return true
+	}
+
+	n, _ := y.Int64()
+	if n < 0 {
+		n = -n
+	}
+	var p big.Int
+	if pow10 {
+		p.SetUint64(1)
+	} else {
+		// a**n has more than n×(a.BitLen()-1)×log10(2) digits, which is
+		// more than prec+2 if n×(a.BitLen()-1) > 8×(prec+2). The quotient
+		// 1/a**n terminates only if a is a power of two or five, and then has
+		// at least as many digits.
+		if bits := int64(a.BitLen() - 1); n > int64(8*(prec+2))/bits {
+			return false
+		}
+		p.Exp(a, big.NewInt(n), nil)
+	}
+
+	// The exponent of a**n is x.exp×n.
+	var exp big.Int
+	exp.Mul(big.NewInt(int64(x.exp)), big.NewInt(n))
+	if exp.CmpAbs(big.NewInt(cst.MaxScaleInf)) > 0 {
+		if exp.Sign() > 0 == y.Signbit() {
+			z.Context.Conditions |= Clamped
+			z.xflow(c.etiny(), false, sign != 0)
+		} else {
+			z.xflow(c.minScale(), true, sign != 0)
+		}
+		return true
+	}
+	e := int(exp.Int64())
+
+	if y.Signbit() {
+		var one, d Big
+		one.Context.OperatingMode = c.OperatingMode
+		d.Context.OperatingMode = c.OperatingMode
+		one.SetMantScale(1, 0).form |= sign
+		c.Quo(z, &one, d.SetBigMantScale(&p, -e))
+		return true
+	}
+	z.SetBigMantScale(&p, -e)
+	z.form |= sign
+	// Pad the coefficient toward the ideal exponent with as many zeros as
+	// fit in c's precision.
+	ideal := exp.Mul(big.NewInt(int64(xexp)), big.NewInt(n))
+	if t := int64(e) - int64(prec-z.Precision()); ideal.Cmp(big.NewInt(t)) < 0 {
+		ideal.SetInt64(t)
+	}
+	if t := ideal.Int64(); t < int64(e) {
+		z.SetScaleFloor(z, int(-t))
+	}
+	c.round(z)
+	return true
+}
+
+// powRoot returns v and m such that x**y = v**m, where x is positive and has a
+// reduced coefficient, y is not an integer, v is a positive decimal with a
+// reduced coefficient, and m is an integer. It reports false if there is no
+// such v, in which case x**y is irrational.
+func powRoot(x, y *Big) (v, m *Big, ok bool) {
+	// With y = m/d in lowest terms, x**y is rational only if x = v**d. If
+	// x = a × 10**e and a is not a multiple of 10, neither is a**(1/d), so
+	// x = v**d only if d divides e and a = s**d for an integer s. Since y's
+	// coefficient is not a multiple of 10, d >= 5**k for y = c × 10**-k,
+	// which is far too large for that unless k is small.
+	var yr Big
+	Context{Precision: UnlimitedPrecision}.simpleReduce(yr.Copy(y))
+	k := -yr.exp
+	if k > 27 {
+		return nil, nil, false
+	}
+	num := scaledMod(&yr, yr.exp, nil)
+	den := new(big.Int).Set(arith.BigPow10(uint64(k)))
+	g := new(big.Int).GCD(nil, nil, new(big.Int).Abs(num), den)
+	num.Quo(num, g)
+	den.Quo(den, g)
+	if !den.IsInt64() {
+		return nil, nil, false
+	}
+	d := den.Int64()
+	if int64(x.exp)%d != 0 {
+		return nil, nil, false
+	}
+
+	a := scaledMod(x, x.exp, nil)
+	if a.Cmp(cst.OneInt) != 0 {
+		if d > int64(a.BitLen()) {
+			return nil, nil, false
+		}
+		s := iroot(a, int(d))
+		if new(big.Int).Exp(s, den, nil).Cmp(a) != 0 {
+			return nil, nil, false
+		}
+		a = s
+	}
+	v = new(Big).SetBigMantScale(a, -int(int64(x.exp)/d))
+	return v, new(Big).SetBigMantScale(num, 0), true
+}
+
+// iroot returns ⌊a**(1/n)⌋ for a positive a and n >= 2.
+func iroot(a *big.Int, n int) *big.Int {
+	if n == 2 {
+		return new(big.Int).Sqrt(a)
+	}
+	// Newton's method converges to the root from above.
+	x := new(big.Int).Lsh(cst.OneInt, uint(a.BitLen()/n+1))
+	bn, bn1 := big.NewInt(int64(n)), big.NewInt(int64(n-1))
+	var t, y big.Int
+	for {
+		t.Quo(a, t.Exp(x, bn1, nil))
+		y.Mul(x, bn1)
+		y.Quo(y.Add(&y, &t), bn)
+		if y.Cmp(x) >= 0 {
+			return x
+		}
+		x.Set(&y)
+	}
+}
+
+// isOddInt reports whether the finite integer x is odd.
+func isOddInt(x *Big) bool {
+	if x.exp > 0 {
+		return false
+	}
+	q := scaledMod(x, x.exp, nil)
+	q.Quo(q.Abs(q), arith.BigPow10(uint64(-x.exp)))
+	return q.Bit(0) != 0
+}
+
+// powApprox sets z to x**y with the given sign, correctly rounded, where x is
+// positive and not 1 and y is finite and non-zero, and returns z. x**y must not
+// be exactly representable with two more digits than c's precision.
+func (c Context) powApprox(z, x, y *Big, sign form) *Big {
+	prec := precision(c)
+	// As in Log, ln(x) might be as small as |x-1|/2, and the digits of y's
+	// integer part are also needed to compute y×ln(x) to wp fractional
+	// digits.
+	extra := 0
+	if adj := x.adjusted(); adj == 0 || adj == -1 {
+		var delta Big
+		Context{Precision: UnlimitedPrecision}.Sub(&delta, x, New(1, 0))
+		if adj := delta.adjusted(); adj < 0 {
+			extra = -adj
+		}
+	}
+	if adj := y.adjusted(); adj >= 0 {
+		extra += adj + 1
+	}
+
+	u := Context{Precision: UnlimitedPrecision, OperatingMode: c.OperatingMode}
+	var lo, hi, tlo, thi Big
+	tlo.Context.OperatingMode = c.OperatingMode
+	thi.Context.OperatingMode = c.OperatingMode
+	for wp := prec + 5; ; wp += wp / 2 {
+		// y×ln(x) is in the open interval (tlo, thi).
+		ly, ld, f := logApprox(x, wp+extra)
+		u.Mul(&tlo, y, tlo.SetBigMantScale(new(big.Int).Sub(ly, ld), f))
+		u.Mul(&thi, y, thi.SetBigMantScale(ly.Add(ly, ld), f))
+		if y.Signbit() {
+			tlo, thi = thi, tlo
+		}
+
+		switch {
+		case tlo.Sign() > 0 && tlo.adjusted() >= 20:
+			return z.xflow(c.minScale(), true, sign != 0)
+		case thi.Sign() < 0 && thi.adjusted() >= 20:
+			z.Context.Conditions |= Clamped
+			return z.xflow(c.etiny(), false, sign != 0)
+		case tlo.Sign() == thi.Sign() &&
+			tlo.adjusted() < -(prec+3) && thi.adjusted() < -(prec+3):
+			// As in Exp, x**y is too close to 1 for any other value to round
+			// differently from 1 ± 10**-(prec+3).
+			v := new(big.Int).Set(arith.BigPow10(uint64(prec + 3)))
+			if tlo.Signbit() {
+				v.Sub(v, cst.OneInt)
+			} else {
+				v.Add(v, cst.OneInt)
+			}
+			lo.SetBigMantScale(v, prec+3).form |= sign
+			lo.Context.Conditions = 0
+			c.Round(&lo)
+			hi.Copy(&lo)
+		default:
+			// e**tlo and e**thi bound x**y; see Exp.
+			max := big.NewInt(int64(c.maxScale()) + 1)
+			min := big.NewInt(int64(c.etiny()) - 2)
+			y1, d1, n1, f1 := expApprox(&tlo, wp)
+			if n1.Cmp(max) > 0 {
+				return z.xflow(c.minScale(), true, sign != 0)
+			}
+			y2, d2, n2, f2 := expApprox(&thi, wp)
+			if n2.Cmp(min) < 0 {
+				z.Context.Conditions |= Clamped
+				return z.xflow(c.etiny(), false, sign != 0)
+			}
+			if n1.Cmp(min) < 0 || n2.Cmp(max) > 0 {
+				continue
+			}
+			lo.SetBigMantScale(y1.Sub(y1, d1), f1-int(n1.Int64()))
+			hi.SetBigMantScale(y2.Add(y2, d2), f2-int(n2.Int64()))
+			if sign != 0 {
+				lo, hi = hi, lo
+				lo.form |= sign
+				hi.form |= sign
+			}
+			lo.Context.Conditions = 0
+			hi.Context.Conditions = 0
+			c.Round(&lo)
+			c.Round(&hi)
+			if lo.Context.Conditions&hi.Context.Conditions&Overflow != 0 {
+				return z.xflow(c.minScale(), true, sign != 0)
+			}
+		}
+		if lo.Cmp(&hi) == 0 {
+			break
+		}
+	}
+	z.Copy(&lo)
+	z.Context.Conditions |= lo.Context.Conditions | Inexact | Rounded
+	return z
+}
+
 // Quantize sets z to the number equal in value and sign to z with the scale, n.
 func (c Context) Quantize(z *Big, n int) *Big {
+	defer c.adopt(z)
+	if c.hasHooks() {
+		op := hookOp{name: "quantize", params: strconv.Itoa(n) + ", " + c.roundingMode().String()}
+		return c.hooked(z, op, func(c Context) *Big {
+			return c.Quantize(z, n)
+		}, z)
+	}
+	if c.Traps != 0 && z != nil {
+		return c.trapped(z, "Quantize", func(c Context) *Big {
+			return c.Quantize(z, n)
+		}, z)
+	}
+	mustNotNil("Quantize", z, z)
 	if debug {
 		z.validate()
 	}
@@ -324,7 +1570,7 @@ func (c Context) Quantize(z *Big, n int) *Big {
 		return z
 	}
 
-	if n > c.maxScale() || n < c.etiny() {
+	if n > c.etop() || n < c.etiny() {
 		return z.setNaN(InvalidOperation, qnan, quantminmax)
 	}
 
@@ -333,9 +1579,19 @@ func (c Context) Quantize(z *Big, n int) *Big {
 		return z
 	}
 
+	// z.exp and n are within [MinScale - MaxPrecision, MaxScale], so shift
+	// does not overflow.
 	shift := z.exp - n
-	if z.Precision()+shift > precision(c) {
+	if prec := precision(c); z.Precision()+shift > prec {
 		return z.setNaN(InvalidOperation, qnan, quantprec)
+	} else if prec == UnlimitedPrecision && z.Precision()+shift > MaxPracticalPrecision {
+		return z.setNaN(InsufficientStorage, qnan, toolong)
+	}
+
+	if shift < 0 && c.ExactOnly {
+		if lost := z.lostDigits(-shift); lost > 0 {
+			return c.setInexact(z, "Quantize", lost)
+		}
 	}
 
 	z.exp = n
@@ -347,7 +1603,7 @@ func (c Context) Quantize(z *Big, n int) *Big {
 		z.Context.Conditions |= Rounded
 	}
 
-	m := c.RoundingMode
+	m := c.roundingMode()
 	neg := z.form & signbit
 	if z.isCompact() {
 		if shift > 0 {
@@ -357,7 +1613,7 @@ func (c Context) Quantize(z *Big, n int) *Big {
 			// shift < 0
 		} else if yc, ok := arith.Pow10(uint64(-shift)); ok {
 			z.quo(m, z.compact, neg, yc, 0)
-			return z
+			return c.quantCarry(z, n)
 		}
 		z.unscaled.SetUint64(z.compact)
 		z.compact = cst.Inflated
@@ -369,19 +1625,85 @@ func (c Context) Quantize(z *Big, n int) *Big {
 	} else {
 		var r big.Int
 		z.quoBig(m, &z.unscaled, neg, arith.BigPow10(uint64(-shift)), 0, &r)
+		return c.quantCarry(z, n)
+	}
+	return z
+}
+
+// lostDigits returns the number of digits lost by discarding the n least
+// significant digits of z's coefficient, not counting trailing zeros.
+func (z *Big) lostDigits(n int) int {
+	var r big.Int
+	if z.isCompact() {
+		r.SetUint64(z.compact)
+	} else {
+		r.Set(&z.unscaled)
+	}
+	r.Rem(&r, arith.BigPow10(uint64(n)))
+	if r.Sign() == 0 {
+		return 0
+	}
+	var (
+		ten = big.NewInt(10)
+		q   big.Int
+		m   big.Int
+	)
+	for {
+		q.QuoRem(&r, ten, &m)
+		if m.Sign() != 0 {
+			return n
+		}
+		r.Set(&q)
+		n--
+	}
+}
+
+// quantCarry restores z's exponent to n after z has been rounded by quo or
+// quoBig. Both absorb a carry into a new digit (e.g., 9.9 -> 10) by
+// incrementing the exponent, but quantization requires the exponent to remain
+// fixed.
+func (c Context) quantCarry(z *Big, n int) *Big {
+	if z.exp == n {
+		return z
+	}
+	if z.isCompact() {
+		z.unscaled.SetUint64(z.compact)
+	}
+	checked.MulBigPow10(&z.unscaled, &z.unscaled, uint64(z.exp-n))
+	z.exp = n
+	z.norm()
+	if z.Precision() > precision(c) {
+		return z.setNaN(InvalidOperation, qnan, quantprec)
 	}
 	return z
 }
 
 // Quo sets z to x / y and returns z.
 func (c Context) Quo(z, x, y *Big) *Big {
+	defer c.adopt(z)
+	if c.hookedOp(z, x, y) {
+		return c.hooked(z, hookOp{name: "÷", infix: true, tagged: true}, func(c Context) *Big {
+			return c.Quo(z, x, y)
+		}, x, y)
+	}
+	if c.Traps != 0 && z != nil {
+		return c.trapped(z, "Quo", func(c Context) *Big {
+			return c.Quo(z, x, y)
+		}, x, y)
+	}
+	if z.checkNil("Quo", x, y) {
+		return z
+	}
 	if debug {
 		x.validate()
 		y.validate()
 	}
-	if z.invalidContext(c) {
+	if z.invalidContext(c) || z.mixedModes(c, x, y) {
 		return z
 	}
+	if c.ExactOnly {
+		return c.quoExact(z, x, y)
+	}
 
 	sign := (x.form & signbit) ^ (y.form & signbit)
 	if x.isSpecial() || y.isSpecial() {
@@ -421,7 +1743,7 @@ func (c Context) Quo(z, x, y *Big) *Big {
 
 	var (
 		ideal = x.exp - y.exp // preferred exponent.
-		m     = c.RoundingMode
+		m     = c.roundingMode()
 		yp    = y.Precision() // stored since we might decrement it.
 		zp    = precision(c)  // stored because of overhead.
 	)
@@ -440,36 +1762,38 @@ func (c Context) Quo(z, x, y *Big) *Big {
 		expadj := ideal - z.exp
 		if shift > 0 {
 			if sx, ok := checked.MulPow10(x.compact, uint64(shift)); ok {
-				if z.quo(m, sx, x.form, y.compact, y.form) && expadj > 0 {
-					c.simpleReduce(z)
+				if z.quo(m, sx, x.form, y.compact, y.form) {
+					c.quoIdeal(z, expadj)
 				}
 				return z
 			}
 			xb := z.unscaled.SetUint64(x.compact)
 			xb = checked.MulBigPow10(xb, xb, uint64(shift))
 			yb := new(big.Int).SetUint64(y.compact)
-			if z.quoBig(m, xb, x.form, yb, y.form, yb) && expadj > 0 {
-				c.simpleReduce(z)
+			// yb cannot be used for the remainder since quoBig needs it to
+			// round the quotient.
+			if z.quoBig(m, xb, x.form, yb, y.form, new(big.Int)) {
+				c.quoIdeal(z, expadj)
 			}
 			return z
 		}
 		if shift < 0 {
 			if sy, ok := checked.MulPow10(y.compact, uint64(-shift)); ok {
-				if z.quo(m, x.compact, x.form, sy, y.form) && expadj > 0 {
-					c.simpleReduce(z)
+				if z.quo(m, x.compact, x.form, sy, y.form) {
+					c.quoIdeal(z, expadj)
 				}
 				return z
 			}
 			yb := z.unscaled.SetUint64(y.compact)
 			yb = checked.MulBigPow10(yb, yb, uint64(-shift))
 			xb := new(big.Int).SetUint64(x.compact)
-			if z.quoBig(m, xb, x.form, yb, y.form, xb) && expadj > 0 {
-				c.simpleReduce(z)
+			if z.quoBig(m, xb, x.form, yb, y.form, xb) {
+				c.quoIdeal(z, expadj)
 			}
 			return z
 		}
-		if z.quo(m, x.compact, x.form, y.compact, y.form) && expadj > 0 {
-			c.simpleReduce(z)
+		if z.quo(m, x.compact, x.form, y.compact, y.form) {
+			c.quoIdeal(z, expadj)
 		}
 		return z
 	}
@@ -500,12 +1824,82 @@ func (c Context) Quo(z, x, y *Big) *Big {
 	}
 
 	expadj := ideal - z.exp
-	if z.quoBig(m, xb, x.form, yb, y.form, alias(tmp, &z.unscaled)) && expadj > 0 {
-		c.simpleReduce(z)
+	if z.quoBig(m, xb, x.form, yb, y.form, alias(tmp, &z.unscaled)) {
+		c.quoIdeal(z, expadj)
 	}
 	return z
 }
 
+// quoIdeal moves the exact quotient z, whose exponent is expadj less than the
+// ideal exponent, toward the ideal exponent. If the exponent is too large
+// because the quotient needed more than the Context's precision, the dropped
+// digits were zeros, so z is Rounded but not Inexact.
+func (c Context) quoIdeal(z *Big, expadj int) {
+	if expadj > 0 {
+		c.simpleReduce(z)
+	} else if expadj < 0 {
+		z.Context.Conditions |= Rounded
+	}
+}
+
+// QuoOrDefault sets z to x / y and returns z. If y is zero and neither x nor y
+// is NaN, z is set to def, rounded to c, and neither DivisionByZero nor
+// DivisionUndefined is raised. Otherwise, it behaves exactly like Quo.
+func (c Context) QuoOrDefault(z, x, y, def *Big) *Big {
+	defer c.adopt(z)
+	if z.checkNil("QuoOrDefault", x, y) || z.checkNil("QuoOrDefault", def, def) {
+		return z
+	}
+	if z.invalidContext(c) || z.mixedModes(c, x, y, def) {
+		return z
+	}
+	if y.IsFinite() && y.compact == 0 && !x.IsNaN(0) {
+		return c.Set(z, def)
+	}
+	return c.Quo(z, x, y)
+}
+
+// QuoOrZero is like QuoOrDefault with a default of 0.
+func (c Context) QuoOrZero(z, x, y *Big) *Big {
+	defer c.adopt(z)
+	if z.checkNil("QuoOrZero", x, y) {
+		return z
+	}
+	if z.invalidContext(c) || z.mixedModes(c, x, y) {
+		return z
+	}
+	if y.IsFinite() && y.compact == 0 && !x.IsNaN(0) {
+		var zero Big
+		return c.Set(z, &zero)
+	}
+	return c.Quo(z, x, y)
+}
+
+// quoExact implements Quo for a Context with ExactOnly set.
+func (c Context) quoExact(z, x, y *Big) *Big {
+	c.ExactOnly = false
+	q := WithContext(c)
+	c.Quo(q, x, y)
+	if q.Context.Conditions&Inexact == 0 {
+		z.Context.Conditions |= q.Context.Conditions
+		return z.Copy(q)
+	}
+
+	// Determine how many digits the exact quotient requires, if any.
+	ec := Context{Precision: UnlimitedPrecision}
+	e := WithContext(ec)
+	ec.Quo(e, x, y)
+	if e.IsNaN(0) {
+		return c.setInexact(z, "Quo", -1)
+	}
+	if lost := ec.Reduce(e).Precision() - precision(c); lost > 0 {
+		return c.setInexact(z, "Quo", lost)
+	}
+	// The quotient is exact but overflowed or underflowed.
+	z.Context.Conditions |= q.Context.Conditions
+	return z.Copy(q)
+}
+
 func (z *Big) quo(m RoundingMode, x uint64, xneg form, y uint64, yneg form) bool {
 	z.form = xneg ^ yneg
 	z.compact = x / y
@@ -606,11 +2000,25 @@ func (z *Big) quoBig(
 // QuoInt sets z to x / y with the remainder truncated. See QuoRem for more
 // details.
 func (c Context) QuoInt(z, x, y *Big) *Big {
+	defer c.adopt(z)
+	if c.hookedOp(z, x, y) {
+		return c.hooked(z, hookOp{name: "quoint", tagged: true}, func(c Context) *Big {
+			return c.QuoInt(z, x, y)
+		}, x, y)
+	}
+	if c.Traps != 0 && z != nil {
+		return c.trapped(z, "QuoInt", func(c Context) *Big {
+			return c.QuoInt(z, x, y)
+		}, x, y)
+	}
+	if z.checkNil("QuoInt", x, y) {
+		return z
+	}
 	if debug {
 		x.validate()
 		y.validate()
 	}
-	if z.invalidContext(c) {
+	if z.invalidContext(c) || z.mixedModes(c, x, y) {
 		return z
 	}
 
@@ -632,7 +2040,7 @@ func (c Context) QuoInt(z, x, y *Big) *Big {
 		z, _ = c.quorem(z, nil, x, y)
 		z.exp = 0
 		if z.Precision() > precision(c) {
-			return z.setNaN(DivisionImpossible, qnan, quointprec)
+			return z.setNaN(InvalidOperation|DivisionImpossible, qnan, quointprec)
 		}
 		return z
 	}
@@ -657,14 +2065,31 @@ func (c Context) QuoInt(z, x, y *Big) *Big {
 }
 
 // QuoRem sets z to the quotient x / y and r to the remainder x % y, such that
-// x = z * y + r, and returns the pair (z, r).
+// x = z * y + r, and returns the pair (z, r). z and r, including the conditions
+// raised on each, are identical to the results of QuoInt(z, x, y) and
+// Rem(r, x, y), respectively.
 func (c Context) QuoRem(z, x, y, r *Big) (*Big, *Big) {
+	defer c.adopt(z)
+	defer c.adopt(r)
+	if (c.hookedOp(z, x, y) || c.hookedOp(r, nil, nil)) && z != nil && r != nil {
+		return c.hookedQuoRem(z, x, y, r)
+	}
+	if c.Traps != 0 && z != nil && r != nil {
+		return c.trappedQuoRem(z, x, y, r)
+	}
+	mustNotNil("QuoRem", z, r)
+	if z.checkNil("QuoRem", x, y) {
+		r.checkNil("QuoRem", x, y)
+		return z, r
+	}
 	if debug {
 		x.validate()
 		y.validate()
 	}
-	if z.invalidContext(c) {
-		r.invalidContext(c)
+	if z.invalidContext(c) || z.mixedModes(c, x, y) {
+		if !r.invalidContext(c) {
+			r.mixedModes(c, x, y)
+		}
 		return z, r
 	}
 
@@ -675,54 +2100,66 @@ func (c Context) QuoRem(z, x, y, r *Big) (*Big, *Big) {
 				// 0 / 0
 				z.setNaN(InvalidOperation|DivisionUndefined, qnan, quo00)
 				r.setNaN(InvalidOperation|DivisionUndefined, qnan, quo00)
+				return z, r
 			}
 			// x / 0
 			z.Context.Conditions |= DivisionByZero
-			r.Context.Conditions |= DivisionByZero
-			return z.SetInf(sign != 0), r.SetInf(x.Signbit())
+			return z.SetInf(sign != 0), r.setNaN(InvalidOperation, qnan, remx0)
 		}
 		if x.compact == 0 {
 			// 0 / y
-			z.setZero((x.form^y.form)&signbit, 0)
-			r.setZero(x.form, y.exp-x.exp)
+			z.setZero(sign, 0)
+			r.setZero(x.form&signbit, min(x.exp, y.exp))
 			return c.fix(z), c.fix(r)
 		}
-		return c.quorem(z, r, x, y)
+		xexp, yexp := x.exp, y.exp // copy in case z or r == x or y
+		c.quorem(z, r, x, y)
+		if z.IsNaN(0) {
+			return z, r
+		}
+		z.exp = 0
+		r.exp = min(xexp, yexp)
+		if z.Precision() > precision(c) {
+			z.setNaN(InvalidOperation|DivisionImpossible, qnan, quointprec)
+			r.setNaN(InvalidOperation|DivisionImpossible, qnan, quointprec)
+			return z, r
+		}
+		return z, c.round(r)
 	}
 
 	// NaN / NaN
 	// NaN / y
 	// x / NaN
 	if z.checkNaNs(x, y, division) {
-		return z, r.Set(z)
+		r.checkNaNs(x, y, division)
+		return z, r
 	}
 
 	if x.form&inf != 0 {
 		if y.form&inf != 0 {
 			// ±Inf / ±Inf
 			z.setNaN(InvalidOperation, qnan, quoinfinf)
-			return z, r.Set(z)
+			r.setNaN(InvalidOperation, qnan, quoinfinf)
+			return z, r
 		}
 		// ±Inf / y
-		return z.SetInf(sign != 0), r.SetInf(x.form&signbit != 0)
+		return z.SetInf(sign != 0), r.setNaN(InvalidOperation, qnan, reminfy)
 	}
 	// x / ±Inf
-	z.Context.Conditions |= Clamped
-	z.setZero(sign, c.etiny())
-	r.setZero(x.form&signbit, 0)
-	return z, r
+	c.round(r.Copy(x))
+	return z.setZero(sign, 0), r
 }
 
 func (c Context) quorem(z0, z1, x, y *Big) (*Big, *Big) {
-	m := c.RoundingMode
+	m := c.roundingMode()
 	zp := precision(c)
 
 	if x.adjusted()-y.adjusted() > zp {
 		if z0 != nil {
-			z0.setNaN(DivisionImpossible, qnan, quorem_)
+			z0.setNaN(InvalidOperation|DivisionImpossible, qnan, quorem_)
 		}
 		if z1 != nil {
-			z1.setNaN(DivisionImpossible, qnan, quorem_)
+			z1.setNaN(InvalidOperation|DivisionImpossible, qnan, quorem_)
 		}
 		return z0, z1
 	}
@@ -812,6 +2249,18 @@ func (m RoundingMode) quoremBig(
 
 // Reduce reduces a finite z to its most simplest form.
 func (c Context) Reduce(z *Big) *Big {
+	defer c.adopt(z)
+	if c.hasHooks() {
+		return c.hooked(z, hookOp{name: "reduce"}, func(c Context) *Big {
+			return c.Reduce(z)
+		}, z)
+	}
+	if c.Traps != 0 && z != nil {
+		return c.trapped(z, "Reduce", func(c Context) *Big {
+			return c.Reduce(z)
+		}, z)
+	}
+	mustNotNil("Reduce", z, z)
 	if debug {
 		z.validate()
 	}
@@ -891,11 +2340,25 @@ func (c Context) simpleReduce(z *Big) *Big {
 
 // Rem sets z to the remainder x % y. See QuoRem for more details.
 func (c Context) Rem(z, x, y *Big) *Big {
+	defer c.adopt(z)
+	if c.hookedOp(z, x, y) {
+		return c.hooked(z, hookOp{name: "rem", tagged: true}, func(c Context) *Big {
+			return c.Rem(z, x, y)
+		}, x, y)
+	}
+	if c.Traps != 0 && z != nil {
+		return c.trapped(z, "Rem", func(c Context) *Big {
+			return c.Rem(z, x, y)
+		}, x, y)
+	}
+	if z.checkNil("Rem", x, y) {
+		return z
+	}
 	if debug {
 		x.validate()
 		y.validate()
 	}
-	if z.invalidContext(c) {
+	if z.invalidContext(c) || z.mixedModes(c, x, y) {
 		return z
 	}
 
@@ -906,7 +2369,7 @@ func (c Context) Rem(z, x, y *Big) *Big {
 				return z.setNaN(InvalidOperation|DivisionUndefined, qnan, quo00)
 			}
 			// x / 0
-			return z.setNaN(InvalidOperation|DivisionByZero, qnan, remx0)
+			return z.setNaN(InvalidOperation, qnan, remx0)
 		}
 		if x.compact == 0 {
 			// 0 / y
@@ -918,7 +2381,7 @@ func (c Context) Rem(z, x, y *Big) *Big {
 		z.exp = min(x.exp, y.exp)
 		tmp.exp = 0
 		if tmp.Precision() > precision(c) {
-			return z.setNaN(DivisionImpossible, qnan, quointprec)
+			return z.setNaN(InvalidOperation|DivisionImpossible, qnan, quointprec)
 		}
 		return c.round(z)
 	}
@@ -939,13 +2402,29 @@ func (c Context) Rem(z, x, y *Big) *Big {
 		return z.setNaN(InvalidOperation, qnan, reminfy)
 	}
 	// x / ±Inf
-	return z.Set(x)
+	return c.round(z.Copy(x))
 }
 
 // Round rounds z down to the Context's precision and returns z. The result is
 // undefined if z is not finite. The result of Round will always be within the
 // interval [⌊10**x⌋, z] where x = the precision of z.
 func (c Context) Round(z *Big) *Big {
+	defer c.adopt(z)
+	return c.roundPrec(z)
+}
+
+// roundPrec implements Round without adopting c, for the operations that
+// round their results, which adopt their Context themselves.
+func (c Context) roundPrec(z *Big) *Big {
+	if c.hasHooks() {
+		return c.hookedRound(z)
+	}
+	if c.Traps != 0 && z != nil {
+		return c.trapped(z, "Round", func(c Context) *Big {
+			return c.roundPrec(z)
+		}, z)
+	}
+	mustNotNil("Round", z, z)
 	if debug {
 		z.validate()
 	}
@@ -986,7 +2465,7 @@ func (c Context) shiftr(z *Big, n uint64) bool {
 		return false
 	}
 
-	m := c.RoundingMode
+	m := c.roundingMode()
 	if z.isCompact() {
 		if y, ok := arith.Pow10(n); ok {
 			return z.quo(m, z.compact, z.form, y, 0)
@@ -999,14 +2478,41 @@ func (c Context) shiftr(z *Big, n uint64) bool {
 }
 
 func (c Context) round(z *Big) *Big {
-	if c.OperatingMode == GDA {
-		return c.Round(z)
+	if c.OperatingMode != Go {
+		return c.roundPrec(z)
 	}
 	return c.fix(z)
 }
 
+// RoundSig sets z to x rounded to sig significant digits using c's
+// RoundingMode and returns z. Rounded and Inexact are raised as by Round; for
+// example, 12345 rounded to 2 significant digits is 1.2E+4, and 0.0012345 is
+// 0.0012. Like Round, it only shortens the coefficient and adjusts the exponent
+// to match, so a result with fewer digits than sig, such as 1.5 with a sig of 4,
+// is x unchanged.
+//
+// Unlike setting c.Precision and calling Round, RoundSig does not depend on c's
+// precision. If sig < 1, z is set to NaN and InvalidOperation is raised.
+func (c Context) RoundSig(z, x *Big, sig int) *Big {
+	defer c.adopt(z)
+	if z.checkNil("RoundSig", x, x) || z.mixedModes(c, x) {
+		return z
+	}
+	if x.IsNaN(0) {
+		z.checkNaNs(x, x, roundsig)
+		return z
+	}
+	if sig < 1 {
+		return z.setNaN(InvalidOperation, qnan, roundsiglt1)
+	}
+	c.Precision = sig
+	return c.Round(z.Copy(x))
+}
+
 // RoundToInt rounds z down to an integral value.
 func (c Context) RoundToInt(z *Big) *Big {
+	defer c.adopt(z)
+	mustNotNil("RoundToInt", z, z)
 	if z.isSpecial() || z.exp >= 0 {
 		return z
 	}
@@ -1016,29 +2522,172 @@ func (c Context) RoundToInt(z *Big) *Big {
 
 // Set sets z to x and returns z. The result might be rounded, even if z == x.
 func (c Context) Set(z, x *Big) *Big {
+	defer c.adopt(z)
+	if z.checkNil("Set", x, x) || z.mixedModes(c, x) {
+		return z
+	}
+	if c.OperatingMode == JS {
+		return c.fix(z.Copy(x))
+	}
 	return c.Round(z.Copy(x))
 }
 
 // SetString sets z to the value of s, returning z and a bool indicating success.
 // See Big.SetString for valid formats.
 func (c Context) SetString(z *Big, s string) (*Big, bool) {
-	if _, ok := z.SetString(s); !ok {
+	defer c.adopt(z)
+	mustNotNil("SetString", z, z)
+	if c.hasHooks() {
+		var ok bool
+		c.hooked(z, hookOp{}, func(c Context) *Big {
+			_, ok = c.SetString(z, s)
+			return z
+		})
+		if !ok {
+			return nil, false
+		}
+		return z, true
+	}
+	if err := z.scanString(s, c); err != nil {
 		return nil, false
 	}
 	return c.Round(z), true
 }
 
+// Sqrt sets z to the square root of x and returns z. If the square root of x
+// has at most c's precision digits, the result is exact and has as few
+// trailing zeros as possible without an exponent less than floor(e/2), where e
+// is the exponent of x; e.g., the square root of 1.21 is 1.1, and of 4E+10 is
+// 2E+5. Otherwise, the result is correctly rounded to c's precision using
+// ToNearestEven, regardless of c's RoundingMode, as the GDA specification
+// requires.
+//
+// The square root of -0 is -0, and of +Inf is +Inf. If x is less than zero, z
+// is set to NaN and InvalidOperation is raised. If c.Precision is
+// UnlimitedPrecision and the square root of x is not exact, z is set to NaN
+// and InvalidOperation is raised.
+func (c Context) Sqrt(z, x *Big) *Big {
+	defer c.adopt(z)
+	if c.hookedOp(z, x, nil) {
+		return c.hooked(z, hookOp{name: "sqrt", tagged: true}, func(c Context) *Big {
+			return c.Sqrt(z, x)
+		}, x)
+	}
+	if c.Traps != 0 && z != nil {
+		return c.trapped(z, "Sqrt", func(c Context) *Big {
+			return c.Sqrt(z, x)
+		}, x)
+	}
+	if z.checkNil("Sqrt", x, x) {
+		return z
+	}
+	if z.invalidContext(c) || z.mixedModes(c, x) {
+		return z
+	}
+	if z.checkNaNs(x, x, sqrtnan) {
+		return z
+	}
+
+	// The ideal exponent of the result is floor(x.exp / 2).
+	ideal := x.exp >> 1
+	switch {
+	case x.IsFinite() && x.compact == 0:
+		return c.fix(z.setZero(x.form&signbit, ideal))
+	case x.Signbit():
+		return z.setNaN(InvalidOperation, qnan, sqrtneg)
+	case x.IsInf(+1):
+		return z.SetInf(false)
+	}
+
+	// With x = a × 10**(2×ideal), the result is sqrt(a) × 10**ideal.
+	c.RoundingMode = ToNearestEven
+	return c.sqrt(z, scaledMod(x, 2*ideal, nil), ideal, sqrtunlim)
+}
+
+// sqrt sets z to sqrt(a) × 10**ideal, where a is positive, rounded using c's
+// precision and RoundingMode, and returns z. If the result is exact, its
+// exponent is as close to ideal as c's precision allows. If c.Precision is
+// UnlimitedPrecision and the result is not exact, z is set to NaN with the
+// payload p. a is modified.
+func (c Context) sqrt(z *Big, a *big.Int, ideal int, p Payload) *Big {
+	prec := precision(c)
+	if prec == UnlimitedPrecision {
+		n := new(big.Int).Sqrt(a)
+		if new(big.Int).Mul(n, n).Cmp(a) != 0 {
+			return z.setNaN(InvalidOperation, qnan, p)
+		}
+		return c.fix(z.SetBigMantScale(n, -ideal))
+	}
+
+	// Scale a by 100**shift so that its integer square root, n, has prec+1
+	// digits.
+	shift := prec + 1 - (arith.BigLength(a)+1)/2
+	exact := true
+	if shift >= 0 {
+		a.Mul(a, arith.BigPow10(uint64(2*shift)))
+	} else {
+		var r big.Int
+		a.QuoRem(a, arith.BigPow10(uint64(-2*shift)), &r)
+		exact = r.Sign() == 0
+	}
+	var n big.Int
+	n.Sqrt(a)
+	if exact {
+		var sq big.Int
+		exact = sq.Mul(&n, &n).Cmp(a) == 0
+	}
+	if exact {
+		// Remove the zeros added by shift to get back to the ideal exponent.
+		// Round removes any more that do not fit in c's precision.
+		if shift >= 0 {
+			n.Quo(&n, arith.BigPow10(uint64(shift)))
+		} else {
+			n.Mul(&n, arith.BigPow10(uint64(-shift)))
+		}
+		z.SetBigMantScale(&n, -ideal)
+	} else {
+		// n is truncated, so its last digit cannot be an exact 0 or 5, which
+		// Round would mistake for an exact result or a tie.
+		var r big.Int
+		if r.Mod(&n, cst.FiveInt).Sign() == 0 {
+			n.Add(&n, cst.OneInt)
+		}
+		z.SetBigMantScale(&n, shift-ideal)
+	}
+	return c.Round(z)
+}
+
 // Sub sets z to x - y and returns z.
 func (c Context) Sub(z, x, y *Big) *Big {
+	defer c.adopt(z)
+	if c.hookedOp(z, x, y) {
+		return c.hooked(z, hookOp{name: "-", infix: true, tagged: true}, func(c Context) *Big {
+			return c.Sub(z, x, y)
+		}, x, y)
+	}
+	if c.Traps != 0 && z != nil {
+		return c.trapped(z, "Sub", func(c Context) *Big {
+			return c.Sub(z, x, y)
+		}, x, y)
+	}
+	if z.checkNil("Sub", x, y) {
+		return z
+	}
 	if debug {
 		x.validate()
 		y.validate()
 	}
-	if z.invalidContext(c) {
+	if z.invalidContext(c) || z.mixedModes(c, x, y) {
 		return z
 	}
+	if c.ExactOnly {
+		c.Precision = UnlimitedPrecision
+	}
 
 	if x.IsFinite() && y.IsFinite() {
+		if c.alignsTooFar(x, y) {
+			return z.setNaN(InsufficientStorage, qnan, toolong)
+		}
 		z.form = finite | c.add(z, x, x.form, y, y.form^signbit)
 		return c.round(z)
 	}
@@ -1059,8 +2708,135 @@ func (c Context) Sub(z, x, y *Big) *Big {
 		// ±Inf - y
 		// -Inf - +Inf
 		// +Inf - -Inf
-		return z.Set(x)
+		return z.Copy(x)
 	}
 	// x - ±Inf
-	return z.Neg(y)
+	return z.SetInf(y.form&signbit == 0)
+}
+
+// Sum sets z to the sum of xs and returns z. The sum of no operands is zero.
+// Each partial sum carries c.Precision+c.GuardDigits digits; only the final
+// result is rounded to c.Precision.
+func (c Context) Sum(z *Big, xs ...*Big) *Big {
+	defer c.adopt(z)
+	if c.hookedOps(z, xs) {
+		return c.hooked(z, hookOp{name: "sum", tagged: true}, func(c Context) *Big {
+			return c.Sum(z, xs...)
+		}, xs...)
+	}
+	if c.Traps != 0 && z != nil {
+		return c.trapped(z, "Sum", func(c Context) *Big {
+			return c.Sum(z, xs...)
+		}, xs...)
+	}
+	for _, x := range xs {
+		if z.checkNil("Sum", x, x) {
+			return z
+		}
+	}
+	if z.invalidContext(c) || z.mixedModes(c, xs...) {
+		return z
+	}
+	wc := c.guarded()
+	acc := WithContext(wc) // z might alias an element of xs
+	for _, x := range xs {
+		wc.Add(acc, acc, x)
+	}
+	z.Context.Conditions |= acc.Context.Conditions
+	return c.round(z.setShared(acc))
+}
+
+// Wrap sets z to x wrapped into the range [lo, hi), the value in the range
+// that differs from x by an integral multiple of the width hi - lo, rounded
+// using c, and returns z. For example, 370 wrapped into [0, 360) is 10 and -1
+// wrapped into [0, 24) is 23.
+//
+// The wrapped value is computed exactly and rounded once, however many widths
+// x is from the range; wrapping with Rem and Add rounds twice, which can
+// produce a result outside of the range when x is near a boundary. If the
+// wrapped value rounds up to hi, z is set to lo, which differs from it by the
+// width.
+//
+// If lo >= hi, or if x, lo, or hi is infinite, z is set to NaN and
+// InvalidOperation is raised.
+func (c Context) Wrap(z, x, lo, hi *Big) *Big {
+	defer c.adopt(z)
+	if c.hookedOp(z, x, lo) || c.hookedOp(nil, hi, nil) {
+		return c.hooked(z, hookOp{name: "wrap", tagged: true}, func(c Context) *Big {
+			return c.Wrap(z, x, lo, hi)
+		}, x, lo, hi)
+	}
+	if c.Traps != 0 && z != nil {
+		return c.trapped(z, "Wrap", func(c Context) *Big {
+			return c.Wrap(z, x, lo, hi)
+		}, x, lo, hi)
+	}
+	if z.checkNil("Wrap", x, lo) || z.checkNil("Wrap", hi, hi) {
+		return z
+	}
+	if z.invalidContext(c) || z.mixedModes(c, x, lo, hi) {
+		return z
+	}
+	if z.checkNaNs(x, lo, wrapping) || z.checkNaNs(hi, hi, wrapping) {
+		return z
+	}
+	if !lo.IsFinite() || !hi.IsFinite() || lo.Cmp(hi) >= 0 {
+		return z.setNaN(InvalidOperation, qnan, wraprange)
+	}
+	if !x.IsFinite() {
+		return z.setNaN(InvalidOperation, qnan, wrapinf)
+	}
+
+	// With every operand scaled to the smallest exponent, e, the wrapped
+	// value is lo + ((x - lo) mod w) × 10**e, where w is the scaled width.
+	// x might be many orders of magnitude larger than w, so it is reduced
+	// modulo w without being scaled.
+	var w Big
+	Context{Precision: UnlimitedPrecision}.Sub(&w, hi, lo)
+	e := w.exp
+	if x.exp < e {
+		e = x.exp
+	}
+	if lo.exp < e {
+		e = lo.exp
+	}
+	m := scaledMod(&w, e, nil)
+	r := scaledMod(x, e, m)
+	r.Sub(r, scaledMod(lo, e, m)).Mod(r, m)
+
+	// z might alias lo or hi. The temporaries take c's OperatingMode, since
+	// they are operands of operations governed by c.
+	var l, h, d Big
+	l.Context.OperatingMode = c.OperatingMode
+	d.Context.OperatingMode = c.OperatingMode
+	l.Copy(lo)
+	h.Copy(hi)
+	d.SetBigMantScale(r, -e)
+	c.Add(z, &l, &d)
+	if cmp(z, &h, false) >= 0 {
+		c.Set(z, &l)
+	}
+	return z
+}
+
+// scaledMod returns the coefficient of the finite x scaled to the exponent
+// e, which must not be greater than x's, modulo m. If m is nil, it returns the
+// scaled coefficient instead.
+func scaledMod(x *Big, e int, m *big.Int) *big.Int {
+	r := new(big.Int)
+	if x.isCompact() {
+		r.SetUint64(x.compact)
+	} else {
+		r.Set(&x.unscaled)
+	}
+	if x.Signbit() {
+		r.Neg(r)
+	}
+	if n := int64(x.exp) - int64(e); n > 0 {
+		r.Mul(r, new(big.Int).Exp(big.NewInt(10), big.NewInt(n), m))
+	}
+	if m != nil {
+		r.Mod(r, m)
+	}
+	return r
 }
@@ -1,9 +1,13 @@
 package decimal_test
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"math"
 	"math/big"
 	"math/rand"
+	"reflect"
 	"strconv"
 	"strings"
 	"sync"
@@ -94,10 +98,59 @@ func TestBig_Int(t *testing.T) {
 		if !ok {
 			t.Fatal("!ok")
 		}
-		if n := a.Int(nil); n.Cmp(b) != 0 {
+		if n, _ := a.Int(nil); n.Cmp(b) != 0 {
 			t.Fatalf("#%d: wanted %q, got %q", i, b, n)
 		}
 	}
+
+	for i, test := range [...]struct {
+		x, want string
+		exact   bool
+	}{
+		{"1E+40", "10000000000000000000000000000000000000000", true},
+		{"-12345678901234567890123.000", "-12345678901234567890123", true},
+		{"-12345678901234567890123.001", "-12345678901234567890123", false},
+		{"99.9", "99", false},
+		{"-0.5", "0", false},
+		{"0.000", "0", true},
+		{"1E-50", "0", false},
+		{"Infinity", "0", false},
+		{"NaN", "0", false},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		n, exact := x.Int(big.NewInt(42))
+		if n.String() != test.want || exact != test.exact {
+			t.Fatalf("#%d: %s.Int(): wanted %s (%t), got %s (%t)", i, test.x, test.want, test.exact, n, exact)
+		}
+	}
+}
+
+func TestBig_SetBigMantExp(t *testing.T) {
+	const r = decimal.Inexact | decimal.Rounded
+	m, _ := new(big.Int).SetString("-1000000000000000000000000000001", 10)
+	for i, test := range [...]struct {
+		mant *big.Int
+		exp  int
+		want string
+		c    decimal.Condition
+	}{
+		{big.NewInt(-125), -2, "-1.25", 0},
+		{big.NewInt(7), 3, "7E+3", 0},
+		{m, -10, "-100000000000000000000.0000000001", 0},
+		{big.NewInt(1), 96, "1E+96", 0},
+		{big.NewInt(1), 97, "Infinity", decimal.Overflow | r},
+		{big.NewInt(-12), 96, "-Infinity", decimal.Overflow | r},
+		{big.NewInt(0), 200, "0E+96", decimal.Clamped},
+		{big.NewInt(1), -98, "1E-98", decimal.Subnormal},
+		{big.NewInt(5), 2 * decimal.MaxScale, "Infinity", decimal.Overflow | r},
+		{big.NewInt(-5), 2 * decimal.MinScale, "-0E-110", decimal.Subnormal | decimal.Underflow | decimal.Clamped | r},
+	} {
+		z := decimal.WithContext(decimal.Context{MaxScale: 96, MinScale: -95, OperatingMode: decimal.GDA})
+		if z.SetBigMantExp(test.mant, test.exp); z.String() != test.want || z.Context.Conditions != test.c {
+			t.Fatalf("#%d: SetBigMantExp(%s, %d): wanted %s (%s), got %s (%s)",
+				i, test.mant, test.exp, test.want, test.c, z, z.Context.Conditions)
+		}
+	}
 }
 
 func TestBig_Int64(t *testing.T) {
@@ -106,22 +159,46 @@ func TestBig_Int64(t *testing.T) {
 		if !ok {
 			t.Fatalf("#%d: !ok", i)
 		}
-		iv := test
+		iv, frac := test, ""
 		switch x := strings.IndexByte(test, '.'); {
 		case x > 0:
-			iv = test[:x]
+			iv, frac = test[:x], test[x+1:]
 		case x == 0:
-			iv = "0"
+			iv, frac = "0", test[1:]
 		}
 		n, ok := a.Int64()
 		gv, err := strconv.ParseInt(iv, 10, 64)
-		if (err == nil) != ok {
-			t.Fatalf("#%d: wanted %t, got %t", i, err == nil, ok)
+		if want := err == nil && strings.Trim(frac, "0") == ""; want != ok {
+			t.Fatalf("#%d: wanted %t, got %t", i, want, ok)
 		}
 		if ok && (n != gv) {
 			t.Fatalf("#%d: wanted %d, got %d", i, gv, n)
 		}
 	}
+
+	for i, test := range [...]struct {
+		x    string
+		want int64
+		ok   bool
+	}{
+		{"9223372036854775807", math.MaxInt64, true},
+		{"-9223372036854775808", math.MinInt64, true},
+		{"9223372036854775808", 0, false},
+		{"-9223372036854775809", 0, false},
+		{"922337203685477580.70", 922337203685477580, false},
+		{"1E+19", 0, false},
+		{"-1.000", -1, true},
+		{"1.5", 0, false},
+		{"1E-30", 0, false},
+		{"0E-30", 0, true},
+		{"Infinity", 0, false},
+		{"NaN", 0, false},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		if n, ok := x.Int64(); ok != test.ok || ok && n != test.want {
+			t.Fatalf("#%d: %s.Int64(): wanted (%d, %t), got (%d, %t)", i, test.x, test.want, test.ok, n, ok)
+		}
+	}
 }
 
 func TestBig_Uint64(t *testing.T) {
@@ -130,16 +207,17 @@ func TestBig_Uint64(t *testing.T) {
 		if !ok {
 			t.Fatalf("#%d: !ok", i)
 		}
-		iv := test
+		iv, frac := test, ""
 		switch x := strings.IndexByte(test, '.'); {
 		case x > 0:
-			iv = test[:x]
+			iv, frac = test[:x], test[x+1:]
 		case x == 0:
-			iv = "0"
+			iv, frac = "0", test[1:]
 		}
 		n, ok := a.Uint64()
-		if _, err := strconv.ParseUint(iv, 10, 64); (err == nil) != ok {
-			t.Fatalf("#%d: wanted %t, got %t", i, err == nil, ok)
+		_, err := strconv.ParseUint(iv, 10, 64)
+		if want := err == nil && strings.Trim(frac, "0") == ""; want != ok {
+			t.Fatalf("#%d: wanted %t, got %t", i, want, ok)
 		}
 		if !ok {
 			continue
@@ -148,6 +226,26 @@ func TestBig_Uint64(t *testing.T) {
 			t.Fatalf("#%d: wanted %q, got %q", i, iv, ns)
 		}
 	}
+
+	for i, test := range [...]struct {
+		x    string
+		want uint64
+		ok   bool
+	}{
+		{"18446744073709551615", math.MaxUint64, true},
+		{"18446744073709551616", 0, false},
+		{"1E+19", 1e19, true},
+		{"1.8446744073709551615E+19", math.MaxUint64, true},
+		{"-0", 0, true},
+		{"-1", 0, false},
+		{"0.5", 0, false},
+		{"Infinity", 0, false},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		if n, ok := x.Uint64(); ok != test.ok || ok && n != test.want {
+			t.Fatalf("#%d: %s.Uint64(): wanted (%d, %t), got (%d, %t)", i, test.x, test.want, test.ok, n, ok)
+		}
+	}
 }
 
 func TestBig_IsInt(t *testing.T) {
@@ -243,7 +341,60 @@ got   : %q
 }
 
 func TestBig_Scan(t *testing.T) {
-	// TODO(eric): write this test
+	for i, test := range [...]struct {
+		in   string
+		want string
+		rest string
+	}{
+		{"1.23e4", "1.23E+4", ""},
+		{"  -1.50\n", "-1.50", "\n"},
+		{"+.5E-3 9", "0.0005", " 9"},
+		{"1.5kg", "1.5", "kg"},
+		{"12,34", "12", ",34"},
+		{"1.2.3", "1.2", ".3"},
+		{"1e5e5", "1E+5", "e5"},
+		{"Infinity!", "Infinity", "!"},
+		{"-inf 2", "-Infinity", " 2"},
+		{"+Inf", "Infinity", ""},
+		{"Infx", "Infinity", "x"},
+		{"NaN123x", "NaN123", "x"},
+		{"-sNaN", "-sNaN", ""},
+	} {
+		z := decimal.New(7, 0)
+		r := strings.NewReader(test.in)
+		if _, err := fmt.Fscan(r, z); err != nil {
+			t.Fatalf("#%d: Fscan(%q): %v", i, test.in, err)
+		}
+		rest, _ := io.ReadAll(r)
+		if z.String() != test.want || string(rest) != test.rest {
+			t.Fatalf("#%d: Fscan(%q): wanted (%s, %q), got (%s, %q)",
+				i, test.in, test.want, test.rest, z, rest)
+		}
+	}
+
+	for i, in := range [...]string{"", "abc", "-", "1e", "1e+", "Infin", ".", "x1"} {
+		z := decimal.New(7, 0)
+		if _, err := fmt.Sscan(in, z); err == nil {
+			t.Fatalf("#%d: Sscan(%q): wanted an error, got %s", i, in, z)
+		}
+		if z.String() != "7" {
+			t.Fatalf("#%d: Sscan(%q): modified z: %s", i, in, z)
+		}
+	}
+
+	// Whitespace-separated columns, with verbs.
+	var a, b, c decimal.Big
+	n, err := fmt.Sscanf("1.5 -2e3 NaN", "%f %e %g", &a, &b, &c)
+	if n != 3 || err != nil || a.String() != "1.5" || b.String() != "-2E+3" || !c.IsNaN(0) {
+		t.Fatalf("Sscanf: got %d (%v): %s %s %s", n, err, &a, &b, &c)
+	}
+	if _, err := fmt.Sscanf("1", "%d", &a); err == nil {
+		t.Fatalf("Sscanf: wanted an error for %%d")
+	}
+	var x *decimal.Big
+	if _, err := fmt.Sscan("1", x); err == nil {
+		t.Fatal("Sscan: wanted an error for a nil receiver")
+	}
 }
 
 func TestBig_SetFloat64(t *testing.T) {
@@ -267,4 +418,1104 @@ got   : %g
 	}
 }
 
+func TestBig_SetFloat64Exact(t *testing.T) {
+	for i, test := range [...]struct {
+		f            float64
+		short, exact string
+	}{
+		{0.1, "0.1", "0.1000000000000000055511151231257827021181583404541015625"},
+		{-2.5, "-2.5", "-2.5"},
+		{1e23, "1E+23", "99999999999999991611392"},
+		{1 << 62, "4.611686018427388E+18", "4611686018427387904"},
+		{math.Copysign(0, -1), "-0", "-0"},
+		{math.Inf(+1), "Infinity", "Infinity"},
+		{math.Inf(-1), "-Infinity", "-Infinity"},
+		{math.NaN(), "NaN", "NaN"},
+		{math.SmallestNonzeroFloat64, "5E-324", "4.940656458412465441765687928682213723650598026143247644255856825006755072702087518652998363616359923797965646954457177309266567103559397963987747960107818781263007131903114045278458171678489821036887186360569987307230500063874091535649843873124733972731696151400317153853980741262385655911710266585566867681870395603106249319452715914924553293054565444011274801297099995419319894090804165633245247571478690147267801593552386115501348035264934720193790268107107491703332226844753335720832431936092382893458368060106011506169809753078342277318329247904982524730776375927247874656084778203734469699533647017972677717585125660551199131504891101451037862738167250955837389733598993664809941164205702637090279242767544565229087538682506419718265533447265625E-324"},
+	} {
+		if got := new(decimal.Big).SetFloat64(test.f).String(); got != test.short {
+			t.Fatalf("#%d: SetFloat64(%g): wanted %s, got %s", i, test.f, test.short, got)
+		}
+		if got := new(decimal.Big).SetFloat64Exact(test.f).String(); got != test.exact {
+			t.Fatalf("#%d: SetFloat64Exact(%g): wanted %s, got %s", i, test.f, test.exact, got)
+		}
+	}
+}
+
+func TestBig_SetFloat32(t *testing.T) {
+	for i, test := range [...]struct {
+		f            float32
+		short, exact string
+	}{
+		{0.1, "0.1", "0.100000001490116119384765625"},
+		{-2.5, "-2.5", "-2.5"},
+		{16777217, "16777216", "16777216"},
+		{math.MaxFloat32, "3.4028235E+38", "340282346638528859811704183484516925440"},
+		{math.SmallestNonzeroFloat32, "1E-45", "1.40129846432481707092372958328991613128026194187651577175706828388979108268586060148663818836212158203125E-45"},
+		{float32(math.Copysign(0, -1)), "-0", "-0"},
+		{float32(math.Inf(-1)), "-Infinity", "-Infinity"},
+	} {
+		if got := new(decimal.Big).SetFloat32(test.f).String(); got != test.short {
+			t.Fatalf("#%d: SetFloat32(%g): wanted %s, got %s", i, test.f, test.short, got)
+		}
+		if got := new(decimal.Big).SetFloat32Exact(test.f).String(); got != test.exact {
+			t.Fatalf("#%d: SetFloat32Exact(%g): wanted %s, got %s", i, test.f, test.exact, got)
+		}
+		if f, _ := new(decimal.Big).SetFloat32(test.f).Float32(); f != test.f {
+			t.Fatalf("#%d: SetFloat32(%g).Float32(): got %g", i, test.f, f)
+		}
+	}
+}
+
+func TestBig_Rat_Special(t *testing.T) {
+	for i, test := range [...]struct {
+		x, want string
+	}{
+		{"1.25", "5/4"},
+		{"-0.001", "-1/1000"},
+		{"12E+3", "12000/1"},
+		{"-0", "0/1"},
+		{"123456789012345678901234567890.5", "246913578024691357802469135781/2"},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		if got := x.Rat(nil).String(); got != test.want {
+			t.Fatalf("#%d: %s.Rat(): wanted %s, got %s", i, test.x, test.want, got)
+		}
+	}
+
+	// InvalidOperation is raised in x's Context, without panicking, in
+	// either OperatingMode.
+	for _, s := range [...]string{"Infinity", "-Infinity", "NaN", "sNaN"} {
+		for _, mode := range [...]decimal.OperatingMode{decimal.GDA, decimal.Go} {
+			x, _ := new(decimal.Big).SetString(s)
+			x.Context.OperatingMode = mode
+			x.Context.Traps = decimal.InvalidOperation
+			r := x.Rat(new(big.Rat))
+			if r != nil || x.Context.Conditions != decimal.InvalidOperation {
+				t.Fatalf("%s.Rat() (%s): wanted nil (%s), got %v (%s)",
+					s, mode, decimal.InvalidOperation, r, x.Context.Conditions)
+			}
+			if err := x.Context.Err(); !errors.Is(err, decimal.ErrInvalidOperation) {
+				t.Fatalf("%s.Rat() (%s): wanted %s error, got %v", s, mode, decimal.InvalidOperation, err)
+			}
+		}
+	}
+}
+
+func TestBig_SetRat(t *testing.T) {
+	const r = decimal.Inexact | decimal.Rounded
+	for i, test := range [...]struct {
+		x    string
+		prec int
+		want string
+		c    decimal.Condition
+	}{
+		{"7/8", 0, "0.875", 0},
+		{"-3/40", 0, "-0.075", 0},
+		{"1/1024", 0, "0.0009765625", 0},
+		{"1/1024", 5, "0.00097656", r},
+		{"1/3", 0, "0.3333333333333333", r},
+		{"2/3", 5, "0.66667", r},
+		{"123456789012345678901/10", 0, "1.234567890123457E+19", r},
+		{"123456789012345678901/10", decimal.UnlimitedPrecision, "12345678901234567890.1", 0},
+		{"-5/1", 0, "-5", 0},
+	} {
+		x, _ := new(big.Rat).SetString(test.x)
+		z := decimal.WithContext(decimal.Context{Precision: test.prec})
+		if z.SetRat(x); z.String() != test.want || z.Context.Conditions != test.c {
+			t.Fatalf("#%d: SetRat(%s) (%d digits): wanted %s (%s), got %s (%s)",
+				i, test.x, test.prec, test.want, test.c, z, z.Context.Conditions)
+		}
+		if z.Context.Conditions&decimal.Inexact == 0 && z.Rat(nil).Cmp(x) != 0 {
+			t.Fatalf("#%d: SetRat(%s).Rat(): got %s", i, test.x, z.Rat(nil))
+		}
+	}
+}
+
 func isSpecial(f float64) bool { return math.IsInf(f, 0) || math.IsNaN(f) }
+
+// zeroValueSkip are methods that cannot be called with zero-valued arguments.
+var zeroValueSkip = map[string]bool{
+	"Format": true, // requires a fmt.State
+	"Scan":   true, // requires a fmt.ScanState
+}
+
+func TestBig_ZeroValue(t *testing.T) {
+	bigType := reflect.TypeOf((*decimal.Big)(nil))
+
+	// args returns the arguments for m, using fn to create each *decimal.Big.
+	args := func(m reflect.Method, fn func() *decimal.Big) []reflect.Value {
+		in := []reflect.Value{reflect.ValueOf(fn())}
+		for i := 1; i < m.Type.NumIn(); i++ {
+			switch typ := m.Type.In(i); {
+			case typ == bigType:
+				in = append(in, reflect.ValueOf(fn()))
+			case typ.Kind() == reflect.Slice && typ.Elem() == bigType:
+				in = append(in, reflect.ValueOf([]*decimal.Big{fn(), fn()}))
+			case typ.Kind() == reflect.Ptr:
+				in = append(in, reflect.New(typ.Elem()))
+			default:
+				in = append(in, reflect.Zero(typ))
+			}
+		}
+		return in
+	}
+
+	// str converts the results of a method call into a comparable form.
+	str := func(out []reflect.Value) string {
+		var b strings.Builder
+		for _, v := range out {
+			if x, ok := v.Interface().(*decimal.Big); ok && x != nil {
+				fmt.Fprintf(&b, "%s (%d, %t, %s) ", x, x.Scale(), x.Signbit(), x.Context.Conditions)
+				continue
+			}
+			fmt.Fprintf(&b, "%v ", v.Interface())
+		}
+		return b.String()
+	}
+
+	for i := 0; i < bigType.NumMethod(); i++ {
+		m := bigType.Method(i)
+		if zeroValueSkip[m.Name] {
+			continue
+		}
+		call := m.Func.Call
+		if m.Type.IsVariadic() {
+			call = m.Func.CallSlice
+		}
+		var got, want string
+		func() {
+			defer func() {
+				if err := recover(); err != nil {
+					t.Fatalf("%s: panicked with zero value: %v", m.Name, err)
+				}
+			}()
+			got = str(call(args(m, func() *decimal.Big { return new(decimal.Big) })))
+			want = str(call(args(m, func() *decimal.Big { return decimal.New(0, 0) })))
+		}()
+		if got != want {
+			t.Fatalf(`%s:
+wanted: %s
+got   : %s
+`, m.Name, want, got)
+		}
+	}
+}
+
+func TestBig_NilOperands(t *testing.T) {
+	bigType := reflect.TypeOf((*decimal.Big)(nil))
+	sliceType := reflect.TypeOf([]*decimal.Big(nil))
+
+	// call calls fn with in, returning the recovered panic value (if any).
+	call := func(fn reflect.Value, in []reflect.Value) (out []reflect.Value, err interface{}) {
+		defer func() { err = recover() }()
+		if fn.Type().IsVariadic() {
+			return fn.CallSlice(in), nil
+		}
+		return fn.Call(in), nil
+	}
+
+	// check tests each *decimal.Big argument of fn with nil, skipping the first
+	// skip arguments. A []*decimal.Big argument is tested with a nil element.
+	check := func(name string, mode decimal.OperatingMode, fn reflect.Value, skip int) {
+		typ := fn.Type()
+		for pos := skip; pos < typ.NumIn(); pos++ {
+			if typ.In(pos) != bigType && typ.In(pos) != sliceType {
+				continue
+			}
+			// CheckNaNs documents y may be nil.
+			if name == "CheckNaNs" && pos == 2 {
+				continue
+			}
+			in := make([]reflect.Value, typ.NumIn())
+			for i := range in {
+				switch arg := typ.In(i); {
+				case arg == bigType && i == pos:
+					in[i] = reflect.Zero(bigType)
+				case arg == bigType:
+					x := new(decimal.Big)
+					x.Context.OperatingMode = mode
+					in[i] = reflect.ValueOf(x)
+				case arg == sliceType && i == pos:
+					in[i] = reflect.ValueOf([]*decimal.Big{nil})
+				case arg == sliceType:
+					x := new(decimal.Big)
+					x.Context.OperatingMode = mode
+					in[i] = reflect.ValueOf([]*decimal.Big{x})
+				case arg.Kind() == reflect.Ptr:
+					in[i] = reflect.New(arg.Elem())
+				default:
+					in[i] = reflect.Zero(arg)
+				}
+			}
+			out, err := call(fn, in)
+			if err != nil {
+				if _, ok := err.(decimal.ErrNilOperand); !ok {
+					t.Fatalf("%s (%s, #%d): wanted ErrNilOperand, got %v", name, mode, pos, err)
+				}
+				continue
+			}
+			for _, v := range out {
+				switch x := v.Interface().(type) {
+				case decimal.Condition:
+					// Returned alongside the error, as by ArrowDecimal128.
+				case error:
+					if _, ok := x.(decimal.ErrNilOperand); !ok {
+						t.Fatalf("%s (%s, #%d): wanted ErrNilOperand, got %v", name, mode, pos, x)
+					}
+				case *decimal.Big:
+					if mode == decimal.Go {
+						t.Fatalf("%s (%s, #%d): expected a panic", name, mode, pos)
+					}
+					if !x.IsNaN(+1) || x.Context.Conditions&decimal.InvalidOperation == 0 {
+						t.Fatalf("%s (%s, #%d): wanted NaN and %s, got %s and %s",
+							name, mode, pos, decimal.InvalidOperation, x, x.Context.Conditions)
+					}
+				}
+			}
+		}
+	}
+
+	for _, mode := range [...]decimal.OperatingMode{decimal.GDA, decimal.Go} {
+		for i := 0; i < bigType.NumMethod(); i++ {
+			m := bigType.Method(i)
+			if !zeroValueSkip[m.Name] {
+				check(m.Name, mode, m.Func, 0)
+			}
+		}
+		ctx := decimal.Context{OperatingMode: mode}
+		ctxv := reflect.ValueOf(ctx)
+		for i := 0; i < ctxv.NumMethod(); i++ {
+			check(ctxv.Type().Method(i).Name, mode, ctxv.Method(i), 0)
+		}
+	}
+
+	if s := fmt.Sprintf("%s", (*decimal.Big)(nil)); s != "<nil>" {
+		t.Fatalf(`Sprintf("%%s", nil): wanted "<nil>", got %q`, s)
+	}
+}
+
+var scalarTests = [...]struct {
+	x string
+	v int64
+}{
+	{"0", 0},
+	{"1", 1},
+	{"-1.5", 3},
+	{"123.456", -789},
+	{"1e-30", 1},
+	{"1e+30", -1},
+	{"18446744073709551615", 1},
+	{"9.99999999999999999999e-5", math.MaxInt64},
+	{"0.000000000000000000001", math.MinInt64},
+	{"-123456789012345678901234567890.123", math.MaxInt64},
+	{"Inf", 5},
+	{"-Inf", 0},
+	{"NaN", 1},
+}
+
+func TestBig_ScalarArithmetic(t *testing.T) {
+	ops := [...]struct {
+		name string
+		fn   func(z, x *decimal.Big, v int64) *decimal.Big
+		ref  func(z, x, y *decimal.Big) *decimal.Big
+	}{
+		{"AddInt64", (*decimal.Big).AddInt64, (*decimal.Big).Add},
+		{"MulInt64", (*decimal.Big).MulInt64, (*decimal.Big).Mul},
+		{"QuoInt64", (*decimal.Big).QuoInt64, (*decimal.Big).Quo},
+	}
+	for i, test := range scalarTests {
+		x, _ := new(decimal.Big).SetString(test.x)
+		y := decimal.New(test.v, 0)
+		for _, op := range ops {
+			got := op.fn(new(decimal.Big), x, test.v)
+			want := op.ref(new(decimal.Big), x, y)
+			if got.String() != want.String() || got.Context.Conditions != want.Context.Conditions {
+				t.Fatalf("#%d: %s(%s, %d): wanted %s (%s), got %s (%s)", i, op.name,
+					test.x, test.v, want, want.Context.Conditions, got, got.Context.Conditions)
+			}
+		}
+		if got, want := x.CmpInt64(test.v), x.Cmp(y); got != want {
+			t.Fatalf("#%d: CmpInt64(%s, %d): wanted %d, got %d", i, test.x, test.v, want, got)
+		}
+	}
+}
+
+func TestBig_AddFloat64(t *testing.T) {
+	for i, v := range [...]float64{
+		0, math.Copysign(0, -1), 0.1, -0.1, 1.5, 123456.789, 1e-300, 5e-324,
+		math.MaxFloat64, -math.SmallestNonzeroFloat64, 1 << 62, math.Inf(+1),
+	} {
+		x := decimal.New(12345, 2)
+		y, _ := new(decimal.Big).SetString(strconv.FormatFloat(v, 'g', -1, 64))
+		got := new(decimal.Big).AddFloat64(x, v)
+		want := new(decimal.Big).Add(x, y)
+		if got.String() != want.String() || got.Scale() != want.Scale() {
+			t.Fatalf("#%d: AddFloat64(%s, %g): wanted %s, got %s", i, x, v, want, got)
+		}
+	}
+}
+
+func TestBig_ScalarAllocs(t *testing.T) {
+	x := decimal.New(12345, 2)
+	z := new(decimal.Big)
+	for _, test := range [...]struct {
+		name string
+		fn   func()
+	}{
+		{"AddInt64", func() { z.AddInt64(x, 42) }},
+		{"AddFloat64", func() { z.AddFloat64(x, 4.2) }},
+		{"MulInt64", func() { z.MulInt64(x, 42) }},
+		{"QuoInt64", func() { z.QuoInt64(x, 42) }},
+		{"CmpInt64", func() { x.CmpInt64(42) }},
+	} {
+		if n := testing.AllocsPerRun(100, test.fn); n != 0 {
+			t.Fatalf("%s: wanted 0 allocations, got %.1f", test.name, n)
+		}
+	}
+}
+
+func BenchmarkBig_AddInt64(b *testing.B) {
+	b.ReportAllocs()
+	x := decimal.New(12345, 2)
+	z := new(decimal.Big)
+	for i := 0; i < b.N; i++ {
+		z.AddInt64(x, int64(i))
+	}
+}
+
+func BenchmarkBig_AddFloat64(b *testing.B) {
+	b.ReportAllocs()
+	x := decimal.New(12345, 2)
+	z := new(decimal.Big)
+	for i := 0; i < b.N; i++ {
+		z.AddFloat64(x, float64(i)/8)
+	}
+}
+
+func BenchmarkBig_MulInt64(b *testing.B) {
+	b.ReportAllocs()
+	x := decimal.New(12345, 2)
+	z := new(decimal.Big)
+	for i := 0; i < b.N; i++ {
+		z.MulInt64(x, int64(i))
+	}
+}
+
+func BenchmarkBig_QuoInt64(b *testing.B) {
+	b.ReportAllocs()
+	x := decimal.New(12345, 2)
+	z := new(decimal.Big)
+	for i := 0; i < b.N; i++ {
+		z.QuoInt64(x, int64(i%100+1))
+	}
+}
+
+func BenchmarkBig_CmpInt64(b *testing.B) {
+	b.ReportAllocs()
+	x := decimal.New(12345, 2)
+	var r int
+	for i := 0; i < b.N; i++ {
+		r += x.CmpInt64(int64(i))
+	}
+	gr = r
+}
+
+var gr int
+
+func TestContext_ExactOnly(t *testing.T) {
+	ctx := decimal.Context{Precision: 5, ExactOnly: true}
+	for i, test := range [...]struct {
+		op   string
+		x, y string
+		n    int // for Quantize
+		r    string
+		err  string
+	}{
+		{op: "Add", x: "123456789.123", y: "0.000000001", r: "123456789.123000001"},
+		{op: "Sub", x: "1e-20", y: "123456789", r: "-123456788.99999999999999999999"},
+		{op: "Mul", x: "123456789", y: "987654321", r: "121932631112635269"},
+		{op: "Quo", x: "1", y: "8", r: "0.125"},
+		{op: "Quo", x: "12345", y: "5", r: "2469"},
+		{op: "Quo", x: "1", y: "64", r: "0.015625"},
+		{op: "Quo", x: "1", y: "256", err: "decimal: Quo would lose 1 digit(s)"},
+		{op: "Quo", x: "1", y: "1024", err: "decimal: Quo would lose 2 digit(s)"},
+		{op: "Quo", x: "1", y: "3", err: "decimal: Quo has a non-terminating result"},
+		{op: "Quantize", x: "1.2300", n: 2, r: "1.23"},
+		{op: "Quantize", x: "1.5", n: 3, r: "1.500"},
+		{op: "Quantize", x: "1.2345", n: 2, err: "decimal: Quantize would lose 2 digit(s)"},
+		{op: "Quantize", x: "1.2050", n: 1, err: "decimal: Quantize would lose 2 digit(s)"},
+		{op: "Quantize", x: "-123456789012345678901.5", n: 0, err: "decimal: Quantize would lose 1 digit(s)"},
+	} {
+		x, _ := decimal.WithContext(ctx).SetString(test.x)
+		y, _ := new(decimal.Big).SetString(test.y)
+		z := decimal.WithContext(ctx)
+		switch test.op {
+		case "Add":
+			z.Add(x, y)
+		case "Sub":
+			z.Sub(x, y)
+		case "Mul":
+			z.Mul(x, y)
+		case "Quo":
+			z.Quo(x, y)
+		case "Quantize":
+			if test.x[0] == '-' {
+				x.Context.Precision = 30
+			}
+			z = x.Quantize(test.n)
+		}
+
+		err := z.Context.Err()
+		if test.err == "" {
+			if err != nil || z.String() != test.r {
+				t.Fatalf("#%d: %s(%s, %s): wanted: %s, got: %s (%v)",
+					i, test.op, test.x, test.y, test.r, z, err)
+			}
+			continue
+		}
+		if err == nil || err.Error() != test.err || !z.IsNaN(0) {
+			t.Fatalf(`#%d: %s(%s, %s)
+wanted: NaN (%s)
+got   : %s (%v)
+`, i, test.op, test.x, test.y, test.err, z, err)
+		}
+		if c, ok := decimal.ConditionCode(err); !ok || c != decimal.Inexact {
+			t.Fatalf("#%d: wanted Inexact, got %s", i, c)
+		}
+	}
+
+	// The failure is reported even if the result's Context does not have
+	// ExactOnly set.
+	z := ctx.Quo(new(decimal.Big), decimal.New(1, 0), decimal.New(3, 0))
+	if _, ok := z.Context.Err().(decimal.ErrNotExact); !ok || !z.IsNaN(0) {
+		t.Fatalf("Context.Quo: wanted NaN and an ErrNotExact, got %s (%v)", z, z.Context.Err())
+	}
+
+	// Go mode panics instead, even if the result is in GDA mode.
+	func() {
+		defer func() {
+			if _, ok := recover().(decimal.ErrNotExact); !ok {
+				t.Fatal("wanted panic with ErrNotExact")
+			}
+		}()
+		ctx.OperatingMode = decimal.Go
+		ctx.Quo(new(decimal.Big), decimal.New(1, 0), decimal.New(3, 0))
+	}()
+}
+
+func TestBig_MantScale(t *testing.T) {
+	for i, test := range [...]struct {
+		x     string
+		mant  int64
+		scale int
+		ok    bool
+	}{
+		{"0", 0, 0, true},
+		{"-0", 0, 0, true},
+		{"-0.00", 0, 2, true},
+		{"1.50", 150, 2, true},
+		{"-1.5E+10", -15, -9, true},
+		{"9223372036854775807", math.MaxInt64, 0, true},
+		{"-9223372036854775808", math.MinInt64, 0, true},
+		{"922337203685477580.8", 0, 0, false},
+		{"-9223372036854775809", 0, 0, false},
+		{"18446744073709551615", 0, 0, false},
+		{"123456789012345678901234567890", 0, 0, false},
+		{"1E-" + strconv.Itoa(decimal.MaxScale), 1, decimal.MaxScale, true},
+		{"-1E+" + strconv.Itoa(decimal.MaxScale), -1, decimal.MinScale, true},
+		{"Inf", 0, 0, false},
+		{"-Inf", 0, 0, false},
+		{"NaN", 0, 0, false},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		mant, scale, ok := x.MantScale()
+		if mant != test.mant || scale != test.scale || ok != test.ok {
+			t.Fatalf(`#%d: MantScale(%s)
+wanted: (%d, %d, %t)
+got   : (%d, %d, %t)
+`, i, test.x, test.mant, test.scale, test.ok, mant, scale, ok)
+		}
+
+		var z big.Int
+		bscale := x.MantScaleBig(&z)
+		if !x.IsFinite() {
+			if z.Sign() != 0 || bscale != 0 {
+				t.Fatalf("#%d: MantScaleBig(%s): wanted (0, 0), got (%s, %d)", i, test.x, &z, bscale)
+			}
+			continue
+		}
+		if y := new(decimal.Big).SetBigMantScale(&z, bscale); y.Cmp(x) != 0 || y.Scale() != x.Scale() {
+			t.Fatalf("#%d: MantScaleBig(%s) round trip: got %s", i, test.x, y)
+		}
+
+		if !ok {
+			continue
+		}
+		if z.Int64() != mant || bscale != scale {
+			t.Fatalf("#%d: MantScaleBig(%s): wanted (%d, %d), got (%s, %d)",
+				i, test.x, mant, scale, &z, bscale)
+		}
+		y := decimal.NewMantScale(mant, scale)
+		if y.Cmp(x) != 0 || y.Scale() != x.Scale() {
+			t.Fatalf("#%d: NewMantScale(%d, %d): wanted %s, got %s", i, mant, scale, x, y)
+		}
+	}
+}
+
+func TestContext_GuardDigits(t *testing.T) {
+	dec := func(s string) *decimal.Big {
+		x, _ := new(decimal.Big).SetString(s)
+		return x
+	}
+	xs := []*decimal.Big{dec("1.00"), dec("0.004"), dec("0.004")}
+	ones := []*decimal.Big{dec("1"), dec("1"), dec("1")}
+
+	for i, test := range [...]struct {
+		guard int
+		want  string
+	}{
+		// 1.00 + 0.004 rounds to 1.00 each time.
+		{0, "1.00"},
+		// 1.004 + 0.004 = 1.008, which correctly rounds to 1.01.
+		{2, "1.01"},
+	} {
+		ctx := decimal.Context{Precision: 3, GuardDigits: test.guard}
+
+		z := decimal.WithContext(ctx).Sum(xs...)
+		if z.String() != test.want {
+			t.Fatalf("#%d: Sum: wanted %s, got %s", i, test.want, z)
+		}
+		if c := z.Context.Conditions; c != decimal.Inexact|decimal.Rounded {
+			t.Fatalf("#%d: Sum: wanted inexact, rounded, got %s", i, c)
+		}
+
+		z = decimal.WithContext(ctx).Dot(ones, xs)
+		if z.String() != test.want {
+			t.Fatalf("#%d: Dot: wanted %s, got %s", i, test.want, z)
+		}
+
+		// Without guard digits, Sum is the same as repeated Add.
+		if test.guard == 0 {
+			w := decimal.WithContext(ctx)
+			for _, x := range xs {
+				w.Add(w, x)
+			}
+			if w.Cmp(z) != 0 || w.Context.Conditions != z.Context.Conditions {
+				t.Fatalf("#%d: Sum: wanted %s (%s), got %s (%s)",
+					i, w, w.Context.Conditions, z, z.Context.Conditions)
+			}
+		}
+	}
+
+	// The result may alias an operand.
+	z := new(decimal.Big).Copy(xs[0])
+	z.Context = decimal.Context{Precision: 3, GuardDigits: 2}
+	if z.Sum(z, xs[1], xs[2]); z.String() != "1.01" {
+		t.Fatalf("aliased Sum: wanted 1.01, got %s", z)
+	}
+	if z := decimal.WithContext(decimal.Context{}).Sum(); z.Sign() != 0 || !z.IsFinite() {
+		t.Fatalf("empty Sum: wanted 0, got %s", z)
+	}
+
+	z = decimal.WithContext(decimal.Context{GuardDigits: -1})
+	if z.Sum(xs...); !z.IsNaN(0) || z.Context.Conditions&decimal.InvalidContext == 0 {
+		t.Fatalf("negative GuardDigits: wanted NaN with InvalidContext, got %s (%s)",
+			z, z.Context.Conditions)
+	}
+}
+
+func TestBig_Int64Saturating(t *testing.T) {
+	const (
+		ok      = 0
+		inexact = decimal.Inexact | decimal.Rounded
+		clamped = decimal.Clamped | decimal.Inexact
+	)
+	for i, test := range [...]struct {
+		x     string
+		scale int
+		mode  decimal.RoundingMode
+		v     int64
+		c     decimal.Condition
+	}{
+		{"9223372036854775807", 0, decimal.ToNearestEven, math.MaxInt64, ok},
+		{"9223372036854775808", 0, decimal.ToNearestEven, math.MaxInt64, clamped},
+		{"-9223372036854775808", 0, decimal.ToNearestEven, math.MinInt64, ok},
+		{"-9223372036854775809", 0, decimal.ToNearestEven, math.MinInt64, clamped},
+		{"9223372036854775807.4", 0, decimal.ToNearestEven, math.MaxInt64, inexact},
+		{"9223372036854775807.5", 0, decimal.ToNearestEven, math.MaxInt64, clamped},
+		{"9223372036854775807.5", 0, decimal.ToZero, math.MaxInt64, inexact},
+		{"-9223372036854775808.9", 0, decimal.ToZero, math.MinInt64, inexact},
+		{"-9223372036854775808.1", 0, decimal.AwayFromZero, math.MinInt64, clamped},
+
+		{"9223372036854.775807", 6, decimal.ToNearestEven, math.MaxInt64, ok},
+		{"9223372036854.775808", 6, decimal.ToNearestEven, math.MaxInt64, clamped},
+		{"-9223372036854.775808", 6, decimal.ToNearestEven, math.MinInt64, ok},
+		{"-9223372036854.775809", 6, decimal.ToNearestEven, math.MinInt64, clamped},
+		{"9223372036854.7758074", 6, decimal.ToNearestEven, math.MaxInt64, inexact},
+		{"9223372036854.7758075", 6, decimal.ToNearestEven, math.MaxInt64, clamped},
+		{"-9223372036854.7758085", 6, decimal.ToNearestEven, math.MinInt64, inexact},
+		{"9223372036854.775807", 7, decimal.ToNearestEven, math.MaxInt64, clamped},
+
+		{"9.223372036854775807", 18, decimal.ToNearestEven, math.MaxInt64, ok},
+		{"-9.223372036854775808", 18, decimal.ToNearestEven, math.MinInt64, ok},
+		{"9.2233720368547758075", 18, decimal.ToNearestEven, math.MaxInt64, clamped},
+
+		{"9223372036854775807000", -3, decimal.ToNearestEven, math.MaxInt64, ok},
+		{"9223372036854775807499", -3, decimal.ToNearestEven, math.MaxInt64, inexact},
+		{"9223372036854775807500", -3, decimal.ToNearestEven, math.MaxInt64, clamped},
+		{"-9223372036854775808500", -3, decimal.ToNearestEven, math.MinInt64, inexact},
+		{"9223372036854775807E+3", -3, decimal.ToNearestEven, math.MaxInt64, ok},
+
+		{"1.234567", 2, decimal.ToNearestEven, 123, inexact},
+		{"-1.235", 2, decimal.ToNearestEven, -124, inexact},
+		{"-1.235", 2, decimal.ToNearestAway, -124, inexact},
+		{"-1.235", 2, decimal.ToPositiveInf, -123, inexact},
+		{"0", 100, decimal.ToNearestEven, 0, ok},
+		{"-1E-50", 0, decimal.ToNearestEven, 0, inexact},
+		{"1E-50", 0, decimal.ToPositiveInf, 1, inexact},
+		{"1E+100", 0, decimal.ToNearestEven, math.MaxInt64, clamped},
+		{"-1E+100", -80, decimal.ToNearestEven, math.MinInt64, clamped},
+		{"1", int(^uint(0) >> 1), decimal.ToNearestEven, math.MaxInt64, clamped},
+		{"1", -int(^uint(0)>>1) - 1, decimal.ToNearestEven, 0, inexact},
+		{"Inf", 0, decimal.ToNearestEven, math.MaxInt64, clamped},
+		{"-Inf", 0, decimal.ToNearestEven, math.MinInt64, clamped},
+		{"NaN", 0, decimal.ToNearestEven, 0, decimal.InvalidOperation},
+	} {
+		x := decimal.WithContext(decimal.Context{RoundingMode: test.mode})
+		x.SetString(test.x)
+		x.Context.Conditions = 0
+		if v := x.Int64Saturating(test.scale); v != test.v || x.Context.Conditions != test.c {
+			t.Fatalf(`#%d: Int64Saturating(%s, %d) (%s)
+wanted: %d (%s)
+got   : %d (%s)
+`, i, test.x, test.scale, test.mode, test.v, test.c, v, x.Context.Conditions)
+		}
+
+		x.Context.Conditions = 0
+		v, err := x.Int64Checked(test.scale)
+		switch {
+		case test.c == decimal.InvalidOperation:
+			if _, ok := err.(decimal.ErrNaN); !ok {
+				t.Fatalf("#%d: Int64Checked(%s, %d): wanted ErrNaN, got %v", i, test.x, test.scale, err)
+			}
+		case test.c&decimal.Clamped != 0:
+			if _, ok := err.(decimal.ErrRange); !ok || x.Context.Conditions != 0 {
+				t.Fatalf("#%d: Int64Checked(%s, %d): wanted ErrRange, got %d, %v (%s)",
+					i, test.x, test.scale, v, err, x.Context.Conditions)
+			}
+		default:
+			if err != nil || v != test.v || x.Context.Conditions != test.c {
+				t.Fatalf("#%d: Int64Checked(%s, %d): wanted %d (%s), got %d, %v (%s)",
+					i, test.x, test.scale, test.v, test.c, v, err, x.Context.Conditions)
+			}
+		}
+	}
+}
+
+func TestBig_Uint64Saturating(t *testing.T) {
+	const (
+		ok      = 0
+		inexact = decimal.Inexact | decimal.Rounded
+		clamped = decimal.Clamped | decimal.Inexact
+	)
+	for i, test := range [...]struct {
+		x     string
+		scale int
+		v     uint64
+		c     decimal.Condition
+	}{
+		{"18446744073709551615", 0, math.MaxUint64, ok},
+		{"18446744073709551616", 0, math.MaxUint64, clamped},
+		{"18446744073709551615.4", 0, math.MaxUint64, inexact},
+		{"18446744073709551615.5", 0, math.MaxUint64, clamped},
+		{"184467440737095516.15", 2, math.MaxUint64, ok},
+		{"184467440737095516.16", 2, math.MaxUint64, clamped},
+		{"18446744073709551615E+2", -2, math.MaxUint64, ok},
+		{"1844674407370955161549", -2, math.MaxUint64, inexact},
+		{"9223372036854775808", 0, 1 << 63, ok},
+		{"-0.4", 0, 0, inexact},
+		{"-0", 0, 0, ok},
+		{"-1", 0, 0, clamped},
+		{"-Inf", 0, 0, clamped},
+		{"Inf", 0, math.MaxUint64, clamped},
+		{"NaN", 0, 0, decimal.InvalidOperation},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		x.Context.Conditions = 0
+		if v := x.Uint64Saturating(test.scale); v != test.v || x.Context.Conditions != test.c {
+			t.Fatalf(`#%d: Uint64Saturating(%s, %d)
+wanted: %d (%s)
+got   : %d (%s)
+`, i, test.x, test.scale, test.v, test.c, v, x.Context.Conditions)
+		}
+
+		x.Context.Conditions = 0
+		v, err := x.Uint64Checked(test.scale)
+		if test.c&(decimal.Clamped|decimal.InvalidOperation) != 0 {
+			if err == nil {
+				t.Fatalf("#%d: Uint64Checked(%s, %d): wanted an error, got %d", i, test.x, test.scale, v)
+			}
+		} else if err != nil || v != test.v || x.Context.Conditions != test.c {
+			t.Fatalf("#%d: Uint64Checked(%s, %d): wanted %d (%s), got %d, %v (%s)",
+				i, test.x, test.scale, test.v, test.c, v, err, x.Context.Conditions)
+		}
+	}
+}
+
+func TestBig_SetFrac(t *testing.T) {
+	for i, test := range [...]struct {
+		num, den int64
+		prec     int
+		want     string
+		conds    decimal.Condition
+	}{
+		{7, 8, 0, "0.875", 0},
+		{3, 40, 0, "0.075", 0},
+		{-1, 4, 0, "-0.25", 0},
+		{1, -1024, 0, "-0.0009765625", 0},
+		{12, 4, 0, "3", 0},
+		{30, 6, 0, "5", 0},
+		{0, 5, 0, "0", 0},
+		{1, 12, 0, "0.08333333333333333", decimal.Inexact | decimal.Rounded},
+		{7, 365, 5, "0.019178", decimal.Inexact | decimal.Rounded},
+		{1, 1 << 40, 0, "9.094947017729282E-13", decimal.Inexact | decimal.Rounded},
+		{5, 0, 0, "Infinity", decimal.DivisionByZero},
+		{-5, 0, 0, "-Infinity", decimal.DivisionByZero},
+		{0, 0, 0, "NaN", decimal.DivisionUndefined | decimal.InvalidOperation},
+		{math.MinInt64, 1, 25, "-9223372036854775808", 0},
+	} {
+		z := decimal.WithPrecision(test.prec).SetFrac(test.num, test.den)
+		s := z.String()
+		if z.IsNaN(0) {
+			s = "NaN" // ignore the diagnostic payload
+		}
+		if s != test.want || z.Context.Conditions != test.conds {
+			t.Fatalf("#%d: SetFrac(%d, %d): wanted %s (%s), got %s (%s)",
+				i, test.num, test.den, test.want, test.conds, z, z.Context.Conditions)
+		}
+		w := decimal.WithPrecision(test.prec).SetFracBig(decimal.New(test.num, 0), decimal.New(test.den, 0))
+		if w.Cmp(z) != 0 && !(z.IsNaN(0) && w.IsNaN(0)) || w.Context.Conditions != z.Context.Conditions {
+			t.Fatalf("#%d: SetFracBig(%d, %d): wanted %s (%s), got %s (%s)",
+				i, test.num, test.den, z, z.Context.Conditions, w, w.Context.Conditions)
+		}
+	}
+}
+
+func TestBig_LargePrecision(t *testing.T) {
+	const prec = 100000
+	ctx := decimal.Context{Precision: prec}
+	one, three := decimal.New(1, 0), decimal.New(3, 0)
+
+	q := decimal.WithContext(ctx).Quo(one, three)
+	if want := "0." + strings.Repeat("3", prec); q.String() != want {
+		t.Fatalf("1/3: wanted %d threes, got %d digits", prec, q.Precision())
+	}
+	if q.Context.Conditions != decimal.Inexact|decimal.Rounded {
+		t.Fatalf("1/3: wanted %s, got %s", decimal.Inexact|decimal.Rounded, q.Context.Conditions)
+	}
+
+	s := decimal.WithContext(ctx).Add(q, q)
+	s.Add(s, q)
+	if want := "0." + strings.Repeat("9", prec); s.String() != want || s.Context.Conditions != 0 {
+		t.Fatalf("1/3 + 1/3 + 1/3: wanted %d nines, got %d digits (%s)", prec, s.Precision(), s.Context.Conditions)
+	}
+
+	// Aligning the operands pads with prec-1 zeros, and the sum is exact.
+	a := decimal.WithContext(ctx).Add(decimal.New(1, -(prec-1)), one)
+	if want := "1" + strings.Repeat("0", prec-2) + "1"; a.String() != want || a.Context.Conditions != 0 {
+		t.Fatalf("1E+%d + 1: wanted an exact sum, got %d digits (%s)", prec-1, a.Precision(), a.Context.Conditions)
+	}
+	a = decimal.WithContext(ctx).Add(decimal.New(1, -prec), one)
+	if want := "1." + strings.Repeat("0", prec-1) + "E+100000"; a.String() != want ||
+		a.Context.Conditions != decimal.Inexact|decimal.Rounded {
+		t.Fatalf("1E+%d + 1: wanted a rounded sum, got %d digits (%s)", prec, a.Precision(), a.Context.Conditions)
+	}
+
+	z := decimal.WithContext(ctx).SetMantScale(1, 0).Quantize(prec - 1)
+	if z.Precision() != prec || z.Scale() != prec-1 || z.Context.Conditions != 0 {
+		t.Fatalf("Quantize(%d): wanted %d digits, got %d (%s)", prec-1, prec, z.Precision(), z.Context.Conditions)
+	}
+	z = decimal.WithContext(ctx).SetMantScale(1, 0).Quantize(prec)
+	if !z.IsNaN(0) || z.Context.Conditions != decimal.InvalidOperation {
+		t.Fatalf("Quantize(%d): wanted NaN, got %s (%s)", prec, z, z.Context.Conditions)
+	}
+}
+
+func TestContext_MaxPracticalPrecision(t *testing.T) {
+	one, two := decimal.New(1, 0), decimal.New(2, 0)
+	for _, prec := range [...]int{
+		decimal.MaxPracticalPrecision + 1,
+		decimal.MaxPrecision,
+	} {
+		z := decimal.WithContext(decimal.Context{Precision: prec}).Quo(one, two)
+		if !z.IsNaN(0) || z.Context.Conditions != decimal.InvalidContext {
+			t.Fatalf("%d: wanted NaN with InvalidContext, got %s (%s)", prec, z, z.Context.Conditions)
+		}
+		if z := decimal.WithPrecision(prec); !z.IsNaN(0) {
+			t.Fatalf("WithPrecision(%d): wanted NaN, got %s", prec, z)
+		}
+	}
+	for _, prec := range [...]int{
+		decimal.MaxPracticalPrecision,
+		decimal.UnlimitedPrecision,
+	} {
+		z := decimal.WithPrecision(prec).Add(one, two)
+		if z.String() != "3" || z.Context.Conditions != 0 {
+			t.Fatalf("%d: wanted 3, got %s (%s)", prec, z, z.Context.Conditions)
+		}
+	}
+
+	// Guard digits must not overflow the working precision.
+	z := decimal.WithContext(decimal.Context{GuardDigits: int(^uint(0) >> 1)}).Sum(one, two)
+	if z.String() != "3" || z.Context.Conditions != 0 {
+		t.Fatalf("Sum with huge GuardDigits: wanted 3, got %s (%s)", z, z.Context.Conditions)
+	}
+
+	// Exact results with unlimited precision must not pad their operands
+	// beyond MaxPracticalPrecision digits.
+	unlimited := decimal.Context{Precision: decimal.UnlimitedPrecision}
+	huge := decimal.New(1, -decimal.MaxPracticalPrecision)
+	for _, z := range [...]*decimal.Big{
+		decimal.WithContext(unlimited).Add(huge, one),
+		decimal.WithContext(unlimited).Sub(one, huge),
+		decimal.WithContext(decimal.Context{ExactOnly: true}).Add(one, huge),
+		decimal.WithContext(unlimited).Copy(huge).Quantize(0),
+	} {
+		if !z.IsNaN(0) || z.Context.Conditions != decimal.InsufficientStorage {
+			t.Fatalf("wanted NaN with InsufficientStorage, got %.10s (%s)", z, z.Context.Conditions)
+		}
+	}
+	z = decimal.WithContext(unlimited).Add(decimal.New(1, -1000), one)
+	if z.Precision() != 1001 || z.Context.Conditions != 0 {
+		t.Fatalf("wanted an exact sum with 1001 digits, got %d digits (%s)", z.Precision(), z.Context.Conditions)
+	}
+}
+
+func TestBig_SetUint64Max(t *testing.T) {
+	// math.MaxUint64 cannot be stored in the compact form.
+	x := new(decimal.Big).SetUint64(math.MaxUint64)
+	if s := x.String(); s != "18446744073709551615" {
+		t.Fatalf("wanted 18446744073709551615, got %s", s)
+	}
+}
+
+func TestBig_QuoOrDefault(t *testing.T) {
+	def := decimal.New(-1, 0)
+	// 1/Infinity is zero with the smallest exponent at precision 16.
+	etiny := "0E" + strconv.Itoa(decimal.MinScale-15)
+	for i, test := range [...]struct {
+		x, y  string
+		def   string // result of QuoOrDefault
+		zero  string // result of QuoOrZero
+		conds decimal.Condition
+	}{
+		{"1", "4", "0.25", "0.25", 0},
+		{"1", "3", "0.3333333333333333", "0.3333333333333333", decimal.Inexact | decimal.Rounded},
+		{"1", "0", "-1", "0", 0},
+		{"0", "0", "-1", "0", 0},
+		{"-5", "-0.00", "-1", "0", 0},
+		{"Infinity", "0", "-1", "0", 0},
+		{"NaN", "0", "NaN", "NaN", 0},
+		{"1", "NaN", "NaN", "NaN", 0},
+		{"sNaN", "0", "NaN", "NaN", decimal.InvalidOperation},
+		{"1", "Infinity", etiny, etiny, decimal.Clamped},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		y, _ := new(decimal.Big).SetString(test.y)
+		for _, c := range [...]struct {
+			name string
+			z    *decimal.Big
+			want string
+		}{
+			{"QuoOrDefault", new(decimal.Big).QuoOrDefault(x, y, def), test.def},
+			{"QuoOrZero", new(decimal.Big).QuoOrZero(x, y), test.zero},
+		} {
+			s := c.z.String()
+			if c.z.IsNaN(0) {
+				s = "NaN" // ignore the diagnostic payload
+			}
+			if s != c.want || c.z.Context.Conditions != test.conds {
+				t.Fatalf("#%d: %s(%s, %s): wanted %s (%s), got %s (%s)",
+					i, c.name, test.x, test.y, c.want, test.conds, c.z, c.z.Context.Conditions)
+			}
+		}
+	}
+
+	// The default is rounded like the result of Quo.
+	z := decimal.WithPrecision(2).QuoOrDefault(decimal.New(1, 0), decimal.New(0, 0), decimal.New(125, 2))
+	if z.String() != "1.2" {
+		t.Fatalf("wanted the default rounded to 1.2, got %s", z)
+	}
+}
+
+func TestBig_Clone(t *testing.T) {
+	for _, s := range []string{
+		"123.45",
+		"-123456789012345678901234567890.12345",
+		"NaN", "-Infinity", "-0",
+	} {
+		x, _ := decimal.WithPrecision(50).SetString(s)
+		x.Context.RoundingMode = decimal.ToZero
+		x.Context.Conditions = decimal.Inexact | decimal.Rounded
+		orig := x.Dump()
+
+		z := x.Clone()
+		want := orig
+		want.Context.Conditions = ""
+		if got := z.Dump(); got != want {
+			t.Fatalf("%s: wanted %+v, got %+v", s, want, got)
+		}
+		if err := z.Context.Err(); err != nil {
+			t.Fatalf("%s: wanted nil error, got %v", s, err)
+		}
+
+		// Mutating the clone in place must not affect x, nor vice versa.
+		if z.IsFinite() {
+			z.Mul(z, z).Add(z, decimal.New(1, 0))
+			if got := x.Dump(); got != orig {
+				t.Fatalf("%s: mutating the clone changed x: %+v", s, got)
+			}
+			zs := z.Dump()
+			x.Mul(x, decimal.New(7, 0))
+			if got := z.Dump(); got != zs {
+				t.Fatalf("%s: mutating x changed the clone: %+v", s, got)
+			}
+		}
+	}
+}
+
+func TestBig_CloneWithContext(t *testing.T) {
+	x, _ := decimal.WithPrecision(50).SetString("1234567890123456789012345.6789")
+	x.Context.Conditions = decimal.Inexact
+	ctx := decimal.Context{Precision: 5, Conditions: decimal.Clamped}
+
+	z := x.CloneWithContext(ctx)
+	if z.Cmp(x) != 0 || z.Scale() != x.Scale() {
+		t.Fatalf("wanted %s, got %s", x, z)
+	}
+	if z.Context.Precision != 5 || z.Context.Conditions != 0 {
+		t.Fatalf("wanted precision 5 and no conditions, got %d and %s",
+			z.Context.Precision, z.Context.Conditions)
+	}
+	if want := "1.2346E+24"; z.Context.Round(z).String() != want {
+		t.Fatalf("wanted %s, got %s", want, z)
+	}
+	if want := "1234567890123456789012345.6789"; x.String() != want {
+		t.Fatalf("rounding the clone changed x: wanted %s, got %s", want, x)
+	}
+}
+
+// TestBig_PrecisionCached checks that every operation maintains the number of
+// digits returned by Precision.
+func TestBig_PrecisionCached(t *testing.T) {
+	mk := func(s string) *decimal.Big {
+		x, ok := decimal.WithPrecision(50).SetString(s)
+		if !ok {
+			t.Fatalf("invalid input %q", s)
+		}
+		return x
+	}
+	check := func(op string, z *decimal.Big) {
+		t.Helper()
+		if !z.IsFinite() {
+			return
+		}
+		if want := len(z.Dump().Coeff); z.Precision() != want {
+			t.Fatalf("%s: %s: wanted precision %d, got %d", op, z, want, z.Precision())
+		}
+	}
+
+	vals := []string{
+		"0", "-0.00", "1", "9", "10", "99999999999999999999", "18446744073709551615",
+		"18446744073709551616", "-123456789012345678901234567890.123",
+		"0.000000000000000000000000000001", "1E+40", "-7.5",
+	}
+	for _, xs := range vals {
+		for _, ys := range vals {
+			x, y := mk(xs), mk(ys)
+			for _, prec := range []int{3, 20, 50} {
+				ctx := decimal.Context{Precision: prec}
+				z := func() *decimal.Big { return decimal.WithContext(ctx) }
+
+				check("Add", z().Add(x, y))
+				check("Sub", z().Sub(x, y))
+				check("Mul", z().Mul(x, y))
+				check("Quo", z().Quo(x, y))
+				check("QuoInt", z().QuoInt(x, y))
+				check("Rem", z().Rem(x, y))
+				q, r := z().QuoRem(x, y, z())
+				check("QuoRem", q)
+				check("QuoRem", r)
+				check("FMA", z().FMA(x, y, x))
+				check("Sum", z().Sum(x, y, x))
+				check("Set", z().Set(x))
+				check("Copy", z().Copy(x))
+				check("Neg", z().Neg(x))
+				check("Abs", z().Abs(x))
+				check("Clone", x.Clone())
+				check("Reduce", z().Copy(x).Reduce())
+				check("Round", z().Copy(x).Round(prec/2+1))
+				check("RoundToInt", z().Copy(x).RoundToInt())
+				check("Quantize", z().Copy(x).Quantize(prec/2))
+				xi, _ := x.Int(nil)
+				check("SetBigMantScale", z().SetBigMantScale(xi, prec))
+				check("SetRat", z().SetRat(x.Rat(nil)))
+				check("SetString", mk(x.String()))
+				check("AddInt64", z().AddInt64(x, 1))
+				check("MulInt64", z().MulInt64(x, -10))
+				check("QuoInt64", z().QuoInt64(x, 3))
+			}
+		}
+		f, _ := mk(xs).Float64()
+		check("SetFloat64", new(decimal.Big).SetFloat64(f))
+	}
+}
+
+func TestBig_AccessorAllocs(t *testing.T) {
+	x, _ := new(decimal.Big).SetString("-123456789012345678901234567890.123")
+	var n int
+	if a := testing.AllocsPerRun(100, func() {
+		n += x.Precision() + x.Scale() + x.Sign()
+		if x.Signbit() {
+			n++
+		}
+	}); a != 0 {
+		t.Fatalf("wanted 0 allocations, got %.1f", a)
+	}
+}
+
+func TestBig_SetScaleFloor(t *testing.T) {
+	for i, test := range [...]struct {
+		x        string
+		minScale int
+		want     string
+	}{
+		{"12E+3", 0, "12000"},
+		{"-12E+3", 0, "-12000"},
+		{"12E+3", -2, "1.20E+4"},
+		{"12E+3", 2, "12000.00"},
+		{"1.5", 2, "1.50"},
+		{"1.500", 2, "1.500"},
+		{"0E+3", 1, "0.0"},
+		{"-0", 3, "-0.000"},
+		{"18446744073709551615", 5, "18446744073709551615.00000"},
+		{"123456789012345678901234567890E+2", 1, "12345678901234567890123456789000.0"},
+		{"Infinity", 2, "Infinity"},
+		{"NaN", 2, "NaN"},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		// The result is not rounded, even though it has more digits than z's
+		// Context allows.
+		z := decimal.WithPrecision(4).SetScaleFloor(x, test.minScale)
+		if got := z.String(); got != test.want {
+			t.Fatalf("#%d: SetScaleFloor(%s, %d): wanted %s, got %s", i, test.x, test.minScale, test.want, got)
+		}
+		if z.IsFinite() && (z.Cmp(x) != 0 || z.Scale() < test.minScale) {
+			t.Fatalf("#%d: SetScaleFloor(%s, %d): got %s (scale %d)", i, test.x, test.minScale, z, z.Scale())
+		}
+		if z.Context.Conditions != 0 {
+			t.Fatalf("#%d: SetScaleFloor(%s, %d): raised %s", i, test.x, test.minScale, z.Context.Conditions)
+		}
+	}
+}
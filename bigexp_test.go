@@ -0,0 +1,220 @@
+package decimal_test
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestBig_Exp(t *testing.T) {
+	// Computed with Python's decimal module at 80 digits and then rounded.
+	for i, test := range [...]struct {
+		x    string
+		want [6]string // indexed by RoundingMode
+	}{
+		{"1", [6]string{"2.718281828459045", "2.718281828459045", "2.718281828459045", "2.718281828459046", "2.718281828459045", "2.718281828459046"}},
+		{"-1", [6]string{"0.3678794411714423", "0.3678794411714423", "0.3678794411714423", "0.3678794411714424", "0.3678794411714423", "0.3678794411714424"}},
+		{"0.5", [6]string{"1.648721270700128", "1.648721270700128", "1.648721270700128", "1.648721270700129", "1.648721270700128", "1.648721270700129"}},
+		{"2.302585092994045684017991454684364", [6]string{"10.00000000000000", "10.00000000000000", "9.999999999999999", "10.00000000000000", "9.999999999999999", "10.00000000000000"}},
+		{"12.345", [6]string{"229808.1248612460", "229808.1248612460", "229808.1248612459", "229808.1248612460", "229808.1248612459", "229808.1248612460"}},
+		{"-0.001", [6]string{"0.9990004998333750", "0.9990004998333750", "0.9990004998333749", "0.9990004998333750", "0.9990004998333749", "0.9990004998333750"}},
+		{"100", [6]string{"2.688117141816135E+43", "2.688117141816135E+43", "2.688117141816135E+43", "2.688117141816136E+43", "2.688117141816135E+43", "2.688117141816136E+43"}},
+		{"-100", [6]string{"3.720075976020836E-44", "3.720075976020836E-44", "3.720075976020835E-44", "3.720075976020836E-44", "3.720075976020835E-44", "3.720075976020836E-44"}},
+		{"-12345.678", [6]string{"2.188614366172398E-5362", "2.188614366172398E-5362", "2.188614366172398E-5362", "2.188614366172399E-5362", "2.188614366172398E-5362", "2.188614366172399E-5362"}},
+		// Within an ULP of 1.
+		{"1E-17", [6]string{"1.000000000000000", "1.000000000000000", "1.000000000000000", "1.000000000000001", "1.000000000000000", "1.000000000000001"}},
+		{"-1E-17", [6]string{"1.000000000000000", "1.000000000000000", "0.9999999999999999", "1.000000000000000", "0.9999999999999999", "1.000000000000000"}},
+		{"1E-999", [6]string{"1.000000000000000", "1.000000000000000", "1.000000000000000", "1.000000000000001", "1.000000000000000", "1.000000000000001"}},
+		{"-1E-999", [6]string{"1.000000000000000", "1.000000000000000", "0.9999999999999999", "1.000000000000000", "0.9999999999999999", "1.000000000000000"}},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		for m, want := range test.want {
+			ctx := decimal.Context{RoundingMode: decimal.ToNearestEven + decimal.RoundingMode(m)}
+			z := decimal.WithContext(ctx).Exp(x)
+			const c = decimal.Inexact | decimal.Rounded
+			if z.String() != want || z.Context.Conditions != c {
+				t.Fatalf("#%d: Exp(%s) (%s): wanted %s (%s), got %s (%s)",
+					i, test.x, decimal.ToNearestEven+decimal.RoundingMode(m), want, c, z, z.Context.Conditions)
+			}
+		}
+	}
+}
+
+func TestBig_Exp_Special(t *testing.T) {
+	const (
+		r = decimal.Inexact | decimal.Rounded
+		u = r | decimal.Subnormal | decimal.Underflow | decimal.Clamped
+	)
+	// Etiny, the exponent of the smallest subnormal, with a precision of 16.
+	tiny := "0E" + strconv.Itoa(decimal.MinScale-15)
+	for i, test := range [...]struct {
+		x, want string
+		c       decimal.Condition
+	}{
+		{"0", "1", 0},
+		{"-0.000", "1", 0},
+		{"Infinity", "Infinity", 0},
+		{"-Infinity", "0", 0},
+		{"225", "Infinity", decimal.Overflow | r},
+		{"230", "Infinity", decimal.Overflow | r},
+		{"1E+1000", "Infinity", decimal.Overflow | r},
+		{"-1E+19", tiny, u},
+		{"-1E+1000", tiny, u},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		z := decimal.WithContext(decimal.Context{
+			Precision:     16,
+			MaxScale:      96,
+			OperatingMode: decimal.GDA,
+		})
+		z.Exp(x)
+		if z.String() != test.want || z.Context.Conditions != test.c {
+			t.Fatalf("#%d: Exp(%s): wanted %s (%s), got %s (%s)",
+				i, test.x, test.want, test.c, z, z.Context.Conditions)
+		}
+	}
+
+	for _, test := range [...]struct {
+		x string
+		c decimal.Condition
+	}{
+		{"NaN", 0},
+		{"sNaN", decimal.InvalidOperation},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		z := decimal.WithContext(decimal.Context{OperatingMode: decimal.GDA}).Exp(x)
+		if !z.IsNaN(0) || z.Context.Conditions != test.c {
+			t.Fatalf("Exp(%s): wanted NaN (%s), got %s (%s)", test.x, test.c, z, z.Context.Conditions)
+		}
+	}
+
+	z := decimal.WithContext(decimal.Context{
+		Precision:     decimal.UnlimitedPrecision,
+		OperatingMode: decimal.GDA,
+	})
+	if z.Exp(decimal.New(1, 0)); !z.IsNaN(0) || z.Context.Conditions != decimal.InvalidOperation {
+		t.Fatalf("Exp(1) (unlimited precision): wanted NaN (invalid operation), got %s (%s)",
+			z, z.Context.Conditions)
+	}
+	if z.Context.Conditions = 0; z.Exp(new(decimal.Big)).Cmp(decimal.New(1, 0)) != 0 || z.Context.Conditions != 0 {
+		t.Fatalf("Exp(0) (unlimited precision): wanted 1, got %s (%s)", z, z.Context.Conditions)
+	}
+}
+
+// TestBig_Exp_Random checks that Exp agrees with itself at a higher precision,
+// rounded to the lower one.
+func TestBig_Exp_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	n := 2000
+	if testing.Short() {
+		n = 200
+	}
+	for i := 0; i < n; i++ {
+		x := decimal.New(rng.Int63n(2e9)-1e9, rng.Intn(12))
+		prec := 1 + rng.Intn(60)
+		m := decimal.ToNearestEven + decimal.RoundingMode(rng.Intn(6))
+
+		hi := decimal.WithPrecision(prec + 25).Exp(x)
+		want := decimal.Context{Precision: prec, RoundingMode: m}.Round(hi)
+
+		z := decimal.WithContext(decimal.Context{Precision: prec, RoundingMode: m}).Exp(x)
+		if z.Cmp(want) != 0 || z.Precision() != want.Precision() {
+			t.Fatalf("#%d: Exp(%s) (%d digits, %s): wanted %s, got %s", i, x, prec, m, want, z)
+		}
+	}
+}
+
+func TestBig_Expm1(t *testing.T) {
+	// Computed with Python's decimal module at 700 digits and then rounded.
+	for i, test := range [...]struct {
+		x    string
+		want [6]string // indexed by RoundingMode
+	}{
+		{"1E-20", [6]string{"1.000000000000000E-20", "1.000000000000000E-20", "1.000000000000000E-20", "1.000000000000001E-20", "1.000000000000000E-20", "1.000000000000001E-20"}},
+		{"-1E-20", [6]string{"-1.000000000000000E-20", "-1.000000000000000E-20", "-9.999999999999999E-21", "-1.000000000000000E-20", "-1.000000000000000E-20", "-9.999999999999999E-21"}},
+		{"0.0000137", [6]string{"0.00001370009384542856", "0.00001370009384542856", "0.00001370009384542856", "0.00001370009384542857", "0.00001370009384542856", "0.00001370009384542857"}},
+		{"1", [6]string{"1.718281828459045", "1.718281828459045", "1.718281828459045", "1.718281828459046", "1.718281828459045", "1.718281828459046"}},
+		{"-1", [6]string{"-0.6321205588285577", "-0.6321205588285577", "-0.6321205588285576", "-0.6321205588285577", "-0.6321205588285577", "-0.6321205588285576"}},
+		{"0.5", [6]string{"0.6487212707001281", "0.6487212707001281", "0.6487212707001281", "0.6487212707001282", "0.6487212707001281", "0.6487212707001282"}},
+		{"-0.001", [6]string{"-0.0009995001666250083", "-0.0009995001666250083", "-0.0009995001666250083", "-0.0009995001666250084", "-0.0009995001666250084", "-0.0009995001666250083"}},
+		{"12.345", [6]string{"229807.1248612460", "229807.1248612460", "229807.1248612459", "229807.1248612460", "229807.1248612459", "229807.1248612460"}},
+		{"-20", [6]string{"-0.9999999979388464", "-0.9999999979388464", "-0.9999999979388463", "-0.9999999979388464", "-0.9999999979388464", "-0.9999999979388463"}},
+		{"1E-5", [6]string{"0.00001000005000016667", "0.00001000005000016667", "0.00001000005000016666", "0.00001000005000016667", "0.00001000005000016666", "0.00001000005000016667"}},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		for m, want := range test.want {
+			ctx := decimal.Context{RoundingMode: decimal.ToNearestEven + decimal.RoundingMode(m)}
+			z := decimal.WithContext(ctx).Expm1(x)
+			const c = decimal.Inexact | decimal.Rounded
+			if z.String() != want || z.Context.Conditions != c {
+				t.Fatalf("#%d: Expm1(%s) (%s): wanted %s (%s), got %s (%s)",
+					i, test.x, decimal.ToNearestEven+decimal.RoundingMode(m), want, c, z, z.Context.Conditions)
+			}
+		}
+	}
+}
+
+func TestBig_Expm1_Special(t *testing.T) {
+	const r = decimal.Inexact | decimal.Rounded
+	for i, test := range [...]struct {
+		x, want string
+		c       decimal.Condition
+	}{
+		{"0", "0", 0},
+		{"-0.000", "-0.000", 0},
+		{"Infinity", "Infinity", 0},
+		{"-Infinity", "-1", 0},
+		{"230", "Infinity", decimal.Overflow | r},
+		{"1E+1000", "Infinity", decimal.Overflow | r},
+		{"-100", "-1.000000000000000", r},
+		{"-1E+1000", "-1.000000000000000", r},
+		{"1E-999", "1.000000000000000E-999", r},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		z := decimal.WithContext(decimal.Context{
+			Precision:     16,
+			MaxScale:      96,
+			OperatingMode: decimal.GDA,
+		})
+		z.Expm1(x)
+		if z.String() != test.want || z.Context.Conditions != test.c {
+			t.Fatalf("#%d: Expm1(%s): wanted %s (%s), got %s (%s)",
+				i, test.x, test.want, test.c, z, z.Context.Conditions)
+		}
+	}
+
+	z := decimal.WithContext(decimal.Context{
+		Precision:     decimal.UnlimitedPrecision,
+		OperatingMode: decimal.GDA,
+	})
+	if z.Expm1(decimal.New(1, 0)); !z.IsNaN(0) || z.Context.Conditions != decimal.InvalidOperation {
+		t.Fatalf("Expm1(1) (unlimited precision): wanted NaN (invalid operation), got %s (%s)",
+			z, z.Context.Conditions)
+	}
+}
+
+// TestBig_Expm1_Random checks that Expm1 agrees with Exp minus one, computed
+// with enough extra digits to make up for the cancellation.
+func TestBig_Expm1_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	n := 2000
+	if testing.Short() {
+		n = 200
+	}
+	for i := 0; i < n; i++ {
+		x := decimal.New(rng.Int63n(2e9)-1e9, rng.Intn(30)+5)
+		prec := 1 + rng.Intn(60)
+		m := decimal.ToNearestEven + decimal.RoundingMode(rng.Intn(6))
+
+		hi := decimal.WithPrecision(prec + 50).Exp(x)
+		decimal.Context{Precision: decimal.UnlimitedPrecision}.Sub(hi, hi, decimal.New(1, 0))
+		want := decimal.Context{Precision: prec, RoundingMode: m}.Round(hi)
+
+		z := decimal.WithContext(decimal.Context{Precision: prec, RoundingMode: m}).Expm1(x)
+		if z.Cmp(want) != 0 || z.Precision() != want.Precision() {
+			t.Fatalf("#%d: Expm1(%s) (%d digits, %s): wanted %s, got %s", i, x, prec, m, want, z)
+		}
+	}
+}
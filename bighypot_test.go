@@ -0,0 +1,150 @@
+package decimal_test
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestBig_Hypot(t *testing.T) {
+	// Computed with Python's decimal module at 200 digits and then rounded.
+	for i, test := range [...]struct {
+		p, q string
+		want [6]string // indexed by RoundingMode
+	}{
+		{"1", "1", [6]string{"1.414213562373095", "1.414213562373095", "1.414213562373095", "1.414213562373096", "1.414213562373095", "1.414213562373096"}},
+		{"2", "3", [6]string{"3.605551275463989", "3.605551275463989", "3.605551275463989", "3.605551275463990", "3.605551275463989", "3.605551275463990"}},
+		{"-5.5", "12.25", [6]string{"13.42804900199579", "13.42804900199579", "13.42804900199578", "13.42804900199579", "13.42804900199578", "13.42804900199579"}},
+		{"1E-20", "1", [6]string{"1.000000000000000", "1.000000000000000", "1.000000000000000", "1.000000000000001", "1.000000000000000", "1.000000000000001"}},
+		{"123456789.123", "0.000987654321", [6]string{"123456789.1230000", "123456789.1230000", "123456789.1230000", "123456789.1230001", "123456789.1230000", "123456789.1230001"}},
+		{"9.99E+50", "9.99E+50", [6]string{"1.412799348810722E+51", "1.412799348810722E+51", "1.412799348810721E+51", "1.412799348810722E+51", "1.412799348810721E+51", "1.412799348810722E+51"}},
+		{"1.5E-30", "-2.5E-30", [6]string{"2.915475947422650E-30", "2.915475947422650E-30", "2.915475947422650E-30", "2.915475947422651E-30", "2.915475947422650E-30", "2.915475947422651E-30"}},
+		{"0.1", "0.2", [6]string{"0.2236067977499790", "0.2236067977499790", "0.2236067977499789", "0.2236067977499790", "0.2236067977499789", "0.2236067977499790"}},
+		{"7", "24.00001", [6]string{"25.00000960000016", "25.00000960000016", "25.00000960000015", "25.00000960000016", "25.00000960000015", "25.00000960000016"}},
+		{"1E+100", "1E+84", [6]string{"1.000000000000000E+100", "1.000000000000000E+100", "1.000000000000000E+100", "1.000000000000001E+100", "1.000000000000000E+100", "1.000000000000001E+100"}},
+	} {
+		p, _ := new(decimal.Big).SetString(test.p)
+		q, _ := new(decimal.Big).SetString(test.q)
+		for m, want := range test.want {
+			ctx := decimal.Context{RoundingMode: decimal.ToNearestEven + decimal.RoundingMode(m)}
+			for _, args := range [][2]*decimal.Big{{p, q}, {q, p}} {
+				z := decimal.WithContext(ctx).Hypot(args[0], args[1])
+				const c = decimal.Inexact | decimal.Rounded
+				if z.String() != want || z.Context.Conditions != c {
+					t.Fatalf("#%d: Hypot(%s, %s) (%s): wanted %s (%s), got %s (%s)",
+						i, args[0], args[1], decimal.ToNearestEven+decimal.RoundingMode(m), want, c, z, z.Context.Conditions)
+				}
+			}
+		}
+	}
+}
+
+func TestBig_Hypot_Exact(t *testing.T) {
+	max, min := strconv.Itoa(decimal.MaxScale-1), strconv.Itoa(decimal.MinScale+1)
+	for i, test := range [...]struct {
+		p, q, want string
+	}{
+		{"3", "4", "5"},
+		{"3.0", "-4", "5.0"},
+		{"0.3", "0.4", "0.5"},
+		{"5E+10", "12E+10", "1.3E+11"},
+		{"-8", "0", "8"},
+		{"0", "1.50", "1.50"},
+		{"0", "-0E-3", "0.000"},
+		{"3E+" + max, "4E+" + max, "5E+" + max},
+		{"3E" + min, "4E" + min, "5E" + min},
+	} {
+		p, _ := new(decimal.Big).SetString(test.p)
+		q, _ := new(decimal.Big).SetString(test.q)
+		z := new(decimal.Big).Hypot(p, q)
+		if z.String() != test.want || z.Context.Conditions != 0 {
+			t.Fatalf("#%d: Hypot(%s, %s): wanted %s, got %s (%s)",
+				i, test.p, test.q, test.want, z, z.Context.Conditions)
+		}
+	}
+}
+
+func TestBig_Hypot_Range(t *testing.T) {
+	// p*p overflows, but the result does not.
+	ctx := decimal.Context{Precision: 16, MaxScale: 100, MinScale: -100}
+	p, _ := decimal.WithContext(ctx).SetString("6E+99")
+	q, _ := decimal.WithContext(ctx).SetString("8E+99")
+	if z := decimal.WithContext(ctx).Mul(p, p); z.Context.Conditions&decimal.Overflow == 0 {
+		t.Fatalf("Mul(%s, %s): wanted Overflow, got %s (%s)", p, p, z, z.Context.Conditions)
+	}
+	if z := decimal.WithContext(ctx).Hypot(p, q); z.String() != "1.0E+100" || z.Context.Conditions != 0 {
+		t.Fatalf("Hypot(%s, %s): wanted 1.0E+100, got %s (%s)", p, q, z, z.Context.Conditions)
+	}
+
+	// The result overflows.
+	p.SetString("9E+100")
+	q.SetString("9E+100")
+	z := decimal.WithContext(ctx).Hypot(p, q)
+	const c = decimal.Overflow | decimal.Inexact | decimal.Rounded
+	if !z.IsInf(+1) || z.Context.Conditions != c {
+		t.Fatalf("Hypot(%s, %s): wanted +Inf (%s), got %s (%s)", p, q, c, z, z.Context.Conditions)
+	}
+
+	// q*q underflows, but the result does not.
+	p.SetString("3E-51")
+	q.SetString("4E-51")
+	if z := decimal.WithContext(ctx).Hypot(p, q); z.String() != "5E-51" || z.Context.Conditions != 0 {
+		t.Fatalf("Hypot(%s, %s): wanted 5E-51, got %s (%s)", p, q, z, z.Context.Conditions)
+	}
+}
+
+func TestBig_Hypot_Special(t *testing.T) {
+	for i, test := range [...]struct {
+		p, q string
+		prec int
+		want string
+		c    decimal.Condition
+	}{
+		{"Inf", "1", 0, "Infinity", 0},
+		{"-Inf", "NaN", 0, "Infinity", 0},
+		{"NaN", "-Inf", 0, "Infinity", 0},
+		{"sNaN", "Inf", 0, "Infinity", 0},
+		{"NaN", "1", 0, "NaN", 0},
+		{"1", "NaN", 0, "NaN", 0},
+		{"sNaN", "1", 0, "NaN", decimal.InvalidOperation},
+		{"6", "8", decimal.UnlimitedPrecision, "10", 0},
+		{"1", "1", decimal.UnlimitedPrecision, "NaN", decimal.InvalidOperation},
+		{"1E+400000000", "1", decimal.UnlimitedPrecision, "NaN", decimal.InvalidOperation},
+	} {
+		p, _ := new(decimal.Big).SetString(test.p)
+		q, _ := new(decimal.Big).SetString(test.q)
+		z := decimal.WithContext(decimal.Context{Precision: test.prec}).Hypot(p, q)
+		got := z.String()
+		if z.IsNaN(0) {
+			got = "NaN"
+		}
+		if got != test.want || z.Context.Conditions != test.c {
+			t.Fatalf("#%d: Hypot(%s, %s): wanted %s (%s), got %s (%s)",
+				i, test.p, test.q, test.want, test.c, z, z.Context.Conditions)
+		}
+	}
+}
+
+func TestBig_Hypot_Random(t *testing.T) {
+	// With ToNearestEven, Hypot must agree with Sqrt of the exact sum of
+	// squares, which is also correctly rounded.
+	rng := rand.New(rand.NewSource(256))
+	u := decimal.Context{Precision: decimal.UnlimitedPrecision}
+	for i := 0; i < 2000; i++ {
+		prec := 1 + rng.Intn(40)
+		ctx := decimal.Context{Precision: prec}
+		p := decimal.New(rng.Int63n(1e12)-5e11, rng.Intn(40)-20)
+		q := decimal.New(rng.Int63n(1e6), rng.Intn(40)-20)
+		var s, t2 decimal.Big
+		u.Add(&s, u.Mul(&s, p, p), u.Mul(&t2, q, q))
+		want := decimal.WithContext(ctx).Sqrt(&s)
+		got := decimal.WithContext(ctx).Hypot(p, q)
+		if got.Cmp(want) != 0 || got.Scale() != want.Scale() ||
+			got.Context.Conditions != want.Context.Conditions {
+			t.Fatalf("#%d: Hypot(%s, %s) (%d digits): wanted %s (%s), got %s (%s)",
+				i, p, q, prec, want, want.Context.Conditions, got, got.Context.Conditions)
+		}
+	}
+}
@@ -0,0 +1,341 @@
+package decimal_test
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestBig_Log(t *testing.T) {
+	// Computed with Python's decimal module at 80 digits and then rounded.
+	for i, test := range [...]struct {
+		x    string
+		want [6]string // indexed by RoundingMode
+	}{
+		{"2", [6]string{"0.6931471805599453", "0.6931471805599453", "0.6931471805599453", "0.6931471805599454", "0.6931471805599453", "0.6931471805599454"}},
+		{"10", [6]string{"2.302585092994046", "2.302585092994046", "2.302585092994045", "2.302585092994046", "2.302585092994045", "2.302585092994046"}},
+		{"0.5", [6]string{"-0.6931471805599453", "-0.6931471805599453", "-0.6931471805599453", "-0.6931471805599454", "-0.6931471805599454", "-0.6931471805599453"}},
+		{"7", [6]string{"1.945910149055313", "1.945910149055313", "1.945910149055313", "1.945910149055314", "1.945910149055313", "1.945910149055314"}},
+		{"123456.789", [6]string{"11.72364648718588", "11.72364648718588", "11.72364648718588", "11.72364648718589", "11.72364648718588", "11.72364648718589"}},
+		{"1E-30", [6]string{"-69.07755278982137", "-69.07755278982137", "-69.07755278982137", "-69.07755278982138", "-69.07755278982138", "-69.07755278982137"}},
+		{"1E+1000", [6]string{"2302.585092994046", "2302.585092994046", "2302.585092994045", "2302.585092994046", "2302.585092994045", "2302.585092994046"}},
+		{"2.718281828459045235360287471352662", [6]string{"1.000000000000000", "1.000000000000000", "0.9999999999999999", "1.000000000000000", "0.9999999999999999", "1.000000000000000"}},
+		// Close to 1.
+		{"0.99", [6]string{"-0.01005033585350144", "-0.01005033585350144", "-0.01005033585350144", "-0.01005033585350145", "-0.01005033585350145", "-0.01005033585350144"}},
+		{"1.0000001", [6]string{"9.999999500000033E-8", "9.999999500000033E-8", "9.999999500000033E-8", "9.999999500000034E-8", "9.999999500000033E-8", "9.999999500000034E-8"}},
+		{"0.9999999", [6]string{"-1.000000050000003E-7", "-1.000000050000003E-7", "-1.000000050000003E-7", "-1.000000050000004E-7", "-1.000000050000004E-7", "-1.000000050000003E-7"}},
+		{"1.000000000000000000000000000000000000001", [6]string{"1.000000000000000E-39", "1.000000000000000E-39", "9.999999999999999E-40", "1.000000000000000E-39", "9.999999999999999E-40", "1.000000000000000E-39"}},
+		{"0.999999999999999999999999999999999999999", [6]string{"-1.000000000000000E-39", "-1.000000000000000E-39", "-1.000000000000000E-39", "-1.000000000000001E-39", "-1.000000000000001E-39", "-1.000000000000000E-39"}},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		for m, want := range test.want {
+			ctx := decimal.Context{RoundingMode: decimal.ToNearestEven + decimal.RoundingMode(m)}
+			z := decimal.WithContext(ctx).Log(x)
+			const c = decimal.Inexact | decimal.Rounded
+			if z.String() != want || z.Context.Conditions != c {
+				t.Fatalf("#%d: Log(%s) (%s): wanted %s (%s), got %s (%s)",
+					i, test.x, decimal.ToNearestEven+decimal.RoundingMode(m), want, c, z, z.Context.Conditions)
+			}
+		}
+	}
+}
+
+// logExtremes returns 1E+MaxScale and 3.3E+MinScale, and their natural
+// logarithms with a precision of 16.
+func logExtremes() (max, maxLog, min, minLog string) {
+	if decimal.MaxScale < 999999999999999999 {
+		return "1E+425000000", "978598664.5224694", "3.3E-425000000", "-978598663.3285469"
+	}
+	return "1E+999999999999999999", "2.302585092994046E+18",
+		"3.3E-999999999999999999", "-2.302585092994046E+18"
+}
+
+func TestBig_Log_Special(t *testing.T) {
+	const r = decimal.Inexact | decimal.Rounded
+	max, maxLog, min, minLog := logExtremes()
+	for i, test := range [...]struct {
+		x, want string
+		c       decimal.Condition
+	}{
+		{"1", "0", 0},
+		{"1.000", "0", 0},
+		{"0", "-Infinity", 0},
+		{"-0", "-Infinity", 0},
+		{"Infinity", "Infinity", 0},
+		{max, maxLog, r},
+		{min, minLog, r},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		z := decimal.WithContext(decimal.Context{OperatingMode: decimal.GDA})
+		z.Log(x)
+		if z.String() != test.want || z.Context.Conditions != test.c {
+			t.Fatalf("#%d: Log(%s): wanted %s (%s), got %s (%s)",
+				i, test.x, test.want, test.c, z, z.Context.Conditions)
+		}
+	}
+
+	for _, test := range [...]struct {
+		x string
+		c decimal.Condition
+	}{
+		{"NaN", 0},
+		{"sNaN", decimal.InvalidOperation},
+		{"-1", decimal.InvalidOperation},
+		{"-1E-999", decimal.InvalidOperation},
+		{"-Infinity", decimal.InvalidOperation},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		z := decimal.WithContext(decimal.Context{OperatingMode: decimal.GDA}).Log(x)
+		if !z.IsNaN(0) || z.Context.Conditions != test.c {
+			t.Fatalf("Log(%s): wanted NaN (%s), got %s (%s)", test.x, test.c, z, z.Context.Conditions)
+		}
+	}
+
+	z := decimal.WithContext(decimal.Context{
+		Precision:     decimal.UnlimitedPrecision,
+		OperatingMode: decimal.GDA,
+	})
+	if z.Log(decimal.New(2, 0)); !z.IsNaN(0) || z.Context.Conditions != decimal.InvalidOperation {
+		t.Fatalf("Log(2) (unlimited precision): wanted NaN (invalid operation), got %s (%s)",
+			z, z.Context.Conditions)
+	}
+	if z.Context.Conditions = 0; z.Log(decimal.New(1, 0)).Sign() != 0 || z.Context.Conditions != 0 {
+		t.Fatalf("Log(1) (unlimited precision): wanted 0, got %s (%s)", z, z.Context.Conditions)
+	}
+}
+
+// TestBig_Log_Random checks that Log agrees with itself at a higher precision,
+// rounded to the lower one.
+func TestBig_Log_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	n := 2000
+	if testing.Short() {
+		n = 200
+	}
+	for i := 0; i < n; i++ {
+		x := decimal.New(1+rng.Int63n(2e9), rng.Intn(24)-6)
+		prec := 1 + rng.Intn(60)
+		m := decimal.ToNearestEven + decimal.RoundingMode(rng.Intn(6))
+
+		hi := decimal.WithPrecision(prec + 25).Log(x)
+		want := decimal.Context{Precision: prec, RoundingMode: m}.Round(hi)
+
+		z := decimal.WithContext(decimal.Context{Precision: prec, RoundingMode: m}).Log(x)
+		if z.Cmp(want) != 0 || z.Precision() != want.Precision() {
+			t.Fatalf("#%d: Log(%s) (%d digits, %s): wanted %s, got %s", i, x, prec, m, want, z)
+		}
+	}
+}
+
+func TestBig_Log10(t *testing.T) {
+	// Computed with Python's decimal module at 90 digits and then rounded.
+	for i, test := range [...]struct {
+		x    string
+		want [6]string // indexed by RoundingMode
+	}{
+		{"2", [6]string{"0.3010299956639812", "0.3010299956639812", "0.3010299956639811", "0.3010299956639812", "0.3010299956639811", "0.3010299956639812"}},
+		{"0.5", [6]string{"-0.3010299956639812", "-0.3010299956639812", "-0.3010299956639811", "-0.3010299956639812", "-0.3010299956639812", "-0.3010299956639811"}},
+		{"12345.6789", [6]string{"4.091514977169270", "4.091514977169270", "4.091514977169270", "4.091514977169271", "4.091514977169270", "4.091514977169271"}},
+		{"7E-20", [6]string{"-19.15490195998574", "-19.15490195998574", "-19.15490195998574", "-19.15490195998575", "-19.15490195998575", "-19.15490195998574"}},
+		{"0.99", [6]string{"-0.004364805402450085", "-0.004364805402450085", "-0.004364805402450084", "-0.004364805402450085", "-0.004364805402450085", "-0.004364805402450084"}},
+		{"1.0000001", [6]string{"4.342944601885292E-8", "4.342944601885292E-8", "4.342944601885291E-8", "4.342944601885292E-8", "4.342944601885291E-8", "4.342944601885292E-8"}},
+		{"10.00000000000000000000000000001", [6]string{"1.000000000000000", "1.000000000000000", "1.000000000000000", "1.000000000000001", "1.000000000000000", "1.000000000000001"}},
+		{"9.999999999999999999999999999", [6]string{"1.000000000000000", "1.000000000000000", "0.9999999999999999", "1.000000000000000", "0.9999999999999999", "1.000000000000000"}},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		for m, want := range test.want {
+			ctx := decimal.Context{RoundingMode: decimal.ToNearestEven + decimal.RoundingMode(m)}
+			z := decimal.WithContext(ctx).Log10(x)
+			const c = decimal.Inexact | decimal.Rounded
+			if z.String() != want || z.Context.Conditions != c {
+				t.Fatalf("#%d: Log10(%s) (%s): wanted %s (%s), got %s (%s)",
+					i, test.x, decimal.ToNearestEven+decimal.RoundingMode(m), want, c, z, z.Context.Conditions)
+			}
+		}
+	}
+}
+
+func TestBig_Log10_Special(t *testing.T) {
+	// The smallest power of ten with an exact 16-digit logarithm.
+	tiny := -999999999
+	if decimal.MinScale > tiny {
+		tiny = decimal.MinScale
+	}
+	for i, test := range [...]struct {
+		x, want string
+		prec    int
+		c       decimal.Condition
+	}{
+		// Powers of ten are exact.
+		{"1", "0", 16, 0},
+		{"1.000", "0", 16, 0},
+		{"10", "1", 16, 0},
+		{"0.001", "-3", 16, 0},
+		{"1E+50", "50", 16, 0},
+		{"1000E+47", "50", 16, 0},
+		{"1E" + strconv.Itoa(tiny), strconv.Itoa(tiny), 16, 0},
+		{"1E+50", "50", decimal.UnlimitedPrecision, 0},
+		{"1E+123", "1.2E+2", 2, decimal.Inexact | decimal.Rounded},
+		{"0", "-Infinity", 16, 0},
+		{"-0", "-Infinity", 16, 0},
+		{"Infinity", "Infinity", 16, 0},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		z := decimal.WithContext(decimal.Context{
+			Precision:     test.prec,
+			OperatingMode: decimal.GDA,
+		})
+		z.Log10(x)
+		if z.String() != test.want || z.Context.Conditions != test.c {
+			t.Fatalf("#%d: Log10(%s): wanted %s (%s), got %s (%s)",
+				i, test.x, test.want, test.c, z, z.Context.Conditions)
+		}
+	}
+
+	for _, test := range [...]struct {
+		x string
+		c decimal.Condition
+	}{
+		{"NaN", 0},
+		{"sNaN", decimal.InvalidOperation},
+		{"-10", decimal.InvalidOperation},
+		{"-Infinity", decimal.InvalidOperation},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		z := decimal.WithContext(decimal.Context{OperatingMode: decimal.GDA}).Log10(x)
+		if !z.IsNaN(0) || z.Context.Conditions != test.c {
+			t.Fatalf("Log10(%s): wanted NaN (%s), got %s (%s)", test.x, test.c, z, z.Context.Conditions)
+		}
+	}
+
+	z := decimal.WithContext(decimal.Context{
+		Precision:     decimal.UnlimitedPrecision,
+		OperatingMode: decimal.GDA,
+	})
+	if z.Log10(decimal.New(2, 0)); !z.IsNaN(0) || z.Context.Conditions != decimal.InvalidOperation {
+		t.Fatalf("Log10(2) (unlimited precision): wanted NaN (invalid operation), got %s (%s)",
+			z, z.Context.Conditions)
+	}
+}
+
+// TestBig_Log10_Random checks that Log10 agrees with itself at a higher
+// precision, rounded to the lower one.
+func TestBig_Log10_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	n := 1000
+	if testing.Short() {
+		n = 100
+	}
+	for i := 0; i < n; i++ {
+		x := decimal.New(1+rng.Int63n(2e9), rng.Intn(24)-6)
+		prec := 1 + rng.Intn(60)
+		m := decimal.ToNearestEven + decimal.RoundingMode(rng.Intn(6))
+
+		hi := decimal.WithPrecision(prec + 25).Log10(x)
+		want := decimal.Context{Precision: prec, RoundingMode: m}.Round(hi)
+
+		z := decimal.WithContext(decimal.Context{Precision: prec, RoundingMode: m}).Log10(x)
+		if z.Cmp(want) != 0 || z.Precision() != want.Precision() {
+			t.Fatalf("#%d: Log10(%s) (%d digits, %s): wanted %s, got %s", i, x, prec, m, want, z)
+		}
+	}
+}
+
+func TestBig_Log1p(t *testing.T) {
+	// Computed with Python's decimal module at 700 digits and then rounded.
+	for i, test := range [...]struct {
+		x    string
+		want [6]string // indexed by RoundingMode
+	}{
+		{"1E-20", [6]string{"1.000000000000000E-20", "1.000000000000000E-20", "9.999999999999999E-21", "1.000000000000000E-20", "9.999999999999999E-21", "1.000000000000000E-20"}},
+		{"-1E-20", [6]string{"-1.000000000000000E-20", "-1.000000000000000E-20", "-1.000000000000000E-20", "-1.000000000000001E-20", "-1.000000000000001E-20", "-1.000000000000000E-20"}},
+		{"0.0000137", [6]string{"0.00001369990615585711", "0.00001369990615585711", "0.00001369990615585710", "0.00001369990615585711", "0.00001369990615585710", "0.00001369990615585711"}},
+		{"1", [6]string{"0.6931471805599453", "0.6931471805599453", "0.6931471805599453", "0.6931471805599454", "0.6931471805599453", "0.6931471805599454"}},
+		{"-0.5", [6]string{"-0.6931471805599453", "-0.6931471805599453", "-0.6931471805599453", "-0.6931471805599454", "-0.6931471805599454", "-0.6931471805599453"}},
+		{"0.05", [6]string{"0.04879016416943200", "0.04879016416943200", "0.04879016416943200", "0.04879016416943201", "0.04879016416943200", "0.04879016416943201"}},
+		{"-0.09", [6]string{"-0.09431067947124133", "-0.09431067947124133", "-0.09431067947124132", "-0.09431067947124133", "-0.09431067947124133", "-0.09431067947124132"}},
+		{"0.1", [6]string{"0.09531017980432486", "0.09531017980432486", "0.09531017980432486", "0.09531017980432487", "0.09531017980432486", "0.09531017980432487"}},
+		{"1E+50", [6]string{"115.1292546497023", "115.1292546497023", "115.1292546497022", "115.1292546497023", "115.1292546497022", "115.1292546497023"}},
+		{"-0.999999999999999999999", [6]string{"-48.35428695287496", "-48.35428695287496", "-48.35428695287495", "-48.35428695287496", "-48.35428695287496", "-48.35428695287495"}},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		for m, want := range test.want {
+			ctx := decimal.Context{RoundingMode: decimal.ToNearestEven + decimal.RoundingMode(m)}
+			z := decimal.WithContext(ctx).Log1p(x)
+			const c = decimal.Inexact | decimal.Rounded
+			if z.String() != want || z.Context.Conditions != c {
+				t.Fatalf("#%d: Log1p(%s) (%s): wanted %s (%s), got %s (%s)",
+					i, test.x, decimal.ToNearestEven+decimal.RoundingMode(m), want, c, z, z.Context.Conditions)
+			}
+		}
+	}
+}
+
+func TestBig_Log1p_Special(t *testing.T) {
+	const r = decimal.Inexact | decimal.Rounded
+	max, maxLog, _, _ := logExtremes()
+	for i, test := range [...]struct {
+		x, want string
+		c       decimal.Condition
+	}{
+		{"0", "0", 0},
+		{"-0.000", "-0.000", 0},
+		{"-1", "-Infinity", 0},
+		{"-1.000", "-Infinity", 0},
+		{"Infinity", "Infinity", 0},
+		{max, maxLog, r},
+		{"-1E-999", "-1.000000000000000E-999", r},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		z := decimal.WithContext(decimal.Context{OperatingMode: decimal.GDA})
+		z.Log1p(x)
+		if z.String() != test.want || z.Context.Conditions != test.c {
+			t.Fatalf("#%d: Log1p(%s): wanted %s (%s), got %s (%s)",
+				i, test.x, test.want, test.c, z, z.Context.Conditions)
+		}
+	}
+
+	for _, test := range [...]struct {
+		x string
+		c decimal.Condition
+	}{
+		{"NaN", 0},
+		{"sNaN", decimal.InvalidOperation},
+		{"-1.0000001", decimal.InvalidOperation},
+		{"-2", decimal.InvalidOperation},
+		{"-Infinity", decimal.InvalidOperation},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		z := decimal.WithContext(decimal.Context{OperatingMode: decimal.GDA}).Log1p(x)
+		if !z.IsNaN(0) || z.Context.Conditions != test.c {
+			t.Fatalf("Log1p(%s): wanted NaN (%s), got %s (%s)", test.x, test.c, z, z.Context.Conditions)
+		}
+	}
+}
+
+// TestBig_Log1p_Random checks that Log1p agrees with Log of 1 + x, computed
+// with enough extra digits to make up for the cancellation.
+func TestBig_Log1p_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	n := 2000
+	if testing.Short() {
+		n = 200
+	}
+	for i := 0; i < n; i++ {
+		x := decimal.New(rng.Int63n(2e9)-1e9, rng.Intn(30)+9)
+		prec := 1 + rng.Intn(60)
+		m := decimal.ToNearestEven + decimal.RoundingMode(rng.Intn(6))
+
+		var s decimal.Big
+		decimal.Context{Precision: decimal.UnlimitedPrecision}.Add(&s, x, decimal.New(1, 0))
+		hi := decimal.WithPrecision(prec + 50).Log(&s)
+		want := decimal.Context{Precision: prec, RoundingMode: m}.Round(hi)
+
+		z := decimal.WithContext(decimal.Context{Precision: prec, RoundingMode: m}).Log1p(x)
+		if z.Cmp(want) != 0 || z.Precision() != want.Precision() {
+			t.Fatalf("#%d: Log1p(%s) (%d digits, %s): wanted %s, got %s", i, x, prec, m, want, z)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package decimal_test
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestBig_Pow(t *testing.T) {
+	// Computed with Python's decimal module at 120 digits and then rounded.
+	for i, test := range [...]struct {
+		x, y string
+		want [6]string // indexed by RoundingMode
+	}{
+		{"2", "0.5", [6]string{"1.414213562373095", "1.414213562373095", "1.414213562373095", "1.414213562373096", "1.414213562373095", "1.414213562373096"}},
+		{"1.05", "0.08333333333333333", [6]string{"1.004074123783648", "1.004074123783648", "1.004074123783648", "1.004074123783649", "1.004074123783648", "1.004074123783649"}},
+		{"8", "0.3333333333", [6]string{"1.999999999861371", "1.999999999861371", "1.999999999861370", "1.999999999861371", "1.999999999861370", "1.999999999861371"}},
+		{"0.5", "-0.5", [6]string{"1.414213562373095", "1.414213562373095", "1.414213562373095", "1.414213562373096", "1.414213562373095", "1.414213562373096"}},
+		{"123.456", "7.89", [6]string{"3.177102825818098E+16", "3.177102825818098E+16", "3.177102825818097E+16", "3.177102825818098E+16", "3.177102825818097E+16", "3.177102825818098E+16"}},
+		{"0.9999", "-12345.5", [6]string{"3.437043766169768", "3.437043766169768", "3.437043766169767", "3.437043766169768", "3.437043766169767", "3.437043766169768"}},
+		{"1.000000000001", "0.5", [6]string{"1.000000000000500", "1.000000000000500", "1.000000000000499", "1.000000000000500", "1.000000000000499", "1.000000000000500"}},
+		{"2", "1E-30", [6]string{"1.000000000000000", "1.000000000000000", "1.000000000000000", "1.000000000000001", "1.000000000000000", "1.000000000000001"}},
+		{"3", "100", [6]string{"5.153775207320113E+47", "5.153775207320113E+47", "5.153775207320113E+47", "5.153775207320114E+47", "5.153775207320113E+47", "5.153775207320114E+47"}},
+		{"7", "-7", [6]string{"0.000001214265678902012", "0.000001214265678902012", "0.000001214265678902012", "0.000001214265678902013", "0.000001214265678902012", "0.000001214265678902013"}},
+		{"1.0001", "10000", [6]string{"2.718145926825225", "2.718145926825225", "2.718145926825224", "2.718145926825225", "2.718145926825224", "2.718145926825225"}},
+		{"-3", "-5", [6]string{"-0.004115226337448560", "-0.004115226337448560", "-0.004115226337448559", "-0.004115226337448560", "-0.004115226337448560", "-0.004115226337448559"}},
+		{"2", "1E+8", [6]string{"3.684665936980459E+30102999", "3.684665936980459E+30102999", "3.684665936980458E+30102999", "3.684665936980459E+30102999", "3.684665936980458E+30102999", "3.684665936980459E+30102999"}},
+		{"1E+10", "0.25", [6]string{"316.2277660168379", "316.2277660168379", "316.2277660168379", "316.2277660168380", "316.2277660168379", "316.2277660168380"}},
+		{"27", "0.3333333333333333333333333333333333333333333333333333333333", [6]string{"3.000000000000000", "3.000000000000000", "2.999999999999999", "3.000000000000000", "2.999999999999999", "3.000000000000000"}},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		y, _ := new(decimal.Big).SetString(test.y)
+		for m, want := range test.want {
+			ctx := decimal.Context{RoundingMode: decimal.ToNearestEven + decimal.RoundingMode(m)}
+			z := decimal.WithContext(ctx).Pow(x, y)
+			const c = decimal.Inexact | decimal.Rounded
+			if z.String() != want || z.Context.Conditions != c {
+				t.Fatalf("#%d: Pow(%s, %s) (%s): wanted %s (%s), got %s (%s)",
+					i, test.x, test.y, decimal.ToNearestEven+decimal.RoundingMode(m), want, c, z, z.Context.Conditions)
+			}
+		}
+	}
+}
+
+// TestBig_Pow_Exact checks exact powers, which are the same in every
+// RoundingMode.
+func TestBig_Pow_Exact(t *testing.T) {
+	const r = decimal.Inexact | decimal.Rounded
+	for i, test := range [...]struct {
+		x, y, want string
+		c          decimal.Condition
+	}{
+		// Integer powers are exact if they fit.
+		{"1.50", "2", "2.2500", 0},
+		{"2", "-2", "0.25", 0},
+		{"1.0", "-2", "1", 0},
+		{"10", "-3", "0.001", 0},
+		{"-2", "3", "-8", 0},
+		{"-2", "-3", "-0.125", 0},
+		{"-1.5", "11", "-86.49755859375", 0},
+		{"1.000", "100", "1.000000000000000", 0},
+		{"5", "1", "5", 0},
+		{"12E+3", "2", "1.44E+8", 0},
+
+		// Non-integer powers are padded and inexact, even if they're exact.
+		{"4", "0.5", "2.000000000000000", r},
+		{"100", "0.5", "10.00000000000000", r},
+		{"0.25", "1.5", "0.1250000000000000", r},
+		{"4E-6", "0.5", "0.002000000000000000", r},
+		{"16", "0.25", "2.000000000000000", r},
+		{"1E+10", "0.1", "10.00000000000000", r},
+		{"1", "0.5", "1.000000000000000", r},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		y, _ := new(decimal.Big).SetString(test.y)
+		for m := decimal.ToNearestEven; m <= decimal.ToPositiveInf; m++ {
+			z := decimal.WithContext(decimal.Context{RoundingMode: m}).Pow(x, y)
+			if z.String() != test.want || z.Context.Conditions != test.c {
+				t.Fatalf("#%d: Pow(%s, %s) (%s): wanted %s (%s), got %s (%s)",
+					i, test.x, test.y, m, test.want, test.c, z, z.Context.Conditions)
+			}
+		}
+	}
+}
+
+func TestBig_Pow_Special(t *testing.T) {
+	const (
+		r = decimal.Inexact | decimal.Rounded
+		u = r | decimal.Subnormal | decimal.Underflow | decimal.Clamped
+	)
+	// Etiny, the exponent of the smallest subnormal, with a precision of 16.
+	tiny := "0E" + strconv.Itoa(decimal.MinScale-15)
+	for i, test := range [...]struct {
+		x, y, want string
+		c          decimal.Condition
+	}{
+		{"0", "2", "0", 0},
+		{"-0", "3", "-0", 0},
+		{"-0", "2", "0", 0},
+		{"0", "-3", "Infinity", 0},
+		{"-0", "-3", "-Infinity", 0},
+		{"-0", "-2", "Infinity", 0},
+		{"-0", "0.5", "0", 0},
+		{"0", "Infinity", "0", 0},
+		{"0", "-Infinity", "Infinity", 0},
+		{"Infinity", "0", "1", 0},
+		{"Infinity", "2", "Infinity", 0},
+		{"-Infinity", "3", "-Infinity", 0},
+		{"-Infinity", "2", "Infinity", 0},
+		{"-Infinity", "-3", "-0", 0},
+		{"Infinity", "-0.5", "0", 0},
+		{"1", "Infinity", "1.000000000000000", r},
+		{"1", "-Infinity", "1.000000000000000", r},
+		{"0.5", "Infinity", "0", 0},
+		{"0.5", "-Infinity", "Infinity", 0},
+		{"2", "Infinity", "Infinity", 0},
+		{"2", "-Infinity", "0", 0},
+		{"5", "0", "1", 0},
+		{"-5", "-0", "1", 0},
+		{"1", "1E+40", "1", 0},
+		{"-1", "-1E+40", "1", 0},
+		{"1.0", "1E+40", "1.000000000000000", 0},
+		{"10", "1E+30", "Infinity", decimal.Overflow | r},
+		{"-10", "1E+18", "Infinity", decimal.Overflow | r},
+		{"2", "1E+30", "Infinity", decimal.Overflow | r},
+		{"10", "-1E+40", tiny, u},
+		{"0.1", "1E+30", tiny, u},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		y, _ := new(decimal.Big).SetString(test.y)
+		z := decimal.WithContext(decimal.Context{OperatingMode: decimal.GDA}).Pow(x, y)
+		if z.String() != test.want || z.Context.Conditions != test.c {
+			t.Fatalf("#%d: Pow(%s, %s): wanted %s (%s), got %s (%s)",
+				i, test.x, test.y, test.want, test.c, z, z.Context.Conditions)
+		}
+	}
+
+	for _, test := range [...]struct {
+		x, y string
+		c    decimal.Condition
+	}{
+		{"NaN", "2", 0},
+		{"2", "sNaN", decimal.InvalidOperation},
+		{"0", "0", decimal.InvalidOperation},
+		{"-0", "-0", decimal.InvalidOperation},
+		{"-2", "0.5", decimal.InvalidOperation},
+		{"-Infinity", "0.5", decimal.InvalidOperation},
+		{"-2", "Infinity", decimal.InvalidOperation},
+		{"-0.5", "-Infinity", decimal.InvalidOperation},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		y, _ := new(decimal.Big).SetString(test.y)
+		z := decimal.WithContext(decimal.Context{OperatingMode: decimal.GDA}).Pow(x, y)
+		if !z.IsNaN(0) || z.Context.Conditions != test.c {
+			t.Fatalf("Pow(%s, %s): wanted NaN (%s), got %s (%s)", test.x, test.y, test.c, z, z.Context.Conditions)
+		}
+	}
+
+	// With unlimited precision, only integer powers can be computed.
+	z := decimal.WithContext(decimal.Context{
+		Precision:     decimal.UnlimitedPrecision,
+		OperatingMode: decimal.GDA,
+	})
+	if z.Pow(decimal.New(4, 0), decimal.New(5, 1)); !z.IsNaN(0) || z.Context.Conditions != decimal.InvalidOperation {
+		t.Fatalf("Pow(4, 0.5) (unlimited precision): wanted NaN (invalid operation), got %s (%s)",
+			z, z.Context.Conditions)
+	}
+	z.Context.Conditions = 0
+	if z.Pow(decimal.New(3, 0), decimal.New(40, 0)); z.String() != "12157665459056928801" || z.Context.Conditions != 0 {
+		t.Fatalf("Pow(3, 40) (unlimited precision): wanted 12157665459056928801, got %s (%s)",
+			z, z.Context.Conditions)
+	}
+}
+
+// TestBig_Pow_Random checks that Pow agrees with itself at a higher precision,
+// rounded to the lower one, and that integer powers agree with repeated
+// multiplication.
+func TestBig_Pow_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	n := 1000
+	if testing.Short() {
+		n = 100
+	}
+	for i := 0; i < n; i++ {
+		x := decimal.New(1+rng.Int63n(2e6), rng.Intn(8))
+		y := decimal.New(rng.Int63n(2e4)-1e4, rng.Intn(5))
+		prec := 1 + rng.Intn(40)
+		m := decimal.ToNearestEven + decimal.RoundingMode(rng.Intn(6))
+
+		hi := decimal.WithPrecision(prec+25).Pow(x, y)
+		want := decimal.Context{Precision: prec, RoundingMode: m}.Round(hi)
+
+		z := decimal.WithContext(decimal.Context{Precision: prec, RoundingMode: m}).Pow(x, y)
+		if z.Cmp(want) != 0 {
+			t.Fatalf("#%d: Pow(%s, %s) (%d digits, %s): wanted %s, got %s", i, x, y, prec, m, want, z)
+		}
+	}
+
+	ctx := decimal.Context{Precision: decimal.UnlimitedPrecision}
+	for i := 0; i < 100; i++ {
+		x := decimal.New(rng.Int63n(2e6)-1e6, rng.Intn(8))
+		k := rng.Intn(30)
+		want := decimal.New(1, 0)
+		for j := 0; j < k; j++ {
+			ctx.Mul(want, want, x)
+		}
+		z := decimal.WithContext(ctx).Pow(x, decimal.New(int64(k), 0))
+		if z.Cmp(want) != 0 || z.Scale() != want.Scale() {
+			t.Fatalf("#%d: Pow(%s, %d): wanted %s, got %s", i, x, k, want, z)
+		}
+	}
+}
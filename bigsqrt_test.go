@@ -0,0 +1,133 @@
+package decimal_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+// sqrtCase is a single test case generated by _testdata/squareroot.py from the
+// GDA square root test vectors.
+type sqrtCase struct {
+	ID    string `json:"id"`
+	Prec  int    `json:"prec"`
+	Emax  int    `json:"emax"`
+	Emin  int    `json:"emin"`
+	X     string `json:"x"`
+	R     string `json:"r"`
+	Conds string `json:"conds"`
+}
+
+func TestBig_Sqrt_GDA(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.Join("_testdata", "squareroot.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cases []sqrtCase
+	if err := json.Unmarshal(data, &cases); err != nil {
+		t.Fatal(err)
+	}
+
+	n := 0
+	for _, c := range cases {
+		if c.Prec == 34 {
+			n++
+		}
+		ctx := decimal.Context{
+			Precision: c.Prec,
+			MaxScale:  c.Emax,
+			MinScale:  c.Emin,
+		}
+		var want decimal.Condition
+		for j := 0; j < len(c.Conds); j++ {
+			want |= conformanceConds[c.Conds[j]]
+		}
+		x, _ := new(decimal.Big).SetString(c.X)
+		z := decimal.WithContext(ctx).Sqrt(x)
+		got := z.String()
+		if z.IsNaN(0) {
+			// NaN results carry a payload, but the vectors' do not.
+			got = "NaN"
+		}
+		if got != c.R || z.Context.Conditions != want {
+			t.Fatalf(`%s: Sqrt(%s) (%d digits):
+wanted: %s (%s)
+got   : %s (%s)`, c.ID, c.X, c.Prec, c.R, want, z, z.Context.Conditions)
+		}
+	}
+	if n == 0 {
+		t.Fatal("no cases at precision 34")
+	}
+}
+
+func TestBig_Sqrt_Exact(t *testing.T) {
+	for i, test := range [...]struct {
+		x, want string
+	}{
+		{"1.21", "1.1"},
+		{"4E+10", "2E+5"},
+		{"4E+11", "6.32455532033675866399778708886543707E+5"},
+		{"0.01", "0.1"},
+		{"100", "10"},
+		{"1.0000", "1.00"},
+		{"152.2756", "12.34"},
+		{"1E-1000", "1E-500"},
+		{"99999999999999999999999999999999980000000000000000000000000000000001", "9999999999999999999999999999999999"},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		for m := decimal.ToNearestEven; m <= decimal.ToPositiveInf; m++ {
+			ctx := decimal.Context{Precision: 34, RoundingMode: m}
+			z := decimal.WithContext(ctx).Sqrt(x)
+			if test.x == "4E+11" {
+				// Not exact, so always rounded using ToNearestEven.
+				if z.String() != "632455.5320336758663997787088865437" ||
+					z.Context.Conditions != decimal.Inexact|decimal.Rounded {
+					t.Fatalf("#%d: Sqrt(%s) (%s): got %s (%s)",
+						i, test.x, m, z, z.Context.Conditions)
+				}
+				continue
+			}
+			if z.String() != test.want || z.Context.Conditions != 0 {
+				t.Fatalf("#%d: Sqrt(%s) (%s): wanted %s, got %s (%s)",
+					i, test.x, m, test.want, z, z.Context.Conditions)
+			}
+		}
+	}
+}
+
+func TestBig_Sqrt_Special(t *testing.T) {
+	for i, test := range [...]struct {
+		x    string
+		prec int
+		want string
+		c    decimal.Condition
+	}{
+		{"-0", 34, "-0", 0},
+		{"-0E+3", 34, "-0E+1", 0},
+		{"0E-3", 34, "0.00", 0},
+		{"Inf", 34, "Infinity", 0},
+		{"-Inf", 34, "NaN", decimal.InvalidOperation},
+		{"-1", 34, "NaN", decimal.InvalidOperation},
+		{"-1E-1000", 34, "NaN", decimal.InvalidOperation},
+		{"NaN", 34, "NaN", 0},
+		{"sNaN", 34, "NaN", decimal.InvalidOperation},
+		{"1.44", decimal.UnlimitedPrecision, "1.2", 0},
+		{"4E+100", decimal.UnlimitedPrecision, "2E+50", 0},
+		{"4E+101", decimal.UnlimitedPrecision, "NaN", decimal.InvalidOperation},
+		{"2", decimal.UnlimitedPrecision, "NaN", decimal.InvalidOperation},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		z := decimal.WithContext(decimal.Context{Precision: test.prec}).Sqrt(x)
+		got := z.String()
+		if z.IsNaN(0) {
+			got = "NaN"
+		}
+		if got != test.want || z.Context.Conditions != test.c {
+			t.Fatalf("#%d: Sqrt(%s): wanted %s (%s), got %s (%s)",
+				i, test.x, test.want, test.c, z, z.Context.Conditions)
+		}
+	}
+}
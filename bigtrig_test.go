@@ -0,0 +1,295 @@
+package decimal_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+// trigFuncs maps the names of the trigonometric functions to their methods.
+var trigFuncs = map[string]func(decimal.Context, *decimal.Big, *decimal.Big) *decimal.Big{
+	"sin": decimal.Context.Sin,
+	"cos": decimal.Context.Cos,
+	"tan": decimal.Context.Tan,
+}
+
+func TestBig_Trig(t *testing.T) {
+	// Computed with Python's decimal module at 400 digits, using Machin's
+	// formula for π and Taylor series, and then rounded.
+	for i, test := range [...]struct {
+		x, fn string
+		want  [6]string // indexed by RoundingMode
+	}{
+		{"1", "sin", [6]string{"0.8414709848078965", "0.8414709848078965", "0.8414709848078965", "0.8414709848078966", "0.8414709848078965", "0.8414709848078966"}},
+		{"1", "cos", [6]string{"0.5403023058681397", "0.5403023058681397", "0.5403023058681397", "0.5403023058681398", "0.5403023058681397", "0.5403023058681398"}},
+		{"1", "tan", [6]string{"1.557407724654902", "1.557407724654902", "1.557407724654902", "1.557407724654903", "1.557407724654902", "1.557407724654903"}},
+		{"-3", "sin", [6]string{"-0.1411200080598672", "-0.1411200080598672", "-0.1411200080598672", "-0.1411200080598673", "-0.1411200080598673", "-0.1411200080598672"}},
+		{"-3", "cos", [6]string{"-0.9899924966004455", "-0.9899924966004455", "-0.9899924966004454", "-0.9899924966004455", "-0.9899924966004455", "-0.9899924966004454"}},
+		{"-3", "tan", [6]string{"0.1425465430742778", "0.1425465430742778", "0.1425465430742778", "0.1425465430742779", "0.1425465430742778", "0.1425465430742779"}},
+		{"0.5", "sin", [6]string{"0.4794255386042030", "0.4794255386042030", "0.4794255386042030", "0.4794255386042031", "0.4794255386042030", "0.4794255386042031"}},
+		{"0.5", "cos", [6]string{"0.8775825618903727", "0.8775825618903727", "0.8775825618903727", "0.8775825618903728", "0.8775825618903727", "0.8775825618903728"}},
+		{"0.5", "tan", [6]string{"0.5463024898437905", "0.5463024898437905", "0.5463024898437905", "0.5463024898437906", "0.5463024898437905", "0.5463024898437906"}},
+		{"3.14159", "sin", [6]string{"0.000002653589793235348", "0.000002653589793235348", "0.000002653589793235348", "0.000002653589793235349", "0.000002653589793235348", "0.000002653589793235349"}},
+		{"3.14159", "cos", [6]string{"-0.9999999999964792", "-0.9999999999964792", "-0.9999999999964792", "-0.9999999999964793", "-0.9999999999964793", "-0.9999999999964792"}},
+		{"3.14159", "tan", [6]string{"-0.000002653589793244691", "-0.000002653589793244691", "-0.000002653589793244691", "-0.000002653589793244692", "-0.000002653589793244692", "-0.000002653589793244691"}},
+		{"1E+10", "sin", [6]string{"-0.4875060250875107", "-0.4875060250875107", "-0.4875060250875106", "-0.4875060250875107", "-0.4875060250875107", "-0.4875060250875106"}},
+		{"1E+10", "cos", [6]string{"0.8731196226768560", "0.8731196226768560", "0.8731196226768560", "0.8731196226768561", "0.8731196226768560", "0.8731196226768561"}},
+		{"1E+10", "tan", [6]string{"-0.5583496378112418", "-0.5583496378112418", "-0.5583496378112418", "-0.5583496378112419", "-0.5583496378112419", "-0.5583496378112418"}},
+		{"1E-5", "sin", [6]string{"0.000009999999999833333", "0.000009999999999833333", "0.000009999999999833333", "0.000009999999999833334", "0.000009999999999833333", "0.000009999999999833334"}},
+		{"1E-5", "cos", [6]string{"0.9999999999500000", "0.9999999999500000", "0.9999999999500000", "0.9999999999500001", "0.9999999999500000", "0.9999999999500001"}},
+		{"1E-5", "tan", [6]string{"0.00001000000000033333", "0.00001000000000033333", "0.00001000000000033333", "0.00001000000000033334", "0.00001000000000033333", "0.00001000000000033334"}},
+		{"710", "sin", [6]string{"0.00006028870669158527", "0.00006028870669158527", "0.00006028870669158526", "0.00006028870669158527", "0.00006028870669158526", "0.00006028870669158527"}},
+		{"710", "cos", [6]string{"0.9999999981826359", "0.9999999981826359", "0.9999999981826359", "0.9999999981826360", "0.9999999981826359", "0.9999999981826360"}},
+		{"710", "tan", [6]string{"0.00006028870680115180", "0.00006028870680115180", "0.00006028870680115179", "0.00006028870680115180", "0.00006028870680115179", "0.00006028870680115180"}},
+		{"-7.853981633974483", "sin", [6]string{"-1.000000000000000", "-1.000000000000000", "-0.9999999999999999", "-1.000000000000000", "-1.000000000000000", "-0.9999999999999999"}},
+		{"-7.853981633974483", "cos", [6]string{"9.615660845819876E-17", "9.615660845819876E-17", "9.615660845819875E-17", "9.615660845819876E-17", "9.615660845819875E-17", "9.615660845819876E-17"}},
+		{"-7.853981633974483", "tan", [6]string{"-1.039970123774405E+16", "-1.039970123774405E+16", "-1.039970123774405E+16", "-1.039970123774406E+16", "-1.039970123774406E+16", "-1.039970123774405E+16"}},
+		{"123456789012345", "sin", [6]string{"-0.5986572942477425", "-0.5986572942477425", "-0.5986572942477425", "-0.5986572942477426", "-0.5986572942477426", "-0.5986572942477425"}},
+		{"123456789012345", "cos", [6]string{"0.8010052709214664", "0.8010052709214664", "0.8010052709214664", "0.8010052709214665", "0.8010052709214664", "0.8010052709214665"}},
+		{"123456789012345", "tan", [6]string{"-0.7473824654849708", "-0.7473824654849708", "-0.7473824654849707", "-0.7473824654849708", "-0.7473824654849708", "-0.7473824654849707"}},
+		{"2.5E-3", "sin", [6]string{"0.002499997395834147", "0.002499997395834147", "0.002499997395834147", "0.002499997395834148", "0.002499997395834147", "0.002499997395834148"}},
+		{"2.5E-3", "cos", [6]string{"0.9999968750016276", "0.9999968750016276", "0.9999968750016276", "0.9999968750016277", "0.9999968750016276", "0.9999968750016277"}},
+		{"2.5E-3", "tan", [6]string{"0.002500005208346354", "0.002500005208346354", "0.002500005208346354", "0.002500005208346355", "0.002500005208346354", "0.002500005208346355"}},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		for m, want := range test.want {
+			ctx := decimal.Context{RoundingMode: decimal.ToNearestEven + decimal.RoundingMode(m)}
+			z := trigFuncs[test.fn](ctx, new(decimal.Big), x)
+			const c = decimal.Inexact | decimal.Rounded
+			if z.String() != want || z.Context.Conditions != c {
+				t.Fatalf("#%d: %s(%s) (%s): wanted %s (%s), got %s (%s)",
+					i, test.fn, x, decimal.ToNearestEven+decimal.RoundingMode(m), want, c, z, z.Context.Conditions)
+			}
+		}
+	}
+}
+
+func TestBig_Trig_Exact(t *testing.T) {
+	for i, test := range [...]struct {
+		x, fn, want string
+	}{
+		{"0", "sin", "0"},
+		{"-0", "sin", "-0"},
+		{"0E-5", "sin", "0.00000"},
+		{"-0E+3", "tan", "-0E+3"},
+		{"0", "cos", "1"},
+		{"-0E-10", "cos", "1"},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		z := trigFuncs[test.fn](decimal.Context{}, new(decimal.Big), x)
+		if z.String() != test.want || z.Context.Conditions != 0 {
+			t.Fatalf("#%d: %s(%s): wanted %s, got %s (%s)",
+				i, test.fn, x, test.want, z, z.Context.Conditions)
+		}
+	}
+}
+
+func TestBig_Trig_Tiny(t *testing.T) {
+	// Arguments close enough to zero that the first term of each series
+	// determines the result.
+	for i, test := range [...]struct {
+		x, fn string
+		m     decimal.RoundingMode
+		want  string
+	}{
+		{"1E-20", "sin", decimal.ToNearestEven, "1.000000000000000E-20"},
+		{"1E-20", "sin", decimal.ToZero, "9.999999999999999E-21"},
+		{"-1E-20", "sin", decimal.AwayFromZero, "-1.000000000000000E-20"},
+		{"1E-20", "tan", decimal.ToZero, "1.000000000000000E-20"},
+		{"1E-20", "tan", decimal.ToPositiveInf, "1.000000000000001E-20"},
+		{"-1.5E-30", "tan", decimal.ToNegativeInf, "-1.500000000000001E-30"},
+		{"1E-20", "cos", decimal.ToNearestEven, "1.000000000000000"},
+		{"-1E-20", "cos", decimal.ToZero, "0.9999999999999999"},
+		{"1E-20", "cos", decimal.ToPositiveInf, "1.000000000000000"},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		ctx := decimal.Context{RoundingMode: test.m}
+		z := trigFuncs[test.fn](ctx, new(decimal.Big), x)
+		const c = decimal.Inexact | decimal.Rounded
+		if z.String() != test.want || z.Context.Conditions != c {
+			t.Fatalf("#%d: %s(%s) (%s): wanted %s (%s), got %s (%s)",
+				i, test.fn, x, test.m, test.want, c, z, z.Context.Conditions)
+		}
+	}
+}
+
+func TestBig_Trig_Special(t *testing.T) {
+	for i, test := range [...]struct {
+		x    string
+		prec int
+	}{
+		{"Inf", 0},
+		{"-Inf", 0},
+		{"sNaN", 0},
+		{"1E+16", 16},
+		{"-1.5E+20", 16},
+		{"123456", 5},
+		{"1", decimal.UnlimitedPrecision},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		for name, fn := range trigFuncs {
+			ctx := decimal.Context{Precision: test.prec, OperatingMode: decimal.GDA}
+			z := fn(ctx, new(decimal.Big), x)
+			if !z.IsNaN(0) || z.Context.Conditions&decimal.InvalidOperation == 0 {
+				t.Fatalf("#%d: %s(%s): wanted NaN (%s), got %s (%s)",
+					i, name, x, decimal.InvalidOperation, z, z.Context.Conditions)
+			}
+		}
+	}
+}
+
+func TestBig_Trig_Random(t *testing.T) {
+	// sin(x)**2 + cos(x)**2 = 1 and tan(x) = sin(x) / cos(x), to within the
+	// errors of the correctly rounded results at 50 digits.
+	rng := rand.New(rand.NewSource(1))
+	ctx := decimal.Context{Precision: 50}
+	tol := decimal.New(1, 47)
+	for i := 0; i < 500; i++ {
+		x := decimal.New(rng.Int63n(1e15)-5e14, rng.Intn(20))
+		s := ctx.Sin(new(decimal.Big), x)
+		c := ctx.Cos(new(decimal.Big), x)
+		tn := ctx.Tan(new(decimal.Big), x)
+
+		hi := decimal.Context{Precision: 120}
+		sum := hi.Add(new(decimal.Big),
+			hi.Mul(new(decimal.Big), s, s), hi.Mul(new(decimal.Big), c, c))
+		one := decimal.New(1, 0)
+		if d := hi.Sub(new(decimal.Big), sum, one); d.CmpAbs(tol) > 0 {
+			t.Fatalf("#%d: sin(%s)**2 + cos(%s)**2 = %s", i, x, x, sum)
+		}
+		q := ctx.Quo(new(decimal.Big), s, c)
+		if d := hi.Quo(new(decimal.Big), hi.Sub(new(decimal.Big), q, tn), tn); d.CmpAbs(tol) > 0 {
+			t.Fatalf("#%d: tan(%s) = %s, but sin/cos = %s", i, x, tn, q)
+		}
+	}
+}
+
+func TestBig_Atan2(t *testing.T) {
+	// Computed as for TestBig_Trig, using argument halving and the Taylor
+	// series for the arctangent.
+	for i, test := range [...]struct {
+		y, x string
+		want [6]string // indexed by RoundingMode
+	}{
+		{"1", "1", [6]string{"0.7853981633974483", "0.7853981633974483", "0.7853981633974483", "0.7853981633974484", "0.7853981633974483", "0.7853981633974484"}},
+		{"1", "-1", [6]string{"2.356194490192345", "2.356194490192345", "2.356194490192344", "2.356194490192345", "2.356194490192344", "2.356194490192345"}},
+		{"-0.5", "3", [6]string{"-0.1651486774146268", "-0.1651486774146268", "-0.1651486774146268", "-0.1651486774146269", "-0.1651486774146269", "-0.1651486774146268"}},
+		{"2E-30", "-7", [6]string{"3.141592653589793", "3.141592653589793", "3.141592653589793", "3.141592653589794", "3.141592653589793", "3.141592653589794"}},
+		{"-1E+20", "3", [6]string{"-1.570796326794897", "-1.570796326794897", "-1.570796326794896", "-1.570796326794897", "-1.570796326794897", "-1.570796326794896"}},
+		{"-3", "-4", [6]string{"-2.498091544796509", "-2.498091544796509", "-2.498091544796508", "-2.498091544796509", "-2.498091544796509", "-2.498091544796508"}},
+		{"1E-30", "1", [6]string{"1.000000000000000E-30", "1.000000000000000E-30", "9.999999999999999E-31", "1.000000000000000E-30", "9.999999999999999E-31", "1.000000000000000E-30"}},
+		{"-0.1", "1", [6]string{"-0.09966865249116203", "-0.09966865249116203", "-0.09966865249116202", "-0.09966865249116203", "-0.09966865249116203", "-0.09966865249116202"}},
+		{"123.456", "0.001", [6]string{"1.570788226743056", "1.570788226743056", "1.570788226743056", "1.570788226743057", "1.570788226743056", "1.570788226743057"}},
+	} {
+		y, _ := new(decimal.Big).SetString(test.y)
+		x, _ := new(decimal.Big).SetString(test.x)
+		for m, want := range test.want {
+			ctx := decimal.Context{RoundingMode: decimal.ToNearestEven + decimal.RoundingMode(m)}
+			z := ctx.Atan2(new(decimal.Big), y, x)
+			const c = decimal.Inexact | decimal.Rounded
+			if z.String() != want || z.Context.Conditions != c {
+				t.Fatalf("#%d: Atan2(%s, %s) (%s): wanted %s (%s), got %s (%s)",
+					i, y, x, decimal.ToNearestEven+decimal.RoundingMode(m), want, c, z, z.Context.Conditions)
+			}
+			if x.Cmp(decimal.New(1, 0)) == 0 {
+				if z := ctx.Atan(new(decimal.Big), y); z.String() != want {
+					t.Fatalf("#%d: Atan(%s) (%s): wanted %s, got %s",
+						i, y, decimal.ToNearestEven+decimal.RoundingMode(m), want, z)
+				}
+			}
+		}
+	}
+}
+
+func TestBig_Atan2_Special(t *testing.T) {
+	const (
+		pi  = "3.141592653589793"
+		pi2 = "1.570796326794897"
+		pi4 = "0.7853981633974483"
+		pi3 = "2.356194490192345"
+	)
+	for i, test := range [...]struct {
+		y, x, want string
+		exact      bool
+	}{
+		{"0", "1", "0", true},
+		{"-0", "1", "-0", true},
+		{"0", "0", "0", true},
+		{"-0", "0", "-0", true},
+		{"0", "-0", pi, false},
+		{"-0", "-0", "-" + pi, false},
+		{"0", "-1", pi, false},
+		{"-0", "-1", "-" + pi, false},
+		{"2", "0", pi2, false},
+		{"-2", "-0", "-" + pi2, false},
+		{"Inf", "Inf", pi4, false},
+		{"-Inf", "Inf", "-" + pi4, false},
+		{"Inf", "-Inf", pi3, false},
+		{"-Inf", "-Inf", "-" + pi3, false},
+		{"5", "Inf", "0", true},
+		{"-5", "Inf", "-0", true},
+		{"5", "-Inf", pi, false},
+		{"-5", "-Inf", "-" + pi, false},
+		{"Inf", "5", pi2, false},
+		{"-Inf", "-0", "-" + pi2, false},
+	} {
+		y, _ := new(decimal.Big).SetString(test.y)
+		x, _ := new(decimal.Big).SetString(test.x)
+		z := new(decimal.Big).Atan2(y, x)
+		var c decimal.Condition
+		if !test.exact {
+			c = decimal.Inexact | decimal.Rounded
+		}
+		if z.String() != test.want || z.Context.Conditions != c {
+			t.Fatalf("#%d: Atan2(%s, %s): wanted %s (%s), got %s (%s)",
+				i, y, x, test.want, c, z, z.Context.Conditions)
+		}
+	}
+
+	for _, s := range [...]string{"Inf", "-Inf"} {
+		x, _ := new(decimal.Big).SetString(s)
+		want := pi2
+		if x.Signbit() {
+			want = "-" + pi2
+		}
+		if z := new(decimal.Big).Atan(x); z.String() != want {
+			t.Fatalf("Atan(%s): wanted %s, got %s", x, want, z)
+		}
+	}
+	for _, s := range [...]string{"0", "-0E-7"} {
+		x, _ := new(decimal.Big).SetString(s)
+		if z := new(decimal.Big).Atan(x); z.Cmp(x) != 0 || z.Signbit() != x.Signbit() ||
+			z.Context.Conditions != 0 {
+			t.Fatalf("Atan(%s): wanted %s, got %s (%s)", x, x, z, z.Context.Conditions)
+		}
+	}
+
+	ctx := decimal.Context{OperatingMode: decimal.GDA}
+	nan, _ := new(decimal.Big).SetString("sNaN")
+	if z := ctx.Atan2(new(decimal.Big), nan, decimal.New(1, 0)); !z.IsNaN(0) {
+		t.Fatalf("Atan2(sNaN, 1): wanted NaN, got %s", z)
+	}
+	ctx.Precision = decimal.UnlimitedPrecision
+	if z := ctx.Atan(new(decimal.Big), decimal.New(1, 0)); !z.IsNaN(0) ||
+		z.Context.Conditions&decimal.InvalidOperation == 0 {
+		t.Fatalf("Atan(1) (unlimited): wanted NaN, got %s (%s)", z, z.Context.Conditions)
+	}
+}
+
+func TestBig_Atan_Random(t *testing.T) {
+	// atan(tan(x)) = x for |x| < π/2, to within the errors of the correctly
+	// rounded results at 50 digits.
+	rng := rand.New(rand.NewSource(1))
+	ctx := decimal.Context{Precision: 50}
+	for i := 0; i < 500; i++ {
+		x := decimal.New(rng.Int63n(3e15)-15e14, 15+rng.Intn(10))
+		tn := ctx.Tan(new(decimal.Big), x)
+		z := ctx.Atan(new(decimal.Big), tn)
+		hi := decimal.Context{Precision: 120}
+		d := hi.Quo(new(decimal.Big), hi.Sub(new(decimal.Big), z, x), x)
+		if d.CmpAbs(decimal.New(1, 47)) > 0 {
+			t.Fatalf("#%d: atan(tan(%s)) = %s", i, x, z)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package decimal
+
+import (
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/big"
+
+	cst "github.com/ericlagergren/decimal/internal/c"
+)
+
+// binaryVersion is the version of the encoding produced by MarshalBinary.
+const binaryVersion = 1
+
+// Bits of the second byte of the binary encoding. The low two bits hold the
+// form.
+const (
+	binFinite = 0
+	binInf    = 1
+	binQNaN   = 2
+	binSNaN   = 3
+	binForm   = 0x3
+	binBig    = 0x40 // the coefficient is a big-endian integer, not a uvarint
+	binSign   = 0x80
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding is compact
+// and much faster to produce and decode than a string. It consists of a
+// version byte; a byte holding the form (finite, infinite, quiet NaN, or
+// signaling NaN) and sign; the scale as a varint; and the coefficient, or NaN
+// payload, as a uvarint if it fits in a uint64 or otherwise as its length in
+// bytes, as a uvarint, followed by its big-endian bytes.
+//
+// The encoding preserves the value of x exactly, including its scale, the sign
+// of a zero, and the payload of a NaN, but not its Context or tag.
+func (x *Big) MarshalBinary() ([]byte, error) {
+	if x == nil {
+		return nil, ErrNilOperand{Op: "MarshalBinary"}
+	}
+	if debug {
+		x.validate()
+	}
+
+	var flags byte
+	switch {
+	case x.IsInf(0):
+		flags = binInf
+	case x.IsNaN(-1):
+		flags = binSNaN
+	case x.IsNaN(0):
+		flags = binQNaN
+	}
+	if x.Signbit() {
+		flags |= binSign
+	}
+	coeff := x.compact
+	switch {
+	case x.IsInf(0):
+		coeff = 0
+	case x.IsFinite() && !x.isCompact():
+		flags |= binBig
+	}
+
+	b := make([]byte, 2, 2+2*binary.MaxVarintLen64)
+	b[0] = binaryVersion
+	b[1] = flags
+	b = binary.AppendVarint(b, -int64(x.exp))
+	if flags&binBig != 0 {
+		c := x.unscaled.Bytes()
+		return append(binary.AppendUvarint(b, uint64(len(c))), c...), nil
+	}
+	return binary.AppendUvarint(b, coeff), nil
+}
+
+var _ encoding.BinaryMarshaler = (*Big)(nil)
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It decodes data as
+// produced by MarshalBinary, leaving z's Context unchanged. If data is
+// truncated or otherwise invalid, it returns an error and z is unchanged.
+func (z *Big) UnmarshalBinary(data []byte) error {
+	if z == nil {
+		return ErrNilOperand{Op: "UnmarshalBinary"}
+	}
+	if len(data) < 2 {
+		return errors.New("decimal: UnmarshalBinary: truncated input")
+	}
+	if data[0] != binaryVersion {
+		return errors.New("decimal: UnmarshalBinary: unsupported version")
+	}
+	flags := data[1]
+	if flags&^(binForm|binBig|binSign) != 0 {
+		return errors.New("decimal: UnmarshalBinary: invalid form")
+	}
+
+	scale, n := binary.Varint(data[2:])
+	switch {
+	case n == 0:
+		return errors.New("decimal: UnmarshalBinary: truncated input")
+	case n < 0 || scale < math.MinInt+1 || scale > math.MaxInt:
+		return errors.New("decimal: UnmarshalBinary: scale out of range")
+	}
+	rest := data[2+n:]
+
+	var (
+		compact uint64
+		coeff   big.Int
+	)
+	compact, n = binary.Uvarint(rest)
+	switch {
+	case n == 0:
+		return errors.New("decimal: UnmarshalBinary: truncated input")
+	case n < 0:
+		return errors.New("decimal: UnmarshalBinary: coefficient out of range")
+	}
+	rest = rest[n:]
+	if flags&binBig != 0 {
+		// compact is the length of the coefficient.
+		if compact > uint64(len(rest)) {
+			return errors.New("decimal: UnmarshalBinary: truncated input")
+		}
+		coeff.SetBytes(rest[:compact])
+		rest = rest[compact:]
+		// Coefficients that fit in a uint64, including every NaN payload, are
+		// encoded as uvarints.
+		if flags&binForm != binFinite || (coeff.IsUint64() && coeff.Uint64() != cst.Inflated) {
+			return errors.New("decimal: UnmarshalBinary: invalid coefficient")
+		}
+	} else if compact == cst.Inflated && flags&binForm == binFinite {
+		return errors.New("decimal: UnmarshalBinary: coefficient out of range")
+	}
+	if len(rest) != 0 {
+		return errors.New("decimal: UnmarshalBinary: trailing data")
+	}
+
+	var sign form
+	if flags&binSign != 0 {
+		sign = signbit
+	}
+	switch flags & binForm {
+	case binFinite:
+		if flags&binBig == 0 {
+			z.setTriple(compact, sign, -int(scale))
+			return nil
+		}
+		z.unscaled.Set(&coeff)
+		z.form = finite | sign
+		z.exp = -int(scale)
+		z.norm()
+		return nil
+	case binInf:
+		z.form = pinf | sign
+	case binQNaN:
+		z.form = qnan | sign
+	case binSNaN:
+		z.form = snan | sign
+	}
+	z.compact = compact
+	z.exp = -int(scale)
+	return nil
+}
+
+var _ encoding.BinaryUnmarshaler = (*Big)(nil)
+
+// GobEncode implements gob.GobEncoder using MarshalBinary.
+func (x *Big) GobEncode() ([]byte, error) {
+	if x == nil {
+		return nil, ErrNilOperand{Op: "GobEncode"}
+	}
+	return x.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using UnmarshalBinary.
+func (z *Big) GobDecode(data []byte) error {
+	if z == nil {
+		return ErrNilOperand{Op: "GobDecode"}
+	}
+	return z.UnmarshalBinary(data)
+}
@@ -0,0 +1,122 @@
+package decimal_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestBig_MarshalBinary(t *testing.T) {
+	big40 := "1" + strings.Repeat("0", 39)
+	for i, s := range [...]string{
+		"0", "-0", "1", "-1.50", "0E-50", "-0E+50",
+		"18446744073709551614", "18446744073709551615", "18446744073709551616",
+		big40, "-" + big40 + "E-1000", "0." + big40,
+		"1E" + strconv.Itoa(decimal.MaxScale), "1E" + strconv.Itoa(decimal.MinScale),
+		"-" + big40 + "E" + strconv.Itoa(decimal.MinScale),
+		"Infinity", "-Infinity", "NaN", "-NaN", "NaN123", "-sNaN18446744073709551615", "sNaN",
+	} {
+		x, ok := new(decimal.Big).SetString(s)
+		if !ok {
+			t.Fatalf("#%d: invalid input %q", i, s)
+		}
+		b, err := x.MarshalBinary()
+		if err != nil {
+			t.Fatalf("#%d: MarshalBinary(%s): %v", i, s, err)
+		}
+		z := decimal.New(7, 0)
+		z.Context.Precision = 3
+		if err := z.UnmarshalBinary(b); err != nil {
+			t.Fatalf("#%d: UnmarshalBinary(%x): %v", i, b, err)
+		}
+		if !sameBinary(z, x) || z.String() != x.String() || z.Signbit() != x.Signbit() || z.Scale() != x.Scale() {
+			t.Fatalf("#%d: round trip of %s: got %s (%+v)", i, x, z, z.Dump())
+		}
+		if z.Context.Precision != 3 {
+			t.Fatalf("#%d: UnmarshalBinary modified the Context", i)
+		}
+
+		// Every truncation of b is rejected, as is trailing data.
+		for n := 0; n < len(b); n++ {
+			y := decimal.New(7, 0)
+			if err := y.UnmarshalBinary(b[:n]); err == nil {
+				t.Fatalf("#%d: UnmarshalBinary(%x): wanted an error, got %s", i, b[:n], y)
+			}
+			if y.String() != "7" {
+				t.Fatalf("#%d: UnmarshalBinary(%x): modified z: %s", i, b[:n], y)
+			}
+		}
+		if err := z.UnmarshalBinary(append(b, 0)); err == nil {
+			t.Fatalf("#%d: UnmarshalBinary(%x): wanted an error", i, append(b, 0))
+		}
+	}
+}
+
+func TestBig_UnmarshalBinary_Invalid(t *testing.T) {
+	for i, b := range [...][]byte{
+		nil,
+		{2, 0, 0, 0},    // unsupported version
+		{1, 0x20, 0, 0}, // unknown flag
+		{1, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f, 0}, // scale overflows
+		{1, 0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01},       // Inflated as a uvarint
+		{1, 0x40, 0, 1, 5},                            // small coefficient encoded as big
+		{1, 0x42, 0, 9, 1, 0, 0, 0, 0, 0, 0, 0, 0},    // big NaN payload
+		{1, 0x40, 0, 9, 1, 0, 0, 0, 0, 0, 0, 0},       // truncated coefficient
+		{1, 0x40, 0, 9, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0}, // trailing data
+	} {
+		if err := new(decimal.Big).UnmarshalBinary(b); err == nil {
+			t.Fatalf("#%d: UnmarshalBinary(%x): wanted an error", i, b)
+		}
+	}
+
+	var x *decimal.Big
+	if _, err := x.MarshalBinary(); err == nil {
+		t.Fatal("MarshalBinary: wanted an error for a nil receiver")
+	}
+	if err := x.UnmarshalBinary([]byte{1, 0, 0, 0}); err == nil {
+		t.Fatal("UnmarshalBinary: wanted an error for a nil receiver")
+	}
+}
+
+func TestBig_Gob(t *testing.T) {
+	type row struct {
+		ID    int
+		Price *decimal.Big
+		Total decimal.Big
+	}
+	rng := rand.New(rand.NewSource(272))
+	in := make([]row, 100)
+	for i := range in {
+		in[i].ID = i
+		in[i].Price = decimal.New(rng.Int63(), rng.Intn(20)-10)
+		in[i].Total.SetString(strconv.FormatUint(rng.Uint64(), 10) + strconv.FormatUint(rng.Uint64(), 10) + "E-30")
+	}
+	in[0].Price.SetNaN(true)
+	in[1].Total.SetInf(true)
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatal(err)
+	}
+	var out []row
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	for i := range in {
+		if out[i].ID != in[i].ID || !sameBinary(out[i].Price, in[i].Price) || !sameBinary(&out[i].Total, &in[i].Total) {
+			t.Fatalf("#%d: wanted %s and %s, got %s and %s", i, in[i].Price, &in[i].Total, out[i].Price, &out[i].Total)
+		}
+	}
+}
+
+// sameBinary reports whether x and y have the same binary encoding.
+func sameBinary(x, y *decimal.Big) bool {
+	bx, _ := x.MarshalBinary()
+	by, _ := y.MarshalBinary()
+	return bytes.Equal(bx, by)
+}
@@ -0,0 +1,120 @@
+package decimal
+
+import (
+	"math/big"
+
+	"github.com/ericlagergren/decimal/internal/arith"
+)
+
+// Builder constructs a decimal from its separately-encoded parts—a sign, the
+// integer and fractional digits, and an exponent—without first formatting
+// them as a string. The zero value is an empty Builder ready to use.
+//
+// Building the digits d, the fraction f, and the exponent e is equivalent to
+// calling SetString with "[-]d.fEe".
+type Builder struct {
+	neg      bool
+	digits   []byte
+	fraction []byte
+	exp      int
+}
+
+// Reset resets b to be empty.
+func (b *Builder) Reset() {
+	b.neg = false
+	b.digits = b.digits[:0]
+	b.fraction = b.fraction[:0]
+	b.exp = 0
+}
+
+// Sign sets the sign of the decimal. It is negative if neg is true.
+func (b *Builder) Sign(neg bool) { b.neg = neg }
+
+// Digits appends p to the integer digits of the decimal. Each byte must be an
+// ASCII digit.
+func (b *Builder) Digits(p []byte) { b.digits = append(b.digits, p...) }
+
+// Fraction appends p to the fractional digits of the decimal. Each byte must be
+// an ASCII digit.
+func (b *Builder) Fraction(p []byte) { b.fraction = append(b.fraction, p...) }
+
+// Exponent sets the exponent of the decimal.
+func (b *Builder) Exponent(exp int) { b.exp = exp }
+
+// Build sets z to the decimal described by b. b is not modified.
+//
+// If b has no digits or a digit is not valid, z is set to NaN,
+// ConversionSyntax is raised, and Build returns ConversionSyntax. If b has
+// more than MaxPrecision digits, z is set to NaN, InsufficientStorage is
+// raised, and Build returns InsufficientStorage. If b has more digits than
+// permitted by z's Context (see Context.MaxParseDigits), Build does the same
+// but returns an ErrParseLimit. Like SetString, Build does not round z.
+func (b *Builder) Build(z *Big) error {
+	mustNotNil("Build", z, z)
+
+	n := len(b.digits) + len(b.fraction)
+	if n == 0 {
+		return z.setParseErr(ConversionSyntax)
+	}
+	if n > MaxPrecision {
+		return z.setParseErr(InsufficientStorage)
+	}
+	if _, max := z.Context.parseLimits(); max > 0 && n > max {
+		return z.setParseLimit(ErrParseLimit{Digits: true, Max: max})
+	}
+
+	if n < arith.PowTabLen {
+		// Fast path: the coefficient fits into a uint64.
+		var x uint64
+		for _, p := range [...][]byte{b.digits, b.fraction} {
+			for _, ch := range p {
+				if ch < '0' || ch > '9' {
+					return z.setParseErr(ConversionSyntax)
+				}
+				x = x*10 + uint64(ch-'0')
+			}
+		}
+		z.compact = x
+		z.precision = arith.Length(x)
+	} else {
+		// Accumulate the coefficient in word-sized chunks to avoid a
+		// multiplication per digit.
+		const chunkLen = arith.PowTabLen - 1
+		var (
+			x     = z.unscaled.SetUint64(0)
+			t     big.Int
+			chunk uint64
+			k     uint64
+		)
+		for _, p := range [...][]byte{b.digits, b.fraction} {
+			for _, ch := range p {
+				if ch < '0' || ch > '9' {
+					return z.setParseErr(ConversionSyntax)
+				}
+				chunk = chunk*10 + uint64(ch-'0')
+				if k++; k == chunkLen {
+					x.Mul(x, arith.BigPow10(k))
+					x.Add(x, t.SetUint64(chunk))
+					chunk, k = 0, 0
+				}
+			}
+		}
+		if k > 0 {
+			x.Mul(x, arith.BigPow10(k))
+			x.Add(x, t.SetUint64(chunk))
+		}
+		z.norm()
+	}
+
+	z.form = finite
+	if b.neg {
+		z.form |= signbit
+	}
+	if b.exp > MaxScale || b.exp < MinScale {
+		// The exponent cannot be represented.
+		z.xflow(MinScale, b.exp > 0, b.neg)
+		return nil
+	}
+	z.exp = b.exp - len(b.fraction)
+	return nil
+}
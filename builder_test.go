@@ -0,0 +1,136 @@
+package decimal_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestBuilder(t *testing.T) {
+	for i, test := range [...]struct {
+		neg    bool
+		digits string
+		frac   string
+		exp    int
+		r      string
+		err    error
+	}{
+		{digits: "123", frac: "45", r: "123.45"},
+		{neg: true, digits: "0", r: "-0"},
+		{digits: "007", frac: "500", exp: 3, r: "7500"},
+		{frac: "25", exp: -2, r: "0.0025"},
+		{digits: "1", exp: 5, r: "1E+5"},
+		{digits: "123456789012345678901234567890", frac: "123", r: "123456789012345678901234567890.123"},
+		{neg: true, digits: "18446744073709551615", r: "-18446744073709551615"},
+		{digits: "", frac: "", err: decimal.ConversionSyntax},
+		{digits: "12a", err: decimal.ConversionSyntax},
+		{digits: "1", frac: "2.3", err: decimal.ConversionSyntax},
+		{digits: "1234567890123456789012345", frac: "-", err: decimal.ConversionSyntax},
+	} {
+		var b decimal.Builder
+		b.Sign(test.neg)
+		b.Digits([]byte(test.digits))
+		b.Fraction([]byte(test.frac))
+		b.Exponent(test.exp)
+
+		z := new(decimal.Big)
+		err := b.Build(z)
+		if err != test.err {
+			t.Fatalf("#%d: wanted error %v, got %v", i, test.err, err)
+		}
+		if err != nil {
+			if !z.IsNaN(0) || z.Context.Conditions&decimal.ConversionSyntax == 0 {
+				t.Fatalf("#%d: wanted NaN with ConversionSyntax, got %s (%s)",
+					i, z, z.Context.Conditions)
+			}
+			continue
+		}
+		if z.String() != test.r {
+			t.Fatalf("#%d: wanted %s, got %s", i, test.r, z)
+		}
+	}
+}
+
+func TestBuilder_Incremental(t *testing.T) {
+	var b decimal.Builder
+	for _, p := range []string{"12", "34", "56789012345678901234"} {
+		b.Digits([]byte(p))
+	}
+	b.Fraction([]byte("5"))
+	b.Fraction([]byte("25"))
+	z := new(decimal.Big)
+	if err := b.Build(z); err != nil {
+		t.Fatal(err)
+	}
+	if want := "123456789012345678901234.525"; z.String() != want {
+		t.Fatalf("wanted %s, got %s", want, z)
+	}
+
+	b.Reset()
+	b.Digits([]byte("42"))
+	if err := b.Build(z); err != nil {
+		t.Fatal(err)
+	}
+	if z.String() != "42" {
+		t.Fatalf("after Reset: wanted 42, got %s", z)
+	}
+}
+
+func isDigits(p []byte) bool {
+	for _, c := range p {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// FuzzBuilder checks that Builder and SetString accept the same inputs and
+// produce identical results.
+func FuzzBuilder(f *testing.F) {
+	f.Add(false, []byte("123"), []byte("45"), int32(0))
+	f.Add(true, []byte("0"), []byte(""), int32(-3))
+	f.Add(false, []byte(""), []byte("001"), int32(7))
+	f.Add(false, []byte("18446744073709551615"), []byte(""), int32(0))
+	f.Add(true, []byte("9999999999999999999"), []byte("9"), int32(-20))
+	f.Add(false, []byte("123456789012345678901234567890"), []byte("1234567890"), int32(12))
+	f.Add(false, []byte("1e5"), []byte(""), int32(0))
+	f.Add(false, []byte("nan"), []byte("1"), int32(0))
+
+	f.Fuzz(func(t *testing.T, neg bool, digits, frac []byte, exp int32) {
+		var b decimal.Builder
+		b.Sign(neg)
+		b.Digits(digits)
+		b.Fraction(frac)
+		b.Exponent(int(exp))
+		z := new(decimal.Big)
+		err := b.Build(z)
+
+		if !isDigits(digits) || !isDigits(frac) || len(digits)+len(frac) == 0 {
+			if err != decimal.ConversionSyntax {
+				t.Fatalf("(%q, %q): wanted ConversionSyntax, got %v (%s)", digits, frac, err, z)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("(%q, %q): unexpected error: %v", digits, frac, err)
+		}
+
+		s := string(digits) + "." + string(frac) + "e" + strconv.Itoa(int(exp))
+		if neg {
+			s = "-" + s
+		}
+		x, ok := new(decimal.Big).SetString(s)
+		if !ok {
+			t.Fatalf("SetString(%q) failed", s)
+		}
+		if z.String() != x.String() || z.Scale() != x.Scale() || z.Signbit() != x.Signbit() ||
+			z.Precision() != x.Precision() {
+			t.Fatalf(`%q:
+wanted: %s (scale: %d, prec: %d)
+got   : %s (scale: %d, prec: %d)
+`, s, x, x.Scale(), x.Precision(), z, z.Scale(), z.Precision())
+		}
+	})
+}
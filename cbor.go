@@ -0,0 +1,318 @@
+package decimal
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/big"
+
+	cst "github.com/ericlagergren/decimal/internal/c"
+)
+
+// CBOR major types and tags used by decimal fractions.
+const (
+	cborUint    = 0
+	cborNegInt  = 1
+	cborBytes   = 2
+	cborArray   = 4
+	cborTag     = 6
+	cborSimple  = 7
+	cborTagPos  = 2 // unsigned bignum
+	cborTagNeg  = 3 // negative bignum
+	cborTagFrac = 4 // decimal fraction
+	cborFloat16 = 25
+	cborFloat32 = 26
+	cborFloat64 = 27
+)
+
+// CBOR returns x encoded as an RFC 8949 decimal fraction: tag 4 followed by
+// the array [exponent, mantissa], where x = mantissa × 10**exponent. The
+// mantissa is an integer if it fits in one, and otherwise a bignum (tag 2 or
+// 3). Integers are encoded in the preferred (shortest) serialization; for
+// example, 1.50 is C4 82 21 18 96, that is, 4([-2, 150]).
+//
+// Decimal fractions cannot represent NaN or infinite values, which are encoded
+// as the half-precision floats NaN, Infinity, and -Infinity instead. The
+// payload of a NaN, whether it is signaling, and the sign of a zero are lost.
+func (x *Big) CBOR() ([]byte, error) {
+	if x == nil {
+		return nil, ErrNilOperand{Op: "CBOR"}
+	}
+	if debug {
+		x.validate()
+	}
+
+	switch {
+	case x.IsNaN(0):
+		return []byte{cborSimple<<5 | cborFloat16, 0x7e, 0x00}, nil
+	case x.IsInf(+1):
+		return []byte{cborSimple<<5 | cborFloat16, 0x7c, 0x00}, nil
+	case x.IsInf(-1):
+		return []byte{cborSimple<<5 | cborFloat16, 0xfc, 0x00}, nil
+	}
+
+	b := make([]byte, 0, 2+2*9)
+	b = cborAppendHead(b, cborTag, cborTagFrac)
+	b = cborAppendHead(b, cborArray, 2)
+	if x.exp < 0 {
+		b = cborAppendHead(b, cborNegInt, uint64(-1-x.exp))
+	} else {
+		b = cborAppendHead(b, cborUint, uint64(x.exp))
+	}
+
+	neg := x.Signbit() && x.compact != 0
+	if x.isCompact() {
+		if neg {
+			return cborAppendHead(b, cborNegInt, x.compact-1), nil
+		}
+		return cborAppendHead(b, cborUint, x.compact), nil
+	}
+
+	// A negative integer is encoded as -1 - n.
+	var n big.Int
+	n.Set(&x.unscaled)
+	if neg {
+		n.Sub(&n, cst.OneInt)
+	}
+	if n.IsUint64() {
+		if neg {
+			return cborAppendHead(b, cborNegInt, n.Uint64()), nil
+		}
+		return cborAppendHead(b, cborUint, n.Uint64()), nil
+	}
+	tag := uint64(cborTagPos)
+	if neg {
+		tag = cborTagNeg
+	}
+	m := n.Bytes()
+	b = cborAppendHead(b, cborTag, tag)
+	b = cborAppendHead(b, cborBytes, uint64(len(m)))
+	return append(b, m...), nil
+}
+
+// cborAppendHead appends the head of a data item with the major type major
+// and argument n to b, using the shortest encoding of n.
+func cborAppendHead(b []byte, major byte, n uint64) []byte {
+	major <<= 5
+	switch {
+	case n < 24:
+		return append(b, major|byte(n))
+	case n <= math.MaxUint8:
+		return append(b, major|24, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, major|25), uint16(n))
+	case n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, major|26), uint32(n))
+	default:
+		return binary.BigEndian.AppendUint64(append(b, major|27), n)
+	}
+}
+
+// cborReadHead reads the head of a data item from b, returning its major
+// type, its additional information, its argument, and the rest of b. It does
+// not accept indefinite lengths.
+func cborReadHead(b []byte) (major, info byte, n uint64, rest []byte, err error) {
+	if len(b) == 0 {
+		return 0, 0, 0, nil, cborErr("unexpected end of input")
+	}
+	major, info = b[0]>>5, b[0]&0x1f
+	b = b[1:]
+	switch {
+	case info < 24:
+		return major, info, uint64(info), b, nil
+	case info > cborFloat64:
+		return 0, 0, 0, nil, cborErr("indefinite length or reserved additional information")
+	}
+	size := 1 << (info - 24)
+	if len(b) < size {
+		return 0, 0, 0, nil, cborErr("unexpected end of input")
+	}
+	switch size {
+	case 1:
+		n = uint64(b[0])
+	case 2:
+		n = uint64(binary.BigEndian.Uint16(b))
+	case 4:
+		n = uint64(binary.BigEndian.Uint32(b))
+	case 8:
+		n = binary.BigEndian.Uint64(b)
+	}
+	return major, info, n, b[size:], nil
+}
+
+// cborErr returns an error for an invalid decimal fraction.
+func cborErr(msg string) error {
+	return fmt.Errorf("decimal: invalid CBOR decimal fraction: %s: %w", msg, ConversionSyntax)
+}
+
+// SetCBOR sets z to the RFC 8949 decimal fraction b, as encoded by CBOR. Any
+// valid encoding of the integers is accepted, as is a bignum mantissa that
+// would fit in an integer, but b must be exactly one tag 4 data item whose
+// content is a definite-length array of an integer exponent and an integer or
+// bignum mantissa. The half-precision, single-precision, and double-precision
+// floats NaN, Infinity, and -Infinity are also accepted, as CBOR produces them
+// for NaN and infinite values. z's Context is unchanged and z is not rounded,
+// but an exponent outside the range of a Big overflows or underflows as it
+// does for SetString.
+//
+// If b is invalid, SetCBOR returns an error that wraps ConversionSyntax and z
+// is unchanged.
+func (z *Big) SetCBOR(b []byte) error {
+	if z == nil {
+		return ErrNilOperand{Op: "SetCBOR"}
+	}
+	x := Big{Context: z.Context}
+	x.Context.Conditions = 0
+
+	major, info, n, rest, err := cborReadHead(b)
+	if err != nil {
+		return err
+	}
+	switch {
+	case major == cborSimple:
+		if err := x.setCBORFloat(info, n); err != nil {
+			return err
+		}
+	case major != cborTag || n != cborTagFrac:
+		return cborErr("not a decimal fraction")
+	default:
+		if rest, err = x.setCBORFrac(rest); err != nil {
+			return err
+		}
+	}
+	if len(rest) != 0 {
+		return cborErr("trailing data")
+	}
+	x.Context.Conditions |= z.Context.Conditions
+	*z = x
+	return nil
+}
+
+// setCBORFloat sets z to the float with additional information info and bits
+// n, which must be a NaN or infinity.
+func (z *Big) setCBORFloat(info byte, n uint64) error {
+	var f float64
+	switch info {
+	case cborFloat16:
+		// Only the exponent and the sign are needed to tell whether it is a
+		// NaN or infinity.
+		switch {
+		case n&0x7c00 != 0x7c00:
+			f = 0
+		case n&0x3ff != 0:
+			f = math.NaN()
+		default:
+			f = math.Inf(1 - int(n>>15)*2)
+		}
+	case cborFloat32:
+		f = float64(math.Float32frombits(uint32(n)))
+	case cborFloat64:
+		f = math.Float64frombits(n)
+	default:
+		return cborErr("not a decimal fraction")
+	}
+	switch {
+	case math.IsNaN(f):
+		z.form = qnan
+		z.compact = 0
+	case math.IsInf(f, 0):
+		z.SetInf(f < 0)
+	default:
+		return cborErr("finite float instead of a decimal fraction")
+	}
+	return nil
+}
+
+// setCBORFrac sets z to the array [exponent, mantissa] at the start of b and
+// returns the rest of b.
+func (z *Big) setCBORFrac(b []byte) ([]byte, error) {
+	major, _, n, b, err := cborReadHead(b)
+	if err != nil {
+		return nil, err
+	}
+	if major != cborArray || n != 2 {
+		return nil, cborErr("content is not an array of two items")
+	}
+
+	major, _, n, b, err = cborReadHead(b)
+	if err != nil {
+		return nil, err
+	}
+	var exp int64
+	switch {
+	case (major != cborUint && major != cborNegInt) || n > math.MaxInt64:
+		return nil, cborErr("invalid exponent")
+	case major == cborUint:
+		exp = int64(n)
+	default:
+		exp = -1 - int64(n)
+	}
+
+	major, _, n, b, err = cborReadHead(b)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		sign form
+		c    big.Int
+	)
+	switch major {
+	case cborUint:
+		if n != cst.Inflated {
+			z.setCBORCompact(n, sign)
+			z.clampExp(exp)
+			return b, nil
+		}
+		c.SetUint64(n)
+	case cborNegInt:
+		// A negative integer is encoded as -1 - n.
+		sign = signbit
+		if n < cst.Inflated-1 {
+			z.setCBORCompact(n+1, sign)
+			z.clampExp(exp)
+			return b, nil
+		}
+		c.SetUint64(n).Add(&c, cst.OneInt)
+	case cborTag:
+		if n != cborTagPos && n != cborTagNeg {
+			return nil, cborErr("invalid mantissa")
+		}
+		if n == cborTagNeg {
+			sign = signbit
+		}
+		major, _, n, b, err = cborReadHead(b)
+		if err != nil {
+			return nil, err
+		}
+		if major != cborBytes {
+			return nil, cborErr("bignum content is not a byte string")
+		}
+		if n > uint64(len(b)) {
+			return nil, cborErr("unexpected end of input")
+		}
+		c.SetBytes(b[:n])
+		b = b[n:]
+		if sign != 0 {
+			c.Add(&c, cst.OneInt)
+		}
+	default:
+		return nil, cborErr("invalid mantissa")
+	}
+	if c.IsUint64() && c.Uint64() != cst.Inflated {
+		z.setCBORCompact(c.Uint64(), sign)
+	} else {
+		z.SetBigMantScale(&c, 0)
+		z.form |= sign
+	}
+	z.clampExp(exp)
+	return b, nil
+}
+
+// setCBORCompact sets z to the integer with the magnitude c and the sign sign.
+func (z *Big) setCBORCompact(c uint64, sign form) {
+	if c == 0 {
+		z.setZero(sign, 0)
+	} else {
+		z.setTriple(c, sign, 0)
+	}
+}
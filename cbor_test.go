@@ -0,0 +1,139 @@
+package decimal_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestBig_CBOR(t *testing.T) {
+	for i, test := range [...]struct {
+		in, enc, out string
+	}{
+		{"0", "c4820000", "0"},
+		{"-0.00", "c4822100", "0.00"},
+		{"1.50", "c482211896", "1.50"},
+		{"273.15", "c48221196ab3", "273.15"},
+		{"-1", "c4820020", "-1"},
+		{"1E+3", "c4820301", "1E+3"},
+		{"1E-100", "c482386301", "1E-100"},
+		{"18446744073709551615", "c482001bffffffffffffffff", "18446744073709551615"},
+		{"18446744073709551616", "c48200c249010000000000000000", "18446744073709551616"},
+		{"-18446744073709551616", "c482003bffffffffffffffff", "-18446744073709551616"},
+		{"-18446744073709551617", "c48200c349010000000000000000", "-18446744073709551617"},
+		{"NaN", "f97e00", "NaN"},
+		{"-sNaN12", "f97e00", "NaN"},
+		{"Infinity", "f97c00", "Infinity"},
+		{"-Infinity", "f9fc00", "-Infinity"},
+	} {
+		x, _ := new(decimal.Big).SetString(test.in)
+		b, err := x.CBOR()
+		if err != nil || hex.EncodeToString(b) != test.enc {
+			t.Fatalf("#%d: %s.CBOR(): wanted %s, got %x (%v)", i, test.in, test.enc, b, err)
+		}
+		z := decimal.New(7, 0)
+		if err := z.SetCBOR(b); err != nil || z.String() != test.out {
+			t.Fatalf("#%d: SetCBOR(%x): wanted %s, got %s (%v)", i, b, test.out, z, err)
+		}
+	}
+}
+
+func TestBig_SetCBOR(t *testing.T) {
+	for i, test := range [...]struct {
+		enc, out string
+	}{
+		// Integers that are not in the preferred serialization.
+		{"c4821b000000000000000218ff", "2.55E+4"},
+		{"c482390001" + "1a00000005", "0.05"},
+		// Bignums that fit in an integer.
+		{"c48200c24105", "5"},
+		{"c48200c340", "-1"},
+		{"c48200c24800000000000000ff", "255"},
+		// Other encodings of NaN and infinite values.
+		{"fa7fc00000", "NaN"},
+		{"f97c01", "NaN"},
+		{"fbfff0000000000000", "-Infinity"},
+	} {
+		b, _ := hex.DecodeString(test.enc)
+		var z decimal.Big
+		if err := z.SetCBOR(b); err != nil || z.String() != test.out {
+			t.Fatalf("#%d: SetCBOR(%s): wanted %s, got %s (%v)", i, test.enc, test.out, &z, err)
+		}
+	}
+
+	// The exponent is clamped as it is by SetString.
+	b, _ := hex.DecodeString("c4821b7fffffffffffffff01")
+	var z decimal.Big
+	if err := z.SetCBOR(b); err != nil || !z.IsInf(+1) || z.Context.Conditions&decimal.Overflow == 0 {
+		t.Fatalf("SetCBOR(%x): wanted Infinity and Overflow, got %s and %s (%v)", b, &z, z.Context.Conditions, err)
+	}
+
+	for i, enc := range [...]string{
+		"",
+		"c4",
+		"c482",
+		"c48200",
+		"c4830000",
+		"c48300000000",
+		"c4810000",
+		"c49f0000ff",
+		"c5820000",   // bigfloat
+		"d8c4820000", // tag 196
+		"820000",
+		"c482000000", // trailing data
+		"c482c2410101",
+		"c4821b800000000000000001",
+		"c48200c26141",
+		"c48200c24500",
+		"c48200c25f4101ff",
+		"c48200f5",
+		"c48200c4820000",
+		"c482001c",
+		"f93c00", // 1.0
+		"fb3ff0000000000000",
+		"f5",
+		"60",
+	} {
+		b, _ := hex.DecodeString(enc)
+		z := decimal.New(7, 0)
+		err := z.SetCBOR(b)
+		if err == nil || !errors.Is(err, decimal.ConversionSyntax) {
+			t.Fatalf("#%d: SetCBOR(%s): wanted a ConversionSyntax error, got %s (%v)", i, enc, z, err)
+		}
+		if z.Cmp(decimal.New(7, 0)) != 0 || z.Context.Conditions != 0 {
+			t.Fatalf("#%d: SetCBOR(%s): z was modified: %s", i, enc, z)
+		}
+	}
+}
+
+func TestBig_CBOR_RoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		var c big.Int
+		c.Rand(rng, new(big.Int).Lsh(big.NewInt(1), uint(rng.Intn(200))))
+		if rng.Intn(2) == 0 {
+			c.Neg(&c)
+		}
+		x := new(decimal.Big).SetBigMantScale(&c, rng.Intn(2000)-1000)
+		b, err := x.CBOR()
+		if err != nil {
+			t.Fatalf("%s.CBOR(): %v", x, err)
+		}
+		var z decimal.Big
+		if err := z.SetCBOR(b); err != nil {
+			t.Fatalf("SetCBOR(%x): %v", b, err)
+		}
+		want := x.String()
+		if x.Sign() == 0 {
+			want = strings.TrimPrefix(want, "-")
+		}
+		if z.String() != want {
+			t.Fatalf("SetCBOR(%x): wanted %s, got %s", b, want, &z)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package decimal
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Column describes the decimal values that a database column type can store.
+// Arithmetic performed with a Column's Context rounds results to the column's
+// precision; Round rounds a value to the column's scale as well, and FitFor
+// reports whether a value can be stored without rounding.
+type Column struct {
+	// Name is the name of the column type, used in error messages.
+	Name string
+
+	// Precision is the maximum number of significant digits.
+	Precision int
+
+	// Scale is the maximum number of digits after the decimal point. It may
+	// be negative, in which case stored values are multiples of 10^-Scale.
+	Scale int
+
+	// MinExp and MaxExp are the smallest and largest adjusted exponents of a
+	// non-zero stored value. For example, the smallest non-zero value of a
+	// column whose MinExp is -2 is 0.01.
+	MinExp, MaxExp int
+
+	// RoundingMode determines how Round and the Column's Context round.
+	RoundingMode RoundingMode
+}
+
+// NumericColumn returns the Column for the SQL type NUMERIC(p, s), whose
+// values have at most p digits, s of which are after the decimal point. Like
+// the SQL type, it rounds half away from zero. It returns an error if p < 1.
+func NumericColumn(p, s int) (Column, error) {
+	if p < 1 {
+		return Column{}, fmt.Errorf("decimal: invalid NUMERIC precision %d", p)
+	}
+	return Column{
+		Name:         "NUMERIC(" + strconv.Itoa(p) + "," + strconv.Itoa(s) + ")",
+		Precision:    p,
+		Scale:        s,
+		MinExp:       -s,
+		MaxExp:       p - s - 1,
+		RoundingMode: ToNearestAway,
+	}, nil
+}
+
+// ContextNumeric returns the Context of NumericColumn(p, s). If p < 1, the
+// Context is invalid, so operations that use it raise InvalidContext.
+func ContextNumeric(p, s int) Context {
+	c, err := NumericColumn(p, s)
+	if err != nil {
+		return Context{Precision: -1, OperatingMode: GDA}
+	}
+	return c.Context()
+}
+
+// The following Columns describe the decimal types of common databases.
+var (
+	// MySQLDecimal is the largest MySQL DECIMAL type, DECIMAL(65,30).
+	MySQLDecimal = Column{
+		Name:         "DECIMAL(65,30)",
+		Precision:    65,
+		Scale:        30,
+		MinExp:       -30,
+		MaxExp:       34,
+		RoundingMode: ToNearestAway,
+	}
+
+	// OracleNumber is the Oracle NUMBER type without a precision or scale,
+	// which stores 38 significant digits of values whose magnitudes are in
+	// the range [1E-130, 1E+126).
+	OracleNumber = Column{
+		Name:         "NUMBER",
+		Precision:    38,
+		Scale:        130 + 37,
+		MinExp:       -130,
+		MaxExp:       125,
+		RoundingMode: ToNearestAway,
+	}
+
+	// ContextMySQLDecimal is the Context of MySQLDecimal.
+	ContextMySQLDecimal = MySQLDecimal.Context()
+
+	// ContextOracleNumber is the Context of OracleNumber.
+	ContextOracleNumber = OracleNumber.Context()
+)
+
+// Context returns a GDA Context with c's precision and rounding mode. Its
+// MaxScale and MinScale are set to c's MaxExp and MinExp when they are in the
+// ranges a Context accepts, so arithmetic overflows where the column does,
+// and Clamp is set; e.g., for NUMERIC(10,2), 5E+1 is stored as 50.00. The
+// Context does not limit the scale of results; see Round.
+func (c Column) Context() Context {
+	ctx := Context{
+		Precision:     c.Precision,
+		RoundingMode:  c.RoundingMode,
+		OperatingMode: GDA,
+		Clamp:         true,
+	}
+	if c.MaxExp > 0 && c.MaxExp <= MaxScale {
+		ctx.MaxScale = c.MaxExp
+	}
+	if c.MinExp < 0 && c.MinExp >= MinScale {
+		ctx.MinScale = c.MinExp
+	}
+	return ctx
+}
+
+// Round rounds z to c's precision and scale, rounding once at whichever digit
+// is more significant, and returns z. The Conditions are raised on z's
+// Context: Rounded and Inexact as by Quantize, Overflow if z is too large for
+// c, in which case z is set to ±Inf, and Underflow if z is too small for c, in
+// which case z is set to ±0.
+func (c Column) Round(z *Big) *Big {
+	mustNotNil("Round", z, z)
+	if !z.IsFinite() {
+		return z
+	}
+
+	e := -c.Scale
+	if z.compact != 0 {
+		if a := z.adjusted() - c.Precision + 1; a > e {
+			e = a
+		}
+	}
+	if z.exp < e {
+		zc := z.Context
+		Context{Precision: UnlimitedPrecision, RoundingMode: c.RoundingMode}.Quantize(z, -e)
+		if z.Precision() > c.Precision {
+			// Rounding carried into a new digit, so the last digit is a
+			// zero that can be dropped.
+			Context{Precision: c.Precision}.Round(z)
+		}
+		zc.adopt(z)
+	}
+	if z.compact == 0 {
+		return z
+	}
+	if adj := z.adjusted(); adj > c.MaxExp {
+		z.SetInf(z.Signbit())
+		z.Context.Conditions |= Overflow | Inexact | Rounded
+	} else if adj < c.MinExp {
+		z.setZero(z.form, -c.Scale)
+		z.Context.Conditions |= Underflow | Subnormal | Inexact | Rounded | Clamped
+	}
+	return z
+}
+
+// FitFor returns nil if x can be stored in c without rounding, and an error
+// describing why it cannot otherwise. Trailing zeros are ignored; e.g., 1.500
+// fits NUMERIC(2,1). NaN and infinite values never fit.
+func (c Column) FitFor(x *Big) error {
+	mustNotNil("FitFor", x, x)
+	if !x.IsFinite() {
+		return fmt.Errorf("decimal: %s cannot store %s", c.Name, x)
+	}
+	if x.compact == 0 {
+		return nil
+	}
+	r := Context{}.simpleReduce(new(Big).Copy(x))
+	var why string
+	switch adj := r.adjusted(); {
+	case adj > c.MaxExp:
+		why = "too large"
+	case adj < c.MinExp:
+		why = "too small"
+	case r.Precision() > c.Precision:
+		why = "too many digits"
+	case r.exp < -c.Scale:
+		why = "too many digits after the decimal point"
+	default:
+		return nil
+	}
+	return fmt.Errorf("decimal: %s cannot store %s: %s", c.Name, x, why)
+}
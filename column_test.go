@@ -0,0 +1,132 @@
+package decimal_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+// numeric returns NumericColumn(p, s), which must be valid.
+func numeric(p, s int) decimal.Column {
+	c, err := decimal.NumericColumn(p, s)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func TestColumn_Round(t *testing.T) {
+	num := numeric(5, 2)
+	for i, test := range [...]struct {
+		col   decimal.Column
+		x     string
+		want  string
+		conds decimal.Condition
+	}{
+		{num, "1.5", "1.5", 0},
+		{num, "1.005", "1.01", decimal.Inexact | decimal.Rounded},
+		{num, "-1.005", "-1.01", decimal.Inexact | decimal.Rounded},
+		{num, "1.0000", "1.00", decimal.Rounded},
+		{num, "999.994", "999.99", decimal.Inexact | decimal.Rounded},
+		{num, "999.995", "Infinity", decimal.Overflow | decimal.Inexact | decimal.Rounded},
+		{num, "-1000", "-Infinity", decimal.Overflow | decimal.Inexact | decimal.Rounded},
+		{num, "0.004", "0.00", decimal.Inexact | decimal.Rounded},
+		{num, "0E-9", "0.00", 0},
+		{num, "12E+1", "1.2E+2", 0},
+		{numeric(3, -2), "12345", "1.23E+4", decimal.Inexact | decimal.Rounded},
+		{decimal.MySQLDecimal, "1E-31", "0E-30", decimal.Inexact | decimal.Rounded},
+		{decimal.OracleNumber, "1.23456789012345678901234567890123456789", "1.2345678901234567890123456789012345679",
+			decimal.Inexact | decimal.Rounded},
+		{decimal.OracleNumber, "9.999999999999999999999999999999999999999E+125", "Infinity",
+			decimal.Overflow | decimal.Inexact | decimal.Rounded},
+		{decimal.OracleNumber, "9.99E+125", "9.99E+125", 0},
+		{decimal.OracleNumber, "1E-130", "1E-130", 0},
+		{decimal.OracleNumber, "-4E-131", "-0E-167",
+			decimal.Underflow | decimal.Subnormal | decimal.Inexact | decimal.Rounded | decimal.Clamped},
+		{decimal.OracleNumber, "NaN", "NaN", 0},
+	} {
+		z, _ := new(decimal.Big).SetString(test.x)
+		test.col.Round(z)
+		if z.String() != test.want || z.Context.Conditions != test.conds {
+			t.Fatalf("#%d: %s.Round(%s): wanted %s (%s), got %s (%s)",
+				i, test.col.Name, test.x, test.want, test.conds, z, z.Context.Conditions)
+		}
+		err := test.col.FitFor(z)
+		if (err == nil) != z.IsFinite() {
+			t.Fatalf("#%d: %s.FitFor(%s) after Round: %v", i, test.col.Name, z, err)
+		}
+	}
+}
+
+func TestColumn_FitFor(t *testing.T) {
+	for i, test := range [...]struct {
+		col decimal.Column
+		x   string
+		ok  bool
+	}{
+		{numeric(10, 2), "12345678.12", true},
+		{numeric(10, 2), "123456789.1", false},
+		{numeric(10, 2), "0.123", false},
+		{numeric(10, 2), "0.12000", true},
+		{numeric(2, 1), "1.500", true},
+		{numeric(2, 2), "0.99", true},
+		{numeric(2, 2), "1", false},
+		{numeric(3, -2), "12300", true},
+		{numeric(3, -2), "12310", false},
+		{decimal.MySQLDecimal, "-99999999999999999999999999999999999.999999999999999999999999999999", true},
+		{decimal.MySQLDecimal, "1E+35", false},
+		{decimal.OracleNumber, "1E-130", true},
+		{decimal.OracleNumber, "1.5E-130", true},
+		{decimal.OracleNumber, "1E-131", false},
+		{decimal.OracleNumber, "9." + strings.Repeat("9", 37) + "E+125", true},
+		{decimal.OracleNumber, "1E+126", false},
+		{decimal.OracleNumber, "1.000000000000000000000000000000000000001", false},
+		{decimal.OracleNumber, "-0", true},
+		{decimal.OracleNumber, "Infinity", false},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		if err := test.col.FitFor(x); (err == nil) != test.ok {
+			t.Fatalf("#%d: %s.FitFor(%s): wanted %t, got %v", i, test.col.Name, test.x, test.ok, err)
+		}
+	}
+}
+
+func TestColumn_Context(t *testing.T) {
+	ctx := numeric(10, 2).Context()
+	if ctx.Precision != 10 || ctx.MaxScale != 7 || ctx.MinScale != -2 ||
+		ctx.RoundingMode != decimal.ToNearestAway {
+		t.Fatalf("NUMERIC(10,2): unexpected Context %+v", ctx)
+	}
+	z := ctx.Mul(new(decimal.Big), decimal.New(5e7, 0), decimal.New(2, 0))
+	if !z.IsInf(+1) || z.Context.Conditions&decimal.Overflow == 0 {
+		t.Fatalf("NUMERIC(10,2): wanted overflow, got %s (%s)", z, z.Context.Conditions)
+	}
+
+	// MaxExp 0 cannot be expressed by a Context.
+	if ctx := numeric(3, 2).Context(); ctx.MaxScale != 0 {
+		t.Fatalf("NUMERIC(3,2): wanted default MaxScale, got %d", ctx.MaxScale)
+	}
+
+	if got := decimal.ContextNumeric(10, 2); got != ctx {
+		t.Fatalf("ContextNumeric(10, 2): wanted %+v, got %+v", ctx, got)
+	}
+	z = decimal.ContextNumeric(10, 2).Add(new(decimal.Big), decimal.New(5, -1), decimal.New(0, 0))
+	if z.String() != "50.00" || z.Context.Conditions != decimal.Clamped {
+		t.Fatalf("NUMERIC(10,2): wanted 50.00 (clamped), got %s (%s)", z, z.Context.Conditions)
+	}
+	if ctx := decimal.ContextOracleNumber; ctx.Precision != 38 || ctx.MaxScale != 125 || ctx.MinScale != -130 {
+		t.Fatalf("NUMBER: unexpected Context %+v", ctx)
+	}
+	if ctx := decimal.ContextMySQLDecimal; ctx.Precision != 65 || ctx.MaxScale != 34 || ctx.MinScale != -30 {
+		t.Fatalf("DECIMAL(65,30): unexpected Context %+v", ctx)
+	}
+
+	if _, err := decimal.NumericColumn(0, 0); err == nil {
+		t.Fatal("NumericColumn(0, 0): wanted an error")
+	}
+	z = decimal.ContextNumeric(0, 0).Add(new(decimal.Big), decimal.New(1, 0), decimal.New(2, 0))
+	if !z.IsNaN(0) || z.Context.Conditions != decimal.InvalidContext {
+		t.Fatalf("ContextNumeric(0, 0): wanted NaN with InvalidContext, got %s (%s)", z, z.Context.Conditions)
+	}
+}
@@ -0,0 +1,156 @@
+package decimal_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+// conformanceCase is a single test case generated by
+// _testdata/conformance.py using CPython's decimal module.
+type conformanceCase struct {
+	Op    string `json:"op"`
+	Prec  int    `json:"prec"`
+	Mode  string `json:"mode"`
+	X     string `json:"x"`
+	Y     string `json:"y"`
+	R     string `json:"r"`
+	Exp   int64  `json:"exp"`
+	Conds string `json:"conds"`
+}
+
+var conformanceModes = map[string]decimal.RoundingMode{
+	"=0": decimal.ToNearestEven,
+	"=^": decimal.ToNearestAway,
+	"0":  decimal.ToZero,
+	"<":  decimal.ToNegativeInf,
+	">":  decimal.ToPositiveInf,
+	"^":  decimal.AwayFromZero,
+}
+
+var conformanceConds = map[byte]decimal.Condition{
+	'c': decimal.Clamped,
+	'z': decimal.DivisionByZero,
+	'x': decimal.Inexact,
+	'i': decimal.InvalidOperation,
+	'o': decimal.Overflow,
+	'r': decimal.Rounded,
+	's': decimal.Subnormal,
+	'u': decimal.Underflow,
+}
+
+// pythonConds converts c into the set of conditions Python would report.
+// Python reports DivisionImpossible and DivisionUndefined as InvalidOperation,
+// which this package raises alongside both.
+func pythonConds(c decimal.Condition) decimal.Condition {
+	return c &^ (decimal.DivisionImpossible | decimal.DivisionUndefined)
+}
+
+// pythonMinEmin is MIN_EMIN, the Emin of the contexts in conformance.py. It is
+// the package's MinScale on 64-bit platforms.
+const pythonMinEmin = -999999999999999999
+
+// conformanceExp returns the exponent this package should produce for the
+// exponent exp that Python produced at the given precision. Python's Etiny,
+// MIN_EMIN - (prec - 1), is Etiny with this package's MinScale, which is
+// closer to zero on 32-bit platforms.
+func conformanceExp(exp int64, prec int) int {
+	if exp == pythonMinEmin-int64(prec-1) {
+		return decimal.MinScale - (prec - 1)
+	}
+	return int(exp)
+}
+
+func TestPythonConformance(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.Join("_testdata", "conformance.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cases []conformanceCase
+	if err := json.Unmarshal(data, &cases); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, c := range cases {
+		ctx := decimal.Context{
+			Precision:    c.Prec,
+			RoundingMode: conformanceModes[c.Mode],
+		}
+		var want decimal.Condition
+		for j := 0; j < len(c.Conds); j++ {
+			want |= conformanceConds[c.Conds[j]]
+		}
+
+		x, _ := decimal.WithContext(ctx).SetString(c.X)
+		z := decimal.WithContext(ctx)
+		switch c.Op {
+		case "quo":
+			y, _ := new(decimal.Big).SetString(c.Y)
+			z.Quo(x, y)
+		case "quoint":
+			y, _ := new(decimal.Big).SetString(c.Y)
+			z.QuoInt(x, y)
+			q, _ := decimal.WithContext(ctx).QuoRem(x, y, decimal.WithContext(ctx))
+			checkQuoRem(t, i, c, "quotient", q, z)
+		case "rem":
+			y, _ := new(decimal.Big).SetString(c.Y)
+			z.Rem(x, y)
+			_, r := decimal.WithContext(ctx).QuoRem(x, y, decimal.WithContext(ctx))
+			checkQuoRem(t, i, c, "remainder", r, z)
+		case "quant":
+			n, _ := strconv.Atoi(c.Y)
+			z = x.Quantize(n)
+		case "cmp":
+			// Cmp does not modify its operands, so it cannot raise any
+			// conditions. Only the result is checked.
+			if c.R == "NaN" {
+				continue
+			}
+			y, _ := new(decimal.Big).SetString(c.Y)
+			if r, _ := strconv.Atoi(c.R); x.Cmp(y) != r {
+				t.Fatalf("#%d: Cmp(%s, %s): wanted %d, got %d", i, c.X, c.Y, r, x.Cmp(y))
+			}
+			continue
+		default:
+			t.Fatalf("#%d: unknown op %q", i, c.Op)
+		}
+
+		r, _ := new(decimal.Big).SetString(c.R)
+		got := pythonConds(z.Context.Conditions)
+		ok := got == want && z.Signbit() == r.Signbit()
+		switch {
+		case r.IsNaN(0):
+			ok = ok && z.IsNaN(0)
+		case r.IsInf(0):
+			ok = ok && z.IsInf(0)
+		default:
+			ok = ok && z.IsFinite() && z.Cmp(r) == 0 && -z.Scale() == conformanceExp(c.Exp, c.Prec)
+		}
+		if !ok {
+			t.Errorf(`#%d: %s(%s, %s) (prec: %d, mode: %s)
+wanted: %s (%s, exp: %d)
+got   : %s (%s, exp: %d)
+`, i, c.Op, c.X, c.Y, c.Prec, ctx.RoundingMode,
+				c.R, want, c.Exp, z, z.Context.Conditions, -z.Scale())
+		}
+	}
+}
+
+// checkQuoRem checks that got, the result of QuoRem, is identical to want, the
+// result of QuoInt or Rem.
+func checkQuoRem(t *testing.T, i int, c conformanceCase, part string, got, want *decimal.Big) {
+	if got.String() != want.String() ||
+		(got.IsFinite() && got.Scale() != want.Scale()) ||
+		got.Context.Conditions != want.Context.Conditions {
+		t.Errorf(`#%d: QuoRem(%s, %s) %s (prec: %d)
+wanted: %s (%s, exp: %d)
+got   : %s (%s, exp: %d)
+`, i, c.X, c.Y, part, c.Prec,
+			want, want.Context.Conditions, -want.Scale(),
+			got, got.Context.Conditions, -got.Scale())
+	}
+}
@@ -0,0 +1,125 @@
+package decimal
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/ericlagergren/decimal/internal/arith"
+	cst "github.com/ericlagergren/decimal/internal/c"
+)
+
+// Pi sets z to π, correctly rounded using z.Context's precision and
+// RoundingMode, and returns z. Inexact and Rounded are always raised.
+//
+// The digits of π are computed as they are needed and cached, so later calls
+// with at most the same precision are fast. If z.Context.Precision is
+// UnlimitedPrecision, z is set to NaN and InvalidContext is raised.
+func Pi(z *Big) *Big {
+	mustNotNil("Pi", z, z)
+	return z.Context.constant(z, &piCache)
+}
+
+// E sets z to Euler's number e, correctly rounded using z.Context's precision
+// and RoundingMode, and returns z. Like Pi, it caches the digits it computes.
+func E(z *Big) *Big {
+	mustNotNil("E", z, z)
+	return z.Context.constant(z, &eCache)
+}
+
+// constant sets z to the constant cached by cc, correctly rounded using c, and
+// returns z.
+func (c Context) constant(z *Big, cc *constCache) *Big {
+	if z.invalidContext(c) {
+		return z
+	}
+	if precision(c) == UnlimitedPrecision {
+		return z.setNaN(InvalidContext, qnan, constunlim)
+	}
+	return c.ziv(z, func(wp int) (*big.Int, *big.Int, int) {
+		return cc.fixed(wp), cst.OneInt, wp
+	})
+}
+
+var (
+	piCache = constCache{gen: piDigits}
+	eCache  = constCache{gen: eDigits}
+)
+
+// constCache caches the digits of a positive mathematical constant.
+type constCache struct {
+	mu sync.Mutex
+	f  int      // number of digits after the decimal point in v
+	v  *big.Int // the constant × 10**f, truncated
+
+	// gen returns the constant × 10**f, truncated.
+	gen func(f int) *big.Int
+}
+
+// fixed returns the constant × 10**f, truncated, computing more digits if f
+// exceeds the number cached. The result may be modified by the caller.
+func (cc *constCache) fixed(f int) *big.Int {
+	cc.mu.Lock()
+	if f > cc.f {
+		// Grow geometrically so that a Ziv loop computes few generations.
+		g := 2 * cc.f
+		if g < f {
+			g = f
+		}
+		if g < 64 {
+			g = 64
+		}
+		cc.v, cc.f = cc.gen(g), g
+	}
+	v, cf := cc.v, cc.f
+	cc.mu.Unlock()
+	return new(big.Int).Quo(v, arith.BigPow10(uint64(cf-f)))
+}
+
+// piFixed returns π × 10**f, truncated.
+func piFixed(f int) *big.Int { return piCache.fixed(f) }
+
+// piDigits returns π × 10**f, truncated, using Machin's formula
+//
+//	π = 16×atan(1/5) - 4×atan(1/239)
+func piDigits(f int) *big.Int {
+	g := f + arith.Length(uint64(f)) + 3
+	one := arith.BigPow10(uint64(g))
+	s := atanInv(5, one)
+	s.Lsh(s, 4)
+	t := atanInv(239, one)
+	s.Sub(s, t.Lsh(t, 2))
+	return s.Quo(s, arith.BigPow10(uint64(g-f)))
+}
+
+// atanInv returns atan(1/q) × one, truncated, using the series
+//
+//	atan(1/q) = Σ (-1)**i / ((2i+1) × q**(2i+1))
+func atanInv(q int64, one *big.Int) *big.Int {
+	p := new(big.Int).Quo(one, big.NewInt(q))
+	q2 := big.NewInt(q * q)
+	var s, t big.Int
+	for i := int64(1); p.Sign() != 0; i += 2 {
+		if t.Quo(p, t.SetInt64(i)); i&2 != 0 {
+			t.Neg(&t)
+		}
+		s.Add(&s, &t)
+		p.Quo(p, q2)
+	}
+	return &s
+}
+
+// eDigits returns e × 10**f, truncated, using the series
+//
+//	e = Σ 1/k!
+func eDigits(f int) *big.Int {
+	// Each term is truncated, so the sum is low by at most one unit per term.
+	g := f + arith.Length(uint64(f)) + 3
+	t := new(big.Int).Set(arith.BigPow10(uint64(g)))
+	s := new(big.Int)
+	var k big.Int
+	for i := int64(1); t.Sign() != 0; i++ {
+		s.Add(s, t)
+		t.Quo(t, k.SetInt64(i))
+	}
+	return s.Quo(s, arith.BigPow10(uint64(g-f)))
+}
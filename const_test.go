@@ -0,0 +1,82 @@
+package decimal_test
+
+import (
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestPi_E(t *testing.T) {
+	for _, test := range [...]struct {
+		name string
+		fn   func(*decimal.Big) *decimal.Big
+		want [6]string // indexed by RoundingMode
+		long string    // 100 digits, rounded to nearest
+	}{
+		{
+			"Pi", decimal.Pi,
+			[6]string{
+				"3.141592653589793238462643383279503",
+				"3.141592653589793238462643383279503",
+				"3.141592653589793238462643383279502",
+				"3.141592653589793238462643383279503",
+				"3.141592653589793238462643383279502",
+				"3.141592653589793238462643383279503",
+			},
+			"3.141592653589793238462643383279502884197169399375105820974944592307816406286208998628034825342117068",
+		},
+		{
+			"E", decimal.E,
+			[6]string{
+				"2.718281828459045235360287471352662",
+				"2.718281828459045235360287471352662",
+				"2.718281828459045235360287471352662",
+				"2.718281828459045235360287471352663",
+				"2.718281828459045235360287471352662",
+				"2.718281828459045235360287471352663",
+			},
+			"2.718281828459045235360287471352662497757247093699959574966967627724076630353547594571382178525166427",
+		},
+	} {
+		// Check the short results before and after the long result has been
+		// cached.
+		for i := 0; i < 2; i++ {
+			for m, want := range test.want {
+				ctx := decimal.Context128
+				ctx.RoundingMode = decimal.ToNearestEven + decimal.RoundingMode(m)
+				z := test.fn(decimal.WithContext(ctx))
+				const c = decimal.Inexact | decimal.Rounded
+				if z.String() != want || z.Context.Conditions != c {
+					t.Fatalf("%s (%s): wanted %s (%s), got %s (%s)",
+						test.name, decimal.ToNearestEven+decimal.RoundingMode(m), want, c, z, z.Context.Conditions)
+				}
+			}
+			z := test.fn(decimal.WithPrecision(100))
+			if z.String() != test.long {
+				t.Fatalf("%s (100 digits): wanted %s, got %s", test.name, test.long, z)
+			}
+		}
+
+		z := test.fn(decimal.WithPrecision(2000))
+		if s := z.String(); s[:50] != test.long[:50] {
+			t.Fatalf("%s (2000 digits): wanted %s..., got %s...", test.name, test.long[:50], s[:50])
+		}
+
+		z = decimal.WithContext(decimal.Context{
+			Precision:     decimal.UnlimitedPrecision,
+			OperatingMode: decimal.GDA,
+		})
+		if test.fn(z); !z.IsNaN(0) || z.Context.Conditions&decimal.InvalidContext == 0 {
+			t.Fatalf("%s (unlimited): wanted NaN (%s), got %s (%s)",
+				test.name, decimal.InvalidContext, z, z.Context.Conditions)
+		}
+	}
+}
+
+func BenchmarkPi(b *testing.B) {
+	b.ReportAllocs()
+	z := decimal.WithContext(decimal.Context128)
+	for i := 0; i < b.N; i++ {
+		decimal.Pi(z)
+	}
+}
@@ -1,8 +1,11 @@
 package decimal
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/ericlagergren/decimal/internal/c"
 )
@@ -11,27 +14,42 @@ import (
 const (
 	MaxScale           = c.MaxScale       // largest allowed scale.
 	MinScale           = -MaxScale        // smallest allowed scale.
-	MaxPrecision       = MaxScale         // largest allowed Context precision.
+	MaxPrecision       = MaxScale         // largest possible precision; see MaxPracticalPrecision.
 	MinPrecision       = 1                // smallest allowed Context precision.
 	UnlimitedPrecision = MaxPrecision + 1 // no precision, but may error.
 	DefaultPrecision   = 16               // default precision for literals.
 )
 
+// MaxPracticalPrecision is the largest Context precision, other than
+// UnlimitedPrecision, accepted by arithmetic operations; a larger precision
+// raises InvalidContext. Operations pad their operands with up to Precision
+// zeros, so a precision approaching MaxPrecision would exhaust memory (or time)
+// instead of failing. A coefficient with MaxPracticalPrecision digits occupies
+// about 40 MB.
+const MaxPracticalPrecision = 100000000
+
 // Context is a per-decimal contextual object that governs specific operations.
 type Context struct {
 	// MaxScale overrides the MaxScale constant so long as it's in the range
-	// (0, MaxScale].
+	// (0, MaxScale]. It is the largest adjusted exponent of a finite result,
+	// Emax in the GDA specification: a result with a larger adjusted exponent
+	// raises Overflow and is rounded to the infinity with its sign or, if the
+	// RoundingMode rounds it toward zero, to the largest finite value.
 	MaxScale int
 
-	// MinScale overrides the MaxScale constant so long as it's in the range
-	// [MinScale, 0).
+	// MinScale overrides the MinScale constant so long as it's in the range
+	// [MinScale, 0). It is the smallest adjusted exponent of a normal result,
+	// Emin in the GDA specification: a non-zero result with a smaller adjusted
+	// exponent raises Subnormal and has an exponent of at least Etiny, MinScale
+	// - (Precision - 1), rounding it to Etiny if necessary. If rounding
+	// discards non-zero digits, Underflow is raised as well.
 	MinScale int
 
 	// Precision is the Context's precision; that is, the maximum number of
 	// significant digits that may result from any arithmetic operation.
 	// Excluding any package-defined constants (e.g., ``UnlimitedPrecision''),
-	// if precision is not in the range [1, MaxPrecision] operations might
-	// result in an error. A precision of 0 will be interpreted as
+	// if precision is not in the range [1, MaxPracticalPrecision] operations
+	// might result in an error. A precision of 0 will be interpreted as
 	// DefaultPrecision. For example,
 	//
 	//   precision ==  4 // 4
@@ -54,6 +72,145 @@ type Context struct {
 	// OperatingMode which dictates how the decimal operates under certain
 	// conditions. See OperatingMode for more information.
 	OperatingMode OperatingMode
+
+	// Clamp, if true, limits the exponent of a finite result to MaxScale -
+	// (Precision - 1), as IEEE 754 interchange formats such as decimal128
+	// require and as decNumber's clamp does. A result with a larger exponent
+	// that does not overflow has its coefficient padded with zeros to reduce
+	// its exponent, which raises Clamped; for example, under Context128 with
+	// Clamp set, 1E+6144 is stored as the 34-digit coefficient 10**33 with an
+	// exponent of 6111. Quantize raises InvalidOperation if the requested
+	// exponent is larger than the limit. Clamp has no effect with
+	// UnlimitedPrecision.
+	Clamp bool
+
+	// ExactOnly requires arithmetic to either be exact or fail. Add, Sub, and
+	// Mul ignore Precision and always compute exact results. Quo fails unless
+	// the exact quotient has at most Precision digits, and Quantize fails if
+	// it would discard any non-zero digits.
+	//
+	// A failed operation sets its result to NaN and raises InvalidOperation,
+	// Inexact, and Rounded, after which Err returns an ErrNotExact describing
+	// the failure. In Go mode it panics with the ErrNotExact instead.
+	ExactOnly bool
+
+	// Underscores, if true, allows methods that parse decimals to accept
+	// underscores between digits, as in 1_000_000.000_1 or 1e1_000, like Go
+	// and JavaScript numeric literals. As in Go, each underscore must separate
+	// two digits; one next to a sign, the decimal point, or the exponent
+	// indicator raises ConversionSyntax. If false, only the strict GDA syntax
+	// is accepted.
+	Underscores bool
+
+	// Specials determines how NaN and infinite values are encoded by
+	// MarshalText, MarshalJSON, and the SQL Valuer. See SpecialsPolicy.
+	Specials SpecialsPolicy
+
+	// QuoteUnsafeJSON, if true, makes MarshalJSON encode finite values that
+	// might not survive a round trip through a JavaScript Number as JSON
+	// strings instead of JSON numbers. See Big.MarshalJSON.
+	QuoteUnsafeJSON bool
+
+	// MaxParseBytes and MaxParseDigits limit the length of the input, in bytes,
+	// and the number of digits in its coefficient, respectively, accepted by
+	// methods that parse decimals (e.g., SetString, UnmarshalText, and Scan).
+	// Input exceeding MaxParseBytes raises ConversionSyntax; input exceeding
+	// MaxParseDigits raises InsufficientStorage. In both cases the result is
+	// NaN and the method returns an ErrParseLimit, if it returns an error.
+	//
+	// A negative value means unlimited. Zero means the package-level limit of
+	// the same name in GDA and JS mode, and unlimited in Go mode.
+	MaxParseBytes  int
+	MaxParseDigits int
+
+	// GuardDigits is the number of extra digits of precision carried by the
+	// intermediate results of multi-step operations; only the final result is
+	// rounded to Precision. It is honored by Sum, Dot, and the functions in
+	// package math that compute their results in multiple steps (Exp, Log,
+	// Log10, Pow, Sqrt, Hypot, and the trigonometric functions). FMA and
+	// QuoRem compute their results exactly before rounding once, so they need
+	// no guard digits.
+	//
+	// Zero means no extra digits. A negative value raises InvalidContext.
+	GuardDigits int
+
+	// CompatLevel pins operations whose results depend on the choice of
+	// algorithm (e.g., the series cutoffs of transcendental functions, the
+	// number of guard digits used by multi-step operations, and how overflow
+	// saturates) to the behavior of a numbered compatibility level. A result
+	// computed at a given level is identical, digit for digit, in every later
+	// release of this package.
+	//
+	// Zero means the latest level, LatestCompatLevel, whose results may change
+	// between releases as algorithms improve. Any other value must be in the
+	// range [1, LatestCompatLevel]; otherwise, operations raise InvalidContext.
+	CompatLevel int
+
+	// hooks are the Context's Tracer, TagPolicy, and other rarely used
+	// settings, and per-value state such as the tag of the decimal that owns
+	// the Context.
+	hooks *hooks
+}
+
+// Default limits for Context.MaxParseBytes and Context.MaxParseDigits. A value
+// less than or equal to zero means unlimited.
+var (
+	MaxParseBytes  = 1 << 22
+	MaxParseDigits = 1 << 20
+)
+
+// LatestCompatLevel is the most recent compatibility level. A change that could
+// alter any digit of a result computed at LatestCompatLevel must increment it
+// and preserve the previous behavior for Contexts that request the older level.
+//
+// Level 1 is the behavior of this package when compatibility levels were
+// introduced. Level 2 rounds results that overflow according to the
+// RoundingMode, to the largest finite value or the infinity with the result's
+// sign, and uses the Context's MinScale to compute Etiny, the smallest exponent
+// of a subnormal. Level 3 computes the functions of the math package that this
+// package also provides (e.g., Exp, Log, Pow, Sqrt, Sin, and Pi) with this
+// package's correctly rounded implementations.
+const LatestCompatLevel = 3
+
+// guarded returns the Context used for the intermediate results of multi-step
+// operations: c with GuardDigits extra digits of precision, and without any
+// conditions or hooks.
+func (c Context) guarded() Context {
+	if p := precision(c); c.GuardDigits > 0 && p < MaxPracticalPrecision {
+		// Compare before adding, since p+GuardDigits may overflow.
+		c.Precision = MaxPracticalPrecision
+		if c.GuardDigits < MaxPracticalPrecision-p {
+			c.Precision = p + c.GuardDigits
+		}
+	}
+	c.Conditions = 0
+	c.hooks = nil
+	return c
+}
+
+// Compat returns the compatibility level c selects: c.CompatLevel, or
+// LatestCompatLevel if c.CompatLevel is zero.
+func (c Context) Compat() int {
+	if c.CompatLevel == 0 {
+		return LatestCompatLevel
+	}
+	return c.CompatLevel
+}
+
+// parseLimits returns the maximum number of bytes and digits that c permits
+// when parsing. Zero means unlimited.
+func (c Context) parseLimits() (nbytes, ndigits int) {
+	limit := func(n, def int) int {
+		switch {
+		case n > 0:
+			return n
+		case n < 0 || c.OperatingMode == Go || def < 0:
+			return 0
+		default:
+			return def
+		}
+	}
+	return limit(c.MaxParseBytes, MaxParseBytes), limit(c.MaxParseDigits, MaxParseDigits)
 }
 
 func (c Context) maxScale() int {
@@ -70,18 +227,178 @@ func (c Context) minScale() int {
 	return MinScale
 }
 
-// Err returns non-nil if there are any trapped exceptional conditions.
+// Validate returns an ErrInvalidSetting describing the first invalid setting
+// of c, or nil if c is valid. Operations performed with an invalid Context set
+// their result to NaN, with a payload naming the setting, and raise
+// InvalidContext; in Go mode, they panic with the error Validate returns.
+//
+// A Context is valid if its Precision is in [0, MaxPracticalPrecision] or is
+// UnlimitedPrecision, its RoundingMode and OperatingMode are among the defined
+// constants, its MaxScale is in [0, MaxScale] and its MinScale in [MinScale,
+// 0], so that MaxScale >= MinScale, its CompatLevel is in [0,
+// LatestCompatLevel], and its GuardDigits is not negative.
+func (c Context) Validate() error {
+	e := ErrInvalidSetting{}
+	switch c.invalid() {
+	case 0:
+		return nil
+	case invctxpltz:
+		e = ErrInvalidSetting{Field: "Precision", Value: c.Precision, Msg: "less than zero"}
+	case invctxpgtu:
+		e = ErrInvalidSetting{Field: "Precision", Value: c.Precision, Msg: "greater than UnlimitedPrecision"}
+	case invctxpgtp:
+		e = ErrInvalidSetting{Field: "Precision", Value: c.Precision, Msg: "greater than MaxPracticalPrecision"}
+	case invctxrmode:
+		e = ErrInvalidSetting{Field: "RoundingMode", Value: int(c.RoundingMode), Msg: "not a RoundingMode constant"}
+	case invctxomode:
+		e = ErrInvalidSetting{Field: "OperatingMode", Value: int(c.OperatingMode), Msg: "not an OperatingMode constant"}
+	case invctxsgtu:
+		e = ErrInvalidSetting{Field: "MaxScale", Value: c.MaxScale, Msg: "greater than MaxScale"}
+	case invctxsltz:
+		e = ErrInvalidSetting{Field: "MaxScale", Value: c.MaxScale, Msg: "less than zero"}
+	case invctxsltu:
+		e = ErrInvalidSetting{Field: "MinScale", Value: c.MinScale, Msg: "less than MinScale"}
+	case invctxsgtz:
+		e = ErrInvalidSetting{Field: "MinScale", Value: c.MinScale, Msg: "greater than zero"}
+	case invctxcompat:
+		e = ErrInvalidSetting{Field: "CompatLevel", Value: c.CompatLevel, Msg: "not in [0, LatestCompatLevel]"}
+	case invctxguard:
+		e = ErrInvalidSetting{Field: "GuardDigits", Value: c.GuardDigits, Msg: "less than zero"}
+	}
+	return e
+}
+
+// Err returns non-nil if there are any trapped exceptional conditions, or if an
+// operation failed because the Context that performed it had ExactOnly set,
+// whether or not c has ExactOnly set.
+//
+// The error is an ErrNotExact in the latter case, and otherwise a
+// ConditionError holding every trapped flag that was raised and, if it is
+// known, the most recent operation that raised one. Either way, errors.Is
+// reports which Conditions it contains, even if it is wrapped; e.g.,
+// errors.Is(err, ErrDivisionByZero) distinguishes a division by zero from an
+// Overflow, and errors.As(err, &c), where c is a Condition, retrieves the
+// flags.
 func (c Context) Err() error {
+	if e := c.inexact(); e != nil && c.Conditions&(InvalidOperation|Inexact) == InvalidOperation|Inexact {
+		return *e
+	}
 	if m := c.Conditions & c.Traps; m != 0 {
-		return m
+		e := ConditionError{Cond: m}
+		// The record is stale if its Conditions have since been cleared.
+		if t := c.trap(); t != nil && c.Conditions&t.Cond == t.Cond {
+			e.Op, e.Operands = t.Op, t.Operands
+		}
+		return e
 	}
 	return nil
 }
 
+// DoOption modifies the behavior of Context.Do.
+type DoOption uint8
+
+const (
+	// MergeConditions merges the conditions raised by Do's function, and any
+	// ExactOnly failure, into the Context on which Do was called.
+	MergeConditions DoOption = 1 << iota
+)
+
+// Do calls f with a child of c that has the same settings (precision,
+// rounding mode, traps, and so on) but no conditions, and returns the
+// conditions raised within f and the error, if any, that Err would report for
+// them. c is not modified unless MergeConditions is provided.
+//
+// The conditions raised within f are those raised by the child Context's
+// methods, those raised by the methods of decimals whose Context is a copy of
+// the child (e.g., decimal.WithContext(*ctx)), and any set directly on the
+// child's Conditions. Since a call to Do with MergeConditions sets the
+// conditions on the Context it was called on, nested calls compose: the
+// conditions of an inner Do are reported by the outer Do if and only if they
+// were merged.
+//
+// The decimals used within f still accumulate conditions as usual.
+func (c *Context) Do(f func(ctx *Context), opts ...DoOption) (Condition, error) {
+	var opt DoOption
+	for _, o := range opts {
+		opt |= o
+	}
+
+	s := new(condScope)
+	child := *c
+	child.Conditions = 0
+	child.hooks = child.hooks.update(func(h *hooks) {
+		h.scope = s
+		h.inexact, h.trap = nil, nil
+	})
+	f(&child)
+
+	r := child
+	r.Conditions |= s.conds
+	if s.inexact != nil || s.trap != nil {
+		r.hooks = r.hooks.update(func(h *hooks) { h.inexact, h.trap = s.inexact, s.trap })
+	}
+	if opt&MergeConditions != 0 {
+		c.Conditions |= r.Conditions
+		if e, t := r.inexact(), r.trap(); e != nil || t != nil {
+			c.hooks = c.hooks.update(func(h *hooks) {
+				if e != nil {
+					h.inexact = e
+				}
+				if t != nil {
+					h.trap = t
+				}
+			})
+		}
+	}
+	return r.Conditions, r.Err()
+}
+
+// inexact returns the most recent failure caused by ExactOnly recorded in c, or
+// nil if there is none.
+func (c Context) inexact() *ErrNotExact {
+	if c.hooks == nil {
+		return nil
+	}
+	return c.hooks.inexact
+}
+
+// trap returns the most recent operation that raised a trapped Condition
+// recorded in c, or nil if there is none.
+func (c Context) trap() *ConditionError {
+	if c.hooks == nil {
+		return nil
+	}
+	return c.hooks.trap
+}
+
+// condScope collects the conditions raised within a call to Context.Do.
+type condScope struct {
+	mu      sync.Mutex
+	conds   Condition
+	inexact *ErrNotExact
+	trap    *ConditionError
+}
+
+// add adds conds, the conditions raised by an operation, to s, along with
+// inexact if the operation failed because ExactOnly was set, and trap if it
+// raised a trapped Condition.
+func (s *condScope) add(conds Condition, inexact *ErrNotExact, trap *ConditionError) {
+	s.mu.Lock()
+	s.conds |= conds
+	if inexact != nil && conds&Inexact != 0 {
+		s.inexact = inexact
+	}
+	if trap != nil {
+		s.trap = trap
+	}
+	s.mu.Unlock()
+}
+
 // WithContext is shorthand to create a Big decimal from a Context.
 func WithContext(c Context) *Big {
 	z := new(Big)
 	z.Context = c
+	z.Context.hooks = c.hooks.settings()
 	return z
 }
 
@@ -89,10 +406,12 @@ func WithContext(c Context) *Big {
 func WithPrecision(p int) *Big {
 	z := new(Big)
 	switch {
-	case p > 0 && p <= UnlimitedPrecision:
+	case p > 0 && p <= MaxPracticalPrecision, p == UnlimitedPrecision:
 		z.Context.Precision = p
 	case p == 0:
 		z.Context.Precision = DefaultPrecision
+	case p > 0 && p <= UnlimitedPrecision:
+		z.setNaN(InvalidContext, qnan, invctxpgtp)
 	default:
 		z.setNaN(InvalidContext, qnan, invctxpgtu)
 	}
@@ -152,18 +471,37 @@ type RoundingMode uint8
 
 // The following rounding modes are supported.
 const (
-	ToNearestEven RoundingMode = iota // == IEEE 754-2008 roundTiesToEven
-	ToNearestAway                     // == IEEE 754-2008 roundTiesToAway
-	ToZero                            // == IEEE 754-2008 roundTowardZero
-	AwayFromZero                      // no IEEE 754-2008 equivalent
-	ToNegativeInf                     // == IEEE 754-2008 roundTowardNegative
-	ToPositiveInf                     // == IEEE 754-2008 roundTowardPositive
+	DefaultRounding RoundingMode = iota // ToNearestEven, or ToNearestAway in JS mode
+	ToNearestEven                       // == IEEE 754-2008 roundTiesToEven
+	ToNearestAway                       // == IEEE 754-2008 roundTiesToAway
+	ToZero                              // == IEEE 754-2008 roundTowardZero
+	AwayFromZero                        // no IEEE 754-2008 equivalent
+	ToNegativeInf                       // == IEEE 754-2008 roundTowardNegative
+	ToPositiveInf                       // == IEEE 754-2008 roundTowardPositive
 
 	unnecessary // placeholder for x / y with UnlimitedPrecision.
 )
 
 //go:generate stringer -type RoundingMode
 
+// roundingMode returns the RoundingMode that c rounds with, which is c's
+// RoundingMode unless it is DefaultRounding. See DefaultRounding.
+func (c Context) roundingMode() RoundingMode {
+	return c.RoundingMode.resolve(c.OperatingMode)
+}
+
+// resolve returns the RoundingMode that m stands for under the OperatingMode
+// o, which is m itself unless m is DefaultRounding.
+func (m RoundingMode) resolve(o OperatingMode) RoundingMode {
+	if m != DefaultRounding {
+		return m
+	}
+	if o == JS {
+		return ToNearestAway
+	}
+	return ToNearestEven
+}
+
 func (m RoundingMode) needsInc(odd bool, r int, pos bool) bool {
 	switch m {
 	case AwayFromZero:
@@ -192,6 +530,16 @@ func (m RoundingMode) needsInc(odd bool, r int, pos bool) bool {
 
 // OperatingMode dictates how the decimal approaches specific non-numeric
 // operations like conversions to strings and panicking on NaNs.
+//
+// Decimals with different OperatingModes may be mixed. An operation is
+// governed by its receiver's OperatingMode alone: z's for a method that sets
+// z, such as z.Add(x, y), and x's for a method that only reads x, such as
+// x.String or x.MarshalJSON. The OperatingModes of the operands are ignored, so
+// z.Add(x, y) behaves the same whatever the modes of x and y. A Context method,
+// such as c.Add(z, x, y), rounds according to c, but whether an invalid
+// operation panics depends on z's OperatingMode, since z receives the NaN.
+// Comparisons, like Cmp, do not depend on any OperatingMode. Set CheckModeMix
+// to find operations that mix modes.
 type OperatingMode uint8
 
 const (
@@ -216,10 +564,35 @@ const (
 	//     "+Inf", and "-Inf", respectively
 	//
 	Go
+	// JS mirrors the defaults of decimal.js. In particular:
+	//
+	//  - like GDA, it does not panic, and it raises conditions and honors
+	//    traps
+	//  - arithmetic operations are rounded to the proper precision, but Set
+	//    is not: like decimal.js's constructor, it copies the value as is
+	//  - the zero value of RoundingMode, DefaultRounding, rounds like
+	//    ToNearestAway, which is decimal.js's default ROUND_HALF_UP, rather
+	//    than ToNearestEven
+	//  - its string forms of qNaN, sNaN, +Inf, and -Inf are "NaN", "NaN",
+	//    "Infinity", and "-Infinity", respectively
+	//  - String removes trailing zeros and uses exponential notation, with a
+	//    lower-case 'e', if the adjusted exponent is at most -7 or at least
+	//    21, as decimal.js's toString does; e.g., 1.50 is "1.5", 1E-7 is
+	//    "1e-7", and -0 is "-0"
+	//
+	JS
 )
 
 //go:generate stringer -type OperatingMode
 
+// CheckModeMix, if true, makes mixing OperatingModes an error, to help find
+// decimals accidentally used in the wrong mode. An operation whose operands'
+// OperatingModes differ from the mode that governs it sets z to NaN and raises
+// InvalidContext (and, in Go mode, panics), and a comparison of decimals with
+// different OperatingModes panics with an ErrModeMix. It is intended for
+// debugging and should only be set before the package is used.
+var CheckModeMix = false
+
 // Condition is a bitmask value raised after or during specific operations. For
 // example, dividing by zero is undefined so a DivisionByZero Condition flag
 // will be set in the decimal's Context.
@@ -284,8 +657,53 @@ const (
 	Underflow
 )
 
+// Sentinel errors, one for each Condition flag. Each is the flag itself, so
+// errors.Is(err, ErrOverflow) reports whether err, such as the error returned
+// by Context.Err or an ErrNotExact, contains Overflow, even if other Conditions
+// were raised along with it or err has been wrapped.
+var (
+	ErrClamped             error = Clamped
+	ErrConversionSyntax    error = ConversionSyntax
+	ErrDivisionByZero      error = DivisionByZero
+	ErrDivisionImpossible  error = DivisionImpossible
+	ErrDivisionUndefined   error = DivisionUndefined
+	ErrInexact             error = Inexact
+	ErrInsufficientStorage error = InsufficientStorage
+	ErrInvalidContext      error = InvalidContext
+	ErrInvalidOperation    error = InvalidOperation
+	ErrOverflow            error = Overflow
+	ErrRounded             error = Rounded
+	ErrSubnormal           error = Subnormal
+	ErrUnderflow           error = Underflow
+)
+
 func (c Condition) Error() string { return c.String() }
 
+// Is reports whether target is a non-zero Condition whose flags are all set in
+// c. Together with Unwrap, it allows errors.Is to test an error for any
+// Condition, or combination of Conditions, that it contains; e.g.,
+// errors.Is(ctx.Err(), Overflow) is true even if Inexact and Rounded were
+// raised along with Overflow.
+func (c Condition) Is(target error) bool {
+	t, ok := target.(Condition)
+	return ok && t != 0 && c&t == t
+}
+
+// Unwrap returns each of the flags set in c as a separate Condition, or nil if
+// c has fewer than two flags set.
+func (c Condition) Unwrap() []error {
+	if c&(c-1) == 0 {
+		return nil
+	}
+	var errs []error
+	for i := Condition(1); i != 0 && i <= c; i <<= 1 {
+		if c&i != 0 {
+			errs = append(errs, i)
+		}
+	}
+	return errs
+}
+
 func (c Condition) String() string {
 	if c == 0 {
 		return ""
@@ -332,3 +750,108 @@ func (c Condition) String() string {
 }
 
 var _ error = Condition(0)
+
+// conditionCodes maps each Condition flag, by bit position, to its code.
+var conditionCodes = [...]string{
+	"clamped",
+	"conversion_syntax",
+	"division_by_zero",
+	"division_impossible",
+	"division_undefined",
+	"inexact",
+	"insufficient_storage",
+	"invalid_context",
+	"invalid_operation",
+	"overflow",
+	"rounded",
+	"subnormal",
+	"underflow",
+}
+
+// Code returns a short, stable, machine-readable code for c, suitable for use
+// in API responses. For example, DivisionByZero's code is "division_by_zero".
+// If c has multiple flags set, their codes are joined by commas in the same
+// order as String. Code returns "" if c is zero or contains unknown flags.
+func (c Condition) Code() string {
+	var b strings.Builder
+	for i := 0; c != 0; i++ {
+		if c&(1<<uint(i)) == 0 {
+			continue
+		}
+		if i >= len(conditionCodes) {
+			return ""
+		}
+		c &^= 1 << uint(i)
+		if b.Len() > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(conditionCodes[i])
+	}
+	return b.String()
+}
+
+// ParseCondition is the inverse of Condition.String and Condition.Code. It
+// returns the Condition described by s, a list of conditions separated by
+// commas, such as "inexact, rounded". It ignores case and the spaces around
+// each condition, and accepts a code in place of a name (e.g.,
+// "Division_By_Zero"). An empty s is zero, and "unknown(n)", which String
+// produces for a flag n without a name, is n.
+//
+// If s contains an unknown condition, ParseCondition returns an error that
+// includes it.
+func ParseCondition(s string) (Condition, error) {
+	if strings.TrimSpace(s) == "" {
+		return 0, nil
+	}
+	var c Condition
+	for _, tok := range strings.Split(s, ",") {
+		name := strings.ToLower(strings.TrimSpace(tok))
+		code := strings.Replace(name, " ", "_", -1)
+		i := 0
+		for i < len(conditionCodes) && conditionCodes[i] != code {
+			i++
+		}
+		if i < len(conditionCodes) {
+			c |= 1 << uint(i)
+			continue
+		}
+		if n, ok := parseUnknownCondition(name); ok {
+			c |= n
+			continue
+		}
+		return 0, fmt.Errorf("decimal: unknown condition %q", strings.TrimSpace(tok))
+	}
+	return c, nil
+}
+
+// parseUnknownCondition parses "unknown(n)", where n is a single flag without
+// a name.
+func parseUnknownCondition(s string) (Condition, bool) {
+	if !strings.HasPrefix(s, "unknown(") || !strings.HasSuffix(s, ")") {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(s[len("unknown("):len(s)-1], 10, 32)
+	if err != nil || n&(n-1) != 0 || n < 1<<uint(len(conditionCodes)) {
+		return 0, false
+	}
+	return Condition(n), true
+}
+
+// Has reports whether every flag set in flags is set in c. It returns false if
+// flags is zero.
+func (c Condition) Has(flags Condition) bool { return flags != 0 && c&flags == flags }
+
+// Any reports whether any flag set in flags is set in c.
+func (c Condition) Any(flags Condition) bool { return c&flags != 0 }
+
+// ConditionCode returns the Condition carried by err and true, or zero and
+// false if err does not contain a Condition. err may wrap the Condition
+// returned by Context.Err (e.g., using fmt.Errorf's %w verb), in which case
+// ConditionCode unwraps it.
+func ConditionCode(err error) (Condition, bool) {
+	var c Condition
+	if errors.As(err, &c) && c != 0 {
+		return c, true
+	}
+	return 0, false
+}
@@ -1,6 +1,10 @@
 package decimal
 
-import "testing"
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
 
 func TestCondition_String(t *testing.T) {
 	for i, test := range [...]struct {
@@ -18,3 +22,526 @@ func TestCondition_String(t *testing.T) {
 		}
 	}
 }
+
+func TestCondition_Code(t *testing.T) {
+	for i, test := range [...]struct {
+		c Condition
+		s string
+	}{
+		{0, ""},
+		{DivisionByZero, "division_by_zero"},
+		{Inexact, "inexact"},
+		{Clamped | Underflow, "clamped,underflow"},
+		{Inexact | Rounded | Subnormal, "inexact,rounded,subnormal"},
+		{1 << 31, ""},
+		{Inexact | 1<<31, ""},
+	} {
+		s := test.c.Code()
+		if s != test.s {
+			t.Fatalf("#%d: wanted %q, got %q", i, test.s, s)
+		}
+		if s == "" {
+			continue
+		}
+		c, err := ParseCondition(s)
+		if err != nil || c != test.c {
+			t.Fatalf("#%d: ParseCondition(%q): wanted %s, got %s (%v)",
+				i, s, test.c, c, err)
+		}
+	}
+
+	// Every condition must have a distinct code.
+	seen := make(map[string]bool)
+	for c := Clamped; c <= Underflow; c <<= 1 {
+		code := c.Code()
+		if code == "" || seen[code] {
+			t.Fatalf("%s: missing or duplicate code %q", c, code)
+		}
+		seen[code] = true
+	}
+}
+
+func TestParseCondition(t *testing.T) {
+	for i, c := range []Condition{
+		0,
+		DivisionByZero,
+		Inexact | Rounded | Subnormal,
+		Clamped | ConversionSyntax | DivisionByZero | DivisionImpossible |
+			DivisionUndefined | Inexact | InsufficientStorage | InvalidContext |
+			InvalidOperation | Overflow | Rounded | Subnormal | Underflow,
+		Overflow | 1<<31,
+	} {
+		got, err := ParseCondition(c.String())
+		if err != nil || got != c {
+			t.Fatalf("#%d: ParseCondition(%q): wanted %s, got %s (%v)", i, c, c, got, err)
+		}
+	}
+
+	for i, test := range [...]struct {
+		s string
+		c Condition
+	}{
+		{"  ", 0},
+		{"Overflow", Overflow},
+		{"DIVISION BY ZERO,inexact", DivisionByZero | Inexact},
+		{" Division_By_Zero ,  rounded ", DivisionByZero | Rounded},
+		{"inexact, inexact", Inexact},
+	} {
+		c, err := ParseCondition(test.s)
+		if err != nil || c != test.c {
+			t.Fatalf("#%d: ParseCondition(%q): wanted %s, got %s (%v)", i, test.s, test.c, c, err)
+		}
+	}
+
+	for i, test := range [...]struct {
+		s, tok string
+	}{
+		{"nope", "nope"},
+		{"inexact, overflw", "overflw"},
+		{"inexact,", ""},
+		{"division  by zero", "division  by zero"},
+		{"unknown(3)", "unknown(3)"},
+		{"unknown(4)", "unknown(4)"},
+	} {
+		c, err := ParseCondition(test.s)
+		if err == nil {
+			t.Fatalf("#%d: ParseCondition(%q): wanted an error, got %s", i, test.s, c)
+		}
+		if want := fmt.Sprintf("decimal: unknown condition %q", test.tok); err.Error() != want {
+			t.Fatalf("#%d: ParseCondition(%q): wanted %q, got %q", i, test.s, want, err)
+		}
+	}
+}
+
+func TestCondition_HasAny(t *testing.T) {
+	c := Inexact | Rounded | Overflow
+	for i, test := range [...]struct {
+		flags    Condition
+		has, any bool
+	}{
+		{0, false, false},
+		{Inexact, true, true},
+		{Inexact | Rounded, true, true},
+		{Inexact | Underflow, false, true},
+		{Underflow | Clamped, false, false},
+	} {
+		if got := c.Has(test.flags); got != test.has {
+			t.Fatalf("#%d: (%s).Has(%s): wanted %t", i, c, test.flags, test.has)
+		}
+		if got := c.Any(test.flags); got != test.any {
+			t.Fatalf("#%d: (%s).Any(%s): wanted %t", i, c, test.flags, test.any)
+		}
+	}
+}
+
+func TestConditionCode(t *testing.T) {
+	z := WithContext(Context{Traps: DivisionByZero})
+	z.Quo(New(1, 0), New(0, 0))
+	err := z.Context.Err()
+
+	for i, test := range [...]struct {
+		err error
+		c   Condition
+		ok  bool
+	}{
+		{nil, 0, false},
+		{errors.New("decimal: division by zero"), 0, false},
+		{Condition(0), 0, false},
+		{err, DivisionByZero, true},
+		{fmt.Errorf("computing rate: %w", err), DivisionByZero, true},
+		{fmt.Errorf("handler: %w", fmt.Errorf("computing rate: %w", err)), DivisionByZero, true},
+		{fmt.Errorf("computing rate: %v", err), 0, false},
+	} {
+		c, ok := ConditionCode(test.err)
+		if c != test.c || ok != test.ok {
+			t.Fatalf("#%d: ConditionCode(%v): wanted (%s, %t), got (%s, %t)",
+				i, test.err, test.c, test.ok, c, ok)
+		}
+	}
+}
+
+func TestCondition_Is(t *testing.T) {
+	z := WithContext(Context{Precision: 5, MaxScale: 3, Traps: Overflow | Inexact})
+	z.Mul(New(99999, 0), New(10, 0))
+	err := fmt.Errorf("computing total: %w", z.Context.Err())
+
+	for _, c := range []Condition{Overflow, Inexact, Overflow | Inexact} {
+		if !errors.Is(err, c) {
+			t.Fatalf("errors.Is(%v, %s): wanted true", err, c)
+		}
+	}
+	// Rounded was raised, but not trapped.
+	for _, c := range []Condition{0, Rounded, Underflow, Overflow | Rounded} {
+		if errors.Is(err, c) {
+			t.Fatalf("errors.Is(%v, %s): wanted false", err, c)
+		}
+	}
+
+	// Other errors that carry a Condition.
+	if !errors.Is(ErrNotExact{Op: "Quo", Lost: -1}, Inexact) {
+		t.Fatal("ErrNotExact is not Inexact")
+	}
+	if !errors.Is(ErrParseLimit{Digits: true}, InsufficientStorage) {
+		t.Fatal("ErrParseLimit is not InsufficientStorage")
+	}
+
+	if got := (Overflow | Inexact | Rounded).Unwrap(); len(got) != 3 ||
+		got[0] != Inexact || got[1] != Overflow || got[2] != Rounded {
+		t.Fatalf("Unwrap: wanted [%s %s %s], got %v", Inexact, Overflow, Rounded, got)
+	}
+	if got := Inexact.Unwrap(); got != nil {
+		t.Fatalf("Unwrap of a single Condition: wanted nil, got %v", got)
+	}
+}
+
+func TestCondition_Sentinels(t *testing.T) {
+	sentinels := []error{
+		ErrClamped, ErrConversionSyntax, ErrDivisionByZero, ErrDivisionImpossible,
+		ErrDivisionUndefined, ErrInexact, ErrInsufficientStorage, ErrInvalidContext,
+		ErrInvalidOperation, ErrOverflow, ErrRounded, ErrSubnormal, ErrUnderflow,
+	}
+	for i, err := range sentinels {
+		if c := Condition(1) << uint(i); err != c {
+			t.Fatalf("sentinel #%d: wanted %s, got %v", i, c, err)
+		}
+	}
+
+	z := WithContext(Context{Precision: 5, MaxScale: 3, Traps: Overflow | Inexact})
+	z.Mul(New(99999, 0), New(10, 0))
+	err := fmt.Errorf("computing total: %w", z.Context.Err())
+	for _, s := range []error{ErrOverflow, ErrInexact} {
+		if !errors.Is(err, s) {
+			t.Fatalf("errors.Is(%v, %v): wanted true", err, s)
+		}
+	}
+	// Rounded was raised, but not trapped.
+	for _, s := range []error{ErrRounded, ErrUnderflow, ErrDivisionByZero} {
+		if errors.Is(err, s) {
+			t.Fatalf("errors.Is(%v, %v): wanted false", err, s)
+		}
+	}
+
+	if !errors.Is(ErrNotExact{Op: "Quo", Lost: -1}, ErrInexact) {
+		t.Fatal("ErrNotExact is not ErrInexact")
+	}
+	if !errors.Is(ErrInvalidSetting{Field: "Precision"}, ErrInvalidContext) {
+		t.Fatal("ErrInvalidSetting is not ErrInvalidContext")
+	}
+}
+
+func TestConditionError(t *testing.T) {
+	z := WithContext(Context128)
+	z.Quo(New(1, 0), New(0, 0))
+	err := z.Context.Err()
+	var e ConditionError
+	if !errors.As(err, &e) || e.Cond != DivisionByZero || e.Op != "Quo" ||
+		len(e.Operands) != 2 || e.Operands[0] != "1" || e.Operands[1] != "0" {
+		t.Fatalf("Quo(1, 0): wanted a ConditionError for Quo(1, 0), got %#v", err)
+	}
+	if want := "decimal: Quo(1, 0): division by zero"; err.Error() != want {
+		t.Fatalf("Error: wanted %q, got %q", want, err)
+	}
+	if !errors.Is(fmt.Errorf("rate: %w", err), ErrDivisionByZero) || errors.Is(err, ErrOverflow) {
+		t.Fatalf("errors.Is(%v): wrong Conditions", err)
+	}
+
+	// Operands are formatted as they were before the operation, even if the
+	// result is one of them.
+	z = WithContext(Context128).SetMantScale(5, 0)
+	z.Quo(z, New(0, 0))
+	if err := z.Context.Err(); err.Error() != "decimal: Quo(5, 0): division by zero" {
+		t.Fatalf("Quo(z, 0): got %v", err)
+	}
+	q := WithContext(Context{Precision: 2, Traps: DivisionImpossible}).SetMantScale(12345, 0)
+	r := WithContext(q.Context).SetMantScale(1, 0)
+	q.QuoRem(q, r, r)
+	for _, x := range []*Big{q, r} {
+		if err := x.Context.Err(); err == nil || err.Error() != "decimal: QuoRem(12345, 1): division impossible" {
+			t.Fatalf("QuoRem(q, r, r): got %v", err)
+		}
+	}
+
+	// Which Conditions are trapped is unchanged, and untrapped Conditions are
+	// not recorded.
+	z = WithContext(Context{Precision: 5, Traps: Overflow})
+	z.Quo(New(1, 0), New(3, 0))
+	if err := z.Context.Err(); err != nil {
+		t.Fatalf("Quo(1, 3): wanted no error, got %v", err)
+	}
+
+	// A record whose Conditions have been cleared is not reported.
+	z = WithContext(Context128)
+	z.Quo(New(1, 0), New(0, 0))
+	z.Context.Conditions = InvalidOperation
+	if err := z.Context.Err(); !errors.As(err, &e) || e.Cond != InvalidOperation || e.Op != "" {
+		t.Fatalf("stale record: wanted a ConditionError without an Op, got %#v", err)
+	}
+}
+
+func TestContext_Do(t *testing.T) {
+	ctx := Context{Precision: 5, Traps: DivisionByZero}
+	x, y := New(1, 0), New(3, 0)
+
+	// Conditions raised within f are reported, not merged.
+	var total Big
+	conds, err := ctx.Do(func(ctx *Context) {
+		ctx.Quo(&total, x, y)
+	})
+	if conds != Inexact|Rounded || err != nil {
+		t.Fatalf("Quo: wanted (%s, nil), got (%s, %v)", Inexact|Rounded, conds, err)
+	}
+	if ctx.Conditions != 0 {
+		t.Fatalf("wanted no conditions in the parent, got %s", ctx.Conditions)
+	}
+	if total.Context.Conditions != Inexact|Rounded {
+		t.Fatalf("wanted the result to keep its conditions, got %s", total.Context.Conditions)
+	}
+
+	// Conditions raised before Do are not reported.
+	conds, _ = ctx.Do(func(ctx *Context) {
+		ctx.Add(&total, New(1, 0), New(2, 0))
+	})
+	if conds != 0 {
+		t.Fatalf("Add: wanted no conditions, got %s", conds)
+	}
+
+	// Decimals created from the child are recorded too, and traps apply.
+	conds, err = ctx.Do(func(ctx *Context) {
+		z := WithContext(*ctx)
+		z.Quo(x, New(0, 0))
+	})
+	var ce ConditionError
+	if conds != DivisionByZero || !errors.As(err, &ce) || ce.Cond != DivisionByZero || ce.Op != "Quo" {
+		t.Fatalf("Quo by zero: wanted (%s, Quo: %s), got (%s, %v)", DivisionByZero, DivisionByZero, conds, err)
+	}
+
+	// Nested calls compose: only merged conditions are seen by the outer Do.
+	conds, _ = ctx.Do(func(ctx *Context) {
+		ctx.Do(func(ctx *Context) {
+			ctx.Quo(new(Big), x, y)
+		}, MergeConditions)
+		ctx.Do(func(ctx *Context) {
+			ctx.Quo(new(Big), x, New(0, 0))
+		})
+	}, MergeConditions)
+	if conds != Inexact|Rounded {
+		t.Fatalf("nested: wanted %s, got %s", Inexact|Rounded, conds)
+	}
+	if ctx.Conditions != Inexact|Rounded {
+		t.Fatalf("nested: wanted %s merged into the parent, got %s", Inexact|Rounded, ctx.Conditions)
+	}
+
+	// ExactOnly failures are reported by the error.
+	ctx = Context{Precision: 5, ExactOnly: true}
+	_, err = ctx.Do(func(ctx *Context) {
+		ctx.Quo(new(Big), x, y)
+	})
+	var e ErrNotExact
+	if !errors.As(err, &e) {
+		t.Fatalf("ExactOnly: wanted an ErrNotExact, got %v", err)
+	}
+
+	// Operations that return two results record both.
+	ctx = Context{Precision: 2}
+	conds, _ = ctx.Do(func(ctx *Context) {
+		ctx.QuoRem(new(Big), New(12345, 0), New(1, 0), new(Big))
+	})
+	if conds&DivisionImpossible == 0 {
+		t.Fatalf("QuoRem: wanted %s, got %s", DivisionImpossible, conds)
+	}
+}
+
+func TestContext_adopt(t *testing.T) {
+	// The result takes the Context's settings but keeps its own Conditions
+	// and tag.
+	z := WithContext(Context{Precision: 20, RoundingMode: ToPositiveInf})
+	z.SetMantScale(1, 0).SetTag("z")
+	z.Context.Conditions = Clamped
+	ctx := Context{Precision: 5, RoundingMode: ToZero}
+	ctx.Add(z, z, New(2, 0))
+	if z.Context.Precision != 5 || z.Context.RoundingMode != ToZero {
+		t.Fatalf("Add: wanted (5, %s), got (%d, %s)",
+			ToZero, z.Context.Precision, z.Context.RoundingMode)
+	}
+	if z.Context.Conditions != Clamped || z.Tag() != "z" {
+		t.Fatalf("Add: wanted (%s, z), got (%s, %v)", Clamped, z.Context.Conditions, z.Tag())
+	}
+
+	// Hooked operations leave the Context's hooks, not the internal ones.
+	p := &TagPolicy{Mode: TagsRight}
+	ctx.SetTags(p)
+	ctx.Quo(z, New(1, 0), New(3, 0).SetTag("y"))
+	if z.Context.hooks == noHooks || z.Context.Tags() != p || z.Tag() != "y" {
+		t.Fatalf("hooked Quo: wanted the Context's TagPolicy and tag y, got (%v, %v)",
+			z.Context.Tags(), z.Tag())
+	}
+	if z.Context.Conditions != Clamped|Inexact|Rounded {
+		t.Fatalf("hooked Quo: wanted %s, got %s", Clamped|Inexact|Rounded, z.Context.Conditions)
+	}
+
+	// Nor does the scope of Context.Do outlive the call.
+	var w Big
+	ctx.Do(func(ctx *Context) {
+		ctx.Mul(&w, New(2, 0), New(3, 0))
+	})
+	if w.Context.hooks == nil || w.Context.hooks.scope != nil || w.Context.Tags() != p {
+		t.Fatalf("Do: wanted the Context's TagPolicy without the scope, got %+v", w.Context.hooks)
+	}
+
+	// Both results of QuoRem adopt the Context.
+	q, r := new(Big), new(Big)
+	Context{Precision: 7}.QuoRem(q, New(7, 0), New(2, 0), r)
+	if q.Context.Precision != 7 || r.Context.Precision != 7 {
+		t.Fatalf("QuoRem: wanted precision 7, got (%d, %d)", q.Context.Precision, r.Context.Precision)
+	}
+
+	// Methods that use a Context internally keep z's own.
+	z = WithContext(Context{Precision: 10})
+	z.SetString("1.23456")
+	z.Round(2)
+	if z.Context.Precision != 10 || z.Cmp(New(12, 1)) != 0 {
+		t.Fatalf("Round: wanted 1.2 with precision 10, got %s with precision %d",
+			z, z.Context.Precision)
+	}
+}
+
+func TestOperatingMode_JS(t *testing.T) {
+	js := Context{OperatingMode: JS}
+	for i, test := range [...]struct {
+		in, out string
+	}{
+		{"1.50", "1.5"},
+		{"1.5E+3", "1500"},
+		{"0.000001", "0.000001"},
+		{"1E-7", "1e-7"},
+		{"-1.25E-8", "-1.25e-8"},
+		{"123456789012345678901", "123456789012345678901"},
+		{"1E+21", "1e+21"},
+		{"1.2300E+25", "1.23e+25"},
+		{"0.00", "0"},
+		{"-0", "-0"},
+		{"NaN", "NaN"},
+		{"-sNaN12", "NaN"},
+		{"Infinity", "Infinity"},
+		{"-Inf", "-Infinity"},
+	} {
+		x, _ := WithContext(js).SetString(test.in)
+		if s := x.String(); s != test.out {
+			t.Fatalf("#%d: String(%s): wanted %s, got %s", i, test.in, test.out, s)
+		}
+	}
+
+	x, _ := WithContext(js).SetString("Infinity")
+	if b, err := x.MarshalText(); err != nil || string(b) != "Infinity" {
+		t.Fatalf("MarshalText(Infinity): got %s (%v)", b, err)
+	}
+
+	// Set copies the value as is, while arithmetic rounds, with ties away
+	// from zero by default.
+	js.Precision = 5
+	y, _ := new(Big).SetString("1.000050")
+	z := WithContext(js).Set(y)
+	if s := z.String(); s != "1.00005" {
+		t.Fatalf("Set: wanted 1.00005, got %s", s)
+	}
+	if s := js.Set(WithContext(js), y).String(); s != "1.00005" {
+		t.Fatalf("Context.Set: wanted 1.00005, got %s", s)
+	}
+	if s := z.Add(z, new(Big)).String(); s != "1.0001" {
+		t.Fatalf("Add: wanted 1.0001, got %s", s)
+	}
+	js.RoundingMode = ToZero
+	if s := js.Add(WithContext(js), y, new(Big)).String(); s != "1" {
+		t.Fatalf("Add with ToZero: wanted 1, got %s", s)
+	}
+	// Only DefaultRounding rounds ties away from zero; an explicit
+	// ToNearestEven is kept.
+	js.RoundingMode = ToNearestEven
+	if s := js.Add(WithContext(js), y, new(Big)).String(); s != "1" {
+		t.Fatalf("Add with ToNearestEven: wanted 1, got %s", s)
+	}
+	if s := New(25, 1).ToFixedMode(0, ToNearestEven); s != "2" {
+		t.Fatalf("ToFixedMode with ToNearestEven: wanted 2, got %s", s)
+	}
+	if s := WithContext(Context{OperatingMode: JS}).SetMantScale(25, 1).ToFixedMode(0, DefaultRounding); s != "3" {
+		t.Fatalf("ToFixedMode with DefaultRounding: wanted 3, got %s", s)
+	}
+
+	// Invalid operations do not panic.
+	z = WithContext(Context{OperatingMode: JS})
+	z.Quo(New(0, 0), New(0, 0))
+	if !z.IsNaN(0) || z.Context.Conditions&DivisionUndefined == 0 {
+		t.Fatalf("0/0: wanted NaN and %s, got %s and %s", DivisionUndefined, z, z.Context.Conditions)
+	}
+	if s := JS.String(); s != "JS" {
+		t.Fatalf("JS.String(): got %s", s)
+	}
+}
+
+func TestContext_Validate(t *testing.T) {
+	for i, c := range []Context{
+		{},
+		Context32,
+		Context128,
+		ContextUnlimited,
+		{Precision: MaxPracticalPrecision, MaxScale: MaxScale, MinScale: MinScale},
+		{RoundingMode: ToPositiveInf, OperatingMode: JS, CompatLevel: LatestCompatLevel, GuardDigits: 3},
+	} {
+		if err := c.Validate(); err != nil {
+			t.Fatalf("#%d: %+v: wanted nil, got %v", i, c, err)
+		}
+	}
+
+	for i, test := range [...]struct {
+		c   Context
+		msg string
+	}{
+		{Context{Precision: -1}, "Precision is -1: less than zero"},
+		{Context{Precision: UnlimitedPrecision + 1}, fmt.Sprintf("Precision is %d: greater than UnlimitedPrecision", UnlimitedPrecision+1)},
+		{Context{Precision: MaxPracticalPrecision + 1}, "Precision is 100000001: greater than MaxPracticalPrecision"},
+		{Context{RoundingMode: unnecessary}, "RoundingMode is 7: not a RoundingMode constant"},
+		{Context{RoundingMode: 255}, "RoundingMode is 255: not a RoundingMode constant"},
+		{Context{OperatingMode: JS + 1}, "OperatingMode is 3: not an OperatingMode constant"},
+		{Context{MaxScale: MaxScale + 1}, fmt.Sprintf("MaxScale is %d: greater than MaxScale", MaxScale+1)},
+		{Context{MaxScale: -1}, "MaxScale is -1: less than zero"},
+		{Context{MinScale: MinScale - 1}, fmt.Sprintf("MinScale is %d: less than MinScale", MinScale-1)},
+		{Context{MinScale: 1}, "MinScale is 1: greater than zero"},
+		{Context{CompatLevel: -1}, "CompatLevel is -1: not in [0, LatestCompatLevel]"},
+		{Context{CompatLevel: LatestCompatLevel + 1}, fmt.Sprintf("CompatLevel is %d: not in [0, LatestCompatLevel]", LatestCompatLevel+1)},
+		{Context{GuardDigits: -2}, "GuardDigits is -2: less than zero"},
+		// Only the first invalid setting is reported.
+		{Context{Precision: -1, RoundingMode: 255, GuardDigits: -1}, "Precision is -1: less than zero"},
+	} {
+		err := test.c.Validate()
+		if err == nil || err.Error() != "decimal: invalid Context: "+test.msg {
+			t.Fatalf("#%d: wanted %q, got %v", i, test.msg, err)
+		}
+		if !errors.Is(err, InvalidContext) {
+			t.Fatalf("#%d: %v is not InvalidContext", i, err)
+		}
+
+		// Operations raise InvalidContext with a payload naming the setting.
+		c := test.c
+		c.OperatingMode = GDA
+		if c.Validate() == nil {
+			continue // the OperatingMode was the invalid setting
+		}
+		z := WithContext(c)
+		z.Add(New(1, 0), New(2, 0))
+		if !z.IsNaN(0) || z.Context.Conditions != InvalidContext || z.Payload() != c.invalid() {
+			t.Fatalf("#%d: Add: wanted NaN with InvalidContext, got %s (%s)", i, z, z.Context.Conditions)
+		}
+
+		// In Go mode, they panic with the error Validate returns.
+		c.OperatingMode = Go
+		func() {
+			defer func() {
+				if r := recover(); r != c.Validate() {
+					t.Fatalf("#%d: Go mode: wanted a panic with %v, got %v", i, c.Validate(), r)
+				}
+			}()
+			z := WithContext(c)
+			z.Add(New(1, 0), New(2, 0))
+		}()
+	}
+}
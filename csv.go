@@ -0,0 +1,132 @@
+package decimal
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+)
+
+// CSV is a decimal in a CSV cell. It implements encoding.TextMarshaler and
+// encoding.TextUnmarshaler, which CSV libraries that map records to structs
+// (e.g., gocarina/gocsv and jszwec/csvutil) use for custom field types.
+//
+// An empty cell is decoded as a nil V, never as zero, and a nil V is encoded
+// as an empty cell. Non-empty cells must contain one of the formats accepted
+// by SetString, without surrounding spaces; see CSVLenient for dirty files.
+//
+// NaN and infinite values are encoded according to V's SpecialsPolicy, except
+// that under SpecialsNull they are encoded as empty cells. Every spelling of
+// NaN and infinity is accepted when decoding.
+type CSV struct {
+	V *Big
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c CSV) MarshalText() ([]byte, error) {
+	return marshalCSV(c.V)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. If V is non-nil, the
+// cell is decoded into it using its Context.
+func (c *CSV) UnmarshalText(data []byte) error {
+	return unmarshalCSV(&c.V, data)
+}
+
+// CSVLenient is like CSV, but decodes cells written for humans: it ignores
+// leading and trailing spaces, and it accepts thousands separators in the
+// integer part of a number, such as 1,234,567.89 or 1 234 567.89. The
+// separator may be a comma, a space, an underscore, or an apostrophe, and it
+// must be used consistently, with groups of three digits. Other uses of the
+// separators, such as the decimal comma in 1,5, are rejected rather than
+// misread.
+type CSVLenient struct {
+	V *Big
+}
+
+// MarshalText implements encoding.TextMarshaler. The encoding is the same as
+// CSV's; it does not contain thousands separators.
+func (c CSVLenient) MarshalText() ([]byte, error) {
+	return marshalCSV(c.V)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. If V is non-nil, the
+// cell is decoded into it using its Context.
+func (c *CSVLenient) UnmarshalText(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) != 0 {
+		var ok bool
+		if data, ok = stripThousands(data); !ok {
+			return errors.New("decimal: invalid CSV decimal " + string(data))
+		}
+	}
+	return unmarshalCSV(&c.V, data)
+}
+
+func marshalCSV(x *Big) ([]byte, error) {
+	if x == nil || x.isSpecial() && x.Context.Specials == SpecialsNull {
+		return []byte{}, nil
+	}
+	return x.MarshalText()
+}
+
+func unmarshalCSV(z **Big, data []byte) error {
+	if len(data) == 0 {
+		*z = nil
+		return nil
+	}
+	x := *z
+	if x == nil {
+		x = new(Big)
+	}
+	if err := x.scanBytes(data, x.Context, new(bytes.Reader)); err != nil {
+		return err
+	}
+	if x.Context.Conditions&ConversionSyntax != 0 {
+		return errors.New("decimal: invalid CSV decimal " + string(data))
+	}
+	*z = x
+	return nil
+}
+
+// stripThousands returns b without the thousands separators in its integer
+// part. It reports false if b uses separators incorrectly.
+func stripThousands(b []byte) ([]byte, bool) {
+	start := 0
+	if len(b) > 0 && (b[0] == '+' || b[0] == '-') {
+		start = 1
+	}
+	end := start
+	for end < len(b) && (isDigit(b[end]) || strings.IndexByte(thousands, b[end]) >= 0) {
+		end++
+	}
+	i := bytes.IndexAny(b[start:end], thousands)
+	if i < 0 {
+		return b, true
+	}
+	sep := b[start+i]
+
+	out := make([]byte, 0, len(b))
+	out = append(out, b[:start]...)
+	for n, group := 0, b[start:end]; ; n++ {
+		j := bytes.IndexByte(group, sep)
+		digits := group
+		if j >= 0 {
+			digits = group[:j]
+		}
+		if len(digits) == 0 || len(digits) > 3 || (n > 0 && len(digits) != 3) ||
+			bytes.IndexAny(digits, thousands) >= 0 {
+			return b, false
+		}
+		out = append(out, digits...)
+		if j < 0 {
+			break
+		}
+		group = group[j+1:]
+	}
+	return append(out, b[end:]...), true
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+// thousands are the thousands separators accepted by CSVLenient.
+const thousands = ", _'"
@@ -0,0 +1,94 @@
+package decimal_test
+
+import (
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestCSV(t *testing.T) {
+	for i, test := range [...]struct {
+		in      string
+		want    string // "" for a nil V, "error" for an error
+		lenient string // likewise, for CSVLenient
+	}{
+		{"", "", ""},
+		{"   ", "error", ""},
+		{"0", "0", "0"},
+		{"-12.50", "-12.50", "-12.50"},
+		{" 12.50 ", "error", "12.50"},
+		{"1E+3", "1E+3", "1E+3"},
+		{"-Infinity", "-Infinity", "-Infinity"},
+		{"+Inf", "Infinity", "Infinity"},
+		{"nan", "NaN", "NaN"},
+		{"1,234,567.89", "error", "1234567.89"},
+		{"-1 234 567", "error", "-1234567"},
+		{"1_000", "error", "1000"},
+		{"1'000.5", "error", "1000.5"},
+		{"999,999", "error", "999999"},
+		{"1,5", "error", "error"},
+		{"1,2345", "error", "error"},
+		{"1234,567", "error", "error"},
+		{",123", "error", "error"},
+		{"1,234,", "error", "error"},
+		{"1,234 567", "error", "error"},
+		{"1,234.567,890", "error", "error"},
+		{"12..3", "error", "error"},
+	} {
+		var c decimal.CSV
+		got := "error"
+		if err := c.UnmarshalText([]byte(test.in)); err == nil {
+			got = ""
+			if c.V != nil {
+				got = c.V.String()
+			}
+		}
+		if got != test.want {
+			t.Fatalf("#%d: CSV(%q): wanted %q, got %q", i, test.in, test.want, got)
+		}
+
+		var l decimal.CSVLenient
+		got = "error"
+		if err := l.UnmarshalText([]byte(test.in)); err == nil {
+			got = ""
+			if l.V != nil {
+				got = l.V.String()
+			}
+		}
+		if got != test.lenient {
+			t.Fatalf("#%d: CSVLenient(%q): wanted %q, got %q", i, test.in, test.lenient, got)
+		}
+	}
+}
+
+func TestCSV_Empty(t *testing.T) {
+	// An empty cell clears a previously decoded value instead of zeroing it.
+	c := decimal.CSV{V: decimal.New(15, 1)}
+	if err := c.UnmarshalText(nil); err != nil || c.V != nil {
+		t.Fatalf("wanted nil, got %v (%v)", c.V, err)
+	}
+	if b, err := c.MarshalText(); err != nil || len(b) != 0 {
+		t.Fatalf("wanted an empty cell, got %q (%v)", b, err)
+	}
+}
+
+func TestCSV_MarshalSpecials(t *testing.T) {
+	for i, test := range [...]struct {
+		policy decimal.SpecialsPolicy
+		want   string
+	}{
+		{decimal.SpecialsDefault, "-Infinity"},
+		{decimal.SpecialsJS, "-Infinity"},
+		{decimal.SpecialsNull, ""},
+		{decimal.SpecialsError, "error"},
+	} {
+		x := decimal.WithContext(decimal.Context{Specials: test.policy}).SetInf(true)
+		got := "error"
+		if b, err := (decimal.CSV{V: x}).MarshalText(); err == nil {
+			got = string(b)
+		}
+		if got != test.want {
+			t.Fatalf("#%d: %s: wanted %q, got %q", i, test.policy, test.want, got)
+		}
+	}
+}
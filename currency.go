@@ -0,0 +1,141 @@
+package decimal
+
+import (
+	"strings"
+	"sync"
+)
+
+// currency is a registered currency's Context and scale.
+type currency struct {
+	ctx   Context
+	scale int
+	cash  int64 // if > 1, amounts are multiples of cash minor units
+}
+
+var currencies = struct {
+	sync.RWMutex
+	m map[string]currency
+}{m: defaultCurrencies()}
+
+// defaultCurrencies returns the ISO 4217 currencies and the number of digits
+// in their minor units. Each uses a Context with unlimited precision, so that
+// an amount of any size can be quantized, which rounds half to even, except
+// for JPY, which rounds half up, and CHF, which also rounds to a multiple of
+// 0.05 like Swiss cash.
+func defaultCurrencies() map[string]currency {
+	ctx := Context{Precision: UnlimitedPrecision}
+	m := make(map[string]currency)
+	for scale, codes := range [...]string{
+		0: "BIF CLP DJF GNF ISK JPY KMF KRW PYG RWF UGX UYI VND VUV XAF XOF XPF",
+		2: "AED AFN ALL AMD AOA ARS AUD AWG AZN BAM BBD BDT BGN BMD BND BOB BRL " +
+			"BSD BTN BWP BYN BZD CAD CDF CHF CNY COP CRC CUP CVE CZK DKK DOP DZD " +
+			"EGP ERN ETB EUR FJD FKP GBP GEL GHS GIP GMD GTQ GYD HKD HNL HTG HUF " +
+			"IDR ILS INR IRR JMD KES KGS KHR KPW KYD KZT LAK LBP LKR LRD LSL MAD " +
+			"MDL MGA MKD MMK MNT MOP MRU MUR MVR MWK MXN MYR MZN NAD NGN NIO NOK " +
+			"NPR NZD PAB PEN PGK PHP PKR PLN QAR RON RSD RUB SAR SBD SCR SDG SEK " +
+			"SGD SHP SLE SOS SRD SSP STN SVC SYP SZL THB TJS TMT TOP TRY TTD TWD " +
+			"TZS UAH USD UYU UZS VES WST XCD XCG YER ZAR ZMW ZWG",
+		3: "BHD IQD JOD KWD LYD OMR TND",
+		4: "CLF UYW",
+	} {
+		for _, code := range strings.Fields(codes) {
+			m[code] = currency{ctx: ctx, scale: scale}
+		}
+	}
+	jpy := m["JPY"]
+	jpy.ctx.RoundingMode = ToNearestAway
+	m["JPY"] = jpy
+	chf := m["CHF"]
+	chf.cash = 5
+	m["CHF"] = chf
+	return m
+}
+
+// RegisterCurrencyContext sets the Context and scale used for amounts in the
+// currency with the ISO 4217 code, replacing any previous registration. The
+// code is case insensitive. It panics if code is not three ASCII letters.
+//
+// The ISO 4217 currencies are registered by default with the scale of their
+// minor unit, e.g., 2 for USD and 0 for JPY, and a Context with
+// UnlimitedPrecision that rounds half to even. JPY instead rounds half up,
+// and RoundForCurrency rounds CHF to a multiple of 0.05, as for Swiss cash,
+// unless CHF is registered again.
+//
+// ctx's Conditions, and any state belonging to a call of Context.Do, are not
+// registered.
+func RegisterCurrencyContext(code string, ctx Context, scale int) {
+	code, ok := currencyCode(code)
+	if !ok {
+		panic("decimal: invalid currency code " + code)
+	}
+	ctx.Conditions = 0
+	if h := ctx.hooks; h != nil {
+		ctx.hooks = h.update(func(h *hooks) { h.scope, h.inexact, h.forced = nil, nil, 0 })
+	}
+	currencies.Lock()
+	currencies.m[code] = currency{ctx: ctx, scale: scale}
+	currencies.Unlock()
+}
+
+// CurrencyContext returns the Context and scale registered for the currency
+// with the ISO 4217 code. The boolean is false if code is not registered.
+func CurrencyContext(code string) (Context, int, bool) {
+	c, ok := lookupCurrency(code)
+	return c.ctx, c.scale, ok
+}
+
+// lookupCurrency returns the registration of the currency with the ISO 4217
+// code.
+func lookupCurrency(code string) (currency, bool) {
+	code, ok := currencyCode(code)
+	if !ok {
+		return currency{}, false
+	}
+	currencies.RLock()
+	c, ok := currencies.m[code]
+	currencies.RUnlock()
+	return c, ok
+}
+
+// RoundForCurrency sets z to x quantized to the scale registered for the
+// currency with the ISO 4217 code, using the registered Context, and returns z.
+// z's Context is not modified, other than its Conditions. The boolean is false,
+// and z is unchanged, if code is not registered.
+func RoundForCurrency(z, x *Big, code string) (*Big, bool) {
+	cur, ok := lookupCurrency(code)
+	if !ok {
+		return z, false
+	}
+	if z.checkNil("RoundForCurrency", x, x) {
+		return z, true
+	}
+	zc := z.Context
+	if cur.cash <= 1 || !x.IsFinite() {
+		cur.ctx.Quantize(z.Copy(x), cur.scale)
+	} else {
+		// Round x/cash to the minor unit, then scale it back up. Both steps
+		// are exact with unlimited precision since cash divides a power of 10.
+		exact := cur.ctx
+		exact.Precision = UnlimitedPrecision
+		var cash Big
+		cash.SetMantScale(cur.cash, 0)
+		exact.Quo(z, x, &cash)
+		cur.ctx.Quantize(z, cur.scale)
+		exact.Mul(z, z, &cash)
+	}
+	zc.adopt(z)
+	return z, true
+}
+
+// currencyCode returns the canonical form of the currency code s.
+func currencyCode(s string) (string, bool) {
+	if len(s) != 3 {
+		return s, false
+	}
+	for i := 0; i < len(s); i++ {
+		if c := s[i] | 0x20; c < 'a' || c > 'z' {
+			return s, false
+		}
+	}
+	return strings.ToUpper(s), true
+}
@@ -0,0 +1,115 @@
+package decimal_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestCurrencyContext(t *testing.T) {
+	for _, test := range [...]struct {
+		code  string
+		scale int
+		ok    bool
+	}{
+		{"USD", 2, true},
+		{"eur", 2, true},
+		{"JPY", 0, true},
+		{"KWD", 3, true},
+		{"CLF", 4, true},
+		{"XXX", 0, false},
+		{"US", 0, false},
+		{"US$", 0, false},
+	} {
+		ctx, scale, ok := decimal.CurrencyContext(test.code)
+		if ok && ctx.Precision != decimal.UnlimitedPrecision {
+			t.Fatalf("%s: wanted unlimited precision, got %+v", test.code, ctx)
+		}
+		if ok != test.ok || scale != test.scale {
+			t.Fatalf("%s: wanted (%d, %t), got (%d, %t, %+v)", test.code, test.scale, test.ok, scale, ok, ctx)
+		}
+	}
+}
+
+func TestRoundForCurrency(t *testing.T) {
+	// Registrations are global, so use codes nothing else depends on.
+	decimal.RegisterCurrencyContext("zzb", decimal.Context{RoundingMode: decimal.ToNearestAway}, 0)
+	decimal.RegisterCurrencyContext("ZZC", decimal.Context{RoundingMode: decimal.ToZero}, -2)
+
+	for i, test := range [...]struct {
+		x, code string
+		want    string
+		conds   decimal.Condition
+	}{
+		{"1.005", "USD", "1.00", decimal.Inexact | decimal.Rounded},
+		{"1.015", "USD", "1.02", decimal.Inexact | decimal.Rounded},
+		{"2.5", "JPY", "3", decimal.Inexact | decimal.Rounded},
+		{"12345678901234567.891", "USD", "12345678901234567.89", decimal.Inexact | decimal.Rounded},
+		{"1.03", "CHF", "1.05", decimal.Inexact | decimal.Rounded},
+		{"1.02", "CHF", "1.00", decimal.Inexact | decimal.Rounded},
+		{"-7.274", "CHF", "-7.25", decimal.Inexact | decimal.Rounded},
+		{"3", "CHF", "3.00", 0},
+		{"-Infinity", "CHF", "NaN", decimal.InvalidOperation},
+		{"2.5", "ZZB", "3", decimal.Inexact | decimal.Rounded},
+		{"1.23456", "BHD", "1.235", decimal.Inexact | decimal.Rounded},
+		{"199", "ZZC", "1E+2", decimal.Inexact | decimal.Rounded},
+		{"7", "USD", "7.00", 0},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		z := decimal.WithPrecision(5)
+		z, ok := decimal.RoundForCurrency(z, x, test.code)
+		got := z.String()
+		if z.IsNaN(0) {
+			got = "NaN" // ignore the diagnostic payload
+		}
+		if !ok || got != test.want || z.Context.Conditions != test.conds {
+			t.Fatalf("#%d: RoundForCurrency(%s, %s): wanted %s (%s), got %s (%s, %t)",
+				i, test.x, test.code, test.want, test.conds, z, z.Context.Conditions, ok)
+		}
+		if z.Context.Precision != 5 {
+			t.Fatalf("#%d: z's Context was modified: %+v", i, z.Context)
+		}
+	}
+
+	z := decimal.New(1, 0)
+	if _, ok := decimal.RoundForCurrency(z, decimal.New(5, 1), "QQQ"); ok || z.Cmp(decimal.New(1, 0)) != 0 {
+		t.Fatalf("unregistered code: wanted z unchanged and false, got %s, %t", z, ok)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("wanted a panic registering an invalid code")
+			}
+		}()
+		decimal.RegisterCurrencyContext("US1", decimal.Context{}, 2)
+	}()
+
+	// The state of Do is not registered.
+	ctx := decimal.Context{Precision: 5}
+	ctx.Do(func(ctx *decimal.Context) {
+		decimal.RegisterCurrencyContext("ZZE", *ctx, 2)
+	})
+	if got, _, _ := decimal.CurrencyContext("ZZE"); got != ctx {
+		t.Fatalf("wanted %+v, got %+v", ctx, got)
+	}
+}
+
+func TestCurrencyContext_Concurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if i%2 == 0 {
+					decimal.RegisterCurrencyContext("ZZD", decimal.Context{}, j%4)
+				} else if _, _, ok := decimal.CurrencyContext("USD"); !ok {
+					t.Error("USD is not registered")
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
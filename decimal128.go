@@ -0,0 +1,233 @@
+package decimal
+
+import (
+	"encoding/binary"
+
+	"github.com/ericlagergren/decimal/internal/arith"
+	cst "github.com/ericlagergren/decimal/internal/c"
+)
+
+// Parameters of the IEEE 754-2008 decimal128 interchange format. q is the
+// exponent of a value whose coefficient is an integer; e.g., the q of 1.25 is
+// -2.
+const (
+	d128Prec = 34                      // digits in a coefficient
+	d128Emax = 6144                    // largest adjusted exponent
+	d128Emin = -6143                   // smallest normal adjusted exponent
+	d128Bias = d128Prec - 1 - d128Emin // biased exponent = q + d128Bias
+)
+
+// Bits of the high 64 bits of a decimal128.
+const (
+	d128Sign     = 1 << 63
+	d128Special  = 0xf << 59 // G0 through G3 are all set: an infinity or NaN
+	d128Large    = 0x3 << 61 // G0 and G1 are set: the coefficient is >= 2**113
+	d128Inf      = 0x1e << 58
+	d128NaN      = 0x1f << 58
+	d128Signal   = 1 << 57
+	d128ExpShift = 49                  // offset of a small coefficient's exponent
+	d128ExpMask  = 1<<14 - 1           // width of the exponent
+	d128CoeffHi  = 1<<d128ExpShift - 1 // coefficient bits
+	d128Payload  = 1<<46 - 1           // NaN payload bits
+)
+
+// interchangeFormat describes an IEEE 754-2008 decimal interchange format.
+type interchangeFormat struct {
+	prec int // digits in a coefficient
+	emax int // largest adjusted exponent
+	emin int // smallest normal adjusted exponent
+}
+
+var (
+	decimal64Format  = interchangeFormat{prec: 16, emax: 384, emin: -383}
+	decimal128Format = interchangeFormat{prec: d128Prec, emax: d128Emax, emin: d128Emin}
+)
+
+// qmax returns the largest q of f.
+func (f *interchangeFormat) qmax() int { return f.emax - f.prec + 1 }
+
+// qmin returns the smallest q of f.
+func (f *interchangeFormat) qmin() int { return f.emin - f.prec + 1 }
+
+// fits reports whether the finite x can be encoded in f without rounding.
+func (f *interchangeFormat) fits(x *Big) bool {
+	return x.Precision() <= f.prec && x.exp <= f.qmax() && x.exp >= f.qmin()
+}
+
+// EncodeDecimal128 returns x in the IEEE 754-2008 decimal128 interchange
+// format, using the Binary Integer Decimal (BID) encoding. The bytes are in
+// big-endian order: b[0] holds the sign bit and the start of the combination
+// field. Reverse b for the little-endian order used in memory by x86 and ARM
+// implementations, such as the Intel Decimal Floating-Point Math Library.
+//
+// If x has more than 34 digits or its exponent is out of range, the encoding is
+// of x rounded as by Context128 with Clamp set but using x's RoundingMode: a
+// value that is too large becomes ±Infinity and one that is too small becomes
+// subnormal or zero. x itself is not modified, so no conditions are raised;
+// Decimal128 returns them. A NaN's payload is kept, and the sign of a zero is
+// always kept.
+func (x *Big) EncodeDecimal128() (b [16]byte) {
+	mustNotNil("EncodeDecimal128", x, x)
+	b, _ = x.encodeDecimal128()
+	return b
+}
+
+// encodeDecimal128 is like EncodeDecimal128, but also returns the conditions
+// raised by rounding x.
+func (x *Big) encodeDecimal128() (b [16]byte, cond Condition) {
+	if debug {
+		x.validate()
+	}
+
+	var hi uint64
+	if x.Signbit() {
+		hi = d128Sign
+	}
+	switch {
+	case x.IsInf(0):
+		binary.BigEndian.PutUint64(b[:8], hi|d128Inf)
+		return b, 0
+	case x.IsNaN(0):
+		hi |= d128NaN
+		if x.form&snan != 0 {
+			hi |= d128Signal
+		}
+		// A Payload has at most 20 digits, so it is always canonical.
+		binary.BigEndian.PutUint64(b[:8], hi)
+		binary.BigEndian.PutUint64(b[8:], x.compact)
+		return b, 0
+	}
+
+	y := x
+	if !decimal128Format.fits(x) {
+		y, cond = x.toInterchange(&decimal128Format)
+		if y.IsInf(0) {
+			binary.BigEndian.PutUint64(b[:8], hi|d128Inf)
+			return b, cond
+		}
+	}
+
+	// The coefficient is less than 10**34 < 2**113, so the exponent is always
+	// stored in the bits following the sign.
+	if y.isCompact() {
+		binary.BigEndian.PutUint64(b[8:], y.compact)
+	} else {
+		y.unscaled.FillBytes(b[:])
+	}
+	hi |= binary.BigEndian.Uint64(b[:8])
+	hi |= uint64(y.exp+d128Bias) << d128ExpShift
+	binary.BigEndian.PutUint64(b[:8], hi)
+	return b, cond
+}
+
+// toInterchange returns a copy of the finite x rounded to f, as by a Context
+// with f's parameters, Clamp set, and x's RoundingMode, and the conditions
+// raised by rounding it. x is not modified. The exponent of the result is in
+// [f.qmin(), f.qmax()] unless it is an infinity.
+func (x *Big) toInterchange(f *interchangeFormat) (*Big, Condition) {
+	ctx := Context{
+		Precision:     f.prec,
+		MaxScale:      f.emax,
+		MinScale:      f.emin,
+		Clamp:         true,
+		RoundingMode:  x.Context.roundingMode(),
+		OperatingMode: GDA,
+	}
+
+	y := ctx.Round(new(Big).Copy(x))
+	return y, y.Context.Conditions
+}
+
+// DecodeDecimal128 sets z to the decimal128 b, encoded as by EncodeDecimal128,
+// and returns z. Every decimal128 is represented exactly, so z is not rounded
+// and no conditions are raised; in particular, a signaling NaN is stored as
+// is.
+//
+// As IEEE 754-2008 requires, a non-canonical coefficient, one that is larger
+// than 10**34 - 1, decodes as a zero with the encoded sign and exponent, and a
+// non-canonical NaN payload decodes as zero. Payloads that are canonical but
+// larger than a Payload can hold are also decoded as zero.
+func (z *Big) DecodeDecimal128(b [16]byte) *Big {
+	mustNotNil("DecodeDecimal128", z, z)
+	hi := binary.BigEndian.Uint64(b[:8])
+	lo := binary.BigEndian.Uint64(b[8:])
+
+	var sign form
+	if hi&d128Sign != 0 {
+		sign = signbit
+	}
+	switch {
+	case hi&d128Special == d128Special:
+		if hi&d128NaN != d128NaN {
+			z.form = pinf | sign
+			return z
+		}
+		z.form = qnan | sign
+		if hi&d128Signal != 0 {
+			z.form = snan | sign
+		}
+		z.compact = 0
+		if hi&d128Payload == 0 {
+			z.compact = lo
+		}
+		return z
+	case hi&d128Large == d128Large:
+		// The implicit 100 prefix makes the coefficient at least 2**113,
+		// which is larger than 10**34 - 1.
+		exp := int(hi>>(d128ExpShift-2)&d128ExpMask) - d128Bias
+		return z.setZero(sign, exp)
+	}
+
+	exp := int(hi>>d128ExpShift&d128ExpMask) - d128Bias
+	hi &= d128CoeffHi
+	if hi == 0 && lo != cst.Inflated {
+		return z.setTriple(lo, sign, exp)
+	}
+	binary.BigEndian.PutUint64(b[:8], hi)
+	z.unscaled.SetBytes(b[:])
+	if z.unscaled.Cmp(arith.BigPow10(d128Prec)) >= 0 {
+		return z.setZero(sign, exp)
+	}
+	z.form = finite | sign
+	z.exp = exp
+	return z.norm()
+}
+
+// Decimal128 returns x as the high and low 64 bits of a decimal128, as used by
+// BSON and by the MongoDB Go driver's primitive.Decimal128:
+//
+//	hi, lo, _, err := x.Decimal128()
+//	d := primitive.NewDecimal128(hi, lo)
+//
+// x is encoded as by EncodeDecimal128, so if it has more than 34 digits or an
+// exponent outside the range of a decimal128 it is rounded rather than
+// rejected. x is not modified, so the conditions raised by rounding it, such
+// as Rounded, Inexact, and Clamped, are returned as cond rather than raised in
+// x's Context. NaN values are encoded as the canonical BSON NaN or signaling
+// NaN, without a sign or payload. The only error is for a nil x.
+func (x *Big) Decimal128() (hi, lo uint64, cond Condition, err error) {
+	if x == nil {
+		return 0, 0, 0, ErrNilOperand{Op: "Decimal128"}
+	}
+	if x.IsNaN(0) {
+		hi = d128NaN
+		if x.form&snan != 0 {
+			hi |= d128Signal
+		}
+		return hi, 0, 0, nil
+	}
+	b, cond := x.encodeDecimal128()
+	return binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:]), cond, nil
+}
+
+// SetDecimal128 sets z to the decimal128 with the high and low 64 bits hi and
+// lo, as returned by Decimal128 or by the MongoDB Go driver's
+// primitive.Decimal128.GetBytes, and returns z. It is otherwise identical to
+// DecodeDecimal128.
+func (z *Big) SetDecimal128(hi, lo uint64) *Big {
+	mustNotNil("SetDecimal128", z, z)
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], hi)
+	binary.BigEndian.PutUint64(b[8:], lo)
+	return z.DecodeDecimal128(b)
+}
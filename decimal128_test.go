@@ -0,0 +1,189 @@
+package decimal_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func d128(t *testing.T, s string) (b [16]byte) {
+	t.Helper()
+	if n, err := hex.Decode(b[:], []byte(s)); err != nil || n != len(b) {
+		t.Fatalf("invalid decimal128 %q: %v", s, err)
+	}
+	return b
+}
+
+func TestBig_Decimal128(t *testing.T) {
+	for i, test := range [...]struct {
+		x, enc string
+	}{
+		{"1", "30400000000000000000000000000001"},
+		{"-1", "b0400000000000000000000000000001"},
+		{"1.25", "303c000000000000000000000000007d"},
+		{"0", "30400000000000000000000000000000"},
+		{"-0", "b0400000000000000000000000000000"},
+		{"0E-6176", "00000000000000000000000000000000"},
+		{"-0E-6176", "80000000000000000000000000000000"},
+		{"1E-6176", "00000000000000000000000000000001"},
+		{"0E+6111", "5ffe0000000000000000000000000000"},
+		{"1.234567890123456789012345678901234", "2ffe3cde6fff9732de825cd07e96aff2"},
+		{"9.999999999999999999999999999999999E+6144", "5fffed09bead87c0378d8e63ffffffff"},
+		{"Infinity", "78000000000000000000000000000000"},
+		{"-Infinity", "f8000000000000000000000000000000"},
+		{"NaN", "7c000000000000000000000000000000"},
+		{"-NaN12345", "fc000000000000000000000000003039"},
+		{"sNaN", "7e000000000000000000000000000000"},
+		{"sNaN18446744073709551615", "7e00000000000000ffffffffffffffff"},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		b := x.EncodeDecimal128()
+		if got := hex.EncodeToString(b[:]); got != test.enc || x.Context.Conditions != 0 {
+			t.Fatalf("#%d: %s.EncodeDecimal128(): wanted %s, got %s (%s)", i, test.x, test.enc, got, x.Context.Conditions)
+		}
+		z := new(decimal.Big).DecodeDecimal128(d128(t, test.enc))
+		if z.String() != x.String() || z.Payload() != x.Payload() || z.Context.Conditions != 0 {
+			t.Fatalf("#%d: DecodeDecimal128(%s): wanted %s, got %s (%s)", i, test.enc, x, z, z.Context.Conditions)
+		}
+	}
+}
+
+func TestBig_EncodeDecimal128_Round(t *testing.T) {
+	const r = decimal.Inexact | decimal.Rounded
+	for i, test := range [...]struct {
+		x, enc string
+		c      decimal.Condition
+	}{
+		{"12345678901234567890123456789012345", "30423cde6fff9732de825cd07e96aff2", r},
+		{"1E+6144", "5ffe314dc6448d9338c15b0a00000000", decimal.Clamped},
+		{"1E+6145", "78000000000000000000000000000000", decimal.Overflow | r},
+		{"-1E+999999", "f8000000000000000000000000000000", decimal.Overflow | r},
+		{"15E-6177", "00000000000000000000000000000002", decimal.Subnormal | decimal.Underflow | r},
+		{"1E-6177", "00000000000000000000000000000000", decimal.Subnormal | decimal.Underflow | decimal.Clamped | r},
+		{"0E+9999", "5ffe0000000000000000000000000000", decimal.Clamped},
+		{"-0E-9999", "80000000000000000000000000000000", decimal.Clamped},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		b := x.EncodeDecimal128()
+		if got := hex.EncodeToString(b[:]); got != test.enc || x.Context.Conditions != 0 {
+			t.Fatalf("#%d: %s.EncodeDecimal128(): wanted %s (), got %s (%s)",
+				i, test.x, test.enc, got, x.Context.Conditions)
+		}
+		// The conditions are those of rounding a copy of x.
+		ctx := decimal.Context128
+		ctx.Clamp = true
+		if y := ctx.Round(new(decimal.Big).Copy(x)); y.Context.Conditions != test.c {
+			t.Fatalf("#%d: Round(%s): wanted %s, got %s", i, test.x, test.c, y.Context.Conditions)
+		}
+	}
+
+	// x's RoundingMode is used.
+	x := decimal.WithContext(decimal.Context{RoundingMode: decimal.ToZero})
+	x.SetString("99999999999999999999999999999999999")
+	b := x.EncodeDecimal128()
+	if got := new(decimal.Big).DecodeDecimal128(b).String(); got != "9.999999999999999999999999999999999E+34" {
+		t.Fatalf("EncodeDecimal128 (ToZero): wanted 9.999999999999999999999999999999999E+34, got %s", got)
+	}
+}
+
+func TestBig_DecodeDecimal128_NonCanonical(t *testing.T) {
+	for i, test := range [...]struct {
+		enc, want string
+	}{
+		// The coefficient is 2**113 + 5.
+		{"6c100000000000000000000000000005", "0"},
+		{"ec100000000000000000000000000005", "-0"},
+		// The coefficient is 10**34.
+		{"3041ed09bead87c0378d8e6400000000", "0"},
+		{"0001ed09bead87c0378d8e6400000000", "0E-6176"},
+		// The payload is 2**64, which does not fit in a Payload.
+		{"7e000000000000010000000000000000", "sNaN"},
+		// The payload is 2**110 - 1 > 10**33 - 1.
+		{"7c003fffffffffffffffffffffffffff", "NaN"},
+		// Bits following the combination field of an infinity are ignored.
+		{"7a0000000000000000000000000000ff", "Infinity"},
+	} {
+		z := new(decimal.Big).DecodeDecimal128(d128(t, test.enc))
+		if z.String() != test.want {
+			t.Fatalf("#%d: DecodeDecimal128(%s): wanted %s, got %s", i, test.enc, test.want, z)
+		}
+	}
+}
+
+// TestBig_Decimal128_Random checks that finite values with at most 34 digits
+// and an exponent in range round trip exactly.
+func TestBig_Decimal128_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 5000; i++ {
+		var mant big.Int
+		mant.Rand(rng, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(1+rng.Intn(34))), nil))
+		if rng.Intn(2) == 0 {
+			mant.Neg(&mant)
+		}
+		x := new(decimal.Big).SetBigMantScale(&mant, rng.Intn(6111+6176+1)-6111)
+		b := x.EncodeDecimal128()
+		z := new(decimal.Big).DecodeDecimal128(b)
+		if z.String() != x.String() || z.Signbit() != x.Signbit() || x.Context.Conditions != 0 {
+			t.Fatalf("#%d: %s: round tripped to %s (%s)", i, x, z, x.Context.Conditions)
+		}
+	}
+}
+
+func TestBig_Decimal128_BSON(t *testing.T) {
+	for i, test := range [...]struct {
+		in     string
+		hi, lo uint64
+		out    string
+		cond   decimal.Condition
+	}{
+		{"0", 0x3040000000000000, 0, "0", 0},
+		{"-0", 0xb040000000000000, 0, "-0", 0},
+		{"1", 0x3040000000000000, 1, "1", 0},
+		{"0.1", 0x303e000000000000, 1, "0.1", 0},
+		{"-1E-6176", 0x8000000000000000, 1, "-1E-6176", 0},
+		{"9.999999999999999999999999999999999E+6144", 0x5fffed09bead87c0, 0x378d8e63ffffffff,
+			"9.999999999999999999999999999999999E+6144", 0},
+		// Too many digits.
+		{"12345678901234567890123456789012345", 0x30423cde6fff9732, 0xde825cd07e96aff2,
+			"1.234567890123456789012345678901234E+34", decimal.Inexact | decimal.Rounded},
+		{"12345678901234567890123456789012340", 0x30423cde6fff9732, 0xde825cd07e96aff2,
+			"1.234567890123456789012345678901234E+34", decimal.Rounded},
+		{"-12345678901234567890123456789012346", 0xb0423cde6fff9732, 0xde825cd07e96aff3,
+			"-1.234567890123456789012345678901235E+34", decimal.Inexact | decimal.Rounded},
+		// Exponents out of range are clamped.
+		{"1E+6144", 0x5ffe314dc6448d93, 0x38c15b0a00000000,
+			"1.000000000000000000000000000000000E+6144", decimal.Clamped},
+		{"0E+9999", 0x5ffe000000000000, 0, "0E+6111", decimal.Clamped},
+		{"0E-9999", 0, 0, "0E-6176", decimal.Clamped},
+		{"1E+6145", 0x7800000000000000, 0, "Infinity", decimal.Overflow | decimal.Inexact | decimal.Rounded},
+		{"Infinity", 0x7800000000000000, 0, "Infinity", 0},
+		{"-Infinity", 0xf800000000000000, 0, "-Infinity", 0},
+		// NaN values are canonical.
+		{"NaN", 0x7c00000000000000, 0, "NaN", 0},
+		{"-NaN123", 0x7c00000000000000, 0, "NaN", 0},
+		{"sNaN4", 0x7e00000000000000, 0, "sNaN", 0},
+	} {
+		x, _ := new(decimal.Big).SetString(test.in)
+		hi, lo, cond, err := x.Decimal128()
+		if err != nil || hi != test.hi || lo != test.lo || cond != test.cond {
+			t.Fatalf("#%d: %s.Decimal128(): wanted (%#x, %#x, %s), got (%#x, %#x, %s) (%v)",
+				i, test.in, test.hi, test.lo, test.cond, hi, lo, cond, err)
+		}
+		if x.Context.Conditions != 0 {
+			t.Fatalf("#%d: %s.Decimal128(): raised %s", i, test.in, x.Context.Conditions)
+		}
+		z := new(decimal.Big).SetDecimal128(hi, lo)
+		if z.String() != test.out || z.Context.Conditions != 0 {
+			t.Fatalf("#%d: SetDecimal128(%#x, %#x): wanted %s, got %s (%s)",
+				i, hi, lo, test.out, z, z.Context.Conditions)
+		}
+	}
+
+	var x *decimal.Big
+	if _, _, _, err := x.Decimal128(); err == nil {
+		t.Fatal("Decimal128: wanted an error for a nil receiver")
+	}
+}
@@ -0,0 +1,75 @@
+//go:build decimaltest
+// +build decimaltest
+
+// Package decimaltest provides helpers for testing code that handles the
+// Conditions raised by package decimal.
+//
+// Conditions such as Overflow and InsufficientStorage are difficult to raise
+// with real inputs, so the code that handles them often goes untested. The
+// helpers in this package use Big.ForceCondition to raise any Condition on
+// demand.
+//
+// Big.ForceCondition, and so this package, only exist in builds with the
+// decimaltest build tag. Run the tests that use them with
+//
+//	go test -tags decimaltest
+package decimaltest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+// Conditions is each individual Condition, in order.
+var Conditions = []decimal.Condition{
+	decimal.Clamped,
+	decimal.ConversionSyntax,
+	decimal.DivisionByZero,
+	decimal.DivisionImpossible,
+	decimal.DivisionUndefined,
+	decimal.Inexact,
+	decimal.InsufficientStorage,
+	decimal.InvalidContext,
+	decimal.InvalidOperation,
+	decimal.Overflow,
+	decimal.Rounded,
+	decimal.Subnormal,
+	decimal.Underflow,
+}
+
+// Force arranges for the next operation that stores its result in z to raise
+// c. See Big.ForceCondition.
+func Force(t testing.TB, z *decimal.Big, c decimal.Condition) {
+	t.Helper()
+	z.ForceCondition(c)
+}
+
+// AssertPropagates checks that a code path reports each of the Conditions in
+// conds as an error. For each Condition c in conds, it calls fn in a subtest
+// named after c, and fails the subtest unless fn returns an error for which
+// errors.Is(err, c) is true, such as the error returned by Context.Err when c
+// is trapped.
+//
+// fn should call Force with c and a decimal used by the code path, then run the
+// code path and return its error.
+func AssertPropagates(t *testing.T, conds decimal.Condition, fn func(t *testing.T, c decimal.Condition) error) {
+	t.Helper()
+	for _, c := range Conditions {
+		if conds&c == 0 {
+			continue
+		}
+		c := c
+		t.Run(c.String(), func(t *testing.T) {
+			t.Helper()
+			err := fn(t, c)
+			switch {
+			case err == nil:
+				t.Fatalf("%s was not propagated: got a nil error", c)
+			case !errors.Is(err, c):
+				t.Fatalf("%s was not propagated: got %v", c, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+//go:build decimaltest
+// +build decimaltest
+
+package decimaltest_test
+
+import (
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+	"github.com/ericlagergren/decimal/decimaltest"
+)
+
+// total is a code path under test: it sums xs and reports any trapped
+// Condition as an error.
+func total(z *decimal.Big, xs ...*decimal.Big) (*decimal.Big, error) {
+	for _, x := range xs {
+		z.Add(z, x)
+		if err := z.Context.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return z, nil
+}
+
+func TestAssertPropagates(t *testing.T) {
+	traps := decimal.Overflow | decimal.Underflow | decimal.InsufficientStorage |
+		decimal.InvalidOperation
+	decimaltest.AssertPropagates(t, traps, func(t *testing.T, c decimal.Condition) error {
+		z := decimal.WithContext(decimal.Context{Traps: traps})
+		decimaltest.Force(t, z, c)
+		_, err := total(z, decimal.New(1, 0), decimal.New(2, 0))
+		return err
+	})
+}
+
+func TestForce(t *testing.T) {
+	t.Run("sub", func(t *testing.T) {
+		z := decimal.WithContext(decimal.Context{Traps: decimal.Overflow})
+		decimaltest.Force(t, z, decimal.Overflow)
+		if _, err := total(z, decimal.New(1, 0)); err == nil {
+			t.Fatal("wanted an error")
+		}
+	})
+}
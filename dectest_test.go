@@ -0,0 +1,115 @@
+package decimal_test
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+var dectestModes = map[string]decimal.RoundingMode{
+	"half_even": decimal.ToNearestEven,
+	"half_up":   decimal.ToNearestAway,
+	"down":      decimal.ToZero,
+	"floor":     decimal.ToNegativeInf,
+	"ceiling":   decimal.ToPositiveInf,
+	"up":        decimal.AwayFromZero,
+}
+
+// dectestFields splits a dectest line into fields, keeping quoted fields,
+// which may be empty or contain spaces, intact.
+func dectestFields(line string) []string {
+	var fields []string
+	for line = strings.TrimSpace(line); line != ""; line = strings.TrimSpace(line) {
+		if q := line[0]; q == '\'' || q == '"' {
+			i := strings.IndexByte(line[1:], q) + 1
+			if i == 0 {
+				i = len(line)
+			}
+			fields = append(fields, line[1:i])
+			line = line[i+1:]
+			continue
+		}
+		i := strings.IndexAny(line, " \t")
+		if i < 0 {
+			i = len(line)
+		}
+		fields = append(fields, line[:i])
+		line = line[i:]
+	}
+	return fields
+}
+
+// TestDectest_Base runs the base conversion vectors in _testdata/base.decTest.
+func TestDectest_Base(t *testing.T) {
+	f, err := os.Open(filepath.Join("_testdata", "base.decTest"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	ctx := decimal.Context{OperatingMode: decimal.GDA}
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "--") {
+			continue
+		}
+		if i := strings.IndexByte(line, ':'); i >= 0 && !strings.Contains(line, "->") {
+			key, val := strings.ToLower(line[:i]), strings.TrimSpace(line[i+1:])
+			n, _ := strconv.Atoi(val)
+			switch key {
+			case "precision":
+				ctx.Precision = n
+			case "rounding":
+				mode, ok := dectestModes[val]
+				if !ok {
+					t.Fatalf("unknown rounding %q", val)
+				}
+				ctx.RoundingMode = mode
+			case "maxexponent":
+				ctx.MaxScale = n
+			case "minexponent":
+				ctx.MinScale = n
+			case "extended":
+			default:
+				t.Fatalf("unknown directive %q", line)
+			}
+			continue
+		}
+
+		fields := dectestFields(line)
+		if len(fields) < 5 || fields[3] != "->" {
+			t.Fatalf("invalid test %q", line)
+		}
+		id, op, in, want := fields[0], fields[1], fields[2], fields[4]
+		conds, err := decimal.ParseCondition(strings.Join(fields[5:], ","))
+		if err != nil {
+			t.Fatalf("%s: %v", id, err)
+		}
+		if op != "toSci" {
+			t.Fatalf("%s: unknown operation %q", id, op)
+		}
+		// Like the conversion to a number in the specification, toSci rounds
+		// to the context.
+		z := decimal.WithContext(ctx)
+		if _, ok := z.SetString(in); ok {
+			ctx.Round(z)
+		}
+		got := z.String()
+		if conds&decimal.ConversionSyntax != 0 && z.IsNaN(0) {
+			got = "NaN" // ignore the diagnostic payload
+		}
+		if got != want || z.Context.Conditions != conds {
+			t.Errorf("%s: toSci(%q): wanted %s (%s), got %s (%s)",
+				id, in, want, conds, got, z.Context.Conditions)
+		}
+	}
+	if err := s.Err(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,316 @@
+package decimal_test
+
+import (
+	"flag"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ericlagergren/decimal"
+)
+
+var long = flag.Bool("long", false,
+	"run the differential tests until the test deadline instead of a bounded number of cases")
+
+// TestDiff checks arithmetic against an oracle that computes the exact result
+// with big.Rat and then rounds it explicitly. Values and the Inexact and
+// Rounded conditions must match.
+//
+// By default a fixed number of cases is run with a fixed seed. With -long, new
+// cases are generated with a random seed until the test's deadline (see
+// -timeout), or forever if there is none.
+func TestDiff(t *testing.T) {
+	seed := int64(1)
+	if *long {
+		seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(seed))
+	t.Logf("seed: %d", seed)
+
+	n := 20000
+	if testing.Short() {
+		n = 2000
+	}
+	deadline, hasDeadline := t.Deadline()
+	for i := 0; ; i++ {
+		if *long {
+			if hasDeadline && time.Until(deadline) < 5*time.Second {
+				break
+			}
+		} else if i >= n {
+			break
+		}
+
+		ctx := decimal.Context{
+			Precision:    1 + rng.Intn(40),
+			RoundingMode: decimal.RoundingMode(rng.Intn(int(decimal.ToPositiveInf) + 1)),
+		}
+		x, y := randOperand(rng), randOperand(rng)
+		if err := diffCase(ctx, rng.Intn(6), x, y, rng.Intn(61)-30); err != nil {
+			t.Fatalf("seed %d, case #%d: %v", seed, i, err)
+		}
+	}
+}
+
+// randOperand returns a random finite decimal from one of several
+// representation classes.
+func randOperand(rng *rand.Rand) *decimal.Big {
+	scale := rng.Intn(41) - 20
+	var x decimal.Big
+	switch rng.Intn(7) {
+	case 0:
+		x.SetMantScale(0, scale)
+	case 1:
+		x.SetMantScale(rng.Int63n(1e6)+1, scale)
+	case 2:
+		// Compact, but close to the limit of a uint64.
+		x.SetUint64(rng.Uint64() | 1<<63)
+		n, _ := x.Int(nil)
+		x.SetBigMantScale(n, scale)
+	case 3:
+		// Inflated.
+		var b big.Int
+		b.Rand(rng, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(20+rng.Intn(40))), nil))
+		b.Add(&b, big.NewInt(1))
+		x.SetBigMantScale(&b, scale)
+	case 4:
+		// Powers of ten, which sit on the boundary of a precision.
+		x.SetMantScale(1, scale-rng.Intn(30))
+	case 5:
+		// All nines, which carry when rounded up.
+		s := strings.Repeat("9", 1+rng.Intn(45))
+		b, _ := new(big.Int).SetString(s, 10)
+		x.SetBigMantScale(b, scale)
+	default:
+		// Ties at various digits.
+		s := fmt.Sprintf("%d5", rng.Int63n(1e9))
+		b, _ := new(big.Int).SetString(s, 10)
+		x.SetBigMantScale(b, scale)
+	}
+	if rng.Intn(2) == 0 {
+		x.Neg(&x)
+	}
+	return &x
+}
+
+const diffConds = decimal.Inexact | decimal.Rounded
+
+// diffCase checks a single operation against the oracle. op selects the
+// operation and n is the scale used by Quantize.
+func diffCase(ctx decimal.Context, op int, x, y *decimal.Big, n int) error {
+	xr, yr := x.Rat(nil), y.Rat(nil)
+	z := decimal.WithContext(ctx)
+
+	var (
+		name  string
+		exact *big.Rat
+		ideal int // ideal exponent of the result
+	)
+	switch op {
+	case 0:
+		name = "Add"
+		ctx.Add(z, x, y)
+		exact = new(big.Rat).Add(xr, yr)
+		ideal = min(-x.Scale(), -y.Scale())
+	case 1:
+		name = "Sub"
+		ctx.Sub(z, x, y)
+		exact = new(big.Rat).Sub(xr, yr)
+		ideal = min(-x.Scale(), -y.Scale())
+	case 2:
+		name = "Mul"
+		ctx.Mul(z, x, y)
+		exact = new(big.Rat).Mul(xr, yr)
+		ideal = -x.Scale() - y.Scale()
+	case 3:
+		if y.Sign() == 0 {
+			return nil
+		}
+		name = "Quo"
+		ctx.Quo(z, x, y)
+		exact = new(big.Rat).Quo(xr, yr)
+		ideal = -x.Scale() + y.Scale()
+	case 4:
+		name = fmt.Sprintf("Quantize(%d)", n)
+		ctx.Quantize(z.Copy(x), n)
+		want, cond, ok := oracleQuantize(xr, -x.Scale(), n, ctx)
+		if !ok {
+			if !z.IsNaN(0) {
+				return fmt.Errorf("%s(%s) (%+v): wanted NaN, got %s", name, x, ctx, z)
+			}
+			return nil
+		}
+		return diffCheck(name, x, y, ctx, z, want, cond)
+	default:
+		want := xr.Cmp(yr)
+		if got := x.Cmp(y); got != want {
+			return fmt.Errorf("Cmp(%s, %s): wanted %d, got %d", x, y, want, got)
+		}
+		return nil
+	}
+	want, cond := oracleRound(exact, ideal, ctx)
+	return diffCheck(name, x, y, ctx, z, want, cond)
+}
+
+func diffCheck(name string, x, y *decimal.Big, ctx decimal.Context, z *decimal.Big, want *big.Rat, cond decimal.Condition) error {
+	if !z.IsFinite() {
+		return fmt.Errorf("%s(%s, %s) (%+v): wanted %s, got %s", name, x, y, ctx, want.RatString(), z)
+	}
+	got := z.Context.Conditions & diffConds
+	if z.Rat(nil).Cmp(want) != 0 || got != cond {
+		return fmt.Errorf(`%s(%s, %s) (precision %d, %s)
+wanted: %s (%s)
+got   : %s (%s)`, name, x, y, ctx.Precision, ctx.RoundingMode,
+			new(decimal.Big).SetRat(want), cond, z, got)
+	}
+	return nil
+}
+
+// oracleRound rounds the exact result r, whose ideal exponent is ideal, to
+// ctx's precision and returns it with the conditions the rounding raises.
+func oracleRound(r *big.Rat, ideal int, ctx decimal.Context) (*big.Rat, decimal.Condition) {
+	if r.Sign() == 0 {
+		return r, 0
+	}
+
+	// The unrounded coefficient is r at the ideal exponent, or at the largest
+	// exponent at which r is an integer if that is smaller. If r does not
+	// terminate, it has infinitely many digits.
+	if e, ok := intExp(r, ideal); ok {
+		c := new(big.Rat).Quo(r, pow10Rat(e))
+		if len(new(big.Int).Abs(c.Num()).String()) <= ctx.Precision {
+			return r, 0
+		}
+	}
+
+	// Round to Precision digits: r = c × 10^e with 10^(p-1) <= |c| < 10^p.
+	e := ilog10(r) - ctx.Precision + 1
+	q := roundRat(new(big.Rat).Quo(r, pow10Rat(e)), ctx.RoundingMode)
+	want := new(big.Rat).Mul(new(big.Rat).SetInt(q), pow10Rat(e))
+	cond := decimal.Rounded
+	if want.Cmp(r) != 0 {
+		cond |= decimal.Inexact
+	}
+	return want, cond
+}
+
+// oracleQuantize quantizes x, whose exponent is exp, to the scale n. It
+// reports false if the result has too many digits for ctx's precision.
+func oracleQuantize(x *big.Rat, exp, n int, ctx decimal.Context) (*big.Rat, decimal.Condition, bool) {
+	if x.Sign() == 0 {
+		return x, 0, true
+	}
+	var cond decimal.Condition
+	if exp < -n {
+		cond = decimal.Rounded
+	}
+	q := roundRat(new(big.Rat).Mul(x, pow10Rat(n)), ctx.RoundingMode)
+	if len(new(big.Int).Abs(q).String()) > ctx.Precision {
+		return nil, 0, false
+	}
+	want := new(big.Rat).Quo(new(big.Rat).SetInt(q), pow10Rat(n))
+	if want.Cmp(x) != 0 {
+		cond |= decimal.Inexact
+	}
+	return want, cond, true
+}
+
+// roundRat rounds r to an integer using mode, where DefaultRounding rounds like
+// ToNearestEven, as it does outside of JS mode.
+func roundRat(r *big.Rat, mode decimal.RoundingMode) *big.Int {
+	neg := r.Sign() < 0
+	abs := new(big.Rat).Abs(r)
+	q, m := new(big.Int).QuoRem(abs.Num(), abs.Denom(), new(big.Int))
+	if m.Sign() != 0 {
+		half := new(big.Int).Lsh(m, 1).Cmp(abs.Denom()) // -1, 0, or +1 vs. 1/2
+		var inc bool
+		switch mode {
+		case decimal.DefaultRounding, decimal.ToNearestEven:
+			inc = half > 0 || half == 0 && q.Bit(0) == 1
+		case decimal.ToNearestAway:
+			inc = half >= 0
+		case decimal.ToZero:
+		case decimal.AwayFromZero:
+			inc = true
+		case decimal.ToNegativeInf:
+			inc = neg
+		case decimal.ToPositiveInf:
+			inc = !neg
+		}
+		if inc {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	if neg {
+		q.Neg(q)
+	}
+	return q
+}
+
+// intExp returns the smaller of ideal and the largest exponent e such that
+// r / 10^e is an integer. It reports false if r does not terminate.
+func intExp(r *big.Rat, ideal int) (int, bool) {
+	// r terminates if and only if its reduced denominator has no prime
+	// factors other than 2 and 5.
+	d := new(big.Int).Set(r.Denom())
+	e := 0
+	for _, p := range []int64{2, 5} {
+		bp, k := big.NewInt(p), 0
+		for m := new(big.Int); ; k++ {
+			q, _ := new(big.Int).QuoRem(d, bp, m)
+			if m.Sign() != 0 {
+				break
+			}
+			d = q
+		}
+		if k > e {
+			e = k
+		}
+	}
+	if d.Cmp(big.NewInt(1)) != 0 {
+		return 0, false
+	}
+	// r = num / (2^a 5^b) = num × 2^(e-a) 5^(e-b) / 10^e, so r × 10^e is an
+	// integer. Strip its trailing zeros to find the largest exponent.
+	v := new(big.Rat).Mul(r, pow10Rat(e)).Num()
+	v = new(big.Int).Abs(v)
+	top := -e
+	ten := big.NewInt(10)
+	for m := new(big.Int); top < ideal; top++ {
+		q, _ := new(big.Int).QuoRem(v, ten, m)
+		if m.Sign() != 0 {
+			break
+		}
+		v = q
+	}
+	return min(top, ideal), true
+}
+
+// ilog10 returns floor(log10(|r|)) for a non-zero r.
+func ilog10(r *big.Rat) int {
+	abs := new(big.Rat).Abs(r)
+	e := len(abs.Num().String()) - len(abs.Denom().String())
+	if abs.Cmp(pow10Rat(e)) < 0 {
+		e--
+	}
+	return e
+}
+
+// pow10Rat returns 10^e.
+func pow10Rat(e int) *big.Rat {
+	p := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(e))), nil)
+	if e < 0 {
+		return new(big.Rat).SetFrac(big.NewInt(1), p)
+	}
+	return new(big.Rat).SetInt(p)
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,264 @@
+package decimal
+
+import (
+	"strconv"
+	"unicode/utf8"
+)
+
+// DisplayOptions configures Display.
+type DisplayOptions struct {
+	// RoundingMode is used to round to the maximum number of significant
+	// digits. The zero value, DefaultRounding, stands for the default of x's
+	// OperatingMode; use ToNearestAway to match JavaScript's
+	// Number.prototype.toPrecision in every mode.
+	RoundingMode RoundingMode
+
+	// Suffixes enables the SI suffixes k, M, G, T, P, and E (10^3 through
+	// 10^18) for values that are too large to be displayed positionally. For
+	// example, 1234567 with six significant digits is displayed as 1.23457M
+	// instead of 1.23457e+6. Values too large for any suffix and values too
+	// small to be displayed positionally use exponential form.
+	Suffixes bool
+}
+
+// siSuffixes[i] is the suffix for 10^(3*(i+1)).
+const siSuffixes = "kMGTPE"
+
+// Display returns x rounded to at most maxSig significant digits and formatted
+// for presentation. x is not modified.
+//
+// x is rounded once, from its exact value, using opts.RoundingMode. Trailing
+// zeros after the decimal point are removed. Like JavaScript's toPrecision,
+// the result is positional if the adjusted exponent of the rounded value is in
+// [-6, maxSig) and exponential (e.g., 1.5e+9 or 2e-7) otherwise, unless
+// opts.Suffixes allows a suffix form. Zeros are displayed as 0 regardless of
+// their sign or exponent, and NaN and infinite values as NaN, Infinity, and
+// -Infinity.
+//
+// For compact values, the only allocation is the returned string.
+//
+// If maxSig <= 0, there is no valid result and Display returns NaN, as an
+// operation whose Context has an invalid precision would.
+func (x *Big) Display(maxSig int, opts DisplayOptions) string {
+	if x == nil {
+		return "<nil>"
+	}
+	if debug {
+		x.validate()
+	}
+
+	switch {
+	case maxSig <= 0, x.IsNaN(0):
+		return "NaN"
+	case x.IsInf(+1):
+		return "Infinity"
+	case x.IsInf(-1):
+		return "-Infinity"
+	case x.compact == 0:
+		return "0"
+	}
+
+	var (
+		digits [20]byte
+		b      []byte
+		exp    = x.exp
+		neg    = x.Signbit()
+	)
+	if x.isCompact() {
+		b = strconv.AppendUint(digits[:0], x.compact, 10)
+	} else {
+		b = formatUnscaled(&x.unscaled)
+	}
+	if len(b) > maxSig {
+		var carry bool
+		exp += len(b) - maxSig
+		m := opts.RoundingMode.resolve(x.Context.OperatingMode)
+		b, carry = roundDigits(b, maxSig, m, !neg)
+		if carry {
+			exp++
+		}
+	}
+	for len(b) > 1 && b[len(b)-1] == '0' {
+		b = b[:len(b)-1]
+		exp++
+	}
+	adj := exp + len(b) - 1
+
+	var buf [64]byte
+	out := buf[:0]
+	if neg {
+		out = append(out, '-')
+	}
+	switch {
+	case adj >= -6 && adj < maxSig:
+		out = appendPlain(out, b, exp)
+	case opts.Suffixes && adj >= 3 && adj < 3*(len(siSuffixes)+1):
+		g := adj / 3
+		out = appendPlain(out, b, exp-3*g)
+		out = append(out, siSuffixes[g-1])
+	default:
+		out = append(out, b[0])
+		if len(b) > 1 {
+			out = append(out, '.')
+			out = append(out, b[1:]...)
+		}
+		out = append(out, 'e')
+		if adj >= 0 {
+			out = append(out, '+')
+		}
+		out = strconv.AppendInt(out, int64(adj), 10)
+	}
+	return string(out)
+}
+
+// roundDigits rounds the plain numeric string b to n digits, where n < len(b).
+// Unlike roundString, it considers every discarded digit, so it rounds
+// correctly from the exact value. If the rounding carried out of the first
+// digit (e.g., 999 -> 10), it reports true and the caller must increment the
+// exponent.
+func roundDigits(b []byte, n int, mode RoundingMode, pos bool) ([]byte, bool) {
+	d, rest := b[n], b[n+1:]
+	if d == '0' && allZeros(rest) {
+		return b[:n], false
+	}
+	r := -1 // below half
+	if d > '5' || (d == '5' && !allZeros(rest)) {
+		r = 1
+	} else if d == '5' {
+		r = 0
+	}
+
+	b = b[:n]
+	if !mode.needsInc(b[n-1]%2 != 0, r, pos) {
+		return b, false
+	}
+	for i := n - 1; i >= 0; i-- {
+		if b[i] != '9' {
+			b[i]++
+			return b, false
+		}
+		b[i] = '0'
+	}
+	b[0] = '1'
+	return b, true
+}
+
+// appendPlain appends the digits b with the exponent exp to dst without using
+// exponential notation.
+func appendPlain(dst, b []byte, exp int) []byte {
+	switch radix := len(b) + exp; {
+	case exp >= 0:
+		dst = append(dst, b...)
+		for ; exp > 0; exp-- {
+			dst = append(dst, '0')
+		}
+	case radix > 0:
+		dst = append(dst, b[:radix]...)
+		dst = append(dst, '.')
+		dst = append(dst, b[radix:]...)
+	default:
+		dst = append(dst, '0', '.')
+		for ; radix < 0; radix++ {
+			dst = append(dst, '0')
+		}
+		dst = append(dst, b...)
+	}
+	return dst
+}
+
+// MaxExpandedDigits is the largest number of digits, including leading and
+// trailing zeros, that Expanded writes before falling back to String.
+const MaxExpandedDigits = 10000
+
+// Expanded returns x formatted in positional notation, regardless of its
+// exponent, with exactly x's scale: 12E+3 is formatted as 12000, 1.50 as 1.50,
+// and 1E-8 as 0.00000001. Unlike String, it never uses exponential notation,
+// unless the result would have more than MaxExpandedDigits digits, in which
+// case it returns String(). A zero with a negative scale is formatted as 0 and
+// NaN and infinite values are formatted as by String.
+func (x *Big) Expanded() string {
+	if x == nil {
+		return "<nil>"
+	}
+	if debug {
+		x.validate()
+	}
+	if b, ok := x.appendExpanded(nil); ok {
+		return string(b)
+	}
+	return x.String()
+}
+
+// appendExpanded appends x formatted as by Expanded to dst. It reports false
+// if x is not finite or would have more than MaxExpandedDigits digits.
+func (x *Big) appendExpanded(dst []byte) ([]byte, bool) {
+	if !x.IsFinite() {
+		return dst, false
+	}
+	b, exp := x.jsDigits()
+	if x.compact == 0 && exp > 0 {
+		exp = 0
+	}
+	n := len(b) + exp // digits before the decimal point, if positive
+	switch {
+	case exp < 0 && n > 0:
+		n = len(b)
+	case exp < 0:
+		n = 1 - exp
+	}
+	if n > MaxExpandedDigits {
+		return dst, false
+	}
+	if x.Signbit() {
+		dst = append(dst, '-')
+	}
+	return appendPlain(dst, b, exp), true
+}
+
+// Group returns x formatted as by Expanded, with the digits of its integer part
+// separated into groups of groupSize digits by sep, counting from the decimal
+// point, and with the decimal point written as decimal. For example, 1234567.891
+// is formatted as 1,234,567.891 with Group(',', '.', 3) and as 1.234.567,891
+// with Group('.', ',', 3). The fractional part is never grouped, and a
+// groupSize of zero or less disables grouping.
+//
+// Like Expanded, Group never uses exponential notation, so 1.5E+6 is formatted
+// as 1,500,000. NaN and infinite values, and values for which Expanded falls
+// back to String, are formatted as by String, without grouping.
+func (x *Big) Group(sep, decimal rune, groupSize int) string {
+	if x == nil {
+		return "<nil>"
+	}
+	if debug {
+		x.validate()
+	}
+	var buf [64]byte
+	b, ok := x.appendExpanded(buf[:0])
+	if !ok {
+		return x.String()
+	}
+
+	dst := make([]byte, 0, len(b)+len(b)/2+utf8.UTFMax)
+	if b[0] == '-' {
+		dst = append(dst, '-')
+		b = b[1:]
+	}
+	n := len(b) // digits in the integer part
+	for i, c := range b {
+		if c == '.' {
+			n = i
+			break
+		}
+	}
+	for i := 0; i < n; i++ {
+		if i > 0 && groupSize > 0 && (n-i)%groupSize == 0 {
+			dst = utf8.AppendRune(dst, sep)
+		}
+		dst = append(dst, b[i])
+	}
+	if n < len(b) {
+		dst = utf8.AppendRune(dst, decimal)
+		dst = append(dst, b[n+1:]...)
+	}
+	return string(dst)
+}
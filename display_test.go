@@ -0,0 +1,143 @@
+package decimal_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestBig_Display(t *testing.T) {
+	even := decimal.DisplayOptions{}
+	away := decimal.DisplayOptions{RoundingMode: decimal.ToNearestAway}
+	si := decimal.DisplayOptions{RoundingMode: decimal.ToNearestAway, Suffixes: true}
+	for i, test := range [...]struct {
+		x      string
+		maxSig int
+		opts   decimal.DisplayOptions
+		want   string
+	}{
+		{"0", 6, even, "0"},
+		{"-0.000", 6, even, "0"},
+		{"NaN", 6, even, "NaN"},
+		{"-Infinity", 6, even, "-Infinity"},
+		{"1.50", 6, even, "1.5"},
+		{"-1.50", 6, even, "-1.5"},
+		{"1200", 6, even, "1200"},
+		{"1.2E+3", 6, even, "1200"},
+		{"123456", 6, even, "123456"},
+		{"1234567", 6, even, "1.23457e+6"},
+		{"999999.7", 6, even, "1e+6"},
+		{"0.000001", 6, even, "0.000001"},
+		{"0.0000001", 6, even, "1e-7"},
+		{"0.00000012345678", 6, even, "1.23457e-7"},
+		{"3.14159265358979", 3, even, "3.14"},
+
+		// Ties are decided by the exact value, not by the first discarded
+		// digit alone.
+		{"1.25", 2, even, "1.2"},
+		{"1.250000000000000000000000001", 2, even, "1.3"},
+		{"1.35", 2, even, "1.4"},
+		{"1.25", 2, away, "1.3"},
+		{"-1.25", 2, away, "-1.3"},
+		{"1.29", 2, decimal.DisplayOptions{RoundingMode: decimal.ToZero}, "1.2"},
+		{"-1.21", 2, decimal.DisplayOptions{RoundingMode: decimal.ToNegativeInf}, "-1.3"},
+
+		{"1234567", 6, si, "1.23457M"},
+		{"1234567", 3, si, "1.23M"},
+		{"999999.7", 6, si, "1M"},
+		{"1500", 2, si, "1.5k"},
+		{"123456789012", 3, si, "123G"},
+		{"1.5E+20", 6, si, "150E"},
+		{"1.5E+21", 6, si, "1.5e+21"},
+		{"0.0000001", 6, si, "1e-7"},
+		{"-98765", 2, si, "-99k"},
+		{"123456789012345678901234567890", 4, si, "1.235e+29"},
+
+		{"12.5", 0, even, "NaN"},
+		{"0", -1, even, "NaN"},
+	} {
+		x, ok := new(decimal.Big).SetString(test.x)
+		if !ok {
+			t.Fatalf("#%d: invalid input %q", i, test.x)
+		}
+		orig := x.String()
+		if got := x.Display(test.maxSig, test.opts); got != test.want {
+			t.Fatalf("#%d: Display(%s, %d): wanted %q, got %q", i, test.x, test.maxSig, test.want, got)
+		}
+		if x.String() != orig {
+			t.Fatalf("#%d: Display modified x: %s -> %s", i, orig, x)
+		}
+	}
+}
+
+func TestBig_DisplayAllocs(t *testing.T) {
+	x := decimal.New(-123456789, 3)
+	opts := decimal.DisplayOptions{Suffixes: true}
+	if n := testing.AllocsPerRun(100, func() { x.Display(6, opts) }); n > 1 {
+		t.Fatalf("wanted at most 1 allocation, got %.1f", n)
+	}
+}
+
+func TestBig_Expanded(t *testing.T) {
+	for i, test := range [...]struct {
+		x, want string
+	}{
+		{"0", "0"},
+		{"-0", "-0"},
+		{"0E+5", "0"},
+		{"0.000", "0.000"},
+		{"12E+3", "12000"},
+		{"-12E+3", "-12000"},
+		{"1.2E+3", "1200"},
+		{"1.50", "1.50"},
+		{"-0.012", "-0.012"},
+		{"1E-8", "0.00000001"},
+		{"123456789012345678901234567890E+5", "12345678901234567890123456789000000"},
+		{"1E+9999", "1" + strings.Repeat("0", 9999)},
+		{"1E+10000", "1E+10000"},
+		{"1E-9999", "0." + strings.Repeat("0", 9998) + "1"},
+		{"1E-10000", "1E-10000"},
+		{"NaN", "NaN"},
+		{"-Infinity", "-Infinity"},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		if got := x.Expanded(); got != test.want {
+			t.Fatalf("#%d: Expanded(%s): wanted %q, got %q", i, test.x, test.want, got)
+		}
+	}
+}
+
+func TestBig_Group(t *testing.T) {
+	for i, test := range [...]struct {
+		x            string
+		sep, decimal rune
+		size         int
+		want         string
+	}{
+		{"1234567.891", ',', '.', 3, "1,234,567.891"},
+		{"1234567.891", '.', ',', 3, "1.234.567,891"},
+		{"-1234567.891", ' ', ',', 3, "-1 234 567,891"},
+		{"1234567.891", '\u202f', ',', 3, "1\u202f234\u202f567,891"},
+		{"123456789", ',', '.', 4, "1,2345,6789"},
+		{"123456", ',', '.', 3, "123,456"},
+		{"12345", ',', '.', 3, "12,345"},
+		{"123", ',', '.', 3, "123"},
+		{"0.0001234", ',', '.', 3, "0.0001234"},
+		{"-0", ',', '.', 3, "-0"},
+		{"1000.50", ',', '.', 3, "1,000.50"},
+		{"1.5E+6", ',', '.', 3, "1,500,000"},
+		{"1E-8", ',', '.', 3, "0.00000001"},
+		{"1234567.891", ',', '.', 0, "1234567.891"},
+		{"1234567.891", ',', '·', -1, "1234567·891"},
+		{"1E+10000", ',', '.', 3, "1E+10000"},
+		{"NaN", ',', '.', 3, "NaN"},
+		{"-Infinity", ',', '.', 3, "-Infinity"},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		if got := x.Group(test.sep, test.decimal, test.size); got != test.want {
+			t.Fatalf("#%d: Group(%s, %q, %q, %d): wanted %q, got %q",
+				i, test.x, test.sep, test.decimal, test.size, test.want, got)
+		}
+	}
+}
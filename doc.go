@@ -27,11 +27,12 @@
 // Instead, this library accepts a simple ``int'' which can be derived from an
 // existing decimal if required.
 //
-// It contains two modes of operation designed to make transitioning to various
+// It contains three modes of operation designed to make transitioning to various
 // GDA "quirks" (like always rounding lossless operations) easier.
 //
 //     GDA: strictly adhere to the GDA specification (default)
 //     Go: utilize Go idioms, more flexibility
+//	JS: mirror the defaults of decimal.js
 //
 // Goals
 //
@@ -68,6 +69,11 @@
 //     y := new(Big)
 //     z := &Big{}
 //
+// Each of the above is indistinguishable from New(0, 0): it prints as "0", has
+// a scale of 0 and a precision of 1, and compares equal to any other zero. Its
+// zero Context uses DefaultPrecision, ToNearestEven, and the GDA
+// OperatingMode. This makes it safe to embed a Big by value inside of structs.
+//
 // Method naming is the same as math/big's, meaning:
 //
 //     func (z *T) SetV(v V) *T          // z = v
@@ -105,4 +111,17 @@
 //    go func() { g.Add(x, x) }() // BAD! RACE CONDITION!
 //    go func() { g.Add(x, x) }() // BAD! RACE CONDITION!
 //
+// A method on Big, like Add, performs its operation under the receiver's
+// Context. Each such method has a counterpart on Context, like Context.Add,
+// that performs it under an explicit Context instead, regardless of the
+// Context of the receiver, as the GDA specification and Python's decimal module
+// do. This makes it simple to apply one policy to many values, or to run the
+// same calculation under, say, Context64 and Context128 for comparison.
+//
+// A Context method sets the Context of its result to the Context it was
+// performed under, keeping the result's Conditions, to which it adds the
+// conditions it raised, and its tag, so later operations on the result follow
+// the same policy. Context.Do collects the conditions raised by every
+// operation performed under a Context.
+//
 package decimal
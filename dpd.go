@@ -0,0 +1,328 @@
+package decimal
+
+import (
+	"encoding/binary"
+	"math/big"
+	"math/bits"
+
+	cst "github.com/ericlagergren/decimal/internal/c"
+)
+
+// dpdFormat describes an IEEE 754-2008 decimal interchange format using the
+// Densely Packed Decimal (DPD) encoding. From the most significant bit, an
+// encoding consists of the sign bit, the 5-bit combination field, the exponent
+// continuation, and the coefficient continuation, a sequence of declets that
+// each encode three digits in 10 bits.
+type dpdFormat struct {
+	interchangeFormat
+	bits    uint // width of the encoding
+	econt   uint // width of the exponent continuation
+	declets uint // declets in the coefficient continuation
+}
+
+var (
+	dpd64Format  = dpdFormat{interchangeFormat: decimal64Format, bits: 64, econt: 8, declets: 5}
+	dpd128Format = dpdFormat{interchangeFormat: decimal128Format, bits: 128, econt: 12, declets: 11}
+)
+
+// EncodeDPD128 returns x in the IEEE 754-2008 decimal128 interchange format,
+// using the Densely Packed Decimal (DPD) encoding. Like EncodeDecimal128, the
+// bytes are in big-endian order and a copy of x is rounded if x does not fit.
+// Every declet is canonical.
+func (x *Big) EncodeDPD128() (b [16]byte) {
+	mustNotNil("EncodeDPD128", x, x)
+	u := x.encodeDPD(&dpd128Format)
+	binary.BigEndian.PutUint64(b[:8], u.hi)
+	binary.BigEndian.PutUint64(b[8:], u.lo)
+	return b
+}
+
+// DecodeDPD128 sets z to the DPD decimal128 b, encoded as by EncodeDPD128, and
+// returns z. Like DecodeDecimal128, z is not rounded and no conditions are
+// raised.
+//
+// Non-canonical declets decode to the digits IEEE 754-2008 assigns to them,
+// and bits that IEEE 754-2008 ignores, such as those following the
+// combination field of an infinity, are ignored. A NaN's payload is decoded as
+// zero if it is larger than a Payload can hold.
+func (z *Big) DecodeDPD128(b [16]byte) *Big {
+	mustNotNil("DecodeDPD128", z, z)
+	u := uint128{
+		hi: binary.BigEndian.Uint64(b[:8]),
+		lo: binary.BigEndian.Uint64(b[8:]),
+	}
+	return z.decodeDPD(u, &dpd128Format)
+}
+
+// EncodeDPD64 is like EncodeDPD128, but returns x in the decimal64 interchange
+// format, which has 16 digits and exponents in [-398, 369]. A NaN's payload is
+// dropped if it has more than 15 digits.
+func (x *Big) EncodeDPD64() (b [8]byte) {
+	mustNotNil("EncodeDPD64", x, x)
+	u := x.encodeDPD(&dpd64Format)
+	binary.BigEndian.PutUint64(b[:], u.lo)
+	return b
+}
+
+// DecodeDPD64 is like DecodeDPD128, but decodes the DPD decimal64 b.
+func (z *Big) DecodeDPD64(b [8]byte) *Big {
+	mustNotNil("DecodeDPD64", z, z)
+	return z.decodeDPD(uint128{lo: binary.BigEndian.Uint64(b[:])}, &dpd64Format)
+}
+
+// e18 is 10**18, the base of the halves of a coefficient with up to 34 digits.
+const e18 = 1e18
+
+func (x *Big) encodeDPD(f *dpdFormat) (u uint128) {
+	if debug {
+		x.validate()
+	}
+
+	coff := f.bits - 6     // offset of the combination field
+	eoff := 10 * f.declets // offset of the exponent continuation
+	if x.Signbit() {
+		u.set(f.bits-1, 1, 1)
+	}
+	switch {
+	case x.IsInf(0):
+		u.set(coff, 5, 0x1e)
+		return u
+	case x.IsNaN(0):
+		u.set(coff, 5, 0x1f)
+		if x.form&snan != 0 {
+			u.set(eoff+f.econt-1, 1, 1)
+		}
+		// Drop a payload that does not fit in the coefficient continuation,
+		// whose most significant digit is always zero.
+		if p := x.compact; f.declets > 6 || p < pow1000(f.declets) {
+			f.putDeclets(&u, p/e18, p%e18)
+		}
+		return u
+	}
+
+	y := x
+	if !f.fits(x) {
+		y, _ = x.toInterchange(&f.interchangeFormat)
+		if y.IsInf(0) {
+			u.set(coff, 5, 0x1e)
+			return u
+		}
+	}
+
+	var hi, lo uint64
+	if y.isCompact() {
+		hi, lo = y.compact/e18, y.compact%e18
+	} else {
+		var q, r big.Int
+		q.QuoRem(&y.unscaled, big.NewInt(e18), &r)
+		hi, lo = q.Uint64(), r.Uint64()
+	}
+	msd := f.putDeclets(&u, hi, lo)
+
+	e := uint64(y.exp - f.qmin())
+	if msd < 8 {
+		u.set(coff, 5, e>>f.econt<<3|msd)
+	} else {
+		u.set(coff, 5, 0x18|e>>f.econt<<1|msd&1)
+	}
+	u.set(eoff, f.econt, e&(1<<f.econt-1))
+	return u
+}
+
+// putDeclets encodes the coefficient hi×10**18 + lo into the coefficient
+// continuation of u and returns its most significant digit.
+func (f *dpdFormat) putDeclets(u *uint128, hi, lo uint64) uint64 {
+	for i := uint(0); i < f.declets; i++ {
+		src := &lo
+		if i >= 6 {
+			src = &hi
+		}
+		u.set(10*i, 10, uint64(dpdEncode[*src%1000]))
+		*src /= 1000
+	}
+	// Either lo has been consumed or, for decimal64, hi is zero.
+	return hi + lo
+}
+
+func (z *Big) decodeDPD(u uint128, f *dpdFormat) *Big {
+	coff := f.bits - 6
+	eoff := 10 * f.declets
+
+	var sign form
+	if u.get(f.bits-1, 1) != 0 {
+		sign = signbit
+	}
+	g := u.get(coff, 5)
+
+	var hi, lo, mul uint64 = 0, 0, 1
+	for i := uint(0); i < f.declets; i++ {
+		if i == 6 {
+			mul = 1
+		}
+		d := uint64(dpdDecode[u.get(10*i, 10)]) * mul
+		if i < 6 {
+			lo += d
+		} else {
+			hi += d
+		}
+		mul *= 1000
+	}
+
+	var e, msd uint64
+	switch {
+	case g>>1 == 0xf:
+		if g&1 == 0 {
+			z.form = pinf | sign
+			return z
+		}
+		z.form = qnan | sign
+		if u.get(eoff+f.econt-1, 1) != 0 {
+			z.form = snan | sign
+		}
+		// The payload is hi×10**18 + lo, which might not fit in a uint64.
+		h, l := bits.Mul64(hi, e18)
+		p, c := bits.Add64(l, lo, 0)
+		z.compact = 0
+		if h == 0 && c == 0 {
+			z.compact = p
+		}
+		return z
+	case g>>3 == 3:
+		e, msd = g>>1&3, 8|g&1
+	default:
+		e, msd = g>>3, g&7
+	}
+	e = e<<f.econt | u.get(eoff, f.econt)
+
+	if f.declets > 6 {
+		hi += msd * mul
+	} else {
+		lo += msd * mul
+	}
+	exp := int(e) + f.qmin()
+	if hi == 0 && lo != cst.Inflated {
+		return z.setTriple(lo, sign, exp)
+	}
+	z.unscaled.SetUint64(hi)
+	z.unscaled.Mul(&z.unscaled, big.NewInt(e18))
+	z.unscaled.Add(&z.unscaled, new(big.Int).SetUint64(lo))
+	z.form = finite | sign
+	z.exp = exp
+	return z.norm()
+}
+
+// pow1000 returns 1000**n, which must fit in a uint64.
+func pow1000(n uint) uint64 {
+	p := uint64(1)
+	for ; n > 0; n-- {
+		p *= 1000
+	}
+	return p
+}
+
+// uint128 is a 128-bit unsigned integer.
+type uint128 struct {
+	hi, lo uint64
+}
+
+// set sets the width bits of u at offset off, which must be zero, to v.
+func (u *uint128) set(off, width uint, v uint64) {
+	if off >= 64 {
+		u.hi |= v << (off - 64)
+		return
+	}
+	u.lo |= v << off
+	if off+width > 64 {
+		u.hi |= v >> (64 - off)
+	}
+}
+
+// get returns the width bits of u at offset off.
+func (u uint128) get(off, width uint) uint64 {
+	var v uint64
+	if off >= 64 {
+		v = u.hi >> (off - 64)
+	} else {
+		v = u.lo >> off
+		if off+width > 64 {
+			v |= u.hi << (64 - off)
+		}
+	}
+	return v & (1<<width - 1)
+}
+
+// dpdEncode maps three digits to their canonical declet and dpdDecode maps
+// every declet, canonical or not, to its three digits.
+var (
+	dpdEncode [1000]uint16
+	dpdDecode [1024]uint16
+)
+
+func init() {
+	for n := range dpdEncode {
+		dpdEncode[n] = encodeDeclet(uint16(n))
+	}
+	for d := range dpdDecode {
+		dpdDecode[d] = decodeDeclet(uint16(d))
+	}
+}
+
+// encodeDeclet returns the canonical declet of the three digits of n, which
+// must be in [0, 999], per table 3.4 of IEEE 754-2008. The bits of the digits
+// are abcd, efgh, and ijkm, and a, e, and i are set only for 8 and 9.
+func encodeDeclet(n uint16) uint16 {
+	d1, d2, d3 := n/100, n/10%10, n%10
+	a, e, i := d1>>3, d2>>3, d3>>3
+	bcd, fgh, jkm := d1&7, d2&7, d3&7
+	d, h, m := d1&1, d2&1, d3&1
+	jk, fg := d3&6, d2&6 // jk0 and fg0
+
+	switch a<<2 | e<<1 | i {
+	case 0: // bcd fgh 0 jkm
+		return bcd<<7 | fgh<<4 | jkm
+	case 1: // bcd fgh 1 00m
+		return bcd<<7 | fgh<<4 | 0x8 | m
+	case 2: // bcd jkh 1 01m
+		return bcd<<7 | jk<<4 | h<<4 | 0xa | m
+	case 4: // jkd fgh 1 10m
+		return jk<<7 | d<<7 | fgh<<4 | 0xc | m
+	case 6: // jkd 00h 1 11m
+		return jk<<7 | d<<7 | h<<4 | 0xe | m
+	case 5: // fgd 01h 1 11m
+		return fg<<7 | d<<7 | 0x20 | h<<4 | 0xe | m
+	case 3: // bcd 10h 1 11m
+		return bcd<<7 | 0x40 | h<<4 | 0xe | m
+	default: // 00d 11h 1 11m
+		return d<<7 | 0x60 | h<<4 | 0xe | m
+	}
+}
+
+// decodeDeclet returns the three digits, as an integer, encoded by the declet
+// pqr stu v wxy, per table 3.3 of IEEE 754-2008. The bits marked as ignored
+// by the table are what make the 24 non-canonical declets.
+func decodeDeclet(b uint16) uint16 {
+	pqr, stu, wxy := b>>7, b>>4&7, b&7
+	pq, st, wx := b>>7&6, b>>4&6, b&6
+	r, u, y := b>>7&1, b>>4&1, b&1
+
+	var d1, d2, d3 uint16
+	switch {
+	case b&0x8 == 0: // v = 0
+		d1, d2, d3 = pqr, stu, wxy
+	case wx == 0:
+		d1, d2, d3 = pqr, stu, 8|y
+	case wx == 2:
+		d1, d2, d3 = pqr, 8|u, st|y
+	case wx == 4:
+		d1, d2, d3 = 8|r, stu, pq|y
+	case st == 0:
+		d1, d2, d3 = 8|r, 8|u, pq|y
+	case st == 2:
+		d1, d2, d3 = 8|r, pq|u, 8|y
+	case st == 4:
+		d1, d2, d3 = pqr, 8|u, 8|y
+	default: // pq is ignored
+		d1, d2, d3 = 8|r, 8|u, 8|y
+	}
+	return d1*100 + d2*10 + d3
+}
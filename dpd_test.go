@@ -0,0 +1,226 @@
+package decimal_test
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func d64(t *testing.T, s string) (b [8]byte) {
+	t.Helper()
+	if n, err := hex.Decode(b[:], []byte(s)); err != nil || n != len(b) {
+		t.Fatalf("invalid decimal64 %q: %v", s, err)
+	}
+	return b
+}
+
+// TestBig_DPD_Declets checks every declet: each of the 1000 canonical declets
+// round trips, and each of the 24 non-canonical ones decodes to the digits
+// IEEE 754-2008 assigns to it.
+func TestBig_DPD_Declets(t *testing.T) {
+	const zero uint64 = 0x2238000000000000 // 0 in decimal64
+
+	canon := make(map[uint64]int64)
+	for n := int64(0); n < 1000; n++ {
+		x := decimal.New(n, 0)
+		b := x.EncodeDPD64()
+		u := binary.BigEndian.Uint64(b[:])
+		if u&^0x3ff != zero {
+			t.Fatalf("%d: EncodeDPD64(): wanted %x, got %x", n, zero, u&^0x3ff)
+		}
+		if m, ok := canon[u&0x3ff]; ok {
+			t.Fatalf("%d: EncodeDPD64(): declet %#x also encodes %d", n, u&0x3ff, m)
+		}
+		canon[u&0x3ff] = n
+		if z := new(decimal.Big).DecodeDPD64(b); z.Cmp(x) != 0 {
+			t.Fatalf("%d: round tripped to %s", n, z)
+		}
+	}
+
+	nonCanon := 0
+	for d := uint64(0); d < 1024; d++ {
+		var b [8]byte
+		binary.BigEndian.PutUint64(b[:], zero|d)
+		z := new(decimal.Big).DecodeDPD64(b)
+		n, ok := canon[d]
+		if ok {
+			if z.Cmp(decimal.New(n, 0)) != 0 {
+				t.Fatalf("%#x: DecodeDPD64: wanted %d, got %s", d, n, z)
+			}
+			continue
+		}
+		nonCanon++
+		// The non-canonical declets are those of the form ab11x111x1, with
+		// a or b set, which decode as the digits 8 and 9 only.
+		if d&0x6e != 0x6e || d&0x300 == 0 {
+			t.Fatalf("%#x: unexpected non-canonical declet", d)
+		}
+		want := decimal.New(int64(888+d>>7&1*100+d>>4&1*10+d&1), 0)
+		if z.Cmp(want) != 0 {
+			t.Fatalf("%#x: DecodeDPD64: wanted %s, got %s", d, want, z)
+		}
+	}
+	if nonCanon != 24 {
+		t.Fatalf("wanted 24 non-canonical declets, got %d", nonCanon)
+	}
+}
+
+func TestBig_DPD64(t *testing.T) {
+	for i, test := range [...]struct {
+		x, enc string
+	}{
+		{"0", "2238000000000000"},
+		{"-0", "a238000000000000"},
+		{"1", "2238000000000001"},
+		{"-7.50", "a2300000000003d0"},
+		{"999", "22380000000000ff"},
+		{"888", "223800000000006e"},
+		{"1E-398", "0000000000000001"},
+		{"8E-383", "003c000000000008"},
+		{"9.000000000000000E+384", "77fc000000000000"},
+		{"9.999999999999999E+384", "77fcff3fcff3fcff"},
+		{"Infinity", "7800000000000000"},
+		{"-Infinity", "f800000000000000"},
+		{"NaN", "7c00000000000000"},
+		{"-NaN123", "fc000000000000a3"},
+		{"sNaN", "7e00000000000000"},
+		{"sNaN999999999999999", "7e00ff3fcff3fcff"},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		b := x.EncodeDPD64()
+		if got := hex.EncodeToString(b[:]); got != test.enc || x.Context.Conditions != 0 {
+			t.Fatalf("#%d: %s.EncodeDPD64(): wanted %s, got %s (%s)", i, test.x, test.enc, got, x.Context.Conditions)
+		}
+		z := new(decimal.Big).DecodeDPD64(d64(t, test.enc))
+		if z.String() != x.String() || z.Payload() != x.Payload() || z.Context.Conditions != 0 {
+			t.Fatalf("#%d: DecodeDPD64(%s): wanted %s, got %s (%s)", i, test.enc, x, z, z.Context.Conditions)
+		}
+	}
+}
+
+func TestBig_DPD128(t *testing.T) {
+	for i, test := range [...]struct {
+		x, enc string
+	}{
+		{"0", "22080000000000000000000000000000"},
+		{"-0", "a2080000000000000000000000000000"},
+		{"1", "22080000000000000000000000000001"},
+		{"1E-6176", "00000000000000000000000000000001"},
+		{"9.999999999999999999999999999999999E+6144", "77ffcff3fcff3fcff3fcff3fcff3fcff"},
+		{"Infinity", "78000000000000000000000000000000"},
+		{"NaN", "7c000000000000000000000000000000"},
+		{"-sNaN123", "fe0000000000000000000000000000a3"},
+		// The largest Payload has 20 digits, which always fit.
+		{"NaN18446744073709551615", "7c00000000000001891bc41cf89b4715"},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		b := x.EncodeDPD128()
+		if got := hex.EncodeToString(b[:]); got != test.enc || x.Context.Conditions != 0 {
+			t.Fatalf("#%d: %s.EncodeDPD128(): wanted %s, got %s (%s)", i, test.x, test.enc, got, x.Context.Conditions)
+		}
+		z := new(decimal.Big).DecodeDPD128(d128(t, test.enc))
+		if z.String() != x.String() || z.Payload() != x.Payload() || z.Context.Conditions != 0 {
+			t.Fatalf("#%d: DecodeDPD128(%s): wanted %s, got %s (%s)", i, test.enc, x, z, z.Context.Conditions)
+		}
+	}
+}
+
+// TestBig_DPD_ExponentContinuation checks the bits that follow the combination
+// field: those of infinities and NaNs, and the exponent continuation of finite
+// values with each form of the combination field.
+func TestBig_DPD_ExponentContinuation(t *testing.T) {
+	for i, test := range [...]struct {
+		enc, want string
+	}{
+		// Everything following the combination field of an infinity is
+		// ignored.
+		{"7bfcff3fcff3fcff", "Infinity"},
+		{"f9ff000000000000", "-Infinity"},
+		// The first bit of the exponent continuation of a NaN marks it as
+		// signaling, and the others are ignored.
+		{"7dfc000000000000", "NaN"},
+		{"7ffc000000000001", "sNaN1"},
+		// A non-canonical declet in a payload.
+		{"7c00ff3fcff3fdff", "NaN999999999999999"},
+		// The exponent's two most significant bits are in the combination
+		// field, followed by either three bits of a small most significant
+		// digit or one bit of a large one.
+		{"0000000000000000", "0E-398"},
+		{"2230000000000000", "0.00"},
+		{"5c00000000000000", "7.000000000000000E+129"},
+		{"5ffc000000000000", "7.000000000000000E+384"},
+		{"6000000000000000", "8.000000000000000E-383"},
+		{"6ffc000000000000", "9.000000000000000E+128"},
+		{"7200000000000000", "8.000000000000000E+257"},
+		{"77fc000000000000", "9.000000000000000E+384"},
+		// Non-canonical declets.
+		{"22380000000001ff", "999"},
+		{"22380000000002ff", "999"},
+		{"22380000000003ff", "999"},
+		{"6404ff3fcff3fcff", "9.999999999999999E-382"},
+	} {
+		z := new(decimal.Big).DecodeDPD64(d64(t, test.enc))
+		if z.String() != test.want || z.Context.Conditions != 0 {
+			t.Fatalf("#%d: DecodeDPD64(%s): wanted %s, got %s (%s)", i, test.enc, test.want, z, z.Context.Conditions)
+		}
+	}
+}
+
+func TestBig_EncodeDPD_Round(t *testing.T) {
+	const r = decimal.Inexact | decimal.Rounded
+	for i, test := range [...]struct {
+		x, enc string
+		c      decimal.Condition
+	}{
+		{"12345678901234567", "263d34b9c1e28e57", r},
+		{"1E+384", "47fc000000000000", decimal.Clamped},
+		{"1E+385", "7800000000000000", decimal.Overflow | r},
+		{"15E-399", "0000000000000002", decimal.Subnormal | decimal.Underflow | r},
+		{"1E-399", "0000000000000000", decimal.Subnormal | decimal.Underflow | decimal.Clamped | r},
+		{"-0E-999", "8000000000000000", decimal.Clamped},
+		// Payloads with more than 15 digits are dropped.
+		{"NaN1000000000000000", "7c00000000000000", 0},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		b := x.EncodeDPD64()
+		if got := hex.EncodeToString(b[:]); got != test.enc || x.Context.Conditions != 0 {
+			t.Fatalf("#%d: %s.EncodeDPD64(): wanted %s (), got %s (%s)",
+				i, test.x, test.enc, got, x.Context.Conditions)
+		}
+		// The conditions are those of rounding a copy of x.
+		ctx := decimal.Context64
+		ctx.Clamp = true
+		if y := ctx.Round(new(decimal.Big).Copy(x)); y.Context.Conditions != test.c {
+			t.Fatalf("#%d: Round(%s): wanted %s, got %s", i, test.x, test.c, y.Context.Conditions)
+		}
+	}
+}
+
+// TestBig_DPD_Random checks that finite values that fit in each format round
+// trip exactly.
+func TestBig_DPD_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 5000; i++ {
+		var mant big.Int
+		mant.Rand(rng, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(1+rng.Intn(34))), nil))
+		if rng.Intn(2) == 0 {
+			mant.Neg(&mant)
+		}
+		x := new(decimal.Big).SetBigMantScale(&mant, rng.Intn(6111+6176+1)-6111)
+		z := new(decimal.Big).DecodeDPD128(x.EncodeDPD128())
+		if z.String() != x.String() || z.Signbit() != x.Signbit() || x.Context.Conditions != 0 {
+			t.Fatalf("#%d: %s: round tripped to %s (%s)", i, x, z, x.Context.Conditions)
+		}
+
+		mant.Rand(rng, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(1+rng.Intn(16))), nil))
+		x.SetBigMantScale(&mant, rng.Intn(369+398+1)-369)
+		z.DecodeDPD64(x.EncodeDPD64())
+		if z.String() != x.String() || x.Context.Conditions != 0 {
+			t.Fatalf("#%d: %s: round tripped to %s (%s)", i, x, z, x.Context.Conditions)
+		}
+	}
+}
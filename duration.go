@@ -0,0 +1,55 @@
+package decimal
+
+import "time"
+
+// durationPrecision is enough digits for the exact quotient of any two
+// time.Durations that has a terminating decimal expansion: at most 19 digits
+// for the dividend and 44 for a power of two divisor.
+const durationPrecision = 64
+
+// FromDuration returns d in units of unit. For example,
+// FromDuration(90*time.Minute, time.Hour) returns 1.5.
+//
+// The result is exact whenever d/unit has a terminating decimal expansion,
+// which is always the case if unit is time.Nanosecond, time.Microsecond,
+// time.Millisecond, or time.Second. Otherwise (e.g., a minute in hours), it
+// is rounded to DefaultPrecision digits and Inexact and Rounded are raised in
+// its Context. If unit is zero, the result is as for Quo.
+func FromDuration(d, unit time.Duration) *Big {
+	var x, y Big
+	x.SetMantScale(int64(d), 0)
+	y.SetMantScale(int64(unit), 0)
+
+	z := new(Big)
+	Context{Precision: durationPrecision}.Quo(z, &x, &y)
+	if z.Context.Conditions&Inexact != 0 {
+		z.Context = Context{}
+		return z.Quo(&x, &y)
+	}
+	Context{}.adopt(z)
+	return z
+}
+
+// Duration returns x units of unit as a time.Duration, rounded to a whole
+// number of nanoseconds using x's RoundingMode. For example, if x is 1.5,
+// x.Duration(time.Hour) returns 90 minutes.
+//
+// The returned boolean is false if the result was rounded or does not fit in
+// a time.Duration, in which case it is clamped to the minimum or maximum
+// time.Duration. If x is NaN, Duration returns 0 and false. x is not modified.
+func (x *Big) Duration(unit time.Duration) (time.Duration, bool) {
+	mustNotNil("Duration", x, x)
+	if x.IsNaN(0) {
+		return 0, false
+	}
+
+	var u, p Big
+	u.SetMantScale(int64(unit), 0)
+	Context{
+		Precision:    UnlimitedPrecision,
+		RoundingMode: x.Context.roundingMode(),
+	}.Mul(&p, x, &u)
+	p.Context.RoundingMode = x.Context.roundingMode()
+	v, fits, cond := p.scaledInt64(0)
+	return time.Duration(v), fits && cond == 0
+}
@@ -0,0 +1,87 @@
+package decimal_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestFromDuration(t *testing.T) {
+	for i, test := range [...]struct {
+		d, unit time.Duration
+		want    string
+		inexact bool
+	}{
+		{90 * time.Minute, time.Hour, "1.5", false},
+		{2 * time.Hour, time.Hour, "2", false},
+		{-45 * time.Minute, time.Hour, "-0.75", false},
+		{time.Nanosecond, time.Hour, "2.777777777777778E-13", true},
+		{time.Minute, time.Hour, "0.01666666666666667", true},
+		{1500 * time.Millisecond, time.Second, "1.5", false},
+		{math.MaxInt64, time.Second, "9223372036.854775807", false},
+		{math.MinInt64, time.Nanosecond, "-9223372036854775808", false},
+		{1, 1 << 62, "2.1684043449710088680149056017398834228515625E-19", false},
+		{0, time.Hour, "0", false},
+	} {
+		z := decimal.FromDuration(test.d, test.unit)
+		if got := z.String(); got != test.want {
+			t.Fatalf("#%d: FromDuration(%d, %d): wanted %s, got %s",
+				i, test.d, test.unit, test.want, got)
+		}
+		if inexact := z.Context.Conditions&decimal.Inexact != 0; inexact != test.inexact {
+			t.Fatalf("#%d: wanted inexact == %t, got %s", i, test.inexact, z.Context.Conditions)
+		}
+	}
+}
+
+func TestBig_Duration(t *testing.T) {
+	for i, test := range [...]struct {
+		x    string
+		unit time.Duration
+		want time.Duration
+		ok   bool
+	}{
+		{"1.5", time.Hour, 90 * time.Minute, true},
+		{"-0.75", time.Hour, -45 * time.Minute, true},
+		{"2.5E-9", time.Second, 2, false},
+		{"3.5E-9", time.Second, 4, false},
+		{"9223372036.854775807", time.Second, math.MaxInt64, true},
+		{"9223372036.854775808", time.Second, math.MaxInt64, false},
+		{"-9223372036.854775808", time.Second, math.MinInt64, true},
+		{"1E+1000", time.Second, math.MaxInt64, false},
+		{"-Infinity", time.Second, math.MinInt64, false},
+		{"NaN", time.Second, 0, false},
+		{"0", time.Hour, 0, true},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		orig := x.String()
+		got, ok := x.Duration(test.unit)
+		if got != test.want || ok != test.ok {
+			t.Fatalf("#%d: %s.Duration(%d): wanted (%d, %t), got (%d, %t)",
+				i, test.x, test.unit, test.want, test.ok, got, ok)
+		}
+		if x.String() != orig || x.Context.Conditions != 0 {
+			t.Fatalf("#%d: Duration modified x: %s (%s)", i, x, x.Context.Conditions)
+		}
+	}
+}
+
+func TestFromDuration_Charge(t *testing.T) {
+	// 10000 usage records of 45 seconds each, billed at 1.20 per hour.
+	rate := decimal.New(120, 2)
+	total := new(decimal.Big)
+	for i := 0; i < 10000; i++ {
+		h := decimal.FromDuration(45*time.Second, time.Hour)
+		total.Add(total, h)
+	}
+	var charge decimal.Big
+	charge.Mul(rate, total)
+	if got := charge.String(); got != "150.000000" || charge.Context.Conditions != 0 {
+		t.Fatalf("wanted 150.000000, got %s (%s)", got, charge.Context.Conditions)
+	}
+	if d, ok := total.Duration(time.Hour); d != 450000*time.Second || !ok {
+		t.Fatalf("wanted (%s, true), got (%s, %t)", 450000*time.Second, d, ok)
+	}
+}
@@ -0,0 +1,62 @@
+package decimal
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+)
+
+func ExampleCSV() {
+	// A CSV library that maps records to structs calls UnmarshalText and
+	// MarshalText for each CSV field, as this example does by hand.
+	type item struct {
+		SKU      string
+		Price    CSV
+		Discount CSVLenient
+	}
+	in := "sku,price,discount\n" +
+		"A-1,19.99,\n" +
+		"B-2,1250.00,\" 1,000.50 \"\n" +
+		"C-3,NaN,0\n"
+
+	records, _ := csv.NewReader(bytes.NewBufferString(in)).ReadAll()
+	var items []item
+	for _, r := range records[1:] {
+		it := item{SKU: r[0]}
+		if err := it.Price.UnmarshalText([]byte(r[1])); err != nil {
+			fmt.Println(err)
+		}
+		if err := it.Discount.UnmarshalText([]byte(r[2])); err != nil {
+			fmt.Println(err)
+		}
+		items = append(items, it)
+	}
+
+	for _, it := range items {
+		if it.Discount.V == nil {
+			fmt.Printf("%s: no discount\n", it.SKU)
+		} else {
+			fmt.Printf("%s: discount %s\n", it.SKU, it.Discount.V)
+		}
+	}
+
+	var out bytes.Buffer
+	w := csv.NewWriter(&out)
+	w.Write([]string{"sku", "price", "discount"})
+	for _, it := range items {
+		it.Price.V.Context.Specials = SpecialsNull
+		price, _ := it.Price.MarshalText()
+		discount, _ := it.Discount.MarshalText()
+		w.Write([]string{it.SKU, string(price), string(discount)})
+	}
+	w.Flush()
+	fmt.Print(out.String())
+	// Output:
+	// A-1: no discount
+	// B-2: discount 1000.50
+	// C-3: discount 0
+	// sku,price,discount
+	// A-1,19.99,
+	// B-2,1250.00,1000.50
+	// C-3,,0
+}
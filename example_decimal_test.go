@@ -1,6 +1,7 @@
 package decimal
 
 import (
+	"errors"
 	"fmt"
 )
 
@@ -76,3 +77,32 @@ func ExampleBig_Quantize() {
 	// C: -0.1
 	// D: -0E+5
 }
+
+func ExampleContext_Quo() {
+	x, y := New(1, 0), New(3, 0)
+	for _, ctx := range []Context{Context32, Context64, Context128} {
+		var z Big
+		ctx.Quo(&z, x, y)
+		fmt.Printf("%d digits: %s (%s)\n", ctx.Precision, &z, z.Context.Conditions)
+	}
+	// Output:
+	// 7 digits: 0.3333333 (inexact, rounded)
+	// 16 digits: 0.3333333333333333 (inexact, rounded)
+	// 34 digits: 0.3333333333333333333333333333333333 (inexact, rounded)
+}
+
+func ExampleContext_Err() {
+	z := WithContext(Context64)
+	z.Quo(New(1, 0), New(0, 0))
+
+	err := fmt.Errorf("computing ratio: %w", z.Context.Err())
+	fmt.Println(errors.Is(err, DivisionByZero), errors.Is(err, Overflow))
+
+	var c Condition
+	if errors.As(err, &c) {
+		fmt.Println(c)
+	}
+	// Output:
+	// true false
+	// division by zero
+}
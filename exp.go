@@ -0,0 +1,60 @@
+package decimal
+
+// CheckedAddScale returns a + b and true, or 0 and false if the sum overflows
+// an int. It is useful for computing the scale of a product, which is the sum
+// of the scales of its operands.
+func CheckedAddScale(a, b int) (int, bool) {
+	sum := a + b
+	if (b > 0 && sum < a) || (b < 0 && sum > a) {
+		return 0, false
+	}
+	return sum, true
+}
+
+// AdjustedExp returns the adjusted exponent of a decimal with coeffDigits
+// digits in its coefficient and the given scale, which is the exponent of its
+// most significant digit: coeffDigits - 1 - scale. For example, the adjusted
+// exponent of 123.45 (5 digits, scale 2) is 2. It returns 0 and false if
+// coeffDigits < 1 or the result overflows an int.
+func AdjustedExp(coeffDigits, scale int) (int, bool) {
+	if coeffDigits < 1 {
+		return 0, false
+	}
+	a := coeffDigits - 1
+	adj := a - scale
+	if (scale > 0 && adj > a) || (scale < 0 && adj < a) {
+		return 0, false
+	}
+	return adj, true
+}
+
+// InExpRange reports whether a non-zero finite decimal with the adjusted
+// exponent adjusted is a normal number in c; that is, whether adjusted is in
+// [c.MinScale, c.MaxScale], where zero limits mean the package's MinScale and
+// MaxScale. If not, cond reports the Conditions that storing such a decimal
+// would raise, based on its adjusted exponent alone:
+//
+//   - Overflow if adjusted > c.MaxScale. The result is rounded to infinity or
+//     the largest finite number, which also raises Inexact and Rounded.
+//   - Subnormal if adjusted < c.MinScale. Additionally, Underflow, Inexact,
+//     and Rounded if adjusted is less than the smallest possible exponent of a
+//     subnormal, Etiny, which is c.MinScale - (Precision - 1), in which case
+//     every digit must be discarded, as the GDA specification requires. (At
+//     compatibility level 1, Etiny is the package's MinScale - (Precision - 1),
+//     and storing such a decimal raises only Subnormal and Underflow.)
+//
+// A subnormal decimal whose exponent is too small for its number of digits
+// may also raise Underflow, which can only be determined with its precision.
+// Zero never raises these Conditions.
+func (c Context) InExpRange(adjusted int) (ok bool, cond Condition) {
+	switch {
+	case adjusted > c.maxScale():
+		return false, Overflow | Inexact | Rounded
+	case adjusted < c.etiny():
+		return false, Subnormal | Underflow | Inexact | Rounded
+	case adjusted < c.minScale():
+		return false, Subnormal
+	default:
+		return true, 0
+	}
+}
@@ -0,0 +1,207 @@
+package decimal_test
+
+import (
+	"math/bits"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+const (
+	maxInt = 1<<(bits.UintSize-1) - 1
+	minInt = -maxInt - 1
+)
+
+func TestCheckedAddScale(t *testing.T) {
+	for i, test := range [...]struct {
+		a, b int
+		want int
+		ok   bool
+	}{
+		{1, 2, 3, true},
+		{-5, 3, -2, true},
+		{maxInt, 0, maxInt, true},
+		{maxInt, 1, 0, false},
+		{maxInt, -1, maxInt - 1, true},
+		{minInt, -1, 0, false},
+		{minInt, 1, minInt + 1, true},
+		{minInt, maxInt, -1, true},
+		{maxInt, maxInt, 0, false},
+		{minInt, minInt, 0, false},
+	} {
+		got, ok := decimal.CheckedAddScale(test.a, test.b)
+		if got != test.want || ok != test.ok {
+			t.Fatalf("#%d: CheckedAddScale(%d, %d): wanted (%d, %t), got (%d, %t)",
+				i, test.a, test.b, test.want, test.ok, got, ok)
+		}
+	}
+}
+
+func TestAdjustedExp(t *testing.T) {
+	for i, test := range [...]struct {
+		digits, scale int
+		want          int
+		ok            bool
+	}{
+		{5, 2, 2, true},
+		{1, 0, 0, true},
+		{1, 3, -3, true},
+		{3, -2, 4, true},
+		{0, 0, 0, false},
+		{-1, 0, 0, false},
+		{1, minInt + 1, maxInt, true},
+		{2, minInt + 1, 0, false},
+		{1, minInt, 0, false},
+		{maxInt, 0, maxInt - 1, true},
+		{maxInt, -1, maxInt, true},
+		{maxInt, -2, 0, false},
+		{1, maxInt, -maxInt, true},
+		{2, maxInt, 1 - maxInt, true},
+	} {
+		got, ok := decimal.AdjustedExp(test.digits, test.scale)
+		if got != test.want || ok != test.ok {
+			t.Fatalf("#%d: AdjustedExp(%d, %d): wanted (%d, %t), got (%d, %t)",
+				i, test.digits, test.scale, test.want, test.ok, got, ok)
+		}
+	}
+}
+
+func TestContext_InExpRange(t *testing.T) {
+	for _, ctx := range []decimal.Context{
+		{Precision: 5, MaxScale: 10, MinScale: -10},
+		{Precision: 5},
+		decimal.Context128,
+	} {
+		max, min := ctx.MaxScale, ctx.MinScale
+		if max == 0 {
+			max = decimal.MaxScale
+		}
+		if min == 0 {
+			min = decimal.MinScale
+		}
+		etiny := min - (ctx.Precision - 1)
+		for _, adj := range []int{
+			0, max - 1, max, max + 1, min + 1, min, min - 1,
+			etiny + 1, etiny, etiny - 1,
+		} {
+			ok, cond := ctx.InExpRange(adj)
+			if ok != (cond == 0) {
+				t.Fatalf("%+v: InExpRange(%d): ok = %t, but cond = %s", ctx, adj, ok, cond)
+			}
+
+			// The Conditions must agree with those raised by storing 1E+adj.
+			var z decimal.Big
+			ctx.Traps = 0
+			ctx.Set(&z, decimal.New(1, -adj))
+			got := z.Context.Conditions & (decimal.Overflow | decimal.Subnormal |
+				decimal.Underflow | decimal.Inexact | decimal.Rounded)
+			if cond != got {
+				t.Fatalf("%+v: InExpRange(%d): wanted %s, got %s", ctx, adj, got, cond)
+			}
+		}
+	}
+
+	// Underflow discards every digit regardless of the compatibility level.
+	ctx := decimal.Context{Precision: 5, CompatLevel: 1}
+	want := decimal.Subnormal | decimal.Underflow | decimal.Inexact | decimal.Rounded
+	if ok, cond := ctx.InExpRange(decimal.MinScale - 5); ok || cond != want {
+		t.Fatalf("level 1: wanted (false, %s), got (%t, %s)", want, ok, cond)
+	}
+}
+
+func TestContext_ExpLimits(t *testing.T) {
+	const (
+		r = decimal.Inexact | decimal.Rounded
+		o = decimal.Overflow | r
+		u = decimal.Subnormal | decimal.Underflow | r
+	)
+	for i, test := range [...]struct {
+		mode  decimal.RoundingMode
+		level int
+		in    string
+		want  string
+		c     decimal.Condition
+	}{
+		{decimal.ToNearestEven, 0, "1E+97", "Infinity", o},
+		{decimal.ToNearestEven, 0, "-1E+97", "-Infinity", o},
+		{decimal.AwayFromZero, 0, "1E+97", "Infinity", o},
+		{decimal.AwayFromZero, 0, "-1E+97", "-Infinity", o},
+		{decimal.ToZero, 0, "1.5E+97", "9.999999E+96", o},
+		{decimal.ToZero, 0, "-1.5E+97", "-9.999999E+96", o},
+		{decimal.ToPositiveInf, 0, "1E+97", "Infinity", o},
+		{decimal.ToPositiveInf, 0, "-1E+97", "-9.999999E+96", o},
+		{decimal.ToNegativeInf, 0, "1E+97", "9.999999E+96", o},
+		{decimal.ToNegativeInf, 0, "-1E+97", "-Infinity", o},
+		{decimal.ToNegativeInf, 1, "-1E+97", "Infinity", o},
+
+		// Etiny is -95 - (7 - 1) = -101.
+		{decimal.ToNearestEven, 0, "1E-95", "1E-95", 0},
+		{decimal.ToNearestEven, 0, "1E-98", "1E-98", decimal.Subnormal},
+		{decimal.ToNearestEven, 0, "1.230000E-98", "1.230E-98", decimal.Subnormal | decimal.Rounded},
+		{decimal.ToNearestEven, 0, "1.234567E-98", "1.235E-98", u},
+		{decimal.ToZero, 0, "-1.234567E-98", "-1.234E-98", u},
+		{decimal.ToNearestEven, 0, "9.999999E-97", "1.00000E-96", u},
+		{decimal.ToNearestEven, 0, "6E-102", "1E-101", u},
+		{decimal.ToNearestEven, 0, "5E-102", "0E-101", u | decimal.Clamped},
+		{decimal.AwayFromZero, 0, "1E-999", "1E-101", u},
+		{decimal.ToNearestEven, 0, "0E-999", "0E-101", decimal.Clamped},
+	} {
+		ctx := decimal.Context32
+		ctx.Traps = 0
+		ctx.RoundingMode = test.mode
+		ctx.CompatLevel = test.level
+		x, _ := new(decimal.Big).SetString(test.in)
+		var z decimal.Big
+		ctx.Set(&z, x)
+		if z.String() != test.want || z.Context.Conditions != test.c {
+			t.Fatalf("#%d: %s (%s, level %d): wanted %s (%s), got %s (%s)",
+				i, test.in, test.mode, test.level, test.want, test.c, &z, z.Context.Conditions)
+		}
+	}
+}
+
+func TestContext_Clamp(t *testing.T) {
+	ctx := decimal.Context32 // the largest exponent is 96 - (7 - 1) = 90
+	ctx.Traps = 0
+	ctx.Clamp = true
+	for i, test := range [...]struct {
+		in    string
+		want  string
+		scale int
+		c     decimal.Condition
+	}{
+		{"1E+90", "1E+90", -90, 0},
+		{"1E+96", "1.000000E+96", -90, decimal.Clamped},
+		{"-1.5E+92", "-1.50E+92", -90, decimal.Clamped},
+		{"9.999999E+96", "9.999999E+96", -90, 0},
+		{"0E+96", "0E+90", -90, decimal.Clamped},
+		{"0E+200", "0E+90", -90, decimal.Clamped},
+		{"1E+97", "Infinity", 0, decimal.Overflow | decimal.Inexact | decimal.Rounded},
+		{"1.5E-98", "1.5E-98", 99, decimal.Subnormal},
+	} {
+		x, _ := new(decimal.Big).SetString(test.in)
+		var z decimal.Big
+		ctx.Set(&z, x)
+		if z.String() != test.want || z.Context.Conditions != test.c ||
+			(z.IsFinite() && z.Scale() != test.scale) {
+			t.Fatalf("#%d: %s: wanted %s (scale %d, %s), got %s (scale %d, %s)",
+				i, test.in, test.want, test.scale, test.c, &z, z.Scale(), z.Context.Conditions)
+		}
+	}
+
+	var z decimal.Big
+	ctx.Mul(&z, decimal.New(5, -50), decimal.New(2, -45))
+	if z.String() != "1.000000E+96" || z.Context.Conditions != decimal.Clamped {
+		t.Fatalf("5E+50 * 2E+45: wanted 1.000000E+96 (clamped), got %s (%s)", &z, z.Context.Conditions)
+	}
+
+	z.Context.Conditions = 0
+	ctx.Quantize(z.SetMantScale(3, -92), -90)
+	if z.String() != "3.00E+92" || z.Context.Conditions != 0 {
+		t.Fatalf("Quantize(3E+92, -90): wanted 3.00E+92, got %s (%s)", &z, z.Context.Conditions)
+	}
+	ctx.Quantize(z.SetMantScale(3, -92), -91)
+	if !z.IsNaN(0) || z.Context.Conditions != decimal.InvalidOperation {
+		t.Fatalf("Quantize(3E+92, -91): wanted NaN (invalid operation), got %s (%s)", &z, z.Context.Conditions)
+	}
+}
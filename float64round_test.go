@@ -0,0 +1,340 @@
+package decimal_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+var float64RoundModes = [...]decimal.RoundingMode{
+	decimal.ToNearestEven, decimal.ToNearestAway, decimal.ToZero,
+	decimal.AwayFromZero, decimal.ToNegativeInf, decimal.ToPositiveInf,
+}
+
+// float64Boundaries returns the floats at which Float64Round is most likely
+// to fail: every power of two and its predecessor, which straddle a binary
+// exponent boundary, along with the subnormal and overflow thresholds and
+// the integers around 2^53.
+func float64Boundaries() []float64 {
+	fs := []float64{
+		0, math.SmallestNonzeroFloat64, 2 * math.SmallestNonzeroFloat64,
+		math.Nextafter(0x1p-1022, 0), 0x1p-1022, math.MaxFloat64,
+		math.Nextafter(math.MaxFloat64, 0),
+	}
+	for i := -3.0; i <= 3; i++ {
+		fs = append(fs, 1<<53+2*i, 1<<52+i)
+	}
+	for e := -1074; e <= 1023; e++ {
+		f := math.Ldexp(1, e)
+		fs = append(fs, f, math.Nextafter(f, 0))
+	}
+	return fs
+}
+
+// wantFloat64Round returns the result of rounding a value between the
+// adjacent, non-negative floats lo and hi using mode. pos is 0 if the value
+// is lo, 1 if it is between lo and their midpoint, 2 if it is the midpoint,
+// and 3 if it is between the midpoint and hi.
+func wantFloat64Round(lo, hi float64, pos int, mode decimal.RoundingMode) (float64, big.Accuracy) {
+	if pos == 0 {
+		return lo, big.Exact
+	}
+	up := false
+	switch mode {
+	case decimal.ToNearestEven:
+		up = pos == 3 || pos == 2 && math.Float64bits(lo)&1 != 0
+	case decimal.ToNearestAway:
+		up = pos >= 2
+	case decimal.AwayFromZero, decimal.ToPositiveInf:
+		up = true
+	}
+	if up {
+		return hi, big.Above
+	}
+	return lo, big.Below
+}
+
+func TestBig_Float64Round_Boundaries(t *testing.T) {
+	ctx := decimal.ContextUnlimited
+	for _, lo := range float64Boundaries() {
+		hi := math.Nextafter(lo, math.Inf(+1))
+		dlo := new(decimal.Big).SetFloat64Exact(lo)
+		var ulp decimal.Big
+		if math.IsInf(hi, +1) {
+			ulp.SetFloat64Exact(math.Ldexp(1, 971))
+		} else {
+			ctx.Sub(&ulp, new(decimal.Big).SetFloat64Exact(hi), dlo)
+		}
+		mid := ctx.Add(new(decimal.Big), dlo, ctx.Mul(new(decimal.Big), &ulp, decimal.New(5, 1)))
+		eps := decimal.New(1, mid.Scale()+5)
+
+		points := [...]*decimal.Big{
+			dlo,
+			ctx.Add(new(decimal.Big), dlo, eps),
+			mid,
+			ctx.Add(new(decimal.Big), mid, eps),
+		}
+		if lo != 0 {
+			// Both sides of the midpoint.
+			points[1] = ctx.Sub(new(decimal.Big), mid, eps)
+		}
+		for pos, x := range points {
+			neg := new(decimal.Big).CopySign(x, decimal.New(-1, 0))
+			for _, mode := range float64RoundModes {
+				wf, wa := wantFloat64Round(lo, hi, pos, mode)
+				checkFloat64Round(t, x, mode, wf, wa)
+
+				// -x rounds to the negation of x rounded in the
+				// opposite direction.
+				m := mode
+				switch mode {
+				case decimal.ToNegativeInf:
+					m = decimal.ToPositiveInf
+				case decimal.ToPositiveInf:
+					m = decimal.ToNegativeInf
+				}
+				wf, wa = wantFloat64Round(lo, hi, pos, m)
+				checkFloat64Round(t, neg, mode, -wf, -wa)
+			}
+		}
+	}
+}
+
+func checkFloat64Round(t *testing.T, x *decimal.Big, mode decimal.RoundingMode, want float64, acc big.Accuracy) {
+	t.Helper()
+	f, a := x.Float64Round(mode)
+	if math.Float64bits(f) != math.Float64bits(want) || a != acc {
+		t.Fatalf("%.20g.Float64Round(%s): wanted %g (%s), got %g (%s)", x, mode, want, acc, f, a)
+	}
+}
+
+func TestBig_Float64Round(t *testing.T) {
+	for i, test := range [...]struct {
+		x    string
+		mode decimal.RoundingMode
+		want float64
+		acc  big.Accuracy
+	}{
+		{"0.1", decimal.ToNearestEven, 0.1, big.Above},
+		{"0.1", decimal.ToNegativeInf, math.Nextafter(0.1, 0), big.Below},
+		{"0.1", decimal.ToPositiveInf, 0.1, big.Above},
+		{"-0.1", decimal.ToNegativeInf, -0.1, big.Below},
+		{"-0.1", decimal.ToZero, -math.Nextafter(0.1, 0), big.Above},
+		{"-0", decimal.ToNearestEven, math.Copysign(0, -1), big.Exact},
+		{"0E+100", decimal.ToPositiveInf, 0, big.Exact},
+		{"1E+400", decimal.ToNearestEven, math.Inf(+1), big.Above},
+		{"1E+400", decimal.ToZero, math.MaxFloat64, big.Below},
+		{"-1E+400", decimal.ToPositiveInf, -math.MaxFloat64, big.Above},
+		{"-1E+400", decimal.ToNegativeInf, math.Inf(-1), big.Below},
+		{"1E-400", decimal.ToNearestEven, 0, big.Below},
+		{"1E-400", decimal.ToPositiveInf, math.SmallestNonzeroFloat64, big.Above},
+		{"-1E-400", decimal.ToPositiveInf, math.Copysign(0, -1), big.Above},
+		{"-1E-400", decimal.AwayFromZero, -math.SmallestNonzeroFloat64, big.Below},
+		{"9007199254740993", decimal.ToNearestEven, 1 << 53, big.Below},
+		{"9007199254740993", decimal.ToNearestAway, 1<<53 + 2, big.Above},
+		{"Infinity", decimal.ToZero, math.Inf(+1), big.Exact},
+		{"-Infinity", decimal.ToZero, math.Inf(-1), big.Exact},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		f, acc := x.Float64Round(test.mode)
+		if math.Float64bits(f) != math.Float64bits(test.want) || acc != test.acc {
+			t.Fatalf("#%d: %s.Float64Round(%s): wanted %g (%s), got %g (%s)",
+				i, test.x, test.mode, test.want, test.acc, f, acc)
+		}
+	}
+	if f, acc := decimal.New(0, 0).SetNaN(false).Float64Round(decimal.ToZero); !math.IsNaN(f) || acc != big.Exact {
+		t.Fatalf("NaN.Float64Round(ToZero): wanted NaN (Exact), got %g (%s)", f, acc)
+	}
+}
+
+// TestBig_Float64Round_Random checks that ToNearestEven agrees with
+// strconv.ParseFloat and that the directed modes bracket x.
+func TestBig_Float64Round_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	n := 50000
+	if testing.Short() {
+		n = 5000
+	}
+	for i := 0; i < n; i++ {
+		var mant big.Int
+		mant.Rand(rng, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(1+rng.Intn(25))), nil))
+		x := new(decimal.Big).SetBigMantScale(&mant, rng.Intn(660)-310)
+		if rng.Intn(2) == 0 {
+			x.Neg(x)
+		}
+		s := fmt.Sprintf("%.40e", x)
+
+		want, err := strconv.ParseFloat(s, 64)
+		if err != nil && !math.IsInf(want, 0) {
+			t.Fatal(err)
+		}
+		if f, _ := x.Float64Round(decimal.ToNearestEven); math.Float64bits(f) != math.Float64bits(want) {
+			t.Fatalf("#%d: %s.Float64Round(ToNearestEven): wanted %g, got %g", i, s, want, f)
+		}
+
+		lo, la := x.Float64Round(decimal.ToNegativeInf)
+		hi, ha := x.Float64Round(decimal.ToPositiveInf)
+		if la == big.Exact || ha == big.Exact {
+			if la != ha || lo != hi || new(decimal.Big).SetFloat64Exact(lo).Cmp(x) != 0 {
+				t.Fatalf("#%d: %s: inexact bounds (%g, %g) marked exact", i, s, lo, hi)
+			}
+			continue
+		}
+		if la != big.Below || ha != big.Above || math.Nextafter(lo, math.Inf(+1)) != hi {
+			t.Fatalf("#%d: %s: (%g (%s), %g (%s)) are not adjacent bounds", i, s, lo, la, hi, ha)
+		}
+		if !math.IsInf(lo, 0) && new(decimal.Big).SetFloat64Exact(lo).Cmp(x) >= 0 ||
+			!math.IsInf(hi, 0) && new(decimal.Big).SetFloat64Exact(hi).Cmp(x) <= 0 {
+			t.Fatalf("#%d: %s is not within (%g, %g)", i, s, lo, hi)
+		}
+	}
+}
+
+func TestBig_Float64(t *testing.T) {
+	for i, test := range [...]struct {
+		x     string
+		want  float64
+		exact bool
+	}{
+		{"0.5", 0.5, true},
+		{"3", 3, true},
+		{"1.25", 1.25, true},
+		{"-1.25E+2", -125, true},
+		{"0.1", 0.1, false},
+		{"-0", math.Copysign(0, -1), true},
+		{"0E-100", 0, true},
+		{"9007199254740992", 1 << 53, true},
+		{"9007199254740993", 1 << 53, false},
+		{"1E+22", 1e22, true},
+		{"1E+23", 1e23, false},
+		{"4.940656458412465441765687928682213723651E-324", math.SmallestNonzeroFloat64, false},
+		{"1.79769313486231570814527423731704356798070567525844996598917476803157260780028538760589558632766878171540458953514382464234321326889464182768467546703537516986049910576551282076245490090389328944075868508455133942304583236903222948165808559332123348274797826204144723168738177180919299881250404026184124858368E+308", math.MaxFloat64, true},
+		{"1E+400", math.Inf(+1), false},
+		{"-1E+400", math.Inf(-1), false},
+		{"1E-400", 0, false},
+		{"-1E-400", math.Copysign(0, -1), false},
+		{"Infinity", math.Inf(+1), true},
+		{"-Infinity", math.Inf(-1), true},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		f, exact := x.Float64()
+		if math.Float64bits(f) != math.Float64bits(test.want) || exact != test.exact {
+			t.Fatalf("#%d: %s.Float64(): wanted %g (%t), got %g (%t)",
+				i, test.x, test.want, test.exact, f, exact)
+		}
+	}
+
+	// Float64 does not panic for NaN values, even in the Go OperatingMode.
+	for _, signal := range [...]bool{false, true} {
+		x := decimal.WithContext(decimal.Context{OperatingMode: decimal.Go}).SetNaN(signal)
+		if f, _ := x.Float64(); !math.IsNaN(f) {
+			t.Fatalf("%s.Float64(): wanted NaN, got %g", x, f)
+		}
+	}
+}
+
+// TestBig_Float64_Random checks that Float64 agrees with Float64Round and that
+// it reports a conversion as exact only if it is.
+func TestBig_Float64_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	n := 50000
+	if testing.Short() {
+		n = 5000
+	}
+	for i := 0; i < n; i++ {
+		var mant big.Int
+		mant.Rand(rng, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(1+rng.Intn(20))), nil))
+		x := new(decimal.Big).SetBigMantScale(&mant, rng.Intn(50)-25)
+		if rng.Intn(2) == 0 {
+			x.Neg(x)
+		}
+
+		want, acc := x.Float64Round(decimal.ToNearestEven)
+		f, exact := x.Float64()
+		if math.Float64bits(f) != math.Float64bits(want) || exact != (acc == big.Exact) {
+			t.Fatalf("#%d: %s.Float64(): wanted %g (%t), got %g (%t)", i, x, want, acc == big.Exact, f, exact)
+		}
+		if exact != (new(decimal.Big).SetFloat64Exact(f).Cmp(x) == 0) {
+			t.Fatalf("#%d: %s.Float64(): %g marked exact = %t", i, x, f, exact)
+		}
+	}
+}
+
+func TestBig_Float32(t *testing.T) {
+	for i, test := range [...]struct {
+		x     string
+		want  float32
+		exact bool
+	}{
+		{"0.5", 0.5, true},
+		{"-3", -3, true},
+		{"0.1", 0.1, false},
+		{"-0", float32(math.Copysign(0, -1)), true},
+		// Rounding to a float64 first rounds these to the midpoint between
+		// 1 and the next float32, which then rounds to 1.
+		{"1.000000059604644776257986737988403547205962240695953369140625", math.Nextafter32(1, 2), false},
+		{"1.000000059604644774523263262011596452794037759304046630859375", 1, false},
+		{"1.000000059604644775390625", 1, false},
+		// Subnormals.
+		{"1.40129846432481707092372958328991613128026194187651577175706828388979108268586060148663818836212158203125E-45", math.SmallestNonzeroFloat32, true},
+		{"2.15239444120291902093884863993331117764648234272232822541885688405471910300548188388347625732421875E-42", 3 * math.SmallestNonzeroFloat32 * (1 << 9), true},
+		{"1.4E-45", math.SmallestNonzeroFloat32, false},
+		{"7.00649232162408535461864791644958065640130970938257885878534141944895541342930300743319094181060791015625E-46", 0, false},
+		{"7.00649232162408535461864791644958065640130970938257885878534141944895541342930300743319094181060791015626E-46", math.SmallestNonzeroFloat32, false},
+		{"-1E-50", float32(math.Copysign(0, -1)), false},
+		// The overflow boundary.
+		{"340282346638528859811704183484516925440", math.MaxFloat32, true},
+		{"340282356779733661637539395458142568447", math.MaxFloat32, false},
+		{"340282356779733661637539395458142568448", float32(math.Inf(+1)), false},
+		{"-1E+39", float32(math.Inf(-1)), false},
+		{"Infinity", float32(math.Inf(+1)), true},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		f, exact := x.Float32()
+		if math.Float32bits(f) != math.Float32bits(test.want) || exact != test.exact {
+			t.Fatalf("#%d: %s.Float32(): wanted %g (%t), got %g (%t)",
+				i, test.x, test.want, test.exact, f, exact)
+		}
+	}
+
+	x := decimal.WithContext(decimal.Context{OperatingMode: decimal.Go}).SetNaN(true)
+	if f, _ := x.Float32(); f == f {
+		t.Fatalf("%s.Float32(): wanted NaN, got %g", x, f)
+	}
+}
+
+// TestBig_Float32_Random checks that Float32 agrees with strconv.ParseFloat and
+// that it reports a conversion as exact only if it is.
+func TestBig_Float32_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	n := 50000
+	if testing.Short() {
+		n = 5000
+	}
+	for i := 0; i < n; i++ {
+		var mant big.Int
+		mant.Rand(rng, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(1+rng.Intn(25))), nil))
+		x := new(decimal.Big).SetBigMantScale(&mant, rng.Intn(100)-40)
+		if rng.Intn(2) == 0 {
+			x.Neg(x)
+		}
+		s := fmt.Sprintf("%.40e", x)
+
+		want, err := strconv.ParseFloat(s, 32)
+		if err != nil && !math.IsInf(want, 0) {
+			t.Fatal(err)
+		}
+		f, exact := x.Float32()
+		if math.Float32bits(f) != math.Float32bits(float32(want)) {
+			t.Fatalf("#%d: %s.Float32(): wanted %g, got %g", i, s, want, f)
+		}
+		if exact != (new(decimal.Big).SetFloat32Exact(f).Cmp(x) == 0) {
+			t.Fatalf("#%d: %s.Float32(): %g marked exact = %t", i, s, f, exact)
+		}
+	}
+}
@@ -1,9 +1,6 @@
 package decimal
 
 import (
-	"bytes"
-	"fmt"
-	"io"
 	"math/big"
 	"strconv"
 )
@@ -18,12 +15,13 @@ func allZeros(b []byte) bool {
 	return true
 }
 
-var zero = []byte{'0'}
-
 // roundString rounds the plain numeric string (e.g., "1234") b.
 func roundString(b []byte, mode RoundingMode, pos bool, prec int) []byte {
 	if prec >= len(b) {
-		return append(b, bytes.Repeat(zero, prec-len(b))...)
+		for len(b) < prec {
+			b = append(b, '0')
+		}
+		return b
 	}
 
 	// Trim zeros until prec. This is useful when we can round exactly by simply
@@ -87,12 +85,6 @@ func roundString(b []byte, mode RoundingMode, pos bool, prec int) []byte {
 	return b[:prec]
 }
 
-// formatCompact formats the compact decimal, x, as an unsigned integer.
-func formatCompact(x uint64) []byte {
-	var b [20]byte
-	return strconv.AppendUint(b[0:0], uint64(x), 10)
-}
-
 // formatUnscaled formats the unscaled (non-compact) decimal, unscaled, as an
 // unsigned integer.
 func formatUnscaled(unscaled *big.Int) []byte {
@@ -119,36 +111,38 @@ const (
 
 //go:generate stringer -type=format
 
+// formatter appends the formatted number to b. Writing to a []byte instead of
+// an io.Writer keeps the formatter and its scratch space off the heap.
 type formatter struct {
-	w interface {
-		io.Writer
-		io.ByteWriter
-		WriteString(string) (int, error)
-	}
-	sign  byte  // leading '+' or ' ' flag
-	prec  int   // total precision
-	width int   // min width
-	n     int64 // cumulative number of bytes written to w
+	b     []byte // output
+	sign  byte   // leading '+' or ' ' flag
+	prec  int    // total precision
+	width int    // min width
 }
 
 func (f *formatter) WriteByte(c byte) error {
-	f.n++
-	return f.w.WriteByte(c)
+	f.b = append(f.b, c)
+	return nil
 }
 
 func (f *formatter) WriteString(s string) (int, error) {
-	m, err := f.w.WriteString(s)
-	f.n += int64(m)
-	return m, err
+	f.b = append(f.b, s...)
+	return len(s), nil
 }
 
 func (f *formatter) Write(p []byte) (n int, err error) {
-	n, err = f.w.Write(p)
-	f.n += int64(n)
-	return n, err
+	f.b = append(f.b, p...)
+	return len(p), nil
+}
+
+// pad writes n copies of c.
+func (f *formatter) pad(c byte, n int) {
+	for ; n > 0; n-- {
+		f.b = append(f.b, c)
+	}
 }
 
-var sciE = [2]byte{GDA: 'E', Go: 'e'}
+var sciE = [3]byte{GDA: 'E', Go: 'e', JS: 'e'}
 
 func (f *formatter) format(x *Big, format format, e byte) {
 	if x == nil {
@@ -172,6 +166,9 @@ func (f *formatter) format(x *Big, format format, e byte) {
 			} else {
 				f.WriteString("-Inf")
 			}
+		case JS:
+			s, _ := x.jsSpecial()
+			f.WriteString(s)
 		}
 		return
 	}
@@ -182,7 +179,7 @@ func (f *formatter) format(x *Big, format format, e byte) {
 			f.WriteByte('0')
 		} else {
 			f.WriteString("0.")
-			io.CopyN(f, zeroReader{}, int64(f.width))
+			f.pad('0', f.width)
 		}
 		return
 	}
@@ -197,16 +194,22 @@ func (f *formatter) format(x *Big, format format, e byte) {
 	var (
 		b   []byte
 		exp int
+		buf [20]byte
 	)
 	if f.prec > 0 {
 		if x.isCompact() {
-			b = formatCompact(x.compact)
+			b = strconv.AppendUint(buf[:0], x.compact, 10)
 		} else {
 			b = formatUnscaled(&x.unscaled)
 		}
 		orig := len(b)
-		b = roundString(b, x.Context.RoundingMode, !neg, f.prec)
-		exp = int(x.exp) + orig - len(b)
+		b = roundString(b, x.Context.roundingMode(), !neg, f.prec)
+		exp = int(x.exp) + orig - f.prec
+		if len(b) > f.prec {
+			// Rounding carried into a new digit; e.g., 9.99 -> 10.0.
+			b = b[:f.prec]
+			exp++
+		}
 	} else if f.prec < 0 {
 		f.prec = -f.prec
 		exp = -f.prec
@@ -214,6 +217,21 @@ func (f *formatter) format(x *Big, format format, e byte) {
 		b = []byte{'0'}
 	}
 
+	if o == JS && format == normal {
+		// Like decimal.js's toString, which removes trailing zeros and keeps
+		// the sign of a zero.
+		for len(b) > 1 && b[len(b)-1] == '0' {
+			b = b[:len(b)-1]
+			exp++
+		}
+		if b[0] == '0' {
+			f.WriteByte('0')
+		} else {
+			f.b = appendJSString(f.b, b, exp)
+		}
+		return
+	}
+
 	// "Next, the adjusted exponent is calculated; this is the exponent, plus
 	// the number of characters in the converted coefficient, less one. That
 	// is, exponent+(clength-1), where clength is the length of the coefficient
@@ -233,13 +251,97 @@ func (f *formatter) format(x *Big, format format, e byte) {
 		// No decimal places, write b and fill with zeros.
 		if format == plain && exp > 0 {
 			f.Write(b)
-			io.CopyN(f, zeroReader{}, int64(exp))
+			f.pad('0', exp)
 			return
 		}
 	}
 	f.formatSci(b, adj, e)
 }
 
+// noE is a placeholder for formats that do not use scientific notation and
+// don't require 'e' or 'E'.
+const noE = 0
+
+// formatFloat formats x like strconv.FormatFloat, according to c, which is one
+// of 'e', 'E', 'f', 'F', 'g', or 'G'. f.prec must be the requested precision if
+// hasPrec is true and x's precision otherwise.
+func (f *formatter) formatFloat(x *Big, c byte, hasPrec bool) {
+	switch c {
+	case 'e', 'E':
+		f.format(x, sci, c)
+	case 'f', 'F':
+		if hasPrec {
+			// %f's precision means "number of digits after the radix"
+			if x.exp > 0 {
+				f.prec += x.Precision()
+			} else {
+				if adj := x.exp + x.Precision(); adj > -f.prec {
+					f.prec += adj
+				} else {
+					f.prec = -f.prec
+				}
+			}
+		}
+		f.format(x, plain, noE)
+	case 'g', 'G':
+		// %g's precision means "number of significant digits"
+		if hasPrec && f.prec == 0 {
+			f.prec = 1
+		}
+		if f.useSci(x, hasPrec) {
+			f.format(x, sci, 'e'+c-'g')
+		} else {
+			f.format(x, plain, noE)
+		}
+	}
+}
+
+// formatInt writes x, which is an integer or not finite, without a fraction
+// or exponent.
+func (f *formatter) formatInt(x *Big) {
+	if !x.IsFinite() {
+		f.format(x, normal, sciE[x.Context.OperatingMode])
+		return
+	}
+
+	n, _ := x.Int(nil)
+	if x.Signbit() {
+		f.WriteByte('-')
+	} else if f.sign != 0 {
+		f.WriteByte(f.sign)
+	}
+	f.WriteString(n.Abs(n).String())
+}
+
+// useSci reports whether %g should format x in scientific notation. Like the
+// fmt package, it does so if the adjusted exponent of x, after rounding to
+// f.prec digits, is less than -4 or at least the precision. Without an
+// explicit precision, the threshold is 21, as in JavaScript.
+func (f *formatter) useSci(x *Big, hasPrec bool) bool {
+	if !x.IsFinite() {
+		return false
+	}
+	eprec := 21
+	if hasPrec {
+		eprec = f.prec
+	}
+	adj := x.adjusted()
+	if hasPrec && f.prec < x.Precision() {
+		var (
+			b   []byte
+			buf [20]byte
+		)
+		if x.isCompact() {
+			b = strconv.AppendUint(buf[:0], x.compact, 10)
+		} else {
+			b = formatUnscaled(&x.unscaled)
+		}
+		// Rounding might carry into a new digit; e.g., 9.99 -> 10.0.
+		adj += len(roundString(b, x.Context.roundingMode(), !x.Signbit(), f.prec)) - f.prec
+	}
+	return adj < -4 || adj >= eprec
+}
+
 // formatSci returns the scientific version of b.
 func (f *formatter) formatSci(b []byte, adj int, e byte) {
 	f.WriteByte(b[0])
@@ -249,12 +351,13 @@ func (f *formatter) formatSci(b []byte, adj int, e byte) {
 		f.Write(b[1:])
 	}
 
-	// If negative, the call to strconv.Itoa will add the minus sign for us.
+	// If negative, the call to strconv.AppendInt will add the minus sign for
+	// us.
 	f.WriteByte(e)
 	if adj > 0 {
 		f.WriteByte('+')
 	}
-	f.WriteString(strconv.Itoa(adj))
+	f.b = strconv.AppendInt(f.b, int64(adj), 10)
 }
 
 // formatPlain returns the plain string version of b.
@@ -278,7 +381,7 @@ func (f *formatter) formatPlain(b []byte, exp int) {
 	// log10(b) < scale, so before p "0s" and before b: 0.00000123456
 	default:
 		f.WriteString(zeroRadix)
-		io.CopyN(f, zeroReader{}, -int64(radix))
+		f.pad('0', -radix)
 
 		end := len(b)
 		if f.prec < end {
@@ -287,57 +390,3 @@ func (f *formatter) formatPlain(b []byte, exp int) {
 		f.Write(b[:end])
 	}
 }
-
-// TODO(eric): can we merge zeroReader and spaceReader into a "singleReader" or
-// something and still maintain the same performance?
-
-// zeroReader is an io.Reader that, when read from, only provides the character
-// '0'.
-type zeroReader struct{}
-
-// Read implements io.Reader.
-func (z zeroReader) Read(p []byte) (n int, err error) {
-	// zeroLiterals is 16 '0' bytes. It's used to speed up zeroReader's Read
-	// method.
-	const zeroLiterals = "0000000000000000"
-	for n < len(p) {
-		m := copy(p[n:], zeroLiterals)
-		if m == 0 {
-			break
-		}
-		n += m
-	}
-	return n, nil
-}
-
-// spaceReader is an io.Reader that, when read from, only provides the
-// character ' '.
-type spaceReader struct{}
-
-// Read implements io.Reader.
-func (s spaceReader) Read(p []byte) (n int, err error) {
-	// spaceLiterals is 16 ' ' bytes. It's used to speed up spaceReader's Read
-	// method.
-	const spaceLiterals = "                "
-	for n < len(p) {
-		m := copy(p[n:], spaceLiterals)
-		if m == 0 {
-			break
-		}
-		n += m
-	}
-	return n, nil
-}
-
-// stateWrapper is a wrapper around an io.Writer to add WriteByte and
-// WriteString methods.
-type stateWrapper struct{ fmt.State }
-
-func (w stateWrapper) WriteByte(c byte) error {
-	_, err := w.Write([]byte{c})
-	return err
-}
-
-func (w stateWrapper) WriteString(s string) (int, error) {
-	return io.WriteString(w.State, s)
-}
@@ -76,6 +76,32 @@ func TestDecimal_Format(t *testing.T) {
 		{"%.10f", "0.1234567891", "0.1234567891"},
 		{"%.10f", "0.01", "0.0100000000"},
 		{"%.10f", "0.0000000000000000000000000000000000000000000000000000000000001", "0.0000000000"},
+		{"%010.2f", "-1.5", "-000001.50"},
+		{"%+08.2f", "1.5", "+0001.50"},
+		{"%-08.2f|", "1.5", "1.50    |"},
+		{"% 8.2f", "1.5", "    1.50"},
+		{"%08f", "NaN", "     NaN"},
+		{"%010f", "-Infinity", " -Infinity"},
+		{"%.3e", "1234.5", "1.23e+3"},
+		{"%10.3E", "-1234.5", "  -1.23E+3"},
+		{"%g", "1E+30", "1e+30"},
+		{"%g", "123456789012345678901", "123456789012345678901"},
+		{"%g", "0.0001234", "0.0001234"},
+		{"%g", "0.00001234", "1.234e-5"},
+		{"%G", "1E-7", "1E-7"},
+		{"%.2g", "9.99", "10"},
+		{"%.2g", "99.9", "1.0e+2"},
+		{"%.0g", "123", "1e+2"},
+		{"%d", "-12.75", "%!d(decimal=-12.75)"},
+		{"%d", "1.2E+3", "1200"},
+		{"%06d", "-12", "-00012"},
+		{"%06d", "-12.75", "%!d(decimal=-12.75)"},
+		{"%+d", "0", "+0"},
+		{"%+d", "0.5", "%!d(decimal=0.5)"},
+		{"% d", "7", " 7"},
+		{"%d", "Infinity", "Infinity"},
+		{"%v", "1.2E+7", "1.2E+7"},
+		{"%8v", "-1.50", "   -1.50"},
 	} {
 		z, _ := new(Big).SetString(s.input)
 		got := fmt.Sprintf(s.format, z)
@@ -87,3 +113,93 @@ wanted: %s
 		}
 	}
 }
+
+func TestDecimal_Format_Int(t *testing.T) {
+	for i, test := range [...]struct {
+		input string
+		want  string
+	}{
+		{"12", "12"},
+		{"1.2E+3", "1200"},
+		{"12.00", "12"},
+		{"-0", "-0"},
+		{"-12.75", "%!d(decimal=-12.75)"},
+		{"1.5", "%!d(decimal=1.5)"},
+		{"-0.5", "%!d(decimal=-0.5)"},
+		{"1E-100", "%!d(decimal=1E-100)"},
+		{"NaN", "NaN"},
+	} {
+		z, _ := new(Big).SetString(test.input)
+		if got := fmt.Sprintf("%d", z); got != test.want || z.Context.Conditions != 0 {
+			t.Fatalf("#%d: %%d of %s: wanted %s, got %s (%s)",
+				i, test.input, test.want, got, z.Context.Conditions)
+		}
+	}
+}
+
+func TestDecimal_Format_Go(t *testing.T) {
+	z := WithContext(Context{OperatingMode: Go})
+	for i, test := range [...]struct {
+		format string
+		inf    int
+		want   string
+	}{
+		{"%08f", +1, "    +Inf"},
+		{"%-6d|", -1, "-Inf  |"},
+		{"%g", 0, "NaN"},
+	} {
+		if test.inf != 0 {
+			z.SetInf(test.inf < 0)
+		} else {
+			z.SetNaN(false)
+		}
+		if got := fmt.Sprintf(test.format, z); got != test.want {
+			t.Fatalf("#%d: printf(%s): wanted %q, got %q", i, test.format, test.want, got)
+		}
+	}
+
+	var x *Big
+	if got := fmt.Sprintf("%d %f %g", x, x, x); got != "<nil> <nil> <nil>" {
+		t.Fatalf("nil: got %q", got)
+	}
+}
+
+func TestBig_Append(t *testing.T) {
+	inputs := [...]string{
+		"0", "-0", "1", "-1.50", "123.456", "9.99", "0.000001234", "1E+30",
+		"1.2E-30", "12345678901234567890123.45", "-0E-5", "Infinity", "-Infinity",
+		"NaN", "-sNaN12",
+	}
+	for _, mode := range [...]OperatingMode{GDA, Go} {
+		for _, s := range inputs {
+			x := WithContext(Context{OperatingMode: mode})
+			x.SetString(s)
+			for _, c := range "eEfFgG" {
+				for prec := -1; prec < 6; prec++ {
+					want := fmt.Sprintf("%"+string(c), x)
+					if prec >= 0 {
+						want = fmt.Sprintf("%.*"+string(c), prec, x)
+					}
+					if got := x.Append([]byte("x="), byte(c), prec); string(got) != "x="+want {
+						t.Fatalf("%s (%s): Append(%c, %d): wanted %q, got %q", s, mode, c, prec, want, got)
+					}
+				}
+			}
+		}
+	}
+
+	x := New(-12345, 2)
+	if got := string(x.Append(nil, 'z', 2)); got != "%z" {
+		t.Fatalf("invalid format: got %q", got)
+	}
+	if got := string((*Big)(nil).Append(nil, 'f', -1)); got != "<nil>" {
+		t.Fatalf("nil: got %q", got)
+	}
+
+	buf := make([]byte, 0, 64)
+	for _, c := range []byte("efg") {
+		if n := testing.AllocsPerRun(100, func() { buf = x.Append(buf[:0], c, 3) }); n != 0 {
+			t.Fatalf("Append(%c): wanted 0 allocations, got %v", c, n)
+		}
+	}
+}
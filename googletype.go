@@ -0,0 +1,175 @@
+package decimal
+
+import (
+	"errors"
+	"math/big"
+	"math/bits"
+	"strconv"
+
+	cst "github.com/ericlagergren/decimal/internal/c"
+)
+
+// nanosPerUnit is the number of nanos in a unit of google.type.Money.
+const nanosPerUnit = 1e9
+
+// Money returns x as the units and nanos fields of google.type.Money: x is
+// units + nanos×10**-9, where nanos is in (-1e9, 1e9) and has the same sign as
+// units, as money.proto requires. For example, -1.75 is -1 units and
+// -750000000 nanos.
+//
+// ok is false, and units and nanos are zero, if x cannot be represented
+// exactly: x has non-zero digits more than nine places after the decimal
+// point, its integer part does not fit in an int64, or x is a NaN or infinite
+// value.
+func (x *Big) Money() (units int64, nanos int32, ok bool) {
+	mustNotNil("Money", x, x)
+	if debug {
+		x.validate()
+	}
+	if !x.IsFinite() {
+		return 0, 0, false
+	}
+
+	// n = x×1e9, which must be an integer.
+	var t Big
+	t.Copy(x)
+	t.exp += 9
+	n, exact := t.Int(nil)
+	if !exact {
+		return 0, 0, false
+	}
+	var r big.Int
+	n.QuoRem(n, big.NewInt(nanosPerUnit), &r)
+	if !n.IsInt64() {
+		return 0, 0, false
+	}
+	return n.Int64(), int32(r.Int64()), true
+}
+
+// SetMoney sets z to units + nanos×10**-9, the value of a google.type.Money
+// with the units and nanos fields, and returns z. z has the smallest scale in
+// [0, 9] that represents it exactly, so 1 unit and 500000000 nanos is 1.5 and 2
+// units is 2. z is not rounded.
+//
+// As money.proto requires, nanos must be in (-1e9, 1e9) and must not have a
+// sign opposite to that of units. Otherwise, z is set to NaN and
+// InvalidOperation is raised.
+func (z *Big) SetMoney(units int64, nanos int32) *Big {
+	mustNotNil("SetMoney", z, z)
+	if nanos <= -nanosPerUnit || nanos >= nanosPerUnit ||
+		units > 0 && nanos < 0 || units < 0 && nanos > 0 {
+		return z.setNaN(InvalidOperation, qnan, moneysign)
+	}
+
+	var sign form
+	u, n := uint64(units), uint64(nanos)
+	if units < 0 || nanos < 0 {
+		sign = signbit
+		u, n = -u, -n
+	}
+	// |units|×1e9 + |nanos| < 2**63×1e9 + 1e9 < 2**93.
+	hi, lo := bits.Mul64(u, nanosPerUnit)
+	lo, carry := bits.Add64(lo, n, 0)
+	hi += carry
+
+	exp := -9
+	if hi == 0 && lo != cst.Inflated {
+		for ; exp < 0 && lo%10 == 0; exp++ {
+			lo /= 10
+		}
+		if lo == 0 {
+			return z.setZero(sign, 0)
+		}
+		return z.setTriple(lo, sign, exp)
+	}
+
+	var c, q, r, ten big.Int
+	c.SetUint64(hi).Lsh(&c, 64).Or(&c, q.SetUint64(lo))
+	ten.SetUint64(10)
+	for ; exp < 0; exp++ {
+		if q.QuoRem(&c, &ten, &r); r.Sign() != 0 {
+			break
+		}
+		c.Set(&q)
+	}
+	z.unscaled.Set(&c)
+	z.form = finite | sign
+	z.exp = exp
+	return z.norm()
+}
+
+// GoogleDecimal returns x as the value field of a google.type.Decimal, in the
+// canonical form that decimal.proto recommends: without a leading '+', with a
+// digit before any decimal point, and with a lower-case exponent that is
+// omitted if it is zero. x is written in positional notation if its adjusted
+// exponent is in [-6, 21) and otherwise in exponential notation, as in
+// 1.5e21, 1.5e-7, or 0e-10. Trailing zeros are kept, so 1.50 is written as
+// 1.50, and 1.5E+3 is written as 1500. The sign of a zero is not kept.
+//
+// google.type.Decimal cannot represent NaN or infinite values, for which
+// GoogleDecimal returns an error.
+func (x *Big) GoogleDecimal() (string, error) {
+	if x == nil {
+		return "", ErrNilOperand{Op: "GoogleDecimal"}
+	}
+	if debug {
+		x.validate()
+	}
+	if !x.IsFinite() {
+		return "", errors.New("decimal: GoogleDecimal: google.type.Decimal cannot represent " + x.form.String())
+	}
+
+	var (
+		b   []byte
+		buf [24]byte
+	)
+	if x.isCompact() {
+		b = strconv.AppendUint(buf[:0], x.compact, 10)
+	} else {
+		b = formatUnscaled(&x.unscaled)
+	}
+	dst := make([]byte, 0, len(b)+24)
+	if x.Signbit() && x.compact != 0 {
+		dst = append(dst, '-')
+	}
+	adj := x.exp + len(b) - 1
+	if adj >= -6 && adj < 21 {
+		return string(appendPlain(dst, b, x.exp)), nil
+	}
+	dst = append(dst, b[0])
+	if len(b) > 1 {
+		dst = append(dst, '.')
+		dst = append(dst, b[1:]...)
+	}
+	dst = append(dst, 'e')
+	return string(strconv.AppendInt(dst, int64(adj), 10)), nil
+}
+
+// SetGoogleDecimal sets z to the value field s of a google.type.Decimal and
+// returns z. s may be in any of the finite formats accepted by SetString; in
+// particular, decimal.proto allows a leading '+', a missing integer part (as
+// in .5), and an exponent of either case. z is not rounded.
+//
+// If s is invalid, SetGoogleDecimal returns an error as UnmarshalText does and
+// z is unchanged. NaN and infinite values are invalid, since
+// google.type.Decimal cannot represent them.
+func (z *Big) SetGoogleDecimal(s string) (*Big, error) {
+	mustNotNil("SetGoogleDecimal", z, z)
+	x := Big{Context: z.Context}
+	if err := x.UnmarshalText([]byte(s)); err != nil {
+		return nil, err
+	}
+	if !x.IsFinite() {
+		off := 0
+		if s[0] == '+' || s[0] == '-' {
+			off = 1
+		}
+		return nil, ErrInput{
+			Input:  s,
+			Offset: off,
+			Msg:    "google.type.Decimal cannot represent " + x.form.String(),
+		}
+	}
+	*z = x
+	return z, nil
+}
@@ -0,0 +1,121 @@
+package decimal_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestBig_Money(t *testing.T) {
+	for i, test := range [...]struct {
+		in    string
+		units int64
+		nanos int32
+		ok    bool
+		out   string // of SetMoney(units, nanos)
+	}{
+		{"0", 0, 0, true, "0"},
+		{"-0", 0, 0, true, "0"},
+		{"1.5", 1, 500000000, true, "1.5"},
+		{"-1.75", -1, -750000000, true, "-1.75"},
+		{"-0.000000001", 0, -1, true, "-1E-9"},
+		{"12.3400", 12, 340000000, true, "12.34"},
+		{"1.2E+3", 1200, 0, true, "1200"},
+		{"1.0000000000", 1, 0, true, "1"},
+		{"9223372036854775807.999999999", math.MaxInt64, 999999999, true, "9223372036854775807.999999999"},
+		{"-9223372036854775808.999999999", math.MinInt64, -999999999, true, "-9223372036854775808.999999999"},
+		{"-9223372036854775808", math.MinInt64, 0, true, "-9223372036854775808"},
+		{"0.0000000001", 0, 0, false, ""},
+		{"9223372036854775808", 0, 0, false, ""},
+		{"1E+19", 0, 0, false, ""},
+		{"Infinity", 0, 0, false, ""},
+		{"NaN", 0, 0, false, ""},
+	} {
+		x, _ := new(decimal.Big).SetString(test.in)
+		units, nanos, ok := x.Money()
+		if units != test.units || nanos != test.nanos || ok != test.ok {
+			t.Fatalf("#%d: %s.Money(): wanted (%d, %d, %t), got (%d, %d, %t)",
+				i, test.in, test.units, test.nanos, test.ok, units, nanos, ok)
+		}
+		if !ok {
+			continue
+		}
+		z := new(decimal.Big).SetMoney(units, nanos)
+		if z.String() != test.out || z.Cmp(x) != 0 || z.Context.Conditions != 0 {
+			t.Fatalf("#%d: SetMoney(%d, %d): wanted %s, got %s (%s)", i, units, nanos, test.out, z, z.Context.Conditions)
+		}
+	}
+
+	for i, test := range [...]struct {
+		units int64
+		nanos int32
+	}{
+		{1, -1},
+		{-1, 1},
+		{0, 1000000000},
+		{0, -1000000000},
+		{0, math.MaxInt32},
+	} {
+		z := new(decimal.Big).SetMoney(test.units, test.nanos)
+		if !z.IsNaN(0) || z.Context.Conditions != decimal.InvalidOperation {
+			t.Fatalf("#%d: SetMoney(%d, %d): wanted NaN, got %s (%s)", i, test.units, test.nanos, z, z.Context.Conditions)
+		}
+	}
+}
+
+func TestBig_GoogleDecimal(t *testing.T) {
+	for i, test := range [...]struct {
+		in, want string
+	}{
+		{"0", "0"},
+		{"-0.00", "0.00"},
+		{"1.50", "1.50"},
+		{"-2.5", "-2.5"},
+		{"2.5E+8", "250000000"},
+		{"0.000001", "0.000001"},
+		{"1E-7", "1e-7"},
+		{"-1.5E-7", "-1.5e-7"},
+		{"0E-10", "0e-10"},
+		{"123456789012345678901", "123456789012345678901"},
+		{"1.5E+21", "1.5e21"},
+		{"12345678901234567890123.45", "1.234567890123456789012345e22"},
+	} {
+		x, _ := new(decimal.Big).SetString(test.in)
+		s, err := x.GoogleDecimal()
+		if err != nil || s != test.want {
+			t.Fatalf("#%d: %s.GoogleDecimal(): wanted %s, got %s (%v)", i, test.in, test.want, s, err)
+		}
+		z, err := new(decimal.Big).SetGoogleDecimal(s)
+		if err != nil || z.Cmp(x) != 0 {
+			t.Fatalf("#%d: SetGoogleDecimal(%s): wanted %s, got %s (%v)", i, s, x, z, err)
+		}
+		if s2, _ := z.GoogleDecimal(); s2 != s {
+			t.Fatalf("#%d: round trip of %s: got %s", i, s, s2)
+		}
+	}
+
+	for i, s := range [...]string{"+2.5", ".5", "2.5E8", "2.5e0"} {
+		if _, err := new(decimal.Big).SetGoogleDecimal(s); err != nil {
+			t.Fatalf("#%d: SetGoogleDecimal(%s): %v", i, s, err)
+		}
+	}
+	for i, s := range [...]string{"", "1.2.3", "NaN", "-Infinity", "Inf", " 1"} {
+		z := decimal.New(125, 2)
+		_, err := z.SetGoogleDecimal(s)
+		var e decimal.ErrInput
+		if !errors.As(err, &e) || !errors.Is(err, decimal.ConversionSyntax) {
+			t.Fatalf("#%d: SetGoogleDecimal(%q): wanted ErrInput, got %v", i, s, err)
+		}
+		if z.String() != "1.25" {
+			t.Fatalf("#%d: SetGoogleDecimal(%q): modified z: %s", i, s, z)
+		}
+	}
+	for _, s := range [...]string{"NaN", "Infinity"} {
+		x, _ := new(decimal.Big).SetString(s)
+		if _, err := x.GoogleDecimal(); err == nil {
+			t.Fatalf("%s.GoogleDecimal(): wanted an error", s)
+		}
+	}
+}
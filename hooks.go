@@ -0,0 +1,328 @@
+package decimal
+
+import "strconv"
+
+// hooks holds the parts of a Context that few Contexts use. Contexts are
+// passed by value to every operation, so these are kept out of line: an
+// operation whose Context has no hooks pays for a single nil check. Hooks
+// shared by a Context are never modified; see update.
+//
+// Some hooks, like the tag, belong to the decimal whose Context holds them
+// rather than to the Context's settings. They are kept here so that a Big
+// without them is no larger.
+type hooks struct {
+	tracer  *Tracer         // see Context.SetTracer
+	scope   *condScope      // collects the conditions raised within Context.Do
+	inexact *ErrNotExact    // the most recent failure caused by ExactOnly
+	trap    *ConditionError // the most recent operation that raised a trap
+	tags    *TagPolicy      // see Context.SetTags
+	tag     interface{}     // see Big.SetTag
+	forced  Condition       // see Big.ForceCondition
+}
+
+// noHooks are the hooks of the Context that Context.hooked passes to the
+// operation it performs, so that the operation does not perform itself with
+// hooks again. They are empty, and never stored by update.
+var noHooks = new(hooks)
+
+// update returns a copy of h, or new hooks if h is nil, modified by fn. It
+// returns nil if the modified hooks are empty, so that a Context which no
+// longer uses any hooks has none.
+func (h *hooks) update(fn func(h *hooks)) *hooks {
+	var n hooks
+	if h != nil {
+		n = *h
+	}
+	fn(&n)
+	if n == (hooks{}) {
+		return nil
+	}
+	return &n
+}
+
+// settings returns h without the state of the decimal whose Context has h,
+// such as its tag, for use by a new decimal.
+func (h *hooks) settings() *hooks {
+	if !h.hasState() {
+		return h
+	}
+	return h.update(func(h *hooks) {
+		h.inexact, h.trap, h.forced, h.tag = nil, nil, 0, nil
+	})
+}
+
+// hasState reports whether h holds state of the decimal whose Context has h,
+// such as its tag.
+func (h *hooks) hasState() bool {
+	return h != nil && (h.inexact != nil || h.trap != nil || h.forced != 0 || h.tag != nil)
+}
+
+// adopt returns the hooks of a decimal whose hooks were v and whose Context
+// becomes one with the hooks h: h's settings, except for the scope of
+// Context.Do, which ends with the call, and v's state. It allocates only if
+// neither h nor v can be used as is.
+func (h *hooks) adopt(v *hooks) *hooks {
+	if h == noHooks {
+		h = nil
+	}
+	if h == v && (h == nil || h.scope == nil) {
+		return h
+	}
+	if !v.hasState() && (h == nil || h.scope == nil && !h.hasState()) {
+		return h
+	}
+	if (h == nil || h.tracer == nil && h.tags == nil) &&
+		(v == nil || v.tracer == nil && v.tags == nil && v.scope == nil) {
+		return v
+	}
+	n := &hooks{}
+	if h != nil {
+		n.tracer, n.tags = h.tracer, h.tags
+	}
+	if v != nil {
+		n.inexact, n.trap, n.tag, n.forced = v.inexact, v.trap, v.tag, v.forced
+	}
+	if n.tracer == nil && n.tags == nil && !n.hasState() {
+		return nil
+	}
+	return n
+}
+
+// adopt sets z's Context to c after an operation under c that stored its
+// result in z, keeping z's Conditions and the hooks that belong to z, such as
+// its tag. Every Context method that stores a result adopts its Context.
+func (c Context) adopt(z *Big) {
+	if z == nil {
+		return
+	}
+	c.Conditions = z.Context.Conditions
+	c.hooks = c.hooks.adopt(z.Context.hooks)
+	z.Context = c
+}
+
+// hasHooks reports whether c has hooks. It is false for the Context that
+// Context.hooked passes to the operation it performs.
+func (c Context) hasHooks() bool { return c.hooks != nil && c.hooks != noHooks }
+
+// hasHooks reports whether x is non-nil and its Context has hooks, such as a
+// tag.
+func (x *Big) hasHooks() bool { return x != nil && x.Context.hooks != nil }
+
+// hookedOp reports whether an operation under c that stores its result in z
+// and whose operands are x and y, either of which may be nil, is performed by
+// hooked: whether c has hooks or, since the result's tag is combined from
+// those of its operands, whether any of z, x, and y does.
+func (c Context) hookedOp(z, x, y *Big) bool {
+	if c.hooks != nil {
+		return c.hooks != noHooks
+	}
+	return z.hasHooks() || x.hasHooks() || y.hasHooks()
+}
+
+// hookedOps is like hookedOp, but for an operation whose operands are xs.
+func (c Context) hookedOps(z *Big, xs []*Big) bool {
+	if c.hooks != nil {
+		return c.hooks != noHooks
+	}
+	if z.hasHooks() {
+		return true
+	}
+	for _, x := range xs {
+		if x.hasHooks() {
+			return true
+		}
+	}
+	return false
+}
+
+// hookOp describes an operation performed with hooks.
+type hookOp struct {
+	name   string // function name or infix operator, as shown by RenderTrace
+	infix  bool   // whether name is an infix operator
+	params string // non-decimal parameters, e.g. the scale in Quantize
+	tagged bool   // whether the result's tag is combined from those of xs
+}
+
+// hooked performs op, an operation on xs that stores its result in z, for a
+// Context with hooks or whose result or operands have hooks. fn performs the
+// operation itself with c, which has no hooks, so the operations that fn
+// performs in turn are not recorded. An op without a name is not traced.
+func (c Context) hooked(z *Big, op hookOp, fn func(c Context) *Big, xs ...*Big) *Big {
+	h := c.hooks
+	if h == nil {
+		h = noHooks
+	}
+	c.hooks = noHooks
+	if z == nil {
+		return fn(c)
+	}
+	var n *traceNode
+	if h.tracer != nil && op.name != "" {
+		n = h.tracer.begin(op.name, op.infix, op.params, xs...)
+	}
+	var tag interface{}
+	if op.tagged {
+		// Combine the tags first since z may be one of xs.
+		tag = h.tags.combine(xs...)
+	}
+	conds, trap := z.Context.Conditions, z.Context.trap()
+	z.Context.Conditions = 0
+	fn(c)
+	if op.tagged {
+		z.setTag(tag)
+	}
+	h.done(z, conds, trap, n)
+	return z
+}
+
+// done finishes an operation performed with h that stored its result in z.
+// conds are the conditions z had before the operation, which have been
+// cleared so that z's conditions are those raised by the operation, and trap
+// is the trapped operation z's Context recorded before it. n is the
+// operation's trace, if any.
+func (h *hooks) done(z *Big, conds Condition, trap *ConditionError, n *traceNode) {
+	raised := z.Context.Conditions
+	z.Context.Conditions |= conds
+	if n != nil {
+		h.tracer.end(n, z, raised)
+	}
+	if h.scope != nil {
+		t := z.Context.trap()
+		if t == trap {
+			t = nil // recorded by an earlier operation
+		}
+		h.scope.add(raised, z.Context.inexact(), t)
+	}
+}
+
+// hookedQuoRem is like hooked, but performs QuoRem, which stores its results
+// in both z and r.
+func (c Context) hookedQuoRem(z, x, y, r *Big) (*Big, *Big) {
+	h := c.hooks
+	if h == nil {
+		h = noHooks
+	}
+	c.hooks = noHooks
+	var nz, nr *traceNode
+	if h.tracer != nil {
+		nz = h.tracer.begin("quoint", false, "", x, y)
+		nr = h.tracer.begin("rem", false, "", x, y)
+	}
+	tag := h.tags.combine(x, y)
+	zc, rc := z.Context.Conditions, r.Context.Conditions
+	zt, rt := z.Context.trap(), r.Context.trap()
+	z.Context.Conditions, r.Context.Conditions = 0, 0
+	c.QuoRem(z, x, y, r)
+	z.setTag(tag)
+	r.setTag(tag)
+	h.done(z, zc, zt, nz)
+	h.done(r, rc, rt, nr)
+	return z, r
+}
+
+// hookedRound is like hooked, but performs Round. Since nearly every operation
+// rounds its result, Round is only traced if it modifies z.
+func (c Context) hookedRound(z *Big) *Big {
+	h := c.hooks
+	c.hooks = noHooks
+	if z == nil {
+		return c.roundPrec(z)
+	}
+	var n *traceNode
+	if h.tracer != nil {
+		params := strconv.Itoa(precision(c)) + " digits, " + c.roundingMode().String()
+		n = h.tracer.begin("round", false, params, z)
+	}
+	conds, trap := z.Context.Conditions, z.Context.trap()
+	z.Context.Conditions = 0
+	c.roundPrec(z)
+	if z.Context.Conditions&(Rounded|Clamped) == 0 {
+		n = nil
+	}
+	h.done(z, conds, trap, n)
+	return z
+}
+
+// trapped performs op, an operation on xs that stores its result in z, for a
+// Context that traps Conditions. If the operation raises a trapped Condition,
+// it is recorded in z's Context for Context.Err, along with op and xs. fn
+// performs the operation itself with c, which traps nothing, so the
+// operations that fn performs in turn are not recorded.
+func (c Context) trapped(z *Big, op string, fn func(c Context) *Big, xs ...*Big) *Big {
+	traps := c.Traps
+	c.Traps = 0
+	old := z.snapshot(xs)
+	conds := z.Context.Conditions
+	z.Context.Conditions = 0
+	fn(c)
+	raised := z.Context.Conditions & traps
+	z.Context.Conditions |= conds
+	if raised != 0 {
+		z.setTrap(raised, op, operands(xs, z, old, nil, nil))
+	}
+	return z
+}
+
+// trappedQuoRem is like trapped, but performs QuoRem, which stores its
+// results in both z and r.
+func (c Context) trappedQuoRem(z, x, y, r *Big) (*Big, *Big) {
+	traps := c.Traps
+	c.Traps = 0
+	xs := [...]*Big{x, y}
+	zold, rold := z.snapshot(xs[:]), r.snapshot(xs[:])
+	zc, rc := z.Context.Conditions, r.Context.Conditions
+	z.Context.Conditions, r.Context.Conditions = 0, 0
+	c.QuoRem(z, x, y, r)
+	zr, rr := z.Context.Conditions&traps, r.Context.Conditions&traps
+	z.Context.Conditions |= zc
+	r.Context.Conditions |= rc
+	if zr|rr != 0 {
+		ops := operands(xs[:], z, zold, r, rold)
+		if zr != 0 {
+			z.setTrap(zr, "QuoRem", ops)
+		}
+		if rr != 0 {
+			r.setTrap(rr, "QuoRem", ops)
+		}
+	}
+	return z, r
+}
+
+// snapshot returns a copy of z if z is one of xs, the operands of an
+// operation that stores its result in z, and otherwise nil.
+func (z *Big) snapshot(xs []*Big) *Big {
+	for _, x := range xs {
+		if x == z {
+			old := &Big{Context: z.Context, form: z.form, compact: z.compact, exp: z.exp, precision: z.precision}
+			if z.IsFinite() && z.isInflated() {
+				old.unscaled.Set(&z.unscaled)
+			}
+			return old
+		}
+	}
+	return nil
+}
+
+// operands returns xs, the operands of an operation that stored its results
+// in z and r, formatted with String. zold and rold are the snapshots of z and
+// r taken before the operation.
+func operands(xs []*Big, z, zold, r, rold *Big) []string {
+	s := make([]string, len(xs))
+	for i, x := range xs {
+		switch {
+		case x == z && zold != nil:
+			x = zold
+		case x == r && rold != nil:
+			x = rold
+		}
+		s[i] = x.String()
+	}
+	return s
+}
+
+// setTrap records in z's Context that op, performed on the operands ops,
+// raised the trapped Conditions cond.
+func (z *Big) setTrap(cond Condition, op string, ops []string) {
+	e := &ConditionError{Cond: cond, Op: op, Operands: ops}
+	z.Context.hooks = z.Context.hooks.update(func(h *hooks) { h.trap = e })
+}
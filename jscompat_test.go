@@ -0,0 +1,367 @@
+package decimal_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+// jsCompatFile holds the outputs of JavaScript's Number for a corpus of
+// formatting calls and arithmetic operations. It is generated by
+// _testdata/jscompat.js.
+var jsCompatFile = filepath.Join("_testdata", "jscompat.json")
+
+type jsCompatCase struct {
+	Op   string `json:"op"`
+	X    string `json:"x"`
+	Y    string `json:"y"`
+	Arg  *int   `json:"arg"`
+	Want string `json:"want"`
+}
+
+func TestJSCompat(t *testing.T) {
+	b, err := ioutil.ReadFile(jsCompatFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var corpus struct {
+		Node  string         `json:"node"`
+		Cases []jsCompatCase `json:"cases"`
+	}
+	if err := json.Unmarshal(b, &corpus); err != nil {
+		t.Fatal(err)
+	}
+	for i, c := range corpus.Cases {
+		x, ok := new(decimal.Big).SetString(c.X)
+		if !ok {
+			t.Fatalf("#%d: invalid input %q", i, c.X)
+		}
+		arg := -1
+		if c.Arg != nil {
+			arg = *c.Arg
+		}
+		var got string
+		switch c.Op {
+		case "toString":
+			got = x.JSString()
+		case "toFixed", "toExponential", "toPrecision":
+			got = jsFormat(x, c.Op, arg)
+			// Like decimal.js, but unlike Number, a negative zero keeps
+			// its sign.
+			if c.Op == "toExponential" && x.Sign() == 0 && x.Signbit() {
+				c.Want = "-" + c.Want
+			}
+		case "add", "sub", "mul", "div":
+			y, ok := new(decimal.Big).SetString(c.Y)
+			if !ok {
+				t.Fatalf("#%d: invalid input %q", i, c.Y)
+			}
+			ctx := decimal.ContextUnlimited
+			z := new(decimal.Big)
+			switch c.Op {
+			case "add":
+				ctx.Add(z, x, y)
+			case "sub":
+				ctx.Sub(z, x, y)
+			case "mul":
+				ctx.Mul(z, x, y)
+			case "div":
+				ctx.Quo(z, x, y)
+			}
+			got = z.JSString()
+		case "marshalJSON":
+			// want is what a JavaScript client writes after decoding x. A
+			// value MarshalJSON encodes as a number must survive that, and
+			// it must quote exactly the values its policy calls unsafe.
+			x.Context.QuoteUnsafeJSON = true
+			b, err := x.MarshalJSON()
+			if err != nil {
+				t.Fatalf("#%d: MarshalJSON(%s): %v", i, c.X, err)
+			}
+			adj := x.Precision() - x.Scale() - 1
+			unsafe := x.Sign() != 0 && (x.Precision() > 15 || adj < -21 || adj > 21)
+			if quoted := b[0] == '"'; quoted != unsafe {
+				t.Errorf("#%d: MarshalJSON(%s): wanted quoted=%t, got %s", i, c.X, unsafe, b)
+				continue
+			}
+			if unsafe {
+				continue
+			}
+			if w, _ := new(decimal.Big).SetString(c.Want); w.Cmp(x) != 0 {
+				t.Errorf("#%d: MarshalJSON(%s): encoded as %s, which Node.js %s decodes as %s",
+					i, c.X, b, corpus.Node, c.Want)
+				continue
+			}
+			got = x.JSString()
+		default:
+			t.Fatalf("#%d: unknown op %q", i, c.Op)
+		}
+		if got != c.Want {
+			if c.Arg != nil {
+				t.Errorf("#%d: (%s).%s(%d): wanted %s (Node.js %s), got %s",
+					i, c.X, c.Op, arg, c.Want, corpus.Node, got)
+			} else {
+				t.Errorf("#%d: %s(%s, %s): wanted %s (Node.js %s), got %s",
+					i, c.Op, c.X, c.Y, c.Want, corpus.Node, got)
+			}
+		}
+	}
+}
+
+// jsFormat formats x with the Big method named like op, a method of Number,
+// big.js, and decimal.js, whose argument is arg, or -1 if it has none.
+func jsFormat(x *decimal.Big, op string, arg int) string {
+	switch op {
+	case "toFixed":
+		return x.ToFixed(arg)
+	case "toExponential":
+		return x.ToExponential(arg)
+	case "toPrecision":
+		x.Context.RoundingMode = decimal.ToNearestAway
+		return x.ToPrecision(arg)
+	}
+	panic("unknown op " + op)
+}
+
+// jsLibsFile holds the outputs of big.js and decimal.js for a corpus of
+// formatting calls. It is generated by _testdata/jslibs.js, or by the model of
+// both libraries in _testdata/jslibs.py.
+var jsLibsFile = filepath.Join("_testdata", "jslibs.json")
+
+func TestJSLibs(t *testing.T) {
+	b, err := ioutil.ReadFile(jsLibsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var corpus struct {
+		Big     string `json:"big.js"`
+		Decimal string `json:"decimal.js"`
+		Cases   []struct {
+			jsCompatCase
+			Lib string `json:"lib"`
+		} `json:"cases"`
+	}
+	if err := json.Unmarshal(b, &corpus); err != nil {
+		t.Fatal(err)
+	}
+	version := map[string]string{"big.js": corpus.Big, "decimal.js": corpus.Decimal}
+	for i, c := range corpus.Cases {
+		x, ok := new(decimal.Big).SetString(c.X)
+		if !ok {
+			t.Fatalf("#%d: invalid input %q", i, c.X)
+		}
+		arg := -1
+		if c.Arg != nil {
+			arg = *c.Arg
+		}
+		if got := jsFormat(x, c.Op, arg); got != c.Want {
+			t.Errorf("#%d: (%s).%s(%d): wanted %s (%s %s), got %s",
+				i, c.X, c.Op, arg, c.Want, c.Lib, version[c.Lib], got)
+		}
+	}
+}
+
+// TestBig_JSFormat checks values that Number cannot represent, which are
+// formatted from their exact values.
+func TestBig_JSFormat(t *testing.T) {
+	for i, test := range [...]struct {
+		x    string
+		fn   func(x *decimal.Big) string
+		want string
+	}{
+		{"1.005", func(x *decimal.Big) string { return x.ToFixed(2) }, "1.01"},
+		{"-1.005", func(x *decimal.Big) string { return x.ToFixed(2) }, "-1.01"},
+		{"-0.001", func(x *decimal.Big) string { return x.ToFixed(2) }, "-0.00"},
+		{"0.9999", func(x *decimal.Big) string { return x.ToFixed(3) }, "1.000"},
+		{"0.0005", func(x *decimal.Big) string { return x.ToFixed(3) }, "0.001"},
+		{"0.0004999", func(x *decimal.Big) string { return x.ToFixed(3) }, "0.000"},
+		{"0E+3", func(x *decimal.Big) string { return x.ToFixed(2) }, "0.00"},
+		{"123456789012345678901234567890", func(x *decimal.Big) string { return x.ToFixed(2) },
+			"1.2345678901234567890123456789e+29"},
+		{"999999999999999999999.5", func(x *decimal.Big) string { return x.ToFixed(0) },
+			"1000000000000000000000"},
+		{"1180591620717411303424", (*decimal.Big).JSString, "1.1805916207174113e+21"},
+		{"0.1000000000000000055511151231257827", (*decimal.Big).JSString, "0.1"},
+		{"1.2345678901234567890E-7", (*decimal.Big).JSString, "1.2345678901234568e-7"},
+		{"1.15", func(x *decimal.Big) string { return x.ToExponential(1) }, "1.2e+0"},
+		{"99999999999999999999", func(x *decimal.Big) string { return x.ToExponential(2) }, "1.00e+20"},
+		{"1.2300", func(x *decimal.Big) string { return x.ToExponential(-1) }, "1.23e+0"},
+		{"-0", func(x *decimal.Big) string { return x.ToExponential(2) }, "-0.00e+0"},
+		{"-0", func(x *decimal.Big) string { return x.ToExponential(-1) }, "-0e+0"},
+		{"0E-5", func(x *decimal.Big) string { return x.ToExponential(-1) }, "0e+0"},
+		{"150", func(x *decimal.Big) string { return x.ToExponential(2) }, "1.50e+2"},
+		{"-0.000125", func(x *decimal.Big) string { return x.ToExponential(1) }, "-1.3e-4"},
+		{"1E+100", func(x *decimal.Big) string { return x.ToExponential(-1) }, "1e+100"},
+		{"1.5", func(x *decimal.Big) string { return x.ToExponential(-2) }, "NaN"},
+		{"0.000000999999", func(x *decimal.Big) string { return x.ToPrecision(3) }, "0.00000100"},
+		{"12345678901234567890.5", func(x *decimal.Big) string { return x.ToPrecision(21) },
+			"12345678901234567890.5"},
+		{"0", func(x *decimal.Big) string { return x.ToPrecision(3) }, "0.00"},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		if got := test.fn(x); got != test.want {
+			t.Fatalf("#%d: %s: wanted %s, got %s", i, test.x, test.want, got)
+		}
+	}
+}
+
+func TestBig_JSString(t *testing.T) {
+	for i, test := range [...]struct {
+		x       string
+		want    string
+		inexact bool
+	}{
+		{"0.1", "0.1", true},
+		{"0.5", "0.5", false},
+		{"1.50", "1.5", false},
+		{"-0.00", "0", false},
+		{"100", "100", false},
+		{"123456789012345678901", "123456789012345680000", true},
+		{"1E+21", "1e+21", false},
+		{"1.5E+300", "1.5e+300", true},
+		{"0.000001", "0.000001", true},
+		{"0.0000001", "1e-7", true},
+		{"-1.5E-7", "-1.5e-7", true},
+		{"9007199254740993", "9007199254740992", true},
+		{"9007199254740995", "9007199254740996", true},
+		{"0.30000000000000004", "0.30000000000000004", true},
+		{"5E-324", "5e-324", true},
+		{"2E-324", "0", true},
+		{"-1E+400", "-Infinity", true},
+		{"1.7976931348623157E+308", "1.7976931348623157e+308", true},
+		{"NaN", "NaN", false},
+		{"-Infinity", "-Infinity", false},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		if got := x.JSString(); got != test.want {
+			t.Fatalf("#%d: %s.JSString(): wanted %s, got %s", i, test.x, test.want, got)
+		}
+		var want decimal.Condition
+		if test.inexact {
+			want = decimal.Inexact | decimal.Rounded
+		}
+		if x.Context.Conditions != want {
+			t.Fatalf("#%d: %s.JSString(): wanted %s, got %s", i, test.x, want, x.Context.Conditions)
+		}
+	}
+}
+
+func TestBig_ToFixedMode(t *testing.T) {
+	for i, test := range [...]struct {
+		x      string
+		digits int
+		mode   decimal.RoundingMode
+		want   string
+	}{
+		{"1.25", 1, decimal.ToNearestEven, "1.2"},
+		{"1.35", 1, decimal.ToNearestEven, "1.4"},
+		{"1.25", 1, decimal.ToNearestAway, "1.3"},
+		{"-1.25", 1, decimal.ToNearestAway, "-1.3"},
+		{"1.29", 1, decimal.ToZero, "1.2"},
+		{"-1.29", 1, decimal.ToZero, "-1.2"},
+		{"1.21", 1, decimal.AwayFromZero, "1.3"},
+		{"-1.21", 1, decimal.ToNegativeInf, "-1.3"},
+		{"-1.29", 1, decimal.ToPositiveInf, "-1.2"},
+		{"9.99", 1, decimal.AwayFromZero, "10.0"},
+		{"99.9", 0, decimal.ToPositiveInf, "100"},
+		// Every digit is discarded.
+		{"0.5", 0, decimal.ToNearestEven, "0"},
+		{"0.51", 0, decimal.ToNearestEven, "1"},
+		{"0.0001", 2, decimal.AwayFromZero, "0.01"},
+		{"0.0001", 2, decimal.ToPositiveInf, "0.01"},
+		{"0.0001", 2, decimal.ToNearestAway, "0.00"},
+		{"-0.0001", 2, decimal.ToNegativeInf, "-0.01"},
+		// A negative value that rounds to zero keeps its sign, but -0 does
+		// not.
+		{"-0.0001", 2, decimal.ToPositiveInf, "-0.00"},
+		{"-0.004", 2, decimal.ToNearestEven, "-0.00"},
+		{"-0.00", 2, decimal.AwayFromZero, "0.00"},
+		{"1.5", 3, decimal.ToZero, "1.500"},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		if got := x.ToFixedMode(test.digits, test.mode); got != test.want {
+			t.Fatalf("#%d: %s.ToFixedMode(%d, %s): wanted %s, got %s",
+				i, test.x, test.digits, test.mode, test.want, got)
+		}
+	}
+	// ToFixed is ToFixedMode with ToNearestAway.
+	x := decimal.New(125, 2)
+	if got, want := x.ToFixed(1), x.ToFixedMode(1, decimal.ToNearestAway); got != want {
+		t.Fatalf("ToFixed(1): wanted %s, got %s", want, got)
+	}
+}
+
+func TestBig_ToFixed_Range(t *testing.T) {
+	for _, digits := range [...]int{-1, 101} {
+		x := decimal.New(15, 1)
+		if got := x.ToFixed(digits); got != "NaN" || x.Context.Conditions != decimal.InvalidOperation {
+			t.Fatalf("ToFixed(%d): wanted NaN and %s, got %s and %s",
+				digits, decimal.InvalidOperation, got, x.Context.Conditions)
+		}
+		if got := x.ToFixedMode(digits, decimal.ToZero); got != "NaN" {
+			t.Fatalf("ToFixedMode(%d): wanted NaN, got %s", digits, got)
+		}
+	}
+	if got := decimal.New(15, 1).ToFixed(100); len(got) != 102 {
+		t.Fatalf("ToFixed(100): got %s", got)
+	}
+}
+
+func TestBig_ToPrecision_RoundingMode(t *testing.T) {
+	for i, test := range [...]struct {
+		x    string
+		prec int
+		mode decimal.RoundingMode
+		want string
+	}{
+		{"2.5", 1, decimal.ToNearestEven, "2"},
+		{"3.5", 1, decimal.ToNearestEven, "4"},
+		{"2.5", 1, decimal.ToNearestAway, "3"},
+		{"-2.5", 1, decimal.ToNearestAway, "-3"},
+		{"123.456", 8, decimal.ToNearestEven, "123.45600"},
+		{"123.456", 2, decimal.ToPositiveInf, "1.3e+2"},
+		{"-123.456", 2, decimal.ToPositiveInf, "-1.2e+2"},
+		{"-123.456", 2, decimal.ToNegativeInf, "-1.3e+2"},
+		{"0.00000123", 2, decimal.ToZero, "0.0000012"},
+		{"9.95", 2, decimal.ToNearestEven, "10"},
+		{"9.85", 2, decimal.ToNearestEven, "9.8"},
+		{"99.1", 2, decimal.AwayFromZero, "1.0e+2"},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		x.Context.RoundingMode = test.mode
+		if got := x.ToPrecision(test.prec); got != test.want {
+			t.Fatalf("#%d: %s.ToPrecision(%d) with %s: wanted %s, got %s",
+				i, test.x, test.prec, test.mode, test.want, got)
+		}
+	}
+
+	for _, prec := range [...]int{0, 101} {
+		x := decimal.New(15, 1)
+		if got := x.ToPrecision(prec); got != "NaN" || x.Context.Conditions != decimal.InvalidOperation {
+			t.Fatalf("ToPrecision(%d): wanted NaN and %s, got %s and %s",
+				prec, decimal.InvalidOperation, got, x.Context.Conditions)
+		}
+	}
+}
+
+func TestBig_JSFormat_GoMode(t *testing.T) {
+	for i, fn := range [...]func(x *decimal.Big) string{
+		func(x *decimal.Big) string { return x.ToFixed(-1) },
+		func(x *decimal.Big) string { return x.ToFixed(101) },
+		func(x *decimal.Big) string { return x.ToFixedMode(101, decimal.ToZero) },
+		func(x *decimal.Big) string { return x.ToExponential(-2) },
+		func(x *decimal.Big) string { return x.ToExponential(101) },
+		func(x *decimal.Big) string { return x.ToPrecision(0) },
+		func(x *decimal.Big) string { return x.ToPrecision(101) },
+	} {
+		x := decimal.New(15, 1)
+		x.Context.OperatingMode = decimal.Go
+		if got := fn(x); got != "NaN" || x.Context.Conditions != decimal.InvalidOperation {
+			t.Fatalf("#%d: wanted NaN and %s, got %s and %s",
+				i, decimal.InvalidOperation, got, x.Context.Conditions)
+		}
+	}
+}
@@ -0,0 +1,353 @@
+package decimal
+
+import (
+	"bytes"
+	"math"
+	"strconv"
+)
+
+// maxJSDigits is the largest number of digits accepted by JavaScript's
+// Number.prototype.toFixed, toExponential, and toPrecision.
+const maxJSDigits = 100
+
+// The methods in this file format x as JavaScript's Number formats a Number.
+// JSString formats the float64 nearest to x, which is the Number that x
+// would be in JavaScript. The others format x's exact value, so they agree
+// with Number whenever x is exactly representable as a float64, and with
+// decimal.js (using ROUND_HALF_UP) otherwise, except that ToPrecision only
+// does so if x rounds like ToNearestAway, as by default in JS mode. _testdata/jscompat.json holds
+// the outputs of Node.js that they are tested against, and
+// _testdata/jslibs.js generates those of big.js and decimal.js.
+
+// JSString returns x formatted like String(Number(x)) in JavaScript: x is
+// rounded to the nearest float64, with ties to even, and the float64 is
+// formatted like Number.prototype.toString, with the fewest digits that
+// round-trip. The result is positional if 1E-6 <= |x| < 1E+21 and exponential
+// (e.g., 1.5e+21 or 1e-7) otherwise. Zeros are formatted as 0, regardless of
+// their sign or exponent, and NaN and infinite values as NaN, Infinity, and
+// -Infinity. For example, 0.1000000000000000055511151231257827 is formatted
+// as 0.1, and 1.50 as 1.5.
+//
+// If x is not exactly representable as a float64, JSString raises Inexact and
+// Rounded in x's Context. Like Number, a value too large for a float64 is
+// formatted as Infinity or -Infinity, and one too small as 0.
+func (x *Big) JSString() string {
+	if x == nil {
+		return "<nil>"
+	}
+	if debug {
+		x.validate()
+	}
+	if s, ok := x.jsSpecial(); ok {
+		return s
+	}
+	f, exact := x.Float64()
+	if !exact {
+		x.Context.Conditions |= Inexact | Rounded
+	}
+	switch {
+	case f == 0:
+		return "0"
+	case math.IsInf(f, 0):
+		if f > 0 {
+			return "Infinity"
+		}
+		return "-Infinity"
+	}
+
+	// AppendFloat produces the shortest digits as d.ddde±dd, which are
+	// rearranged into an integer b with the exponent exp.
+	var buf, out [32]byte
+	e := strconv.AppendFloat(buf[:0], math.Abs(f), 'e', -1, 64)
+	i := bytes.IndexByte(e, 'e')
+	adj, _ := strconv.Atoi(string(e[i+1:]))
+	b := e[:i]
+	if len(b) > 2 {
+		b = append(b[:1], b[2:]...)
+	}
+	dst := out[:0]
+	if f < 0 {
+		dst = append(dst, '-')
+	}
+	return string(appendJSString(dst, b, adj-len(b)+1))
+}
+
+// jsExactString is like JSString, but formats x's exact value rather than
+// the nearest float64.
+func (x *Big) jsExactString() string {
+	b, exp := x.jsDigits()
+	for len(b) > 1 && b[len(b)-1] == '0' {
+		b = b[:len(b)-1]
+		exp++
+	}
+	return string(appendJSString(x.jsSign(), b, exp))
+}
+
+// appendJSString appends the non-zero digits b, which have no trailing zeros,
+// with the exponent exp to dst as formatted by Number.prototype.toString.
+func appendJSString(dst, b []byte, exp int) []byte {
+	// n is the position of the decimal point relative to the first digit.
+	if n := len(b) + exp; n > -6 && n <= 21 {
+		return appendPlain(dst, b, exp)
+	}
+	return appendJSExp(dst, b, len(b)+exp-1)
+}
+
+// appendJSExp appends the digits b with the adjusted exponent adj to dst in
+// exponential form, as in 1.5e+21.
+func appendJSExp(dst, b []byte, adj int) []byte {
+	dst = append(dst, b[0])
+	if len(b) > 1 {
+		dst = append(dst, '.')
+		dst = append(dst, b[1:]...)
+	}
+	dst = append(dst, 'e')
+	if adj >= 0 {
+		dst = append(dst, '+')
+	}
+	return strconv.AppendInt(dst, int64(adj), 10)
+}
+
+// ToFixed returns x formatted like JavaScript's Number.prototype.toFixed:
+// rounded to digits digits after the decimal point, with ties rounded away
+// from zero, and without exponential notation. Like toFixed, it uses the
+// notation of JSString if |x| >= 1E+21, but with all of x's digits rather than
+// those of the nearest float64, and a negative x that rounds to zero keeps its
+// sign; e.g., -0.001 is formatted as -0.00 with two digits, but -0 is
+// formatted as 0.00.
+//
+// If digits is not in [0, 100], ToFixed raises InvalidOperation in x's Context
+// and returns NaN.
+func (x *Big) ToFixed(digits int) string {
+	return x.toFixed(digits, ToNearestAway)
+}
+
+// ToFixedMode is like ToFixed, but rounds using mode, as decimal.js's toFixed
+// does with a rounding mode; e.g., with ToZero, 1.29 with one digit is
+// formatted as 1.2. DefaultRounding stands for the default of x's
+// OperatingMode.
+func (x *Big) ToFixedMode(digits int, mode RoundingMode) string {
+	if x == nil {
+		return "<nil>"
+	}
+	return x.toFixed(digits, mode.resolve(x.Context.OperatingMode))
+}
+
+// toFixed implements ToFixed and ToFixedMode.
+func (x *Big) toFixed(digits int, mode RoundingMode) string {
+	if x == nil {
+		return "<nil>"
+	}
+	if !x.jsArgInRange(digits, 0) {
+		return "NaN"
+	}
+	if debug {
+		x.validate()
+	}
+	if s, ok := x.jsSpecial(); ok {
+		return s
+	}
+	if x.compact != 0 && x.adjusted() >= 21 {
+		return x.jsExactString()
+	}
+
+	// Round to an integer coefficient with exponent -digits.
+	b, exp := x.jsDigits()
+	if x.compact == 0 {
+		exp = 0
+	}
+	switch keep := len(b) + exp + digits; {
+	case -exp <= digits:
+		for ; exp > -digits; exp-- {
+			b = append(b, '0')
+		}
+	case keep > 0:
+		var carry bool
+		b, carry = roundDigits(b, keep, mode, !x.Signbit())
+		if carry {
+			b = append(b, '0')
+		}
+	default:
+		// Every digit is discarded, so round 0.b, or 0.01 if x is even
+		// smaller: only whether it is above, at, or below one half matters.
+		if keep < 0 {
+			b = append(b[:0], '1')
+		}
+		b = append([]byte{'0'}, b...)
+		b, _ = roundDigits(b, 1, mode, !x.Signbit())
+	}
+
+	dst := x.jsSign()
+	if n := len(b) - digits; n > 0 {
+		dst = append(dst, b[:n]...)
+	} else {
+		dst = append(dst, '0')
+		if digits > 0 {
+			dst = append(dst, '.')
+		}
+		for ; n < 0; n++ {
+			dst = append(dst, '0')
+		}
+		return string(append(dst, b...))
+	}
+	if digits > 0 {
+		dst = append(dst, '.')
+		dst = append(dst, b[len(b)-digits:]...)
+	}
+	return string(dst)
+}
+
+// ToExponential returns x formatted like JavaScript's
+// Number.prototype.toExponential: in exponential notation with one digit
+// before the decimal point and digits digits after it, with ties rounded away
+// from zero; e.g., 12.5 with one digit is formatted as 1.3e+1 and 150 with two
+// as 1.50e+2. If digits is -1, it uses as many digits as necessary to
+// represent x exactly, like toExponential with no argument.
+//
+// Zeros are formatted as 0e+0, 0.00e+0, and so on. Like decimal.js, but
+// unlike Number, a negative zero keeps its sign, as in -0e+0.
+//
+// If digits is not in [-1, 100], ToExponential raises InvalidOperation in x's
+// Context and returns NaN.
+func (x *Big) ToExponential(digits int) string {
+	if x == nil {
+		return "<nil>"
+	}
+	if !x.jsArgInRange(digits, -1) {
+		return "NaN"
+	}
+	if debug {
+		x.validate()
+	}
+	if s, ok := x.jsSpecial(); ok {
+		return s
+	}
+	if x.compact == 0 {
+		var dst []byte
+		if x.Signbit() {
+			dst = append(dst, '-')
+		}
+		b := []byte{'0'}
+		for i := 0; i < digits; i++ {
+			b = append(b, '0')
+		}
+		return string(appendJSExp(dst, b, 0))
+	}
+	b, exp := x.jsDigits()
+	if digits < 0 {
+		for len(b) > 1 && b[len(b)-1] == '0' {
+			b = b[:len(b)-1]
+			exp++
+		}
+	} else {
+		b, exp = jsRoundSig(b, exp, digits+1, ToNearestAway, true)
+	}
+	return string(appendJSExp(x.jsSign(), b, len(b)+exp-1))
+}
+
+// ToPrecision returns x formatted like JavaScript's
+// Number.prototype.toPrecision: rounded to prec significant digits, in
+// exponential notation if the adjusted exponent of the result is less than -6
+// or at least prec, and positional notation otherwise. Unlike Display,
+// trailing zeros are kept; e.g., 123.456 with eight digits is formatted as
+// 123.45600.
+//
+// x is rounded using x's RoundingMode. toPrecision rounds ties away from zero,
+// so x must round like ToNearestAway to match it, as DefaultRounding does in
+// JS mode; in the other modes, DefaultRounding rounds like ToNearestEven, and
+// 2.5 with one digit is formatted as 2 rather than 3.
+//
+// If prec is not in [1, 100], ToPrecision raises InvalidOperation in x's
+// Context and returns NaN.
+func (x *Big) ToPrecision(prec int) string {
+	if x == nil {
+		return "<nil>"
+	}
+	if !x.jsArgInRange(prec, 1) {
+		return "NaN"
+	}
+	if debug {
+		x.validate()
+	}
+	if s, ok := x.jsSpecial(); ok {
+		return s
+	}
+	var b []byte
+	var exp int
+	if x.compact == 0 {
+		b, exp = make([]byte, prec), 1-prec
+		for i := range b {
+			b[i] = '0'
+		}
+	} else {
+		b, exp = x.jsDigits()
+		b, exp = jsRoundSig(b, exp, prec, x.Context.roundingMode(), !x.Signbit())
+	}
+	if adj := len(b) + exp - 1; adj < -6 || adj >= prec {
+		return string(appendJSExp(x.jsSign(), b, adj))
+	}
+	return string(appendPlain(x.jsSign(), b, exp))
+}
+
+// jsRoundSig returns the digits b with exponent exp rounded to n significant
+// digits using mode, or padded with zeros to n digits. pos reports whether
+// the value is positive.
+func jsRoundSig(b []byte, exp, n int, mode RoundingMode, pos bool) ([]byte, int) {
+	if len(b) > n {
+		var carry bool
+		exp += len(b) - n
+		b, carry = roundDigits(b, n, mode, pos)
+		if carry {
+			exp++
+		}
+	}
+	for len(b) < n {
+		b = append(b, '0')
+		exp--
+	}
+	return b, exp
+}
+
+// jsArgInRange reports whether n, an argument of a formatting method, is in
+// [min, maxJSDigits]. If not, it raises InvalidOperation in x's Context. Like
+// Display, it does not panic if x's OperatingMode is Go, since formatting x
+// does not set a result.
+func (x *Big) jsArgInRange(n, min int) bool {
+	if n >= min && n <= maxJSDigits {
+		return true
+	}
+	x.Context.Conditions |= InvalidOperation
+	return false
+}
+
+// jsSpecial returns the formatting of x if x is a NaN or infinite value.
+func (x *Big) jsSpecial() (string, bool) {
+	switch {
+	case x.IsNaN(0):
+		return "NaN", true
+	case x.IsInf(+1):
+		return "Infinity", true
+	case x.IsInf(-1):
+		return "-Infinity", true
+	}
+	return "", false
+}
+
+// jsSign returns a new buffer containing the sign of x, which is empty unless
+// x is less than zero.
+func (x *Big) jsSign() []byte {
+	b := make([]byte, 0, 24)
+	if x.Sign() < 0 {
+		b = append(b, '-')
+	}
+	return b
+}
+
+// jsDigits returns a copy of the digits of the finite x's coefficient, and its
+// exponent.
+func (x *Big) jsDigits() ([]byte, int) {
+	if x.isCompact() {
+		return strconv.AppendUint(make([]byte, 0, 24), x.compact, 10), x.exp
+	}
+	return formatUnscaled(&x.unscaled), x.exp
+}
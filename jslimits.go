@@ -0,0 +1,96 @@
+package decimal
+
+import (
+	"math/big"
+	"sync"
+)
+
+// The boundaries of JavaScript's Number, built on first use. They are never
+// modified; the exported functions return copies.
+var (
+	jsLimitsOnce sync.Once
+
+	// jsMaxValue is math.MaxFloat64, (2**53-1) * 2**971.
+	jsMaxValue Big
+
+	// jsOverflow is math.MaxFloat64 plus half of its ulp, (2**54-1) * 2**970,
+	// the smallest magnitude that rounds to ±Inf when converted to a float64.
+	jsOverflow Big
+)
+
+func initJSLimits() {
+	var m big.Int
+	m.SetUint64(maxSafeInteger)
+	jsMaxValue.SetBigMantScale(m.Lsh(&m, 971), 0)
+	m.SetUint64(1<<54 - 1)
+	jsOverflow.SetBigMantScale(m.Lsh(&m, 970), 0)
+}
+
+// MaxSafeInteger returns 2**53-1 (9007199254740991), JavaScript's
+// Number.MAX_SAFE_INTEGER: the largest integer n such that n and every integer
+// with a smaller magnitude are exactly representable as a float64.
+func MaxSafeInteger() *Big { return New(maxSafeInteger, 0) }
+
+// MinSafeInteger returns -(2**53-1) (-9007199254740991), JavaScript's
+// Number.MIN_SAFE_INTEGER.
+func MinSafeInteger() *Big { return New(-maxSafeInteger, 0) }
+
+// MaxJSValue returns the exact value of JavaScript's Number.MAX_VALUE (i.e.,
+// math.MaxFloat64), all 309 digits of it: 1.797...E+308.
+func MaxJSValue() *Big {
+	jsLimitsOnce.Do(initJSLimits)
+	return new(Big).Copy(&jsMaxValue)
+}
+
+// ExceedsSafeInteger reports whether |x| is greater than MaxSafeInteger.
+// Infinities exceed it; NaNs do not. x need not be an integer, so 2**53-1 + 0.5
+// exceeds it, too.
+func (x *Big) ExceedsSafeInteger() bool {
+	mustNotNil("ExceedsSafeInteger", x, x)
+	var max Big
+	return cmp(x, max.SetMantScale(maxSafeInteger, 0), true) > 0
+}
+
+// ExceedsFloat64 reports whether x overflows to ±Inf when converted to the
+// nearest float64 (as by Float64Round with ToNearestEven, or JavaScript's
+// Number). This is true of every x whose magnitude is at least
+// math.MaxFloat64 + 2**970, half an ulp above MaxJSValue, and of infinities.
+// NaNs do not exceed it.
+func (x *Big) ExceedsFloat64() bool {
+	mustNotNil("ExceedsFloat64", x, x)
+	if x.IsNaN(0) {
+		return false
+	}
+	if x.IsFinite() && (x.compact == 0 || x.adjusted() < 308) {
+		return false
+	}
+	jsLimitsOnce.Do(initJSLimits)
+	return cmp(x, &jsOverflow, true) >= 0
+}
+
+// FitsFloat64Exactly reports whether x is exactly representable as a float64,
+// so that converting it to one, as by Float64, loses nothing but trailing
+// zeros and the sign of a zero's exponent. For example, 0.5 and 1.50 fit, but
+// 0.1 does not. NaN and infinite values do not fit.
+func (x *Big) FitsFloat64Exactly() bool {
+	mustNotNil("FitsFloat64Exactly", x, x)
+	if !x.IsFinite() {
+		return false
+	}
+	_, exact := x.Float64()
+	return exact
+}
+
+// IsSafeJSNumber reports whether x survives a round trip through a JavaScript
+// Number: x fits a float64 exactly and its magnitude is at most
+// MaxSafeInteger, so JavaScript's integer arithmetic on it is exact, too. This
+// excludes integers like 2**60, which are exactly representable but are not
+// safe integers. Like FitsFloat64Exactly, it does not consider trailing zeros,
+// which a Number does not keep.
+//
+// It can be used to decide whether to encode x as a JSON number or as a
+// string.
+func (x *Big) IsSafeJSNumber() bool {
+	mustNotNil("IsSafeJSNumber", x, x)
+	return x.FitsFloat64Exactly() && !x.ExceedsSafeInteger()
+}
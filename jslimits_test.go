@@ -0,0 +1,145 @@
+package decimal_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestJSLimits(t *testing.T) {
+	if s := decimal.MaxSafeInteger().String(); s != "9007199254740991" {
+		t.Fatalf("MaxSafeInteger: wanted 9007199254740991, got %s", s)
+	}
+	if s := decimal.MinSafeInteger().String(); s != "-9007199254740991" {
+		t.Fatalf("MinSafeInteger: wanted -9007199254740991, got %s", s)
+	}
+
+	want := new(big.Float).SetFloat64(math.MaxFloat64).Text('f', 0)
+	max := decimal.MaxJSValue()
+	if s := fmt.Sprintf("%f", max); s != want || len(s) != 309 {
+		t.Fatalf("MaxJSValue: wanted %s, got %s", want, s)
+	}
+	if f, acc := max.Float64Round(decimal.ToNearestEven); f != math.MaxFloat64 || acc != big.Exact {
+		t.Fatalf("MaxJSValue: wanted math.MaxFloat64 (Exact), got %g (%s)", f, acc)
+	}
+
+	// The values returned are copies.
+	max.SetUint64(1)
+	decimal.MaxSafeInteger().SetUint64(1)
+	if decimal.MaxJSValue().Cmp(max) == 0 {
+		t.Fatal("MaxJSValue: modifying the result changed the next result")
+	}
+}
+
+func TestBig_ExceedsSafeInteger(t *testing.T) {
+	for i, test := range [...]struct {
+		x    string
+		want bool
+	}{
+		{"0", false},
+		{"9007199254740991", false},
+		{"-9007199254740991", false},
+		{"9007199254740991.000", false},
+		{"9007199254740991.5", true},
+		{"9007199254740992", true},
+		{"-9007199254740992", true},
+		{"9.007199254740991E+15", false},
+		{"1E+16", true},
+		{"123.456", false},
+		{"Infinity", true},
+		{"-Infinity", true},
+		{"NaN", false},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		if got := x.ExceedsSafeInteger(); got != test.want {
+			t.Fatalf("#%d: ExceedsSafeInteger(%s): wanted %t, got %t", i, test.x, test.want, got)
+		}
+	}
+}
+
+func TestBig_ExceedsFloat64(t *testing.T) {
+	max := decimal.MaxJSValue()
+	// half is half an ulp of math.MaxFloat64, 2**970.
+	half := new(decimal.Big).SetBigMantScale(new(big.Int).Lsh(big.NewInt(1), 970), 0)
+	tiny := decimal.New(1, 0)
+	ctx := decimal.Context{Precision: decimal.UnlimitedPrecision}
+
+	for i, test := range [...]struct {
+		x    *decimal.Big
+		want bool
+	}{
+		{max, false},
+		{decimal.WithContext(ctx).Neg(max), false},
+		{ctx.Add(new(decimal.Big), max, tiny), false},
+		{ctx.Sub(new(decimal.Big), ctx.Add(new(decimal.Big), max, half), tiny), false},
+		{ctx.Add(new(decimal.Big), max, half), true},
+		{decimal.WithContext(ctx).Neg(ctx.Add(new(decimal.Big), max, half)), true},
+		{decimal.New(17976931348623157, -292), false},
+		{decimal.New(17976931348623159, -292), true},
+		{decimal.New(1, -309), true},
+		{decimal.New(1, -308), false},
+		{decimal.New(0, -400), false},
+		{decimal.New(1, 400), false},
+		{new(decimal.Big).SetInf(false), true},
+		{new(decimal.Big).SetInf(true), true},
+		{new(decimal.Big).SetNaN(false), false},
+	} {
+		got := test.x.ExceedsFloat64()
+		if got != test.want {
+			t.Fatalf("#%d: ExceedsFloat64(%s): wanted %t, got %t", i, test.x, test.want, got)
+		}
+		if test.x.IsFinite() {
+			f, _ := test.x.Float64Round(decimal.ToNearestEven)
+			if math.IsInf(f, 0) != got {
+				t.Fatalf("#%d: ExceedsFloat64(%s) = %t, but Float64Round returned %g", i, test.x, got, f)
+			}
+		}
+	}
+}
+
+func TestBig_IsSafeJSNumber(t *testing.T) {
+	for i, test := range [...]struct {
+		x          string
+		fits, safe bool
+	}{
+		{"0", true, true},
+		{"-0.000", true, true},
+		{"0.5", true, true},
+		{"1.50", true, true},
+		{"-1.125", true, true},
+		{"0.1", false, false},
+		{"1E-7", false, false},
+		{"9007199254740991", true, true},
+		{"-9007199254740991", true, true},
+		{"9007199254740992", true, false},
+		{"9007199254740993", false, false},
+		{"1152921504606846976", true, false}, // 2**60
+		{"1E+22", true, false},
+		{"1E+23", false, false},
+		{"0.1000000000000000055511151231257827021181583404541015625", true, true},
+		{"4.9406564584124654E-324", false, false},
+		{"179769313486231570814527423731704356798070567525844996598917476803157260780028538760589558632766878171540458953514382464234321326889464182768467546703537516986049910576551282076245490090389328944075868508455133942304583236903222948165808559332123348274797826204144723168738177180919299881250404026184124858368", true, false},
+		{"1E+400", false, false},
+		{"Infinity", false, false},
+		{"NaN", false, false},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		if got := x.FitsFloat64Exactly(); got != test.fits {
+			t.Fatalf("#%d: FitsFloat64Exactly(%s): wanted %t, got %t", i, test.x, test.fits, got)
+		}
+		if got := x.IsSafeJSNumber(); got != test.safe {
+			t.Fatalf("#%d: IsSafeJSNumber(%s): wanted %t, got %t", i, test.x, test.safe, got)
+		}
+		if x.Context.Conditions != 0 {
+			t.Fatalf("#%d: IsSafeJSNumber(%s): raised %s", i, test.x, x.Context.Conditions)
+		}
+	}
+
+	x := decimal.New(12345, 2)
+	if n := testing.AllocsPerRun(100, func() { x.IsSafeJSNumber() }); n != 0 {
+		t.Fatalf("IsSafeJSNumber: wanted 0 allocations, got %g", n)
+	}
+}
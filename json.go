@@ -0,0 +1,278 @@
+package decimal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// maxSafeInteger is the largest integer a JavaScript Number (i.e., a float64)
+// can represent such that it and every smaller integer are exact.
+const maxSafeInteger = 1<<53 - 1
+
+// CanonicalJSON returns x encoded as JSON in a single canonical form, such
+// that two decimals with the same value always produce identical bytes.
+//
+// The coefficient is reduced (trailing zeros are removed) and the result is
+// formatted like String, except that the exponent is written with a lower-case
+// 'e' and no '+' sign. For example, 1.500 is encoded as 1.5 and 1200 as 1.2e3.
+// Zeros are encoded as 0, regardless of their sign or exponent.
+//
+// Values a JavaScript Number cannot hold without loss are encoded as JSON
+// strings: those with more than 15 significant digits (unless they are
+// integers no larger than 2^53-1 in magnitude), and those with an adjusted
+// exponent outside [-307, 308]. NaN and infinite values are always encoded as
+// the strings "NaN", "sNaN", "Infinity", and "-Infinity". A nil x is encoded as
+// null.
+//
+// Unlike MarshalText, which preserves the exponent of x, CanonicalJSON is
+// intended for uses that require re-encoding a decoded value to produce
+// identical bytes. See CheckCanonical.
+func (x *Big) CanonicalJSON() []byte {
+	if x == nil {
+		return []byte("null")
+	}
+	if debug {
+		x.validate()
+	}
+
+	switch {
+	case x.IsNaN(0):
+		if x.IsNaN(-1) {
+			return []byte(`"sNaN"`)
+		}
+		return []byte(`"NaN"`)
+	case x.IsInf(+1):
+		return []byte(`"Infinity"`)
+	case x.IsInf(-1):
+		return []byte(`"-Infinity"`)
+	case x.compact == 0:
+		return []byte("0")
+	}
+
+	var r Big
+	Context{Precision: UnlimitedPrecision}.simpleReduce(r.Copy(x))
+
+	b := make([]byte, 0, r.Precision()+16)
+	b = append(b, '"')
+	if r.Signbit() {
+		b = append(b, '-')
+	}
+	start := len(b)
+	if r.isCompact() {
+		b = strconv.AppendUint(b, r.compact, 10)
+	} else {
+		b = r.unscaled.Append(b, 10)
+	}
+	digits := len(b) - start
+	adj := r.exp + digits - 1
+
+	switch {
+	case r.exp == 0:
+		// Integer.
+	case r.exp < 0 && adj >= -6:
+		if adj >= 0 {
+			// ddd.ddd
+			b = append(b, 0)
+			copy(b[start+adj+2:], b[start+adj+1:])
+			b[start+adj+1] = '.'
+		} else {
+			// 0.000ddd
+			n := -adj + 1
+			b = append(b, make([]byte, n)...)
+			copy(b[start+n:], b[start:start+digits])
+			b[start] = '0'
+			b[start+1] = '.'
+			for i := start + 2; i < start+n; i++ {
+				b[i] = '0'
+			}
+		}
+	default:
+		// d.ddde±n
+		if digits > 1 {
+			b = append(b, 0)
+			copy(b[start+2:], b[start+1:])
+			b[start+1] = '.'
+		}
+		b = append(b, 'e')
+		b = strconv.AppendInt(b, int64(adj), 10)
+	}
+
+	safe := adj >= -307 && adj <= 308 &&
+		(digits <= 15 || (r.exp == 0 && r.isCompact() && r.compact <= maxSafeInteger))
+	if safe {
+		return b[1:]
+	}
+	return append(b, '"')
+}
+
+// CheckCanonical returns nil if b is the canonical JSON encoding of a decimal
+// as produced by CanonicalJSON. Otherwise, it returns an error describing how
+// b differs from its canonical form.
+func CheckCanonical(b []byte) error {
+	var x Big
+	if err := x.UnmarshalJSON(b); err != nil {
+		return fmt.Errorf("decimal: invalid JSON decimal %q: %v", b, err)
+	}
+	if x.Context.Conditions&ConversionSyntax != 0 || bytes.Equal(b, []byte("null")) {
+		return fmt.Errorf("decimal: invalid JSON decimal %q", b)
+	}
+	if c := x.CanonicalJSON(); !bytes.Equal(b, c) {
+		return fmt.Errorf("decimal: %s is not canonical: want %s", b, c)
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. A finite x is encoded as a JSON
+// number containing its MarshalText encoding, except that an x with a negative
+// scale is written positionally, as by Expanded, so that 12E+3 and 12000 are
+// both encoded as 12000. Since a JavaScript client decodes a JSON number as a
+// float64, setting x's Context.QuoteUnsafeJSON encodes values that might not
+// survive the conversion, those with more than 15 significant digits or an
+// adjusted exponent outside [-21, 21], as JSON strings instead.
+//
+// NaN and infinite values, which JSON numbers cannot represent, are encoded as
+// JSON strings, or as null under SpecialsNull. If x has a tag and its
+// Context's TagPolicy has JSON set, the encoding is wrapped in a JSON object
+// along with the tag; see TagPolicy.
+func (x *Big) MarshalJSON() ([]byte, error) {
+	if x == nil {
+		return nil, ErrNilOperand{Op: "MarshalJSON"}
+	}
+	b, err := x.marshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	if p := x.Context.Tags(); p != nil && p.JSON {
+		if tag := x.tag(); tag != nil {
+			return json.Marshal(taggedJSON{Value: b, Tag: tag})
+		}
+	}
+	return b, nil
+}
+
+// marshalJSON returns the JSON encoding of x, ignoring its tag.
+func (x *Big) marshalJSON() ([]byte, error) {
+	if x.isSpecial() {
+		s, null, err := x.marshalSpecial("MarshalJSON")
+		if err != nil {
+			return nil, err
+		}
+		if null {
+			return []byte("null"), nil
+		}
+		return []byte(`"` + s + `"`), nil
+	}
+
+	var (
+		b  []byte
+		ok bool
+	)
+	if x.exp > 0 {
+		b, ok = x.appendExpanded(nil)
+	}
+	if !ok {
+		b, _ = x.MarshalText()
+	}
+	if x.Context.QuoteUnsafeJSON && !x.jsonSafe() {
+		// The encoding contains no characters that need to be escaped.
+		return append(append([]byte{'"'}, b...), '"'), nil
+	}
+	return b, nil
+}
+
+// jsonSafe reports whether the finite x survives a round trip through a
+// float64 as formatted by JavaScript. See MarshalJSON.
+func (x *Big) jsonSafe() bool {
+	if x.compact == 0 {
+		return true
+	}
+	adj := x.adjusted()
+	return x.Precision() <= 15 && adj >= -21 && adj <= 21
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts either a JSON number
+// or a JSON string containing any of the formats accepted by SetString, and
+// returns an error wrapping ConversionSyntax for anything else, such as
+// "1.2.3". It also accepts the object encoding of a tagged decimal produced by
+// MarshalJSON, regardless of whether its TagPolicy has JSON set, and sets z's
+// tag to the tag decoded as by encoding/json into an interface{} value.
+//
+// The number is parsed directly from data, never by way of a float64, so every
+// digit is kept: e.g., 0.30000000000000004 and a 40-digit number are decoded
+// exactly. z is not rounded.
+//
+// null leaves z unchanged and is not an error, following encoding/json's
+// convention for values that cannot be null, such as a Big struct field. (A
+// *Big field is set to nil by encoding/json without calling UnmarshalJSON.)
+func (z *Big) UnmarshalJSON(data []byte) error {
+	if z == nil {
+		return ErrNilOperand{Op: "UnmarshalJSON"}
+	}
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '{' {
+		return z.unmarshalTaggedJSON(data)
+	}
+	text := data
+	if n := len(text); n >= 2 && text[0] == '"' && text[n-1] == '"' {
+		text = text[1 : n-1]
+	}
+	return z.scanJSON(text, data)
+}
+
+// FromJSONNumber returns a new Big set to the value of n, as decoded by a
+// json.Decoder with UseNumber. Like UnmarshalJSON, it parses n directly, so
+// every digit is kept. It returns an error wrapping ConversionSyntax if n is
+// not in one of the formats accepted by SetString.
+func FromJSONNumber(n json.Number) (*Big, error) {
+	z := new(Big)
+	if err := z.scanJSON([]byte(n), []byte(n)); err != nil {
+		return nil, err
+	}
+	return z, nil
+}
+
+// scanJSON sets z to the value of text, which is data without its quotes, if
+// any, and returns an error if text is invalid.
+func (z *Big) scanJSON(text, data []byte) error {
+	// Only report a ConversionSyntax raised by this call.
+	prev := z.Context.Conditions & ConversionSyntax
+	z.Context.Conditions &^= ConversionSyntax
+	err := z.scanBytes(text, z.Context, new(bytes.Reader))
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		// E.g., "" or "-".
+		z.Context.Conditions |= ConversionSyntax
+	}
+	bad := z.Context.Conditions&ConversionSyntax != 0
+	z.Context.Conditions |= prev
+	if _, limit := err.(ErrParseLimit); bad && !limit {
+		err = fmt.Errorf("decimal: invalid JSON decimal %s: %w", data, ConversionSyntax)
+	}
+	return err
+}
+
+// taggedJSON is the JSON encoding of a decimal with a tag.
+type taggedJSON struct {
+	Value json.RawMessage `json:"value"`
+	Tag   interface{}     `json:"tag"`
+}
+
+// unmarshalTaggedJSON sets z to the decimal and tag encoded in data.
+func (z *Big) unmarshalTaggedJSON(data []byte) error {
+	var t taggedJSON
+	if err := json.Unmarshal(data, &t); err != nil {
+		return err
+	}
+	if t.Value == nil || t.Value[0] == '{' {
+		return fmt.Errorf("decimal: invalid tagged JSON decimal %s", data)
+	}
+	if err := z.UnmarshalJSON(t.Value); err != nil {
+		return err
+	}
+	z.setTag(t.Tag)
+	return nil
+}
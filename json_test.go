@@ -0,0 +1,278 @@
+package decimal_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestBig_CanonicalJSON(t *testing.T) {
+	for i, test := range [...]struct {
+		in, out string
+	}{
+		{"0", "0"},
+		{"-0.000", "0"},
+		{"0E+10", "0"},
+		{"1", "1"},
+		{"-1.500", "-1.5"},
+		{"1200", "1.2e3"},
+		{"1.2E+3", "1.2e3"},
+		{"12.34", "12.34"},
+		{"0.00012", "0.00012"},
+		{"0.000000012", "1.2e-8"},
+		{"123456789012345", "123456789012345"},
+		{"9007199254740991", "9007199254740991"},
+		{"9007199254740992", `"9007199254740992"`},
+		{"0.1234567890123456", `"0.1234567890123456"`},
+		{"1e308", "1e308"},
+		{"1e309", `"1e309"`},
+		{"-1e-307", "-1e-307"},
+		{"1e-308", `"1e-308"`},
+		{"123456789012345678901234567890", `"1.2345678901234567890123456789e29"`},
+		{"NaN", `"NaN"`},
+		{"-NaN123", `"NaN"`},
+		{"sNaN", `"sNaN"`},
+		{"Inf", `"Infinity"`},
+		{"-Inf", `"-Infinity"`},
+	} {
+		x, ok := new(decimal.Big).SetString(test.in)
+		if !ok {
+			t.Fatalf("#%d: invalid input %q", i, test.in)
+		}
+		if got := string(x.CanonicalJSON()); got != test.out {
+			t.Fatalf("#%d: CanonicalJSON(%s): wanted %s, got %s", i, test.in, test.out, got)
+		}
+		if err := decimal.CheckCanonical([]byte(test.out)); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+	}
+	if got := string((*decimal.Big)(nil).CanonicalJSON()); got != "null" {
+		t.Fatalf("nil: wanted null, got %s", got)
+	}
+}
+
+func TestCheckCanonical(t *testing.T) {
+	for _, s := range []string{
+		"1.50", "1.2E3", "1.2e+3", "1200", `"1.5"`, "-0", "0.0", `"NaN1"`,
+		"01", "null", "true", `"abc"`, "", `"9007199254740991"`,
+	} {
+		if err := decimal.CheckCanonical([]byte(s)); err == nil {
+			t.Fatalf("%s: wanted an error", s)
+		}
+	}
+}
+
+func TestBig_UnmarshalJSON(t *testing.T) {
+	var v struct {
+		A, B, C *decimal.Big
+	}
+	if err := json.Unmarshal([]byte(`{"A": 1.25e2, "B": "-0.5", "C": null}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.A.String() != "125" || v.B.String() != "-0.5" || v.C != nil {
+		t.Fatalf("got %s, %s, %v", v.A, v.B, v.C)
+	}
+}
+
+func randJSONDecimal(r *rand.Rand) string {
+	switch r.Intn(20) {
+	case 0:
+		return `"NaN"`
+	case 1:
+		return `"-Infinity"`
+	case 2:
+		return "0"
+	}
+	var b strings.Builder
+	if r.Intn(2) == 0 {
+		b.WriteByte('-')
+	}
+	n := r.Intn(40) + 1
+	b.WriteByte(byte('1' + r.Intn(9)))
+	for i := 1; i < n; i++ {
+		if r.Intn(4) == 0 {
+			b.WriteByte('0')
+		} else {
+			b.WriteByte(byte('0' + r.Intn(10)))
+		}
+	}
+	if r.Intn(2) == 0 {
+		b.WriteString("e")
+		b.WriteString(strconv.Itoa(r.Intn(700) - 350))
+	}
+	if r.Intn(3) == 0 {
+		return `"` + b.String() + `"`
+	}
+	return b.String()
+}
+
+func TestBig_CanonicalJSONFixedPoint(t *testing.T) {
+	r := rand.New(rand.NewSource(212))
+	for i := 0; i < 10000; i++ {
+		in := randJSONDecimal(r)
+
+		var x, y decimal.Big
+		if err := x.UnmarshalJSON([]byte(in)); err != nil {
+			t.Fatalf("#%d: %s: %v", i, in, err)
+		}
+		c1 := x.CanonicalJSON()
+		if err := y.UnmarshalJSON(c1); err != nil {
+			t.Fatalf("#%d: %s: %v", i, c1, err)
+		}
+		c2 := y.CanonicalJSON()
+		if string(c1) != string(c2) {
+			t.Fatalf("#%d: %s: not a fixed point: %s != %s", i, in, c1, c2)
+		}
+		if err := decimal.CheckCanonical(c1); err != nil {
+			t.Fatalf("#%d: %s: %v", i, in, err)
+		}
+		if x.IsFinite() && x.Cmp(&y) != 0 {
+			t.Fatalf("#%d: %s: value changed: %s != %s", i, in, &x, &y)
+		}
+	}
+}
+
+func TestBig_MarshalJSON_NegativeScale(t *testing.T) {
+	for i, test := range [...]struct {
+		in, out string
+	}{
+		{"12000", "12000"},
+		{"12E+3", "12000"},
+		{"1.2E+4", "12000"},
+		{"-12E+3", "-12000"},
+		{"0E+3", "0"},
+		{"1.50", "1.50"},
+		{"1E-8", "1E-8"},
+		{"1E+10000", "1E+10000"},
+	} {
+		x, _ := new(decimal.Big).SetString(test.in)
+		b, err := json.Marshal(x)
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if string(b) != test.out {
+			t.Fatalf("#%d: MarshalJSON(%s): wanted %s, got %s", i, test.in, test.out, b)
+		}
+		var y decimal.Big
+		if err := json.Unmarshal(b, &y); err != nil || y.Cmp(x) != 0 {
+			t.Fatalf("#%d: round trip of %s: got %s (%v)", i, b, &y, err)
+		}
+	}
+}
+
+func TestBig_MarshalJSON_QuoteUnsafe(t *testing.T) {
+	for i, test := range [...]struct {
+		in, out string
+	}{
+		{"12.34", "12.34"},
+		{"-0", "-0"},
+		{"0E-50", "0E-50"},
+		{"123456789012345", "123456789012345"},
+		{"1234567890123456", `"1234567890123456"`},
+		{"0.1000000000000000", `"0.1000000000000000"`},
+		{"1E+21", "1000000000000000000000"},
+		{"1E+22", `"10000000000000000000000"`},
+		{"1E-21", "1E-21"},
+		{"-1.5E-22", `"-1.5E-22"`},
+		{"NaN", `"NaN"`},
+	} {
+		x, _ := new(decimal.Big).SetString(test.in)
+		b, err := json.Marshal(x)
+		if err != nil || !json.Valid(b) {
+			t.Fatalf("#%d: MarshalJSON(%s): %s (%v)", i, test.in, b, err)
+		}
+		if want := strings.Trim(test.out, `"`); string(b) != want && x.IsFinite() {
+			t.Fatalf("#%d: MarshalJSON(%s): wanted %s, got %s", i, test.in, want, b)
+		}
+
+		x.Context.QuoteUnsafeJSON = true
+		if b, _ = json.Marshal(x); string(b) != test.out {
+			t.Fatalf("#%d: MarshalJSON(%s) (QuoteUnsafeJSON): wanted %s, got %s", i, test.in, test.out, b)
+		}
+		var y decimal.Big
+		if err := json.Unmarshal(b, &y); err != nil || (x.IsFinite() && y.Cmp(x) != 0) {
+			t.Fatalf("#%d: round trip of %s: got %s (%v)", i, b, &y, err)
+		}
+	}
+}
+
+func TestBig_UnmarshalJSON_Invalid(t *testing.T) {
+	for _, s := range [...]string{`"1.2.3"`, "1.2.3", `"abc"`, `""`, `"-"`, `"1e"`} {
+		var z decimal.Big
+		err := z.UnmarshalJSON([]byte(s))
+		if !errors.Is(err, decimal.ConversionSyntax) {
+			t.Fatalf("%s: wanted an error wrapping ConversionSyntax, got %v", s, err)
+		}
+		if z.Context.Conditions&decimal.ConversionSyntax == 0 {
+			t.Fatalf("%s: wanted ConversionSyntax, got %s", s, z.Context.Conditions)
+		}
+	}
+
+	// A ConversionSyntax raised earlier is not reported again.
+	var z decimal.Big
+	z.Context.Conditions = decimal.ConversionSyntax
+	if err := z.UnmarshalJSON([]byte("1.5")); err != nil || z.String() != "1.5" {
+		t.Fatalf("wanted 1.5, got %s (%v)", &z, err)
+	}
+
+	// null leaves a non-pointer Big unchanged, and sets a *Big to nil.
+	v := struct {
+		A decimal.Big
+		B *decimal.Big
+	}{B: decimal.New(1, 0)}
+	v.A.SetMantScale(25, 1)
+	if err := json.Unmarshal([]byte(`{"A": null, "B": null}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.A.String() != "2.5" || v.B != nil {
+		t.Fatalf("wanted 2.5 and nil, got %s and %v", &v.A, v.B)
+	}
+}
+
+func TestBig_JSON_Lossless(t *testing.T) {
+	const in = `{"A":0.30000000000000004,"B":12345678901234567890123456789012345678901234567890,"C":-1.2345678901234567890123456789012345678901234567890E-99}`
+	var v struct {
+		A, B, C *decimal.Big
+	}
+	if err := json.Unmarshal([]byte(in), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.A.String() != "0.30000000000000004" || v.B.Precision() != 50 || v.C.Precision() != 50 {
+		t.Fatalf("got %s, %s, %s", v.A, v.B, v.C)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != in {
+		t.Fatalf("round trip: wanted %s, got %s", in, b)
+	}
+
+	dec := json.NewDecoder(strings.NewReader(in))
+	dec.UseNumber()
+	var m map[string]interface{}
+	if err := dec.Decode(&m); err != nil {
+		t.Fatal(err)
+	}
+	for _, k := range []string{"A", "B", "C"} {
+		x, err := decimal.FromJSONNumber(m[k].(json.Number))
+		if err != nil {
+			t.Fatalf("FromJSONNumber(%s): %v", m[k], err)
+		}
+		want := map[string]*decimal.Big{"A": v.A, "B": v.B, "C": v.C}[k]
+		if x.String() != want.String() {
+			t.Fatalf("FromJSONNumber(%s): wanted %s, got %s", m[k], want, x)
+		}
+	}
+
+	for _, s := range []json.Number{"", "1.2.3", "abc"} {
+		if x, err := decimal.FromJSONNumber(s); !errors.Is(err, decimal.ConversionSyntax) || x != nil {
+			t.Fatalf("FromJSONNumber(%q): wanted an error, got %v (%v)", s, x, err)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package decimal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// jsRoundingModes are the names of decimal.js's rounding modes, indexed by
+// their numbers, and the RoundingModes they correspond to. ROUND_HALF_DOWN,
+// ROUND_HALF_CEIL, and ROUND_HALF_FLOOR have no RoundingMode, so their mode is
+// unnecessary.
+var jsRoundingModes = [...]struct {
+	name string
+	mode RoundingMode
+}{
+	{"ROUND_UP", AwayFromZero},
+	{"ROUND_DOWN", ToZero},
+	{"ROUND_CEIL", ToPositiveInf},
+	{"ROUND_FLOOR", ToNegativeInf},
+	{"ROUND_HALF_UP", ToNearestAway},
+	{"ROUND_HALF_DOWN", unnecessary},
+	{"ROUND_HALF_EVEN", ToNearestEven},
+	{"ROUND_HALF_CEIL", unnecessary},
+	{"ROUND_HALF_FLOOR", unnecessary},
+}
+
+// JS returns the number of the decimal.js rounding mode equivalent to m, such
+// as 4 (Decimal.ROUND_HALF_UP) for ToNearestAway. ok is false if m is not a
+// valid RoundingMode.
+func (m RoundingMode) JS() (n int, ok bool) {
+	for i, r := range jsRoundingModes {
+		if r.mode == m && m != unnecessary {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// JSName is like JS, but returns the name of the decimal.js rounding mode,
+// such as "ROUND_HALF_UP", instead of its number.
+func (m RoundingMode) JSName() (name string, ok bool) {
+	n, ok := m.JS()
+	if !ok {
+		return "", false
+	}
+	return jsRoundingModes[n].name, true
+}
+
+// RoundingModeFromJS returns the RoundingMode equivalent to the decimal.js
+// rounding mode with the number n, in [0, 8]. For example, 6
+// (Decimal.ROUND_HALF_EVEN) is ToNearestEven.
+//
+// RoundingModeFromJS returns an error if n is not a decimal.js rounding mode
+// or is one with no equivalent RoundingMode: 5 (ROUND_HALF_DOWN), 7
+// (ROUND_HALF_CEIL), or 8 (ROUND_HALF_FLOOR).
+func RoundingModeFromJS(n int) (RoundingMode, error) {
+	if n < 0 || n >= len(jsRoundingModes) {
+		return 0, fmt.Errorf("decimal: invalid decimal.js rounding mode: %d", n)
+	}
+	return jsRoundingMode(n)
+}
+
+// RoundingModeFromJSName is like RoundingModeFromJS, but accepts the name of
+// the decimal.js rounding mode, such as "ROUND_HALF_EVEN". The name may be
+// prefixed with "Decimal.", as in "Decimal.ROUND_HALF_EVEN", and is case
+// sensitive.
+func RoundingModeFromJSName(name string) (RoundingMode, error) {
+	s := strings.TrimPrefix(name, "Decimal.")
+	for n, r := range jsRoundingModes {
+		if r.name == s {
+			return jsRoundingMode(n)
+		}
+	}
+	return 0, fmt.Errorf("decimal: invalid decimal.js rounding mode: %q", name)
+}
+
+// jsRoundingMode returns the RoundingMode for the decimal.js rounding mode n,
+// which must be in range.
+func jsRoundingMode(n int) (RoundingMode, error) {
+	r := jsRoundingModes[n]
+	if r.mode == unnecessary {
+		return 0, fmt.Errorf("decimal: decimal.js rounding mode %s (%d) is not supported", r.name, n)
+	}
+	return r.mode, nil
+}
@@ -0,0 +1,57 @@
+package decimal_test
+
+import (
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestRoundingMode_JS(t *testing.T) {
+	for i, test := range [...]struct {
+		mode decimal.RoundingMode
+		n    int
+		name string
+	}{
+		{decimal.AwayFromZero, 0, "ROUND_UP"},
+		{decimal.ToZero, 1, "ROUND_DOWN"},
+		{decimal.ToPositiveInf, 2, "ROUND_CEIL"},
+		{decimal.ToNegativeInf, 3, "ROUND_FLOOR"},
+		{decimal.ToNearestAway, 4, "ROUND_HALF_UP"},
+		{decimal.ToNearestEven, 6, "ROUND_HALF_EVEN"},
+	} {
+		if n, ok := test.mode.JS(); !ok || n != test.n {
+			t.Fatalf("#%d: %s.JS(): wanted (%d, true), got (%d, %t)", i, test.mode, test.n, n, ok)
+		}
+		if name, ok := test.mode.JSName(); !ok || name != test.name {
+			t.Fatalf("#%d: %s.JSName(): wanted (%s, true), got (%q, %t)", i, test.mode, test.name, name, ok)
+		}
+		if m, err := decimal.RoundingModeFromJS(test.n); err != nil || m != test.mode {
+			t.Fatalf("#%d: RoundingModeFromJS(%d): wanted %s, got %s (%v)", i, test.n, test.mode, m, err)
+		}
+		for _, name := range []string{test.name, "Decimal." + test.name} {
+			if m, err := decimal.RoundingModeFromJSName(name); err != nil || m != test.mode {
+				t.Fatalf("#%d: RoundingModeFromJSName(%q): wanted %s, got %s (%v)", i, name, test.mode, m, err)
+			}
+		}
+	}
+
+	m := decimal.RoundingMode(100)
+	if n, ok := m.JS(); ok {
+		t.Fatalf("%s.JS(): wanted false, got (%d, true)", m, n)
+	}
+	if name, ok := m.JSName(); ok {
+		t.Fatalf("%s.JSName(): wanted false, got (%q, true)", m, name)
+	}
+
+	// Out of range, or no equivalent RoundingMode.
+	for _, n := range []int{-1, 5, 7, 8, 9} {
+		if m, err := decimal.RoundingModeFromJS(n); err == nil {
+			t.Fatalf("RoundingModeFromJS(%d): wanted an error, got %s", n, m)
+		}
+	}
+	for _, name := range []string{"", "ROUND_HALF_DOWN", "ROUND_HALF_CEIL", "ROUND_HALF_FLOOR", "round_up", "EUCLID"} {
+		if m, err := decimal.RoundingModeFromJSName(name); err == nil {
+			t.Fatalf("RoundingModeFromJSName(%q): wanted an error, got %s", name, m)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package decimal
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"syscall/js"
+)
+
+// jsTag is Object.prototype.toString, which identifies the type of any
+// JavaScript value, and jsString is String, which converts any JavaScript value
+// to a string. Value.Type and Value.Call cannot be used since they panic for
+// BigInts.
+var (
+	jsTag    = js.Global().Get("Object").Get("prototype").Get("toString")
+	jsString = js.Global().Get("String")
+)
+
+// JSValue returns x as a JavaScript value for use with package syscall/js.
+//
+// A finite x is returned as a Number if JavaScript formats the Number as the
+// value of x: that is, if x has at most 15 significant digits and a magnitude
+// in [1E-307, 1E+308), or if x is an integer whose magnitude is at most 2^53.
+// Otherwise, it is returned as a string, encoded as by MarshalText. Either
+// way, the exponent of x is not preserved; e.g., 1.50 becomes 1.5. Nor is the
+// sign of zero, which syscall/js does not preserve.
+//
+// NaN and infinite values are returned according to x's SpecialsPolicy, like
+// MarshalJSON: as the Numbers NaN, Infinity, and -Infinity by SpecialsDefault
+// and SpecialsJS, as strings by SpecialsGDA, and as null by SpecialsNull.
+// SpecialsError returns undefined.
+func (x *Big) JSValue() js.Value {
+	mustNotNil("JSValue", x, x)
+	if x.isSpecial() {
+		switch x.Context.Specials {
+		case SpecialsNull:
+			return js.Null()
+		case SpecialsError:
+			return js.Undefined()
+		case SpecialsGDA:
+			s, _, _ := x.marshalSpecial("JSValue")
+			return js.ValueOf(s)
+		}
+		if x.IsNaN(0) {
+			return js.ValueOf(math.NaN())
+		}
+		return js.ValueOf(math.Inf(x.Sign()))
+	}
+	if f, ok := x.jsNumber(); ok {
+		return js.ValueOf(f)
+	}
+	b, _ := x.MarshalText()
+	return js.ValueOf(string(b))
+}
+
+// jsNumber returns the finite x as a float64 and reports whether the float64
+// is formatted as the value of x.
+func (x *Big) jsNumber() (float64, bool) {
+	if x.compact == 0 {
+		return 0, true
+	}
+	if x.isCompact() && x.exp == 0 && x.compact <= 1<<53 {
+		f := float64(x.compact)
+		if x.Signbit() {
+			f = -f
+		}
+		return f, true
+	}
+	// A decimal with at most 15 significant digits is recovered when the
+	// nearest float64 is formatted with the fewest digits that round to it.
+	if adj := x.adjusted(); x.Precision() > 15 || adj < -307 || adj > 307 {
+		return 0, false
+	}
+	f, _ := x.Float64()
+	return f, true
+}
+
+// SetJSValue sets z to the value of v, which must be a JavaScript Number,
+// string, or BigInt (or a Number or String object), and returns an error if
+// it is not or if the string is not in a format accepted by SetString. Strings
+// and BigInts are parsed as by SetString.
+//
+// A Number is converted exactly and then rounded using z's Context, raising
+// Inexact if its binary value cannot be represented exactly. For example,
+// under the default precision of 16 digits, the Number 0.1 is set to
+// 0.1000000000000000 (inexact, rounded), since the Number is actually
+// 0.1000000000000000055511151231257827021181583404541015625. Convert the
+// Number to a string in JavaScript to use its shortest decimal form instead.
+func (z *Big) SetJSValue(v js.Value) error {
+	mustNotNil("SetJSValue", z, z)
+	tag := jsTag.Call("call", v).String()
+	switch tag {
+	case "[object Number]":
+		if v.Type() != js.TypeNumber {
+			v = v.Call("valueOf")
+		}
+		z.Context.Round(z.SetFloat64Exact(v.Float()))
+		return nil
+	case "[object String]", "[object BigInt]":
+		s := jsString.Invoke(v).String()
+		if err := z.scanBytes([]byte(s), z.Context, new(bytes.Reader)); err != nil {
+			return err
+		}
+		if z.Context.Conditions&ConversionSyntax != 0 {
+			return errors.New("decimal: invalid JS decimal " + s)
+		}
+		return nil
+	default:
+		return errors.New("decimal: SetJSValue: cannot convert " + tag)
+	}
+}
@@ -0,0 +1,90 @@
+package decimal_test
+
+import (
+	"math"
+	"syscall/js"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestBig_JSValue(t *testing.T) {
+	for i, test := range [...]struct {
+		x        string
+		specials decimal.SpecialsPolicy
+		want     interface{} // float64, string, or nil for null
+	}{
+		{"1.50", 0, 1.5},
+		{"-0", 0, 0.0},
+		{"0.1", 0, 0.1},
+		{"123456789012345", 0, 123456789012345.0},
+		{"9007199254740992", 0, 9007199254740992.0},
+		{"9007199254740993", 0, "9007199254740993"},
+		{"0.1234567890123456", 0, "0.1234567890123456"},
+		{"1E+400", 0, "1E+400"},
+		{"1.5E-308", 0, "1.5E-308"},
+		{"1.5E+300", 0, 1.5e300},
+		{"-Infinity", 0, math.Inf(-1)},
+		{"NaN", decimal.SpecialsJS, math.NaN()},
+		{"-Infinity", decimal.SpecialsGDA, "-Infinity"},
+		{"NaN", decimal.SpecialsNull, nil},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		x.Context.Specials = test.specials
+		v := x.JSValue()
+		switch want := test.want.(type) {
+		case float64:
+			if v.Type() != js.TypeNumber {
+				t.Fatalf("#%d: JSValue(%s): wanted a Number, got %s", i, test.x, v.Type())
+			}
+			got := v.Float()
+			if got != want && !(math.IsNaN(got) && math.IsNaN(want)) ||
+				math.Signbit(got) != math.Signbit(want) {
+				t.Fatalf("#%d: JSValue(%s): wanted %g, got %g", i, test.x, want, got)
+			}
+		case string:
+			if v.Type() != js.TypeString || v.String() != want {
+				t.Fatalf("#%d: JSValue(%s): wanted %q, got %s", i, test.x, want, v)
+			}
+		default:
+			if !v.IsNull() {
+				t.Fatalf("#%d: JSValue(%s): wanted null, got %s", i, test.x, v)
+			}
+		}
+	}
+}
+
+func TestBig_SetJSValue(t *testing.T) {
+	bigInt := js.Global().Get("BigInt")
+	for i, test := range [...]struct {
+		v     js.Value
+		prec  int
+		want  string
+		conds decimal.Condition
+	}{
+		{js.ValueOf(1.5), 0, "1.5", 0},
+		{js.ValueOf(0.1), 0, "0.1000000000000000", decimal.Inexact | decimal.Rounded},
+		{js.ValueOf(0.1), 60, "0.1000000000000000055511151231257827021181583404541015625", 0},
+		{js.ValueOf(math.Inf(-1)), 0, "-Infinity", 0},
+		{js.ValueOf("12.50"), 0, "12.50", 0},
+		{js.ValueOf("1.23456"), 3, "1.23456", 0},
+		{bigInt.Invoke("123456789012345678901234567890"), 0, "123456789012345678901234567890", 0},
+		{bigInt.Invoke(-5), 0, "-5", 0},
+		{js.Global().Get("Number").New(2.5), 0, "2.5", 0},
+	} {
+		z := decimal.WithPrecision(test.prec)
+		if err := z.SetJSValue(test.v); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if z.String() != test.want || z.Context.Conditions != test.conds {
+			t.Fatalf("#%d: SetJSValue(%s): wanted %s (%s), got %s (%s)",
+				i, test.v, test.want, test.conds, z, z.Context.Conditions)
+		}
+	}
+
+	for i, v := range []js.Value{js.ValueOf("12.5x"), js.ValueOf(true), js.Null(), js.Undefined(), js.Global()} {
+		if err := new(decimal.Big).SetJSValue(v); err == nil {
+			t.Fatalf("#%d: SetJSValue(%s): wanted an error", i, v)
+		}
+	}
+}
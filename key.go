@@ -0,0 +1,134 @@
+package decimal
+
+import (
+	"bytes"
+	"encoding"
+	"errors"
+	"sort"
+	"strings"
+)
+
+// Key is a comparable representation of a decimal value, suitable for use as
+// a map key. Two Keys are equal (==) if and only if the decimals they were
+// created from have the same numeric value, regardless of their exponents: the
+// Keys of 2.5 and 2.50 are identical. The zero value is the Key of 0.
+//
+// A Key implements encoding.TextMarshaler and encoding.TextUnmarshaler, so a
+// map[Key]V can be encoded to and decoded from a JSON object. Keys are encoded
+// in the canonical form produced by CanonicalJSON, without quotes (e.g., 2.5,
+// 1.2e3, or -Infinity), and any of the formats accepted by SetString are
+// decoded. Because decoding canonicalizes, the object keys "2.5" and "2.50"
+// decode to the same Key.
+//
+// encoding/json writes map keys in the lexical order of their encodings, which
+// is not numerical order. Use Cmp or SortKeys to order Keys numerically.
+type Key struct {
+	s     string // canonical form, or "" for zero
+	mant  int64  // reduced coefficient, if !big
+	scale int    // scale of mant
+	big   bool   // the value does not fit in mant and scale
+}
+
+// NewKey returns the Key of x. It returns an error if x is nil or NaN, since
+// NaN is not equal to itself.
+func NewKey(x *Big) (Key, error) {
+	if x == nil {
+		return Key{}, ErrNilOperand{Op: "NewKey"}
+	}
+	if x.IsNaN(0) {
+		return Key{}, errors.New("decimal: NaN cannot be a Key")
+	}
+	if x.IsFinite() && x.compact == 0 {
+		return Key{}, nil
+	}
+
+	k := Key{s: strings.Trim(string(x.CanonicalJSON()), `"`)}
+	if x.IsFinite() {
+		var r Big
+		Context{Precision: UnlimitedPrecision}.simpleReduce(r.Copy(x))
+		var ok bool
+		k.mant, k.scale, ok = r.MantScale()
+		k.big = !ok
+	} else {
+		k.big = true
+	}
+	if k.big {
+		k.mant, k.scale = 0, 0
+	}
+	return k, nil
+}
+
+// Big sets z to the value of k and returns z. If z is nil a new Big is
+// allocated. The result is exact; it is not rounded to z's Context.
+func (k Key) Big(z *Big) *Big {
+	if z == nil {
+		z = new(Big)
+	}
+	if !k.big {
+		return z.SetMantScale(k.mant, k.scale)
+	}
+	z.scan(strings.NewReader(k.s))
+	return z
+}
+
+// Cmp compares k and o numerically and returns:
+//
+//	-1 if k <  o
+//	 0 if k == o
+//	+1 if k >  o
+func (k Key) Cmp(o Key) int {
+	if !k.big && !o.big && k.scale == o.scale {
+		switch {
+		case k.mant < o.mant:
+			return -1
+		case k.mant > o.mant:
+			return +1
+		default:
+			return 0
+		}
+	}
+	var x, y Big
+	return k.Big(&x).Cmp(o.Big(&y))
+}
+
+// SortKeys sorts keys in increasing numerical order.
+func SortKeys(keys []Key) {
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Cmp(keys[j]) < 0 })
+}
+
+// String returns k in its canonical form.
+func (k Key) String() string {
+	if k.s == "" {
+		return "0"
+	}
+	return k.s
+}
+
+// MarshalText implements encoding.TextMarshaler. k is encoded in its
+// canonical form.
+func (k Key) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts any of the
+// formats accepted by SetString, other than NaN.
+func (k *Key) UnmarshalText(data []byte) error {
+	var x Big
+	if err := x.scanBytes(data, x.Context, new(bytes.Reader)); err != nil {
+		return err
+	}
+	if x.Context.Conditions&ConversionSyntax != 0 {
+		return errors.New("decimal: invalid Key " + string(data))
+	}
+	v, err := NewKey(&x)
+	if err != nil {
+		return err
+	}
+	*k = v
+	return nil
+}
+
+var (
+	_ encoding.TextMarshaler   = Key{}
+	_ encoding.TextUnmarshaler = (*Key)(nil)
+)
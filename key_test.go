@@ -0,0 +1,112 @@
+package decimal_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func mustKey(t *testing.T, s string) decimal.Key {
+	x, ok := new(decimal.Big).SetString(s)
+	if !ok {
+		t.Fatalf("invalid input %q", s)
+	}
+	k, err := decimal.NewKey(x)
+	if err != nil {
+		t.Fatalf("NewKey(%s): %v", s, err)
+	}
+	return k
+}
+
+func TestKey(t *testing.T) {
+	for i, test := range [...]struct {
+		in, out string
+	}{
+		{"0", "0"},
+		{"-0.00", "0"},
+		{"2.50", "2.5"},
+		{"1200", "1.2e3"},
+		{"-0.0000001", "-1e-7"},
+		{"123456789012345678901234567890.10", "123456789012345678901234567890.1"},
+		{"Inf", "Infinity"},
+		{"-Inf", "-Infinity"},
+	} {
+		k := mustKey(t, test.in)
+		if k.String() != test.out {
+			t.Fatalf("#%d: NewKey(%s): wanted %s, got %s", i, test.in, test.out, k)
+		}
+		x, _ := new(decimal.Big).SetString(test.in)
+		if y := k.Big(nil); y.Cmp(x) != 0 || y.IsInf(0) != x.IsInf(0) || y.Signbit() != (x.Signbit() && x.Sign() != 0) {
+			t.Fatalf("#%d: Key(%s).Big: got %s", i, test.in, y)
+		}
+		if k2 := mustKey(t, k.String()); k2 != k {
+			t.Fatalf("#%d: %s: not a fixed point: %#v != %#v", i, test.in, k, k2)
+		}
+	}
+
+	if mustKey(t, "2.5") != mustKey(t, "2.50") || mustKey(t, "-0") != (decimal.Key{}) {
+		t.Fatal("equal values must have equal Keys")
+	}
+	if _, err := decimal.NewKey(new(decimal.Big).SetNaN(false)); err == nil {
+		t.Fatal("NewKey(NaN): wanted an error")
+	}
+	if _, err := decimal.NewKey(nil); err == nil {
+		t.Fatal("NewKey(nil): wanted an error")
+	}
+
+	// Big is exact regardless of z's Context.
+	z := decimal.WithPrecision(3)
+	if mustKey(t, "1.23456789").Big(z); z.String() != "1.23456789" {
+		t.Fatalf("Big: wanted 1.23456789, got %s", z)
+	}
+}
+
+func TestSortKeys(t *testing.T) {
+	in := []string{"10", "-Inf", "2.5", "1e100", "-3", "0", "2.49999999999999999999999", "0.001", "Inf", "-0.5"}
+	want := []string{"-Infinity", "-3", "-0.5", "0", "0.001", "2.49999999999999999999999", "2.5", "1e1", "1e100", "Infinity"}
+
+	keys := make([]decimal.Key, len(in))
+	for i, s := range in {
+		keys[i] = mustKey(t, s)
+	}
+	decimal.SortKeys(keys)
+	for i, k := range keys {
+		if k.String() != want[i] {
+			t.Fatalf("#%d: wanted %s, got %s", i, want[i], k)
+		}
+	}
+}
+
+func TestKey_JSON(t *testing.T) {
+	var m map[decimal.Key]string
+	if err := json.Unmarshal([]byte(`{"2.50": "a", "1E+3": "b", "-0.125": "c"}`), &m); err != nil {
+		t.Fatal(err)
+	}
+	if len(m) != 3 || m[mustKey(t, "2.5")] != "a" || m[mustKey(t, "1000")] != "b" || m[mustKey(t, "-0.125")] != "c" {
+		t.Fatalf("got %v", m)
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = `{"-0.125":"c","1e3":"b","2.5":"a"}`
+	if string(b) != want {
+		t.Fatalf("wanted %s, got %s", want, b)
+	}
+
+	// "2.5" and "2.50" are the same key.
+	m = nil
+	if err := json.Unmarshal([]byte(`{"2.5": "a", "2.50": "b"}`), &m); err != nil {
+		t.Fatal(err)
+	}
+	if len(m) != 1 {
+		t.Fatalf("wanted 1 key, got %v", m)
+	}
+
+	for _, s := range []string{`{"abc": "a"}`, `{"NaN": "a"}`, `{"": "a"}`} {
+		if err := json.Unmarshal([]byte(s), &m); err == nil {
+			t.Fatalf("%s: wanted an error", s)
+		}
+	}
+}
@@ -0,0 +1,385 @@
+package decimal
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ParseHints guide ParseLenient.
+type ParseHints struct {
+	// Decimal is the decimal separator, '.' or ','. If zero, it is inferred
+	// from the input: if both appear, the last one is the decimal separator;
+	// if only commas appear, a single comma followed by anything other than
+	// three digits is a decimal comma (1,5 and 1 000,50, but not 1,000); and
+	// if only points appear, more than one point means they separate
+	// thousands (1.000.000).
+	Decimal rune
+
+	// Context is the Context of the returned Big, which is used to parse the
+	// normalized input as by SetString.
+	Context Context
+}
+
+// FixupKind is a kind of normalization applied by ParseLenient.
+type FixupKind uint8
+
+const (
+	// FixupSpace removed white space around the number, or between it and a
+	// sign or currency.
+	FixupSpace FixupKind = iota
+	// FixupCurrency removed a currency symbol, such as $ or €, or a
+	// registered ISO 4217 currency code, such as USD (see
+	// RegisterCurrencyContext).
+	FixupCurrency
+	// FixupThousands removed thousands separators.
+	FixupThousands
+	// FixupDecimalComma replaced a decimal comma with a decimal point.
+	FixupDecimalComma
+)
+
+//go:generate stringer -type FixupKind
+
+// Fixup is a normalization applied by ParseLenient to its input.
+type Fixup struct {
+	Kind   FixupKind
+	Offset int    // byte offset in the input of the (first) affected text
+	Text   string // the text that was removed or replaced
+}
+
+func (f Fixup) String() string {
+	var what string
+	switch f.Kind {
+	case FixupSpace:
+		what = "removed white space"
+	case FixupCurrency:
+		what = "removed currency"
+	case FixupThousands:
+		what = "removed thousands separator"
+	case FixupDecimalComma:
+		what = "replaced decimal comma"
+	default:
+		what = f.Kind.String()
+	}
+	return fmt.Sprintf("%s %q at offset %d", what, f.Text, f.Offset)
+}
+
+// ErrInput is returned by ParseLenient when its input cannot be interpreted
+// as a number.
+type ErrInput struct {
+	Input  string // the input
+	Offset int    // byte offset in Input of the problem
+	Msg    string // description of the problem
+
+	// Suggestion, if non-empty, is a corrected input; e.g., "100" for "1O0".
+	Suggestion string
+}
+
+func (e ErrInput) Error() string {
+	s := fmt.Sprintf("decimal: invalid number %q at offset %d: %s", e.Input, e.Offset, e.Msg)
+	if e.Suggestion != "" {
+		s += fmt.Sprintf(" (did you mean %q?)", e.Suggestion)
+	}
+	return s
+}
+
+// Unwrap returns ConversionSyntax.
+func (e ErrInput) Unwrap() error { return ConversionSyntax }
+
+var _ error = ErrInput{}
+
+// ParseLenient parses a number typed by a person, such as " $1,234.50" or
+// "1 000,50 €", and returns it along with the normalizations that were needed
+// to parse it, in the order of their offsets, which a user interface can use to confirm the interpretation
+// of the input. The number itself may be in any format accepted by SetString,
+// except that its integer part may contain thousands separators (a comma,
+// point, space, apostrophe, or underscore, used consistently and in groups of
+// three digits) and its decimal separator may be a comma; see ParseHints.
+//
+// If s cannot be interpreted unambiguously, the error is an ErrInput, which
+// locates the problem and, for common typos such as the letter O in place of
+// a zero, suggests a correction.
+func ParseLenient(s string, hints ParseHints) (*Big, []Fixup, error) {
+	p := lenientParser{s: s, hints: hints}
+	norm, err := p.parse()
+	if err != nil {
+		return nil, nil, err
+	}
+	z := new(Big)
+	z.Context = hints.Context
+	if _, ok := z.SetString(norm); !ok {
+		if err := z.Context.Err(); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, ErrInput{Input: s, Msg: "not a number"}
+	}
+	sort.SliceStable(p.fixups, func(i, j int) bool {
+		return p.fixups[i].Offset < p.fixups[j].Offset
+	})
+	return z, p.fixups, nil
+}
+
+// lenientParser implements ParseLenient.
+type lenientParser struct {
+	s      string
+	hints  ParseHints
+	fixups []Fixup
+}
+
+func (p *lenientParser) fixup(kind FixupKind, start, end int) {
+	p.fixups = append(p.fixups, Fixup{Kind: kind, Offset: start, Text: p.s[start:end]})
+}
+
+func (p *lenientParser) errorf(off int, format string, args ...interface{}) error {
+	return ErrInput{Input: p.s, Offset: off, Msg: fmt.Sprintf(format, args...)}
+}
+
+// parse returns p.s normalized to a form accepted by SetString.
+func (p *lenientParser) parse() (string, error) {
+	start, end := 0, len(p.s)
+	var sign string
+
+	// Strip signs, currencies, and white space from the start of s.
+prefix:
+	for seen := false; start < end; {
+		r, n := utf8.DecodeRuneInString(p.s[start:end])
+		switch {
+		case unicode.IsSpace(r):
+			for start+n < end {
+				r, m := utf8.DecodeRuneInString(p.s[start+n : end])
+				if !unicode.IsSpace(r) {
+					break
+				}
+				n += m
+			}
+			p.fixup(FixupSpace, start, start+n)
+		case (r == '+' || r == '-') && sign == "":
+			sign = string(r)
+		default:
+			m := p.currencyLen(start, end, true)
+			if seen || m == 0 {
+				break prefix
+			}
+			n, seen = m, true
+			p.fixup(FixupCurrency, start, start+n)
+		}
+		start += n
+	}
+	// And from the end.
+suffix:
+	for seen := false; start < end; {
+		r, n := utf8.DecodeLastRuneInString(p.s[start:end])
+		switch {
+		case unicode.IsSpace(r):
+			for start < end-n {
+				r, m := utf8.DecodeLastRuneInString(p.s[start : end-n])
+				if !unicode.IsSpace(r) {
+					break
+				}
+				n += m
+			}
+			p.fixup(FixupSpace, end-n, end)
+		default:
+			m := p.currencyLen(start, end, false)
+			if seen || m == 0 {
+				break suffix
+			}
+			n, seen = m, true
+			p.fixup(FixupCurrency, end-n, end)
+		}
+		end -= n
+	}
+	if start == end {
+		return "", p.errorf(start, "no digits")
+	}
+	body := p.s[start:end]
+	switch strings.ToLower(body) {
+	case "inf", "infinity", "nan", "snan":
+		return sign + body, nil
+	}
+
+	// Split off the exponent, which must be well formed.
+	mant, exp := body, ""
+	if i := strings.IndexAny(body, "eE"); i >= 0 {
+		mant, exp = body[:i], body[i:]
+		e := strings.TrimLeft(exp[1:], "+-")
+		if len(exp)-len(e) > 2 || e == "" || strings.TrimLeft(e, "0123456789") != "" {
+			return "", p.errorf(start+i, "invalid exponent %q", exp)
+		}
+	}
+	return p.mantissa(start, mant, sign, exp)
+}
+
+// mantissa normalizes mant, which begins at the byte offset off in p.s, and
+// returns sign + the normalized mantissa + exp.
+func (p *lenientParser) mantissa(off int, mant, sign, exp string) (string, error) {
+	var (
+		dots, commas int
+		prev         rune
+		lookalike    = -1
+	)
+	for i, r := range mant {
+		switch {
+		case r >= '0' && r <= '9':
+		case lookalikes[r] != 0:
+			if lookalike < 0 {
+				lookalike = off + i
+			}
+		case isSep(r):
+			if isSep(prev) || (i == 0 && isGroupSep(r)) {
+				return "", p.errorf(off+i, "unexpected %q", r)
+			}
+			if r == '.' {
+				dots++
+			} else if r == ',' {
+				commas++
+			}
+		default:
+			return "", p.errorf(off+i, "unexpected %q", r)
+		}
+		prev = r
+	}
+	if lookalike >= 0 {
+		return "", ErrInput{
+			Input:  p.s,
+			Offset: lookalike,
+			Msg:    fmt.Sprintf("letter %q in a number", p.s[lookalike]),
+			Suggestion: p.s[:off] + strings.Map(func(r rune) rune {
+				if d := lookalikes[r]; d != 0 {
+					return d
+				}
+				return r
+			}, mant) + p.s[off+len(mant):],
+		}
+	}
+	if isGroupSep(prev) {
+		return "", p.errorf(off+len(mant)-utf8.RuneLen(prev), "unexpected %q", prev)
+	}
+
+	dec := p.hints.Decimal
+	switch {
+	case dec == '.' || dec == ',':
+	case dec != 0:
+		return "", p.errorf(0, "invalid decimal separator %q", dec)
+	case dots > 0 && commas > 0:
+		dec = rune(mant[strings.LastIndexAny(mant, ".,")])
+	case commas == 1:
+		if i := strings.IndexByte(mant, ','); len(mant)-i-1 != 3 ||
+			strings.Trim(mant[i+1:], "0123456789") != "" {
+			dec = ','
+		} else {
+			dec = '.'
+		}
+	case dots > 1:
+		dec = ','
+	default:
+		dec = '.'
+	}
+
+	intPart, frac := mant, ""
+	if i := strings.IndexRune(mant, dec); i >= 0 {
+		intPart, frac = mant[:i], mant[i+1:]
+		if j := strings.IndexFunc(frac, func(r rune) bool { return r < '0' || r > '9' }); j >= 0 {
+			r, _ := utf8.DecodeRuneInString(frac[j:])
+			if r == dec {
+				return "", p.errorf(off+i+1+j, "more than one decimal separator")
+			}
+			return "", p.errorf(off+i+1+j, "unexpected %q after the decimal separator", r)
+		}
+		if dec == ',' {
+			p.fixup(FixupDecimalComma, off+i, off+i+1)
+		}
+		frac = "." + frac
+	}
+
+	// Check and remove the thousands separators.
+	var (
+		digits = make([]byte, 0, len(intPart))
+		sep    rune
+		group  int
+		sepOff int
+	)
+	for i, r := range intPart {
+		if r >= '0' && r <= '9' {
+			digits = append(digits, byte(r))
+			group++
+			continue
+		}
+		if sep == 0 {
+			sep, sepOff = r, off+i
+		} else if r != sep {
+			return "", p.errorf(off+i, "inconsistent thousands separators %q and %q", sep, r)
+		}
+		if group == 0 || group > 3 || (len(digits) > group && group != 3) {
+			return "", p.errorf(off+i, "ambiguous thousands separator %q", r)
+		}
+		group = 0
+	}
+	if sep != 0 {
+		if group != 3 {
+			return "", p.errorf(off+strings.LastIndex(intPart, string(sep)),
+				"ambiguous thousands separator %q", sep)
+		}
+		p.fixups = append(p.fixups, Fixup{Kind: FixupThousands, Offset: sepOff, Text: string(sep)})
+	}
+	return sign + string(digits) + frac + exp, nil
+}
+
+// currencyLen returns the length of the currency symbol or code at the start
+// (or, if !prefix, the end) of p.s[start:end], or zero if there is none.
+func (p *lenientParser) currencyLen(start, end int, prefix bool) int {
+	s := p.s[start:end]
+	var r rune
+	var n int
+	if prefix {
+		r, n = utf8.DecodeRuneInString(s)
+	} else {
+		r, n = utf8.DecodeLastRuneInString(s)
+	}
+	if unicode.Is(unicode.Sc, r) {
+		return n
+	}
+	if len(s) < 3 {
+		return 0
+	}
+	code, rest := s[:3], s[3:]
+	if !prefix {
+		code, rest = s[len(s)-3:], s[:len(s)-3]
+	}
+	if rest != "" {
+		// The code must not be part of a longer word, such as Infinity.
+		var next rune
+		if prefix {
+			next, _ = utf8.DecodeRuneInString(rest)
+		} else {
+			next, _ = utf8.DecodeLastRuneInString(rest)
+		}
+		if unicode.IsLetter(next) {
+			return 0
+		}
+	}
+	if _, _, ok := CurrencyContext(code); ok {
+		return 3
+	}
+	return 0
+}
+
+// lookalikes maps letters commonly typed in place of digits to the digits.
+var lookalikes = map[rune]rune{'O': '0', 'o': '0', 'l': '1', 'I': '1'}
+
+// isGroupSep reports whether r is a thousands separator other than a comma or
+// a point.
+func isGroupSep(r rune) bool {
+	switch r {
+	case ' ', '\'', '_', '\u00a0', '\u2009', '\u202f', '\u2019':
+		return true
+	}
+	return false
+}
+
+// isSep reports whether r is a decimal or thousands separator.
+func isSep(r rune) bool {
+	return r == '.' || r == ',' || isGroupSep(r)
+}
@@ -0,0 +1,101 @@
+package decimal_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestParseLenient(t *testing.T) {
+	for i, test := range [...]struct {
+		s      string
+		dec    rune
+		want   string
+		fixups string
+	}{
+		{"1234.5", 0, "1234.5", ""},
+		{"-0.05", 0, "-0.05", ""},
+		{"  12 ", 0, "12", `removed white space "  " at offset 0; removed white space " " at offset 4`},
+		{"$1,234.50", 0, "1234.50",
+			`removed currency "$" at offset 0; removed thousands separator "," at offset 2`},
+		{"-$ 5", 0, "-5", `removed currency "$" at offset 1; removed white space " " at offset 2`},
+		{"1 000,50 €", 0, "1000.50", `removed thousands separator " " at offset 1; ` +
+			`replaced decimal comma "," at offset 5; removed white space " " at offset 8; ` +
+			`removed currency "€" at offset 9`},
+		{"1.234.567,8", 0, "1234567.8",
+			`removed thousands separator "." at offset 1; replaced decimal comma "," at offset 9`},
+		{"1.000.000", 0, "1000000", `removed thousands separator "." at offset 1`},
+		{"1,000", 0, "1000", `removed thousands separator "," at offset 1`},
+		{"1,000", ',', "1.000", `replaced decimal comma "," at offset 1`},
+		{"1,5", 0, "1.5", `replaced decimal comma "," at offset 1`},
+		{"1'234'567", 0, "1234567", `removed thousands separator "'" at offset 1`},
+		{"1\u00a0234", 0, "1234", `removed thousands separator "\u00a0" at offset 1`},
+		{"EUR 12.5", 0, "12.5", `removed currency "EUR" at offset 0; removed white space " " at offset 3`},
+		{"12.5usd", 0, "12.5", `removed currency "usd" at offset 4`},
+		{"1,234e-2", 0, "12.34", `removed thousands separator "," at offset 1`},
+		{".5", 0, "0.5", ""},
+		{"-Infinity", 0, "-Infinity", ""},
+		{"nan", 0, "NaN", ""},
+	} {
+		x, fixups, err := decimal.ParseLenient(test.s, decimal.ParseHints{Decimal: test.dec})
+		if err != nil {
+			t.Fatalf("#%d: ParseLenient(%q): %v", i, test.s, err)
+		}
+		var fs []string
+		for _, f := range fixups {
+			fs = append(fs, f.String())
+		}
+		if x.String() != test.want || strings.Join(fs, "; ") != test.fixups {
+			t.Fatalf("#%d: ParseLenient(%q): wanted %s (%s), got %s (%s)",
+				i, test.s, test.want, test.fixups, x, strings.Join(fs, "; "))
+		}
+	}
+}
+
+func TestParseLenient_Errors(t *testing.T) {
+	for i, test := range [...]struct {
+		s          string
+		dec        rune
+		offset     int
+		msg        string
+		suggestion string
+	}{
+		{"1,00.5", 0, 1, "ambiguous thousands separator ','", ""},
+		{"12..5", 0, 3, "unexpected '.'", ""},
+		{"1O0", 0, 1, "letter 'O' in a number", "100"},
+		{"$ l,2OO.5", 0, 2, "letter 'l' in a number", "$ 1,200.5"},
+		{"1,2345", '.', 1, "ambiguous thousands separator ','", ""},
+		{"1234,567.5", 0, 4, "ambiguous thousands separator ','", ""},
+		{"1,234 567", '.', 5, "inconsistent thousands separators ',' and ' '", ""},
+		{"1.5", ',', 1, "ambiguous thousands separator '.'", ""},
+		{"1.5.6", 0, 3, "ambiguous thousands separator '.'", ""},
+		{"1.234,5,6", 0, 7, "more than one decimal separator", ""},
+		{"1.2 3", 0, 3, "unexpected ' ' after the decimal separator", ""},
+		{"1 ", 0, 0, "", ""}, // trailing white space is removed
+		{"1_", 0, 1, "unexpected '_'", ""},
+		{"1e+-5", 0, 1, `invalid exponent "e+-5"`, ""},
+		{"12#", 0, 2, "unexpected '#'", ""},
+		{" $ ", 0, 3, "no digits", ""},
+	} {
+		_, _, err := decimal.ParseLenient(test.s, decimal.ParseHints{Decimal: test.dec})
+		if test.msg == "" {
+			if err != nil {
+				t.Fatalf("#%d: ParseLenient(%q): %v", i, test.s, err)
+			}
+			continue
+		}
+		var e decimal.ErrInput
+		if !errors.As(err, &e) {
+			t.Fatalf("#%d: ParseLenient(%q): wanted ErrInput, got %v", i, test.s, err)
+		}
+		if e.Offset != test.offset || e.Msg != test.msg || e.Suggestion != test.suggestion {
+			t.Fatalf("#%d: ParseLenient(%q): wanted (%d, %s, %q), got (%d, %s, %q)",
+				i, test.s, test.offset, test.msg, test.suggestion, e.Offset, e.Msg, e.Suggestion)
+		}
+		if !errors.Is(err, decimal.ConversionSyntax) {
+			t.Fatalf("#%d: %v does not wrap ConversionSyntax", i, err)
+		}
+	}
+}
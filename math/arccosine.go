@@ -28,6 +28,7 @@ func Acos(z, x *decimal.Big) *decimal.Big {
 	}
 
 	ctx := decimal.Context{Precision: precision(z)}
+	defer keep(z, z.Context)
 
 	if cmp1 == 0 {
 		if x.Signbit() {
@@ -36,13 +37,14 @@ func Acos(z, x *decimal.Big) *decimal.Big {
 		return z.SetUint64(0)
 	}
 
-	ctx.Precision += defaultExtraPrecision
+	g := guard(z)
+	ctx.Precision += defaultExtraPrecision + g
 
 	// Acos(x) = pi/2 - arcsin(x)
 
 	// TODO(eric): when I devise an API for Pi, E, etc. that uses Context, switch
 	// that that instead of allocating new decimals.
 	ctx.Sub(z, pi2(z, ctx), Asin(decimal.WithContext(ctx), x))
-	ctx.Precision -= defaultExtraPrecision
+	ctx.Precision -= defaultExtraPrecision + g
 	return ctx.Round(z)
 }
@@ -28,6 +28,8 @@ func Asin(z, x *decimal.Big) *decimal.Big {
 	}
 
 	ctx := decimal.Context{Precision: precision(z)}
+	zc := z.Context
+	defer keep(z, zc)
 
 	if cmp1 == 0 {
 		pi2(z, ctx)
@@ -37,14 +39,18 @@ func Asin(z, x *decimal.Big) *decimal.Big {
 		return z
 	}
 
-	ctx.Precision += defaultExtraPrecision
+	g := guard(z)
+	ctx.Precision += defaultExtraPrecision + g
 
 	// Asin(x) = 2 * atan(x / (1 + sqrt(1 - x*x)))
 
 	x2 := ctx.Mul(alias(z, x), x, x)
-	ctx.Quo(x2, x, ctx.Add(x2, Sqrt(x2, ctx.Sub(x2, one, x2)), one))
-	z.Copy(Atan(decimal.WithContext(ctx), x2))
+	ctx.Sub(x2, one, x2)
+	// sqrt works at the precision of x2's Context, which Sub replaced with ctx.
+	sqrt(keep(x2, zc), x2)
+	ctx.Quo(x2, x, ctx.Add(x2, x2, one))
+	z.Copy(atan(decimal.WithContext(ctx), x2))
 	ctx.Mul(z, z, two)
-	ctx.Precision -= defaultExtraPrecision
+	ctx.Precision -= defaultExtraPrecision + g
 	return ctx.Round(z)
 }
@@ -81,7 +81,7 @@ func prepAtan(z, x *decimal.Big, ctx decimal.Context) (
 	sq := decimal.WithContext(ctx)
 	for i := 0; i < halved; i++ {
 		ctx.FMA(sq, x0, x0, one)
-		Sqrt(sq, sq)
+		sqrt(sq, sq)
 		ctx.Add(sq, sq, one)
 		ctx.Quo(x0, x0, sq)
 	}
@@ -136,11 +136,21 @@ func getAtanQ(ctx decimal.Context, x2p1 *decimal.Big) SplitFunc {
 //		Atan(NaN)  = NaN
 //		Atan(±Inf) = ±x * pi/2
 func Atan(z, x *decimal.Big) *decimal.Big {
+	if shared(z) {
+		return z.Atan(x)
+	}
+	return atan(z, x)
+}
+
+// atan implements Atan at compatibility levels before 3.
+func atan(z, x *decimal.Big) *decimal.Big {
 	if z.CheckNaNs(x, nil) {
 		return z
 	}
 
-	ctx := decimal.Context{Precision: precision(z) + defaultExtraPrecision}
+	g := guard(z)
+	ctx := decimal.Context{Precision: precision(z) + defaultExtraPrecision + g}
+	defer keep(z, z.Context)
 
 	if x.IsInf(0) {
 		pi2(z, ctx)
@@ -160,7 +170,7 @@ func Atan(z, x *decimal.Big) *decimal.Big {
 
 	// undo the double angle part
 	ySq.Context = ctx
-	tmp := Pow(ySq, two, z.SetMantScale(int64(halfed), 0)) // clobber ySq
+	tmp := pow(ySq, two, z.SetMantScale(int64(halfed), 0)) // clobber ySq
 	ctx.Mul(z, result, tmp)
 
 	// to handle the argument reduction step
@@ -176,7 +186,7 @@ func Atan(z, x *decimal.Big) *decimal.Big {
 	case 2:
 		ctx.Sub(z, pi2(tmp, ctx), z) // clobber _2p
 	}
-	ctx.Precision -= defaultExtraPrecision
+	ctx.Precision -= defaultExtraPrecision + g
 	return ctx.Round(z)
 }
 
@@ -206,6 +216,14 @@ func Atan(z, x *decimal.Big) *decimal.Big {
 //     Atan2(y >= 0, x < 0) = Atan(y/x) + pi
 //     Atan2(y < 0, x < 0)  = Atan(y/x) - pi
 func Atan2(z, y, x *decimal.Big) *decimal.Big {
+	if shared(z) {
+		return z.Atan2(y, x)
+	}
+	return atan2(z, y, x)
+}
+
+// atan2 implements Atan2 at compatibility levels before 3.
+func atan2(z, y, x *decimal.Big) *decimal.Big {
 	if z.CheckNaNs(y, x) {
 		return z
 	}
@@ -213,7 +231,9 @@ func Atan2(z, y, x *decimal.Big) *decimal.Big {
 	// Return context and work context. For this function it's easier to have
 	// two separate contexts than it is to constantly subtract our extra precision.
 	rctx := decimal.Context{Precision: precision(z)}
-	wctx := decimal.Context{Precision: rctx.Precision + defaultExtraPrecision}
+	wctx := decimal.Context{Precision: rctx.Precision + defaultExtraPrecision + guard(z)}
+	zc := z.Context
+	defer keep(z, zc)
 
 	neg := y.Signbit()
 	xs := x.Sign()
@@ -255,7 +275,9 @@ func Atan2(z, y, x *decimal.Big) *decimal.Big {
 		return rctx.Round(misc.SetSignbit(pi2(z, wctx), neg))
 	}
 
-	Atan(z, wctx.Quo(z, y, x))
+	// atan works at the precision of z's Context, which Quo replaced with wctx.
+	wctx.Quo(z, y, x)
+	atan(keep(z, zc), z)
 	if xs < 0 {
 		pi := pi(new(decimal.Big), wctx)
 		if z.Sign() <= 0 {
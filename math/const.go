@@ -34,7 +34,16 @@ var (
 
 // E sets z to the mathematical constant e and returns z.
 func E(z *decimal.Big) *decimal.Big {
+	if shared(z) {
+		return decimal.E(z)
+	}
+	return euler(z)
+}
+
+// euler implements E at compatibility levels before 3.
+func euler(z *decimal.Big) *decimal.Big {
 	ctx := decimal.Context{Precision: precision(z)}
+	defer keep(z, z.Context)
 	if ctx.Precision <= constPrec {
 		return ctx.Set(z, _E)
 	}
@@ -72,7 +81,12 @@ func pi2(z *decimal.Big, ctx decimal.Context) *decimal.Big {
 
 // Pi sets z to the mathematical constant pi and returns z.
 func Pi(z *decimal.Big) *decimal.Big {
-	return pi(z, decimal.Context{Precision: precision(z)})
+	if shared(z) {
+		return decimal.Pi(z)
+	}
+	ctx := decimal.Context{Precision: precision(z)}
+	zc := z.Context
+	return keep(pi(z, ctx), zc)
 }
 
 // pi sets z to the mathematical constant pi and returns z.
@@ -113,6 +127,7 @@ func ln10(z *decimal.Big, prec int) *decimal.Big {
 	}
 
 	ctx := decimal.Context{Precision: prec + 10}
+	defer keep(z, z.Context)
 	g := lgen{
 		ctx: ctx,
 		pow: eightyOne, // 9 * 9
@@ -131,7 +146,7 @@ func sqrt3(z *decimal.Big, ctx decimal.Context) *decimal.Big {
 		return ctx.Set(z, _Sqrt3)
 	}
 	// TODO(eric): get rid of this allocation.
-	return ctx.Set(z, Sqrt(decimal.WithContext(ctx), three))
+	return ctx.Set(z, sqrt(decimal.WithContext(ctx), three))
 }
 
 func ln10_t(z *decimal.Big, prec int) *decimal.Big {
@@ -149,7 +164,7 @@ func ln10_t(z *decimal.Big, prec int) *decimal.Big {
 	for p := bits.Len(uint(prec+2)) + 99; ; p *= 2 {
 		tctx.Precision = p + 5
 		misc.SetSignbit(z, true)
-		Exp(&tmp, z)
+		exp(&tmp, z)
 		misc.SetSignbit(z, false)
 		uctx.Add(z, z, tctx.FMA(&tmp, ten, &tmp, negone))
 		if !z.IsFinite() || p >= prec+2 {
@@ -84,6 +84,7 @@ func Wallis(z *decimal.Big, g Generator) *decimal.Big {
 	b.SetUint64(1)
 
 	ctx := z.Context
+	defer keep(z, ctx)
 	if c, ok := g.(Contexter); ok {
 		ctx = c.Context()
 	}
@@ -214,6 +215,7 @@ func Lentz(z *decimal.Big, g Generator) *decimal.Big {
 	D.SetUint64(0)
 
 	ctx := z.Context
+	defer keep(z, ctx)
 	if c, ok := g.(Contexter); ok {
 		ctx = c.Context()
 	}
@@ -23,7 +23,7 @@ func prepCosine(z, x *decimal.Big, ctx decimal.Context) (*decimal.Big, int, bool
 	ctx.Mul(&twoPi, pi(&twoPi, ctx), two) // 2 * Pi
 	if x.CmpAbs(&twoPi) >= 0 {
 		// for cos to work correctly the input must be in (-2Pi, 2Pi).
-		ctx.Quo(&tmp, x, &twoPi)
+		ctx.QuoInt(&tmp, x, &twoPi)
 		v, ok := tmp.Int64()
 		if !ok {
 			return nil, 0, false
@@ -135,6 +135,14 @@ func getCosineQ(ctx decimal.Context) func(n uint64) *decimal.Big {
 //		Cos(NaN)  = NaN
 //		Cos(±Inf) = NaN
 func Cos(z, x *decimal.Big) *decimal.Big {
+	if shared(z) {
+		return z.Cos(x)
+	}
+	return cos(z, x)
+}
+
+// cos implements Cos at compatibility levels before 3.
+func cos(z, x *decimal.Big) *decimal.Big {
 	if z.CheckNaNs(x, nil) {
 		return z
 	}
@@ -144,7 +152,9 @@ func Cos(z, x *decimal.Big) *decimal.Big {
 		return z.SetNaN(false)
 	}
 
-	ctx := decimal.Context{Precision: precision(z) + defaultExtraPrecision}
+	g := guard(z)
+	ctx := decimal.Context{Precision: precision(z) + defaultExtraPrecision + g}
+	defer keep(z, z.Context)
 
 	negXSq, halved, ok := prepCosine(z, x, ctx)
 	if !ok {
@@ -166,6 +176,6 @@ func Cos(z, x *decimal.Big) *decimal.Big {
 		ctx.Mul(z, z, two)
 		ctx.Sub(z, z, one)
 	}
-	ctx.Precision -= defaultExtraPrecision + halved
+	ctx.Precision -= defaultExtraPrecision + g + halved
 	return ctx.Round(z)
 }
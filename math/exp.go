@@ -7,6 +7,14 @@ import (
 
 // Exp sets z to e ** x and returns z.
 func Exp(z, x *decimal.Big) *decimal.Big {
+	if shared(z) {
+		return z.Exp(x)
+	}
+	return exp(z, x)
+}
+
+// exp implements Exp at compatibility levels before 3.
+func exp(z, x *decimal.Big) *decimal.Big {
 	if z.CheckNaNs(x, nil) {
 		return z
 	}
@@ -42,6 +50,7 @@ func Exp(z, x *decimal.Big) *decimal.Big {
 
 	prec := precision(z)
 	ctx := decimal.Context{Precision: prec + 3}
+	defer keep(z, z.Context)
 	tmp := alias(z, x) // scratch space
 
 	// |x| <= 9 * 10 ** -(prec + 1)
@@ -50,7 +59,7 @@ func Exp(z, x *decimal.Big) *decimal.Big {
 		z.Context.Conditions |= decimal.Rounded | decimal.Inexact
 		return ctx.Round(z.SetMantScale(1, 0).Quantize(ctx.Precision - 1 - 3))
 	}
-	ctx.Precision += k + 3
+	ctx.Precision += k + 3 + guard(z)
 	if ctx.Precision < 10 {
 		ctx.Precision = 10
 	}
@@ -58,7 +67,7 @@ func Exp(z, x *decimal.Big) *decimal.Big {
 	if x.IsInt() {
 		if v, ok := x.Uint64(); ok && v == 1 {
 			// e ** 1 = e
-			return E(z)
+			return euler(z)
 		}
 	}
 
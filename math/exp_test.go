@@ -22,14 +22,18 @@ func BenchmarkExp(b *testing.B) {
 	}
 }
 
+// brokenExpX and brokenExpR are an input to Exp and its correctly rounded
+// result that Exp is off by 1 ULP without guard digits.
+const (
+	brokenExpX = "-4.196711681127197916094391539123262189586909347963506502543424520204269305640664305277347577002702737250370072340050961484385104884969242076870376232111486905959065396493009164151561622858914473431085133053988002190096068967537402110957786645990448175717096753891271854515878515736050199594390165820346179495731423460807010421108300654567720490182E-11"
+	brokenExpR = "0.9999999999580328831896086402857692391751165335212832970499070407439918336957012664619593253323412937604524594000218133755918787968163830746272347910313962376243766200053425149074918367705985619799635171168309589413777382356581724053283138880135649239454288402003937458350598982662476784676965172757399371508486621844945763917842573387863934924333"
+)
+
 func TestBrokenJobs_Exp(t *testing.T) {
 	for i, s := range [...]struct {
 		x, r string
 	}{
-		{
-			x: "-4.196711681127197916094391539123262189586909347963506502543424520204269305640664305277347577002702737250370072340050961484385104884969242076870376232111486905959065396493009164151561622858914473431085133053988002190096068967537402110957786645990448175717096753891271854515878515736050199594390165820346179495731423460807010421108300654567720490182E-11",
-			r: "0.9999999999580328831896086402857692391751165335212832970499070407439918336957012664619593253323412937604524594000218133755918787968163830746272347910313962376243766200053425149074918367705985619799635171168309589413777382356581724053283138880135649239454288402003937458350598982662476784676965172757399371508486621844945763917842573387863934924333",
-		},
+		{x: brokenExpX, r: brokenExpR},
 	} {
 		x, _ := new(decimal.Big).SetString(s.x)
 		r, _ := new(decimal.Big).SetString(s.r)
@@ -43,3 +47,22 @@ got   : %s
 		}
 	}
 }
+
+func TestExp_GuardDigits(t *testing.T) {
+	x, _ := new(decimal.Big).SetString(brokenExpX)
+	r, _ := new(decimal.Big).SetString(brokenExpR)
+
+	// From level 3, Exp is always correctly rounded.
+	z := Exp(decimal.WithContext(decimal.Context{Precision: r.Precision(), CompatLevel: 2}), x)
+	if z.Cmp(r) == 0 {
+		t.Fatal("Exp is correctly rounded without guard digits; pick a new input")
+	}
+	ctx := decimal.Context{Precision: r.Precision(), GuardDigits: 2, CompatLevel: 2}
+	z = Exp(decimal.WithContext(ctx), x)
+	if z.Cmp(r) != 0 || z.Precision() != r.Precision() {
+		t.Fatalf(`Exp(%s) (GuardDigits: 2)
+wanted: %s
+got   : %s
+`, x, r, z)
+	}
+}
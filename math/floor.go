@@ -11,9 +11,10 @@ func Floor(z, x *decimal.Big) *decimal.Big {
 	if z.CheckNaNs(x, nil) {
 		return z
 	}
-	ctx := z.Context
+	zc := z.Context
+	ctx := zc
 	ctx.RoundingMode = decimal.ToNegativeInf
-	return ctx.RoundToInt(z.Copy(x))
+	return keep(ctx.RoundToInt(z.Copy(x)), zc)
 }
 
 // Ceil sets z to the least integer value greater than or equal to x and returns
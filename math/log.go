@@ -8,6 +8,14 @@ import (
 
 // Log10 sets z to the common logarithm of x and returns z.
 func Log10(z, x *decimal.Big) *decimal.Big {
+	if shared(z) {
+		return z.Log10(x)
+	}
+	return log10(z, x)
+}
+
+// log10 implements Log10 at compatibility levels before 3.
+func log10(z, x *decimal.Big) *decimal.Big {
 	if logSpecials(z, x) {
 		return z
 	}
@@ -21,13 +29,22 @@ func Log10(z, x *decimal.Big) *decimal.Big {
 	}
 	if tpow {
 		ctx := decimal.Context{Precision: precision(z)}
-		return ctx.Set(z, z.SetMantScale(int64(adjusted(x)), 0))
+		zc := z.Context
+		return keep(ctx.Set(z, z.SetMantScale(int64(adjusted(x)), 0)), zc)
 	}
 	return log(z, x, true)
 }
 
 // Log sets z to the natural logarithm of x and returns z.
 func Log(z, x *decimal.Big) *decimal.Big {
+	if shared(z) {
+		return z.Log(x)
+	}
+	return ln(z, x)
+}
+
+// ln implements Log at compatibility levels before 3.
+func ln(z, x *decimal.Big) *decimal.Big {
 	if logSpecials(z, x) {
 		return z
 	}
@@ -102,8 +119,9 @@ func log(z, x *decimal.Big, ten bool) *decimal.Big {
 	// multiple iterations of with a precision in [1, 5000) and a 128-bit decimal.
 	prec := precision(z)
 	ctx := decimal.Context{
-		Precision: prec + arith.Length(uint64(prec+x.Precision())) + 5,
+		Precision: prec + arith.Length(uint64(prec+x.Precision())) + 5 + guard(z),
 	}
+	defer keep(z, z.Context)
 	if ten {
 		ctx.Precision += 3
 	}
@@ -9,6 +9,14 @@ import (
 
 // Pow sets z to x**y and returns z.
 func Pow(z, x, y *decimal.Big) *decimal.Big {
+	if shared(z) {
+		return z.Pow(x, y)
+	}
+	return pow(z, x, y)
+}
+
+// pow implements Pow at compatibility levels before 3.
+func pow(z, x, y *decimal.Big) *decimal.Big {
 	if z.CheckNaNs(x, y) {
 		return z
 	}
@@ -56,7 +64,7 @@ func Pow(z, x, y *decimal.Big) *decimal.Big {
 
 	if x.Cmp(ptFive) == 0 {
 		// x ** 0.5 = sqrt(x)
-		return Sqrt(z, x)
+		return sqrt(z, x)
 	}
 
 	if y.IsInt() {
@@ -67,7 +75,8 @@ func Pow(z, x, y *decimal.Big) *decimal.Big {
 
 func powInt(z, x, y *decimal.Big) *decimal.Big {
 	prec := precision(z)
-	ctx := decimal.Context{Precision: prec - y.Scale() + y.Precision() + 2}
+	ctx := decimal.Context{Precision: prec - y.Scale() + y.Precision() + 2 + guard(z)}
+	defer keep(z, z.Context)
 
 	var x0 decimal.Big
 	if y.Signbit() {
@@ -102,7 +111,7 @@ func powInt(z, x, y *decimal.Big) *decimal.Big {
 			}
 		}
 	} else {
-		y0 := y.Int(nil)
+		y0, _ := y.Int(nil)
 		sign = sign && y0.Bit(0) == 1
 		y0.Abs(y0)
 		for y0.Sign() != 0 {
@@ -140,9 +149,9 @@ func powDec(z, x, y *decimal.Big) *decimal.Big {
 
 	oc := z.Context
 	z.Context = decimal.Context{
-		Precision: max(x.Precision(), precision(z)) + 4 + 19,
+		Precision: max(x.Precision(), precision(z)) + 4 + 19 + guard(z),
 	}
-	Exp(z, z.Mul(y, Log(z, x)))
+	exp(z, z.Mul(y, ln(z, x)))
 	if neg && z.IsFinite() {
 		misc.CopyNeg(z, z)
 	}
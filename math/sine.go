@@ -12,6 +12,14 @@ import "github.com/ericlagergren/decimal"
 //     Sin(NaN) = NaN
 //     Sin(Inf) = NaN
 func Sin(z, x *decimal.Big) *decimal.Big {
+	if shared(z) {
+		return z.Sin(x)
+	}
+	return sin(z, x)
+}
+
+// sin implements Sin at compatibility levels before 3.
+func sin(z, x *decimal.Big) *decimal.Big {
 	if z.CheckNaNs(x, nil) {
 		return z
 	}
@@ -21,8 +29,13 @@ func Sin(z, x *decimal.Big) *decimal.Big {
 	}
 
 	// Sin(x) = Cos(pi/2 - x)
-	ctx := decimal.Context{Precision: precision(z) + defaultExtraPrecision}
-	Cos(z, ctx.Sub(z, pi2(alias(z, x), ctx), x))
-	ctx.Precision -= defaultExtraPrecision
+	g := guard(z)
+	ctx := decimal.Context{Precision: precision(z) + defaultExtraPrecision + g}
+	zc := z.Context
+	defer keep(z, zc)
+	// cos works at the precision of z's Context, which Sub replaced with ctx.
+	ctx.Sub(z, pi2(alias(z, x), ctx), x)
+	cos(keep(z, zc), z)
+	ctx.Precision -= defaultExtraPrecision + g
 	return ctx.Round(z)
 }
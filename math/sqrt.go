@@ -6,22 +6,34 @@ import (
 
 // Hypot sets z to Sqrt(p*p + q*q) and returns z.
 func Hypot(z, p, q *decimal.Big) *decimal.Big {
+	if shared(z) {
+		return z.Hypot(p, q)
+	}
+	return hypot(z, p, q)
+}
+
+// hypot implements Hypot at compatibility levels before 3.
+func hypot(z, p, q *decimal.Big) *decimal.Big {
 	if z.CheckNaNs(p, q) {
 		return z
 	}
 
-	ctx := decimal.Context{Precision: precision(z) + 1}
+	ctx := decimal.Context{Precision: precision(z) + 1 + guard(z)}
+	zc := z.Context
 
 	var p0 decimal.Big
 	ctx.Mul(&p0, p, p)
 
+	// sqrt works at the precision of z's Context, which Add replaces with ctx.
 	if p == q {
-		return Sqrt(z, ctx.Add(z, &p0, &p0))
+		ctx.Add(z, &p0, &p0)
+		return sqrt(keep(z, zc), z)
 	}
 
 	var q0 decimal.Big
 	ctx.Mul(&q0, q, q)
-	return Sqrt(z, ctx.Add(z, &p0, &q0))
+	ctx.Add(z, &p0, &q0)
+	return sqrt(keep(z, zc), z)
 }
 
 var (
@@ -34,6 +46,14 @@ var (
 
 // Sqrt sets z to the square root of x and returns z.
 func Sqrt(z, x *decimal.Big) *decimal.Big {
+	if shared(z) {
+		return z.Sqrt(x)
+	}
+	return sqrt(z, x)
+}
+
+// sqrt implements Sqrt at compatibility levels before 3.
+func sqrt(z, x *decimal.Big) *decimal.Big {
 	if z.CheckNaNs(x, nil) {
 		return z
 	}
@@ -57,7 +77,9 @@ func Sqrt(z, x *decimal.Big) *decimal.Big {
 		ctx  = decimal.Context{Precision: prec}
 		rnd  = z.Context.Conditions&decimal.Rounded != 0
 		ixt  = z.Context.Conditions&decimal.Inexact != 0
+		g    = guard(z)
 	)
+	defer keep(z, z.Context)
 
 	// Source for the following algorithm:
 	//
@@ -86,7 +108,7 @@ func Sqrt(z, x *decimal.Big) *decimal.Big {
 		ctx.FMA(z, approx4, f, approx3) // approx := .0819 + 2.59f
 	}
 
-	maxp := prec + 5 // extra prec to skip weird +/- 0.5 adjustments
+	maxp := prec + 5 + g // extra prec to skip weird +/- 0.5 adjustments
 	ctx.Precision = 3
 	for {
 		// p := min(2*p - 2, maxp)
@@ -61,6 +61,14 @@ func prepTan(z, x *decimal.Big, ctx decimal.Context) (*decimal.Big, bool) {
 //     Tan(NaN) = NaN
 //     Tan(±Inf) = NaN
 func Tan(z, x *decimal.Big) *decimal.Big {
+	if shared(z) {
+		return z.Tan(x)
+	}
+	return tan(z, x)
+}
+
+// tan implements Tan at compatibility levels before 3.
+func tan(z, x *decimal.Big) *decimal.Big {
 	if z.CheckNaNs(x, nil) {
 		return z
 	}
@@ -70,7 +78,9 @@ func Tan(z, x *decimal.Big) *decimal.Big {
 		return z.SetNaN(false)
 	}
 
-	ctx := decimal.Context{Precision: precision(z) + defaultExtraPrecision}
+	g := guard(z)
+	ctx := decimal.Context{Precision: precision(z) + defaultExtraPrecision + g}
+	defer keep(z, z.Context)
 	x0, ok := prepTan(z, x, ctx)
 	if !ok {
 		z.Context.Conditions |= decimal.InvalidOperation
@@ -99,14 +109,15 @@ func Tan(z, x *decimal.Big) *decimal.Big {
 	}
 	tmp.Context = tctx
 
-	Cos(&tmp, x0)
+	cos(&tmp, x0)
 	ctx.Mul(&tmp, &tmp, &tmp)
 	ctx.Quo(&tmp, one, &tmp)
 	ctx.Sub(&tmp, &tmp, one)
-	Sqrt(&tmp, &tmp)
+	// sqrt works at the precision of tmp's Context, which Sub replaced with ctx.
+	sqrt(keep(&tmp, tctx), &tmp)
 	if x0.Signbit() {
 		misc.CopyNeg(&tmp, &tmp)
 	}
-	ctx.Precision -= defaultExtraPrecision
+	ctx.Precision -= defaultExtraPrecision + g
 	return ctx.Set(z, &tmp)
 }
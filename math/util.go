@@ -32,7 +32,7 @@ func alias(a, b *decimal.Big) *decimal.Big {
 
 func precision(z *decimal.Big) (p int) {
 	p = z.Context.Precision
-	if p > 0 && p <= decimal.UnlimitedPrecision {
+	if p > 0 && p <= decimal.MaxPracticalPrecision || p == decimal.UnlimitedPrecision {
 		return p
 	}
 	if p == 0 {
@@ -43,6 +43,34 @@ func precision(z *decimal.Big) (p int) {
 	return decimal.DefaultPrecision
 }
 
+// keep sets z's Context back to ctx, the Context z had before a function in
+// this package performed steps on it under its own Contexts, which z adopts,
+// and returns z. The Conditions raised by those steps and the tag they gave z
+// are kept.
+func keep(z *decimal.Big, ctx decimal.Context) *decimal.Big {
+	ctx.Conditions = z.Context.Conditions
+	tag := z.Tag()
+	z.Context = ctx
+	return z.SetTag(tag)
+}
+
+// shared reports whether z's Context selects a compatibility level, 3 or
+// later, at which the functions this package shares with the decimal package
+// (e.g., Exp and Sin) are computed by the decimal package's correctly rounded
+// implementations. Earlier levels use the algorithms in this package.
+func shared(z *decimal.Big) bool { return z.Context.Compat() >= 3 }
+
+// guard returns the number of guard digits z's Context adds to the working
+// precision of intermediate calculations. The working precision never exceeds
+// decimal.MaxPracticalPrecision, ignoring the few extra digits each function
+// adds on its own.
+func guard(z *decimal.Big) int {
+	if g := z.Context.GuardDigits; g > 0 {
+		return max(min(g, decimal.MaxPracticalPrecision-precision(z)), 0)
+	}
+	return 0
+}
+
 func maxscl(x *decimal.Big) int {
 	if x.Context.MaxScale != 0 {
 		return x.Context.MaxScale
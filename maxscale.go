@@ -0,0 +1,77 @@
+package decimal
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrMaxScale is returned by SetStringMaxScale when its input has more
+// fractional digits than allowed.
+type ErrMaxScale struct {
+	Input string // the input
+	Scale int    // the number of fractional digits in Input
+	Max   int    // the limit that was exceeded
+}
+
+func (e ErrMaxScale) Error() string {
+	return fmt.Sprintf("decimal: %q has %d fractional digits, more than the maximum of %d",
+		e.Input, e.Scale, e.Max)
+}
+
+// Unwrap returns ConversionSyntax.
+func (e ErrMaxScale) Unwrap() error { return ConversionSyntax }
+
+var _ error = ErrMaxScale{}
+
+// ScaleOption modifies the behavior of SetStringMaxScale.
+type ScaleOption uint8
+
+const (
+	// AllowTrailingZeros permits fractional digits beyond the maximum scale
+	// if they are all zeros; e.g., "1.2300" with a maximum scale of 2.
+	AllowTrailingZeros ScaleOption = 1 << iota
+)
+
+// SetStringMaxScale sets z to the value of s, which must be in a format
+// accepted by SetString, and returns an error if it is not or if s has more
+// than maxScale fractional digits.
+//
+// The fractional digits are counted as written, so "1.230" has three even
+// though its value needs only two, unless AllowTrailingZeros is provided. The
+// scale of a number in exponential notation is that of its value as written;
+// e.g., "1.5E+1" has none and "15E-3" has three. Infinities and NaNs have no
+// fractional digits.
+//
+// If s has too many fractional digits, z is set to NaN, ConversionSyntax is
+// raised, and the error is an ErrMaxScale. Like SetString, SetStringMaxScale
+// does not round z to its Context, so the check is made before any rounding
+// could discard the excess digits.
+func (z *Big) SetStringMaxScale(s string, maxScale int, opts ...ScaleOption) error {
+	mustNotNil("SetStringMaxScale", z, z)
+	var opt ScaleOption
+	for _, o := range opts {
+		opt |= o
+	}
+
+	if err := z.scanString(s, z.Context); err != nil {
+		return err
+	}
+	if z.Context.Conditions&ConversionSyntax != 0 {
+		return errors.New("decimal: invalid decimal " + s)
+	}
+	if !z.IsFinite() {
+		return nil
+	}
+
+	scale := -z.exp
+	if opt&AllowTrailingZeros != 0 && scale > maxScale {
+		var r Big
+		z.Context.simpleReduce(r.Copy(z))
+		scale = -r.exp
+	}
+	if scale > maxScale {
+		z.setParseErr(ConversionSyntax)
+		return ErrMaxScale{Input: s, Scale: scale, Max: maxScale}
+	}
+	return nil
+}
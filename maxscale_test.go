@@ -0,0 +1,75 @@
+package decimal_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestBig_SetStringMaxScale(t *testing.T) {
+	for i, test := range [...]struct {
+		s     string
+		max   int
+		opts  []decimal.ScaleOption
+		want  string
+		scale int // the scale reported by ErrMaxScale, or -1 if valid
+	}{
+		{"12.34", 2, nil, "12.34", -1},
+		{"12.345", 2, nil, "", 3},
+		{"12.340", 2, nil, "", 3},
+		{"12.340", 2, []decimal.ScaleOption{decimal.AllowTrailingZeros}, "12.340", -1},
+		{"12.345", 2, []decimal.ScaleOption{decimal.AllowTrailingZeros}, "", 3},
+		{"1.000000", 0, []decimal.ScaleOption{decimal.AllowTrailingZeros}, "1.000000", -1},
+		{"0.00", 0, nil, "", 2},
+		{"0.00", 0, []decimal.ScaleOption{decimal.AllowTrailingZeros}, "0.00", -1},
+		{"100", 0, nil, "100", -1},
+		{"1.5E+1", 0, nil, "15", -1},
+		{"1.50E+1", 0, nil, "", 1},
+		{"15E-3", 2, nil, "", 3},
+		{"15E-2", 2, nil, "0.15", -1},
+		{"1E+3", -2, nil, "1E+3", -1},
+		{"1E+1", -2, nil, "", -1},
+		{"-7.5", 1, nil, "-7.5", -1},
+		{"-Infinity", 0, nil, "-Infinity", -1},
+		{"NaN", 0, nil, "NaN", -1},
+	} {
+		z := new(decimal.Big)
+		err := z.SetStringMaxScale(test.s, test.max, test.opts...)
+		if test.want != "" {
+			if err != nil {
+				t.Fatalf("#%d: SetStringMaxScale(%q, %d): %v", i, test.s, test.max, err)
+			}
+			if z.String() != test.want {
+				t.Fatalf("#%d: SetStringMaxScale(%q, %d): wanted %s, got %s",
+					i, test.s, test.max, test.want, z)
+			}
+			continue
+		}
+		var e decimal.ErrMaxScale
+		if !errors.As(err, &e) {
+			t.Fatalf("#%d: SetStringMaxScale(%q, %d): wanted ErrMaxScale, got %v", i, test.s, test.max, err)
+		}
+		if test.scale >= 0 && e.Scale != test.scale {
+			t.Fatalf("#%d: SetStringMaxScale(%q, %d): wanted scale %d, got %d", i, test.s, test.max, test.scale, e.Scale)
+		}
+		if !errors.Is(err, decimal.ConversionSyntax) || !z.IsNaN(0) ||
+			z.Context.Conditions&decimal.ConversionSyntax == 0 {
+			t.Fatalf("#%d: SetStringMaxScale(%q, %d): wanted NaN (conversion syntax), got %s (%s)",
+				i, test.s, test.max, z, z.Context.Conditions)
+		}
+	}
+}
+
+func TestBig_SetStringMaxScale_Syntax(t *testing.T) {
+	for _, s := range [...]string{"", "1.2.3", "abc"} {
+		err := new(decimal.Big).SetStringMaxScale(s, 2)
+		if err == nil {
+			t.Fatalf("SetStringMaxScale(%q): wanted an error", s)
+		}
+		var e decimal.ErrMaxScale
+		if errors.As(err, &e) {
+			t.Fatalf("SetStringMaxScale(%q): wanted a syntax error, got %v", s, err)
+		}
+	}
+}
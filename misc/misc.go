@@ -204,11 +204,14 @@ func NextMinus(z, x *decimal.Big) *decimal.Big {
 		return z.SetBigMantScale(m, -etop(z))
 	}
 
-	ctx := z.Context
+	zc := z.Context
+	ctx := zc
 	ctx.RoundingMode = decimal.ToNegativeInf
 	ctx.Set(z, x)
 	ctx.Sub(z, x, new(decimal.Big).SetMantScale(1, -etiny(z)+1))
-	z.Context.Conditions &= ctx.Conditions
+	// z adopted ctx; restoring its own Context also drops the conditions
+	// raised by the nudge.
+	z.Context = zc
 	return z
 }
 
@@ -229,11 +232,14 @@ func NextPlus(z, x *decimal.Big) *decimal.Big {
 		return z.SetBigMantScale(m, -etop(z))
 	}
 
-	ctx := z.Context
+	zc := z.Context
+	ctx := zc
 	ctx.RoundingMode = decimal.ToPositiveInf
 	ctx.Set(z, x)
 	ctx.Add(z, x, new(decimal.Big).SetMantScale(1, -etiny(z)+1))
-	z.Context.Conditions &= ctx.Conditions
+	// z adopted ctx; restoring its own Context also drops the conditions
+	// raised by the nudge.
+	z.Context = zc
 	return z
 }
 
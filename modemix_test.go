@@ -0,0 +1,149 @@
+package decimal_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+// modeMixOps are operations whose behavior must not depend on the
+// OperatingModes of their operands.
+var modeMixOps = []struct {
+	name string
+	fn   func(z, x, y *decimal.Big) *decimal.Big
+}{
+	{"Add", (*decimal.Big).Add},
+	{"Sub", (*decimal.Big).Sub},
+	{"Mul", (*decimal.Big).Mul},
+	{"Quo", (*decimal.Big).Quo},
+	{"QuoInt", (*decimal.Big).QuoInt},
+	{"Rem", (*decimal.Big).Rem},
+	{"FMA", func(z, x, y *decimal.Big) *decimal.Big { return z.FMA(x, y, x) }},
+	{"Sum", func(z, x, y *decimal.Big) *decimal.Big { return z.Sum(x, y) }},
+	{"Abs", func(z, x, _ *decimal.Big) *decimal.Big { return z.Abs(x) }},
+	{"Neg", func(z, x, _ *decimal.Big) *decimal.Big { return z.Neg(x) }},
+	{"Set", func(z, x, _ *decimal.Big) *decimal.Big { return z.Set(x) }},
+	{"RoundSig", func(z, x, _ *decimal.Big) *decimal.Big { return z.RoundSig(x, 2) }},
+	{"Exp", func(z, x, _ *decimal.Big) *decimal.Big { return z.Exp(x) }},
+}
+
+func TestOperatingMode_Mix(t *testing.T) {
+	operands := []string{"1.25", "-3", "0", "12345678901234567890.5", "Infinity", "NaN"}
+	for _, op := range modeMixOps {
+		for _, xs := range operands {
+			for _, ys := range operands {
+				var want string
+				var wantc decimal.Condition
+				for _, xm := range []decimal.OperatingMode{decimal.GDA, decimal.Go} {
+					for _, ym := range []decimal.OperatingMode{decimal.GDA, decimal.Go} {
+						x := decimal.WithContext(decimal.Context{OperatingMode: xm})
+						x.SetString(xs)
+						y := decimal.WithContext(decimal.Context{OperatingMode: ym})
+						y.SetString(ys)
+						z := decimal.WithContext(decimal.Context{Precision: 10, OperatingMode: decimal.GDA})
+						op.fn(z, x, y)
+						if xm == decimal.GDA && ym == decimal.GDA {
+							want, wantc = z.String(), z.Context.Conditions
+						} else if z.String() != want || z.Context.Conditions != wantc {
+							t.Fatalf("%s(%s (%s), %s (%s)): wanted %s (%s), got %s (%s)",
+								op.name, xs, xm, ys, ym, want, wantc, z, z.Context.Conditions)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestOperatingMode_MixPanics(t *testing.T) {
+	// Whether an invalid operation panics depends only on z's OperatingMode.
+	for _, zm := range []decimal.OperatingMode{decimal.GDA, decimal.Go} {
+		for _, xm := range []decimal.OperatingMode{decimal.GDA, decimal.Go} {
+			x := decimal.WithContext(decimal.Context{OperatingMode: xm})
+			z := decimal.WithContext(decimal.Context{OperatingMode: zm})
+			panicked := func() (p bool) {
+				defer func() { p = recover() != nil }()
+				z.Quo(x, x) // 0 / 0
+				return false
+			}()
+			if panicked != (zm == decimal.Go) {
+				t.Fatalf("z: %s, x: %s: wanted panic: %t, got %t", zm, xm, zm == decimal.Go, panicked)
+			}
+		}
+	}
+}
+
+func TestCheckModeMix(t *testing.T) {
+	decimal.CheckModeMix = true
+	defer func() { decimal.CheckModeMix = false }()
+
+	gda := decimal.New(15, 1)
+	gobig := decimal.WithContext(decimal.Context{OperatingMode: decimal.Go}).SetMantScale(2, 0)
+
+	for _, op := range modeMixOps {
+		z := op.fn(new(decimal.Big), gda, gda)
+		if z.Context.Conditions&decimal.InvalidContext != 0 {
+			t.Fatalf("%s: unexpected %s without mixing", op.name, z.Context.Conditions)
+		}
+		z = op.fn(new(decimal.Big), gobig, gobig)
+		if !z.IsNaN(0) || z.Context.Conditions != decimal.InvalidContext {
+			t.Fatalf("%s: wanted NaN (invalid context), got %s (%s)", op.name, z, z.Context.Conditions)
+		}
+	}
+
+	// Go mode panics instead.
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("Go mode Add with a GDA operand: wanted panic")
+			}
+		}()
+		decimal.WithContext(decimal.Context{OperatingMode: decimal.Go}).Add(gobig, gda)
+	}()
+
+	// A Context method is governed by its Context, which z adopts.
+	z := decimal.Context{OperatingMode: decimal.Go}.Add(new(decimal.Big), gobig, gobig)
+	if z.Context.OperatingMode != decimal.Go {
+		t.Fatalf("Go Context Add: wanted a Go mode result, got %s", z.Context.OperatingMode)
+	}
+	if v, ok := z.Int64(); !ok || v != 4 {
+		t.Fatalf("Go Context Add: wanted 4, got %s (%s)", z, z.Context.Conditions)
+	}
+
+	// Operations that use temporaries internally must not report a mix.
+	goctx := decimal.Context{OperatingMode: decimal.Go}
+	for i, fn := range []func() *decimal.Big{
+		func() *decimal.Big {
+			return decimal.WithContext(goctx).Wrap(gobig, decimal.WithContext(goctx), decimal.WithContext(goctx).SetMantScale(3, 0))
+		},
+		func() *decimal.Big { return decimal.WithContext(goctx).SetFrac(1, 8) },
+		func() *decimal.Big { return decimal.WithContext(goctx).SetRat(big.NewRat(1, 8)) },
+		func() *decimal.Big { return decimal.PctChange(decimal.WithContext(goctx), gobig, gobig) },
+		func() *decimal.Big { return decimal.WithContext(goctx).FMA(gobig, gobig, gobig) },
+		func() *decimal.Big {
+			return decimal.WithContext(goctx).Sub(gobig, decimal.WithContext(goctx).SetInf(false))
+		},
+	} {
+		if z := fn(); z.IsNaN(0) {
+			t.Fatalf("#%d: unexpected NaN (%s)", i, z.Context.Conditions)
+		}
+	}
+
+	for _, fn := range []func(){
+		func() { gda.Cmp(gobig) },
+		func() { gobig.CmpAbs(gda) },
+	} {
+		func() {
+			defer func() {
+				if _, ok := recover().(decimal.ErrModeMix); !ok {
+					t.Fatal("comparison of mixed modes: wanted ErrModeMix panic")
+				}
+			}()
+			fn()
+		}()
+	}
+	if gobig.CmpInt64(2) != 0 || gda.Cmp(decimal.New(15, 1)) != 0 {
+		t.Fatal("unexpected result from comparing the same OperatingMode")
+	}
+}
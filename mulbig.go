@@ -0,0 +1,183 @@
+package decimal
+
+import (
+	"context"
+	"math/big"
+	"math/bits"
+)
+
+// DefaultMulChunkDigits is the number of digits in each chunk of MulBig's
+// product if MulOptions.ChunkDigits is zero.
+const DefaultMulChunkDigits = 2000
+
+// MulOptions configures MulBig.
+type MulOptions struct {
+	// Ctx, if non-nil, cancels the multiplication when it is done. It is
+	// checked between chunks, so a multiplication stops at most one chunk
+	// after Ctx is canceled.
+	Ctx context.Context
+
+	// ChunkDigits is the approximate number of digits of the shorter
+	// coefficient multiplied at a time. If zero, DefaultMulChunkDigits is
+	// used.
+	ChunkDigits int
+
+	// Parallel is the largest number of goroutines that multiply chunks at
+	// once. If it is less than 2, the chunks are multiplied sequentially by
+	// the calling goroutine.
+	Parallel int
+
+	// Progress, if non-nil, is called by the calling goroutine after each
+	// chunk is added to the product with the number of chunks done so far and
+	// the total number of chunks.
+	Progress func(done, total int)
+}
+
+// MulBig sets z to x * y and returns z, like Mul, but multiplies the
+// coefficients in chunks so that the multiplication of very large
+// coefficients can be observed, parallelized, and canceled. See MulOptions.
+//
+// The result, including any rounding and the Conditions raised, is identical
+// to Mul's. If x or y is not finite or either coefficient fits in a uint64,
+// MulBig is Mul and reports no progress.
+//
+// If opts.Ctx is canceled before the product is computed, MulBig returns z
+// unchanged, including its Context, and opts.Ctx.Err().
+func (c Context) MulBig(z, x, y *Big, opts MulOptions) (_ *Big, err error) {
+	defer func(c Context) {
+		if err == nil {
+			c.adopt(z)
+		}
+	}(c)
+	if c.hookedOp(z, x, y) {
+		z = c.hooked(z, hookOp{name: "×", infix: true, tagged: true}, func(c Context) *Big {
+			z, err = c.MulBig(z, x, y, opts)
+			return z
+		}, x, y)
+		return z, err
+	}
+	if c.Traps != 0 && z != nil {
+		z = c.trapped(z, "MulBig", func(c Context) *Big {
+			z, err = c.MulBig(z, x, y, opts)
+			return z
+		}, x, y)
+		return z, err
+	}
+	if z.checkNil("MulBig", x, y) {
+		return z, nil
+	}
+	if opts.Ctx != nil {
+		if err := opts.Ctx.Err(); err != nil {
+			return z, err
+		}
+	}
+	if z.invalidContext(c) || z.mixedModes(c, x, y) {
+		return z, nil
+	}
+	if !x.IsFinite() || !y.IsFinite() || x.isCompact() || y.isCompact() {
+		return c.Mul(z, x, y), nil
+	}
+
+	prod, err := mulChunked(&x.unscaled, &y.unscaled, opts)
+	if err != nil {
+		return z, err
+	}
+	z.form = finite | x.form&signbit ^ y.form&signbit
+	z.exp = x.exp + y.exp
+	z.unscaled.Set(prod)
+	z.norm()
+	if c.ExactOnly {
+		c.Precision = UnlimitedPrecision
+	}
+	return c.round(z), nil
+}
+
+// mulChunked returns the product of the non-negative x and y, computed as
+// the sum of x (or y, if it is shorter) multiplied by each chunk of y (or x).
+func mulChunked(x, y *big.Int, opts MulOptions) (*big.Int, error) {
+	if len(x.Bits()) < len(y.Bits()) {
+		x, y = y, x
+	}
+	digits := opts.ChunkDigits
+	if digits <= 0 {
+		digits = DefaultMulChunkDigits
+	}
+	// log2(10) < 3.322
+	words := digits*3322/1000/bits.UintSize + 1
+	yb := y.Bits()
+	total := (len(yb) + words - 1) / words
+
+	// chunk returns x times the ith chunk of y.
+	chunk := func(i int) *big.Int {
+		hi := (i + 1) * words
+		if hi > len(yb) {
+			hi = len(yb)
+		}
+		var t big.Int
+		t.SetBits(yb[i*words : hi : hi])
+		return t.Mul(x, &t)
+	}
+
+	var done <-chan struct{}
+	if opts.Ctx != nil {
+		done = opts.Ctx.Done()
+	}
+	canceled := func() error {
+		select {
+		case <-done:
+			return opts.Ctx.Err()
+		default:
+			return nil
+		}
+	}
+
+	type part struct {
+		i int
+		p *big.Int
+	}
+	// parts is buffered so that workers never block after a cancellation.
+	parts := make(chan part, total)
+	parallel := opts.Parallel > 1 && total > 1
+	if parallel {
+		jobs := make(chan int)
+		go func() {
+			defer close(jobs)
+			for i := 0; i < total; i++ {
+				select {
+				case jobs <- i:
+				case <-done:
+					return
+				}
+			}
+		}()
+		for n := 0; n < opts.Parallel && n < total; n++ {
+			go func() {
+				for i := range jobs {
+					parts <- part{i, chunk(i)}
+				}
+			}()
+		}
+	}
+
+	z := new(big.Int)
+	for n := 0; n < total; n++ {
+		if err := canceled(); err != nil {
+			return nil, err
+		}
+		var pt part
+		if parallel {
+			select {
+			case pt = <-parts:
+			case <-done:
+				return nil, opts.Ctx.Err()
+			}
+		} else {
+			pt = part{n, chunk(n)}
+		}
+		z.Add(z, pt.p.Lsh(pt.p, uint(pt.i*words*bits.UintSize)))
+		if opts.Progress != nil {
+			opts.Progress(n+1, total)
+		}
+	}
+	return z, nil
+}
@@ -0,0 +1,127 @@
+package decimal_test
+
+import (
+	"context"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+// randBig returns a random decimal with n digits and a random sign and
+// exponent.
+func randBig(rng *rand.Rand, n int) *decimal.Big {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte('0' + rng.Intn(10))
+	}
+	b[0] = byte('1' + rng.Intn(9))
+	u, _ := new(big.Int).SetString(string(b), 10)
+	if rng.Intn(2) == 0 {
+		u.Neg(u)
+	}
+	return new(decimal.Big).SetBigMantScale(u, rng.Intn(2*n)-n)
+}
+
+func TestBig_MulBig(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		x := randBig(rng, 20+rng.Intn(3000))
+		y := randBig(rng, 20+rng.Intn(3000))
+		ctx := decimal.Context{
+			Precision:    []int{0, 50, 2500, decimal.UnlimitedPrecision}[rng.Intn(4)],
+			RoundingMode: decimal.ToNearestEven + decimal.RoundingMode(rng.Intn(6)),
+		}
+		opts := decimal.MulOptions{
+			ChunkDigits: 1 + rng.Intn(500),
+			Parallel:    rng.Intn(5),
+		}
+		var calls int
+		opts.Progress = func(done, total int) {
+			calls++
+			if done != calls || done > total {
+				t.Fatalf("#%d: Progress(%d, %d) after %d calls", i, done, total, calls-1)
+			}
+		}
+
+		want := decimal.WithContext(ctx).Mul(x, y)
+		z, err := decimal.WithContext(ctx).MulBig(x, y, opts)
+		if err != nil {
+			t.Fatalf("#%d: unexpected error: %v", i, err)
+		}
+		if z.Cmp(want) != 0 || z.Scale() != want.Scale() ||
+			z.Context.Conditions != want.Context.Conditions {
+			t.Fatalf(`#%d: MulBig(%s, %s) (%+v)
+wanted: %s (%s)
+got   : %s (%s)`, i, x, y, opts, want, want.Context.Conditions, z, z.Context.Conditions)
+		}
+		if calls == 0 {
+			t.Fatalf("#%d: Progress was not called", i)
+		}
+
+		// z may alias its operands.
+		calls = 0
+		z.Copy(x)
+		z.Context = ctx
+		if z.MulBig(z, y, opts); z.Cmp(want) != 0 {
+			t.Fatalf("#%d: aliased MulBig(%s, %s): wanted %s, got %s", i, x, y, want, z)
+		}
+	}
+}
+
+func TestBig_MulBig_Small(t *testing.T) {
+	for i, test := range [...]struct {
+		x, y, want string
+	}{
+		{"1.5", "-2", "-3.0"},
+		{"Infinity", "-2", "-Infinity"},
+		{"123456789012345678901234567890", "0", "0"},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		y, _ := new(decimal.Big).SetString(test.y)
+		opts := decimal.MulOptions{Progress: func(done, total int) {
+			if test.x != "123456789012345678901234567890" {
+				t.Fatalf("#%d: unexpected Progress(%d, %d)", i, done, total)
+			}
+		}}
+		z, err := new(decimal.Big).MulBig(x, y, opts)
+		if err != nil || z.String() != test.want {
+			t.Fatalf("#%d: MulBig(%s, %s): wanted %s, got %s (%v)", i, x, y, test.want, z, err)
+		}
+	}
+}
+
+func TestBig_MulBig_Cancel(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	x, y := randBig(rng, 5000), randBig(rng, 5000)
+
+	for _, parallel := range []int{0, 4} {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		z := decimal.New(42, 0)
+		_, err := z.MulBig(x, y, decimal.MulOptions{Ctx: ctx, Parallel: parallel})
+		if err != context.Canceled || z.Cmp(decimal.New(42, 0)) != 0 {
+			t.Fatalf("canceled before (parallel: %d): wanted 42 (%v), got %s (%v)",
+				parallel, context.Canceled, z, err)
+		}
+
+		// Cancel after the first chunk.
+		ctx, cancel = context.WithCancel(context.Background())
+		var last int
+		opts := decimal.MulOptions{
+			Ctx:         ctx,
+			ChunkDigits: 100,
+			Parallel:    parallel,
+			Progress: func(done, total int) {
+				last = done
+				cancel()
+			},
+		}
+		_, err = z.MulBig(x, y, opts)
+		if err != context.Canceled || last != 1 || z.Cmp(decimal.New(42, 0)) != 0 {
+			t.Fatalf("canceled during (parallel: %d): wanted 42 (%v) after 1 chunk, got %s (%v) after %d",
+				parallel, context.Canceled, z, err, last)
+		}
+	}
+}
@@ -4,9 +4,9 @@ package decimal
 
 import "strconv"
 
-const _OperatingMode_name = "GDAGo"
+const _OperatingMode_name = "GDAGoJS"
 
-var _OperatingMode_index = [...]uint8{0, 3, 5}
+var _OperatingMode_index = [...]uint8{0, 3, 5, 7}
 
 func (i OperatingMode) String() string {
 	if i >= OperatingMode(len(_OperatingMode_index)-1) {
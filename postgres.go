@@ -0,0 +1,241 @@
+package decimal
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/big"
+	"math/bits"
+	"strconv"
+
+	"github.com/ericlagergren/decimal/internal/arith"
+	cst "github.com/ericlagergren/decimal/internal/c"
+)
+
+// Signs of a PostgreSQL numeric.
+const (
+	pgPos  = 0x0000
+	pgNeg  = 0x4000
+	pgNaN  = 0xc000
+	pgPInf = 0xd000
+	pgNInf = 0xf000
+)
+
+const (
+	pgHeader    = 8      // ndigits, weight, sign, and dscale
+	pgMaxDscale = 0x3fff // NUMERIC_DSCALE_MAX
+	pgMaxDigit  = 9999
+)
+
+// EncodePostgres returns x in the binary wire format of the PostgreSQL NUMERIC
+// type, as used by numeric_send and numeric_recv and by drivers such as pgx: a
+// header of four big-endian 16-bit integers (the number of digits, the weight
+// of the first digit, the sign, and the display scale) followed by the digits,
+// each a big-endian 16-bit integer in [0, 9999]. Each digit is a base-10000
+// digit of x aligned to the decimal point; the weight is the power of 10000 of
+// the first, and leading and trailing zero digits are omitted.
+//
+// The display scale is the scale of x, or zero if x has a negative scale;
+// e.g., 1.50 is encoded with a display scale of 2 and 1.5E+3 with one of 0.
+// PostgreSQL has no negative zero, so -0 is encoded as 0. NaN values are
+// encoded as NaN, without a payload, and infinities as the infinities
+// supported by PostgreSQL 14 and later.
+//
+// EncodePostgres returns an error if x cannot be represented by a NUMERIC:
+// its scale is larger than 16383 or its magnitude is too large or too small
+// for the weight.
+func (x *Big) EncodePostgres() ([]byte, error) {
+	if x == nil {
+		return nil, ErrNilOperand{Op: "EncodePostgres"}
+	}
+	if debug {
+		x.validate()
+	}
+
+	switch {
+	case x.IsNaN(0):
+		return pgAppendHeader(nil, 0, 0, pgNaN, 0), nil
+	case x.IsInf(+1):
+		return pgAppendHeader(nil, 0, 0, pgPInf, 0), nil
+	case x.IsInf(-1):
+		return pgAppendHeader(nil, 0, 0, pgNInf, 0), nil
+	}
+
+	dscale := 0
+	if x.exp < 0 {
+		dscale = -x.exp
+	}
+	if dscale > pgMaxDscale {
+		return nil, errors.New("decimal: EncodePostgres: scale out of range")
+	}
+	sign := uint16(pgPos)
+	if x.Signbit() {
+		sign = pgNeg
+	}
+	if x.compact == 0 {
+		return pgAppendHeader(nil, 0, 0, pgPos, dscale), nil
+	}
+
+	var (
+		digits []byte
+		buf    [23]byte // a uint64 and three zeros
+	)
+	if x.isCompact() {
+		digits = strconv.AppendUint(buf[:0], x.compact, 10)
+	} else {
+		digits = formatUnscaled(&x.unscaled)
+	}
+
+	// Align the last digit to a power of 10000 by appending zeros, then the
+	// first by conceptually prepending them.
+	exp := x.exp
+	r := exp % 4
+	if r < 0 {
+		r += 4
+	}
+	for i := 0; i < r; i++ {
+		digits = append(digits, '0')
+	}
+	exp -= r
+	lead := (4 - len(digits)%4) % 4
+	n := (lead + len(digits)) / 4
+	weight := exp/4 + n - 1
+
+	groups := make([]uint16, 0, n)
+	for i := -lead; i < len(digits); i += 4 {
+		var d uint16
+		for j := i; j < i+4; j++ {
+			d *= 10
+			if j >= 0 {
+				d += uint16(digits[j] - '0')
+			}
+		}
+		groups = append(groups, d)
+	}
+	// The coefficient is non-zero, so neither loop empties groups.
+	for groups[0] == 0 {
+		groups = groups[1:]
+		weight--
+	}
+	for groups[len(groups)-1] == 0 {
+		groups = groups[:len(groups)-1]
+	}
+	if weight > math.MaxInt16 || weight < math.MinInt16 || len(groups) > math.MaxInt16 {
+		return nil, errors.New("decimal: EncodePostgres: exponent out of range")
+	}
+
+	b := make([]byte, 0, pgHeader+2*len(groups))
+	b = pgAppendHeader(b, len(groups), weight, sign, dscale)
+	for _, d := range groups {
+		b = binary.BigEndian.AppendUint16(b, d)
+	}
+	return b, nil
+}
+
+// pgAppendHeader appends the header of a PostgreSQL numeric to b.
+func pgAppendHeader(b []byte, ndigits, weight int, sign uint16, dscale int) []byte {
+	b = binary.BigEndian.AppendUint16(b, uint16(ndigits))
+	b = binary.BigEndian.AppendUint16(b, uint16(int16(weight)))
+	b = binary.BigEndian.AppendUint16(b, sign)
+	return binary.BigEndian.AppendUint16(b, uint16(dscale))
+}
+
+// SetPostgres sets z to the PostgreSQL NUMERIC b, in the binary wire format
+// described by EncodePostgres, and returns z. The result has the display scale
+// of b as its scale, so it prints as PostgreSQL would print it: the digits
+// 1 and 5000 with a weight of 0 and a display scale of 2 are 1.50. Like
+// PostgreSQL, SetPostgres truncates any digits hidden by the display scale.
+// Trailing zero digits are allowed, and z is not rounded.
+//
+// If b is truncated or otherwise invalid, SetPostgres returns an error and z
+// is unchanged.
+func (z *Big) SetPostgres(b []byte) (*Big, error) {
+	mustNotNil("SetPostgres", z, z)
+	if len(b) < pgHeader {
+		return nil, errors.New("decimal: SetPostgres: truncated input")
+	}
+	ndigits := int(binary.BigEndian.Uint16(b[0:]))
+	weight := int(int16(binary.BigEndian.Uint16(b[2:])))
+	sign := binary.BigEndian.Uint16(b[4:])
+	dscale := int(binary.BigEndian.Uint16(b[6:]))
+	if ndigits > math.MaxInt16 || len(b) != pgHeader+2*ndigits {
+		return nil, errors.New("decimal: SetPostgres: invalid length")
+	}
+	if dscale > pgMaxDscale {
+		return nil, errors.New("decimal: SetPostgres: invalid display scale")
+	}
+
+	var neg form
+	switch sign {
+	case pgPos:
+	case pgNeg:
+		neg = signbit
+	case pgNaN:
+		z.form = qnan
+		z.compact = 0
+		return z, nil
+	case pgPInf:
+		z.form = pinf
+		return z, nil
+	case pgNInf:
+		z.form = ninf
+		return z, nil
+	default:
+		return nil, errors.New("decimal: SetPostgres: invalid sign")
+	}
+
+	digits := b[pgHeader:]
+	for i := 0; i < len(digits); i += 2 {
+		if binary.BigEndian.Uint16(digits[i:]) > pgMaxDigit {
+			return nil, errors.New("decimal: SetPostgres: invalid digit")
+		}
+	}
+	if ndigits == 0 {
+		z.setZero(neg, -dscale)
+		return z, nil
+	}
+
+	// The value is the digits, as an integer, times 10**shift, which is
+	// adjusted to the display scale.
+	shift := 4*(weight-ndigits+1) + dscale
+	if ndigits <= 4 {
+		// At most 16 decimal digits, which fit in a uint64.
+		var c uint64
+		for i := 0; i < len(digits); i += 2 {
+			c = c*10000 + uint64(binary.BigEndian.Uint16(digits[i:]))
+		}
+		if shift <= 0 {
+			if p, ok := arith.Pow10(uint64(-shift)); ok {
+				c /= p
+			} else {
+				c = 0
+			}
+			if c == 0 {
+				z.setZero(neg, -dscale)
+			} else {
+				z.setTriple(c, neg, -dscale)
+			}
+			return z, nil
+		}
+		if p, ok := arith.Pow10(uint64(shift)); ok {
+			if hi, lo := bits.Mul64(c, p); hi == 0 && lo != cst.Inflated {
+				z.setTriple(lo, neg, -dscale)
+				return z, nil
+			}
+		}
+	}
+
+	var c, t big.Int
+	for i := 0; i < len(digits); i += 2 {
+		c.Mul(&c, t.SetUint64(10000))
+		c.Add(&c, t.SetUint64(uint64(binary.BigEndian.Uint16(digits[i:]))))
+	}
+	if shift < 0 {
+		c.Quo(&c, arith.BigPow10(uint64(-shift)))
+	} else {
+		c.Mul(&c, arith.BigPow10(uint64(shift)))
+	}
+	z.SetBigMantScale(&c, dscale)
+	z.form |= neg
+	return z, nil
+}
@@ -0,0 +1,184 @@
+package decimal_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestBig_EncodePostgres(t *testing.T) {
+	for i, test := range [...]struct {
+		in, enc, out string
+	}{
+		{"0", "0000000000000000", "0"},
+		{"-0.00", "0000000000000002", "0.00"},
+		{"1", "0001000000000000" + "0001", "1"},
+		{"1.50", "0002000000000002" + "0001" + "1388", "1.50"},
+		{"-0.00012345", "0002ffff40000008" + "0001" + "0929", "-0.00012345"},
+		{"12345678.9", "0003000100000001" + "04d2" + "162e" + "2328", "12345678.9"},
+		{"1E+130", "0001002000000000" + "0064", "1" + strings.Repeat("0", 130)},
+		{"-1.5E+3", "0001000040000000" + "05dc", "-1500"},
+		{"1E-16383", "0001f00000003fff" + "000a", "1E-16383"},
+		{"18446744073709551615", "0005000400000000" + "0734" + "1a58" + "02e1" + "03bb" + "064f", "18446744073709551615"},
+		{"NaN", "00000000c0000000", "NaN"},
+		{"-sNaN12", "00000000c0000000", "NaN"},
+		{"Infinity", "00000000d0000000", "Infinity"},
+		{"-Infinity", "00000000f0000000", "-Infinity"},
+	} {
+		x, _ := new(decimal.Big).SetString(test.in)
+		b, err := x.EncodePostgres()
+		if err != nil || hex.EncodeToString(b) != test.enc {
+			t.Fatalf("#%d: %s.EncodePostgres(): wanted %s, got %x (%v)", i, test.in, test.enc, b, err)
+		}
+		z := decimal.New(7, 0)
+		if _, err := z.SetPostgres(b); err != nil || z.String() != test.out {
+			t.Fatalf("#%d: SetPostgres(%x): wanted %s, got %s (%v)", i, b, test.out, z, err)
+		}
+	}
+
+	for i, s := range [...]string{"1E-16384", "1E+131072"} {
+		x, _ := new(decimal.Big).SetString(s)
+		if b, err := x.EncodePostgres(); err == nil {
+			t.Fatalf("#%d: %s.EncodePostgres(): wanted an error, got %x", i, s, b)
+		}
+	}
+}
+
+func TestBig_SetPostgres(t *testing.T) {
+	for i, test := range [...]struct {
+		enc, out string
+	}{
+		// Trailing zero digits.
+		{"0004000000000002" + "0001" + "1388" + "0000" + "0000", "1.50"},
+		// Leading zero digits.
+		{"0002000100000000" + "0000" + "0007", "7"},
+		// Digits hidden by the display scale are truncated.
+		{"0002000000000002" + "0001" + "162e", "1.56"},
+		{"0001fffe40000002" + "0001", "-0.00"},
+		// A display scale larger than the digits.
+		{"0001000000000003" + "0002", "2.000"},
+		// Many digits.
+		{"0006000200000004" + "270f" + "270f" + "270f" + "270f" + "270f" + "270f", "999999999999.9999"},
+		{"0005000000000000" + "0001" + "0000" + "0000" + "0000" + "0000", "1"},
+	} {
+		z := new(decimal.Big)
+		if _, err := z.SetPostgres(pg(t, test.enc)); err != nil || z.String() != test.out {
+			t.Fatalf("#%d: SetPostgres(%s): wanted %s, got %s (%v)", i, test.enc, test.out, z, err)
+		}
+	}
+
+	for i, enc := range [...]string{
+		"",
+		"00000000000000",                   // truncated header
+		"0001000000000000",                 // missing digit
+		"00000000000000000000",             // trailing data
+		"0001000000000000" + "2710",        // digit > 9999
+		"0000000012340000",                 // invalid sign
+		"0000000000004000",                 // invalid display scale
+		"8000000000000000" + "0000",        // invalid length
+		"0000000000000000" + "0001" + "00", // odd length
+	} {
+		z := decimal.New(125, 2)
+		if _, err := z.SetPostgres(pg(t, enc)); err == nil {
+			t.Fatalf("#%d: SetPostgres(%s): wanted an error, got %s", i, enc, z)
+		}
+		if z.String() != "1.25" {
+			t.Fatalf("#%d: SetPostgres(%s): modified z: %s", i, enc, z)
+		}
+	}
+
+	var x *decimal.Big
+	if _, err := x.EncodePostgres(); err == nil {
+		t.Fatal("EncodePostgres: wanted an error for a nil receiver")
+	}
+	defer func() {
+		if _, ok := recover().(decimal.ErrNilOperand); !ok {
+			t.Fatal("SetPostgres: wanted a panic for a nil receiver")
+		}
+	}()
+	x.SetPostgres(pg(t, "0000000000000000"))
+}
+
+// TestBig_Postgres_Random checks that finite values round trip: the value is
+// unchanged, and the scale is the display scale.
+func TestBig_Postgres_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(280))
+	for i := 0; i < 5000; i++ {
+		var mant big.Int
+		mant.Rand(rng, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(1+rng.Intn(60))), nil))
+		if rng.Intn(2) == 0 {
+			mant.Neg(&mant)
+		}
+		x := new(decimal.Big).SetBigMantScale(&mant, rng.Intn(200)-100)
+		b, err := x.EncodePostgres()
+		if err != nil {
+			t.Fatalf("#%d: %s.EncodePostgres(): %v", i, x, err)
+		}
+		z, err := new(decimal.Big).SetPostgres(b)
+		if err != nil {
+			t.Fatalf("#%d: SetPostgres(%x): %v", i, b, err)
+		}
+		scale := x.Scale()
+		if scale < 0 {
+			scale = 0
+		}
+		if z.Cmp(x) != 0 || z.Scale() != scale {
+			t.Fatalf("#%d: %s: round tripped to %s", i, x, z)
+		}
+	}
+}
+
+func pg(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+var pgBenchInput = func() []*decimal.Big {
+	rng := rand.New(rand.NewSource(280))
+	xs := make([]*decimal.Big, 1024)
+	for i := range xs {
+		xs[i] = decimal.New(rng.Int63n(1e12), rng.Intn(8))
+	}
+	return xs
+}()
+
+func BenchmarkBig_EncodePostgres(b *testing.B) {
+	b.Run("binary", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			pgBenchInput[i%len(pgBenchInput)].EncodePostgres()
+		}
+	})
+	b.Run("text", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = []byte(pgBenchInput[i%len(pgBenchInput)].String())
+		}
+	})
+}
+
+func BenchmarkBig_SetPostgres(b *testing.B) {
+	bin := make([][]byte, len(pgBenchInput))
+	text := make([]string, len(pgBenchInput))
+	for i, x := range pgBenchInput {
+		bin[i], _ = x.EncodePostgres()
+		text[i] = x.String()
+	}
+	var z decimal.Big
+	b.Run("binary", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			z.SetPostgres(bin[i%len(bin)])
+		}
+	})
+	b.Run("text", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			z.SetString(text[i%len(text)])
+		}
+	})
+}
@@ -0,0 +1,173 @@
+package decimal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Range is an interval of decimals with lower bound Lo and upper bound Hi. Each
+// bound is included in the interval if its corresponding Incl field is true.
+// Either bound may be infinite, which leaves that side of the interval
+// unbounded.
+//
+// A Range must be valid (see Validate) for its methods to have meaningful
+// results. Methods returning a Range do not copy its bounds.
+type Range struct {
+	Lo, Hi         *Big
+	LoIncl, HiIncl bool
+}
+
+// Validate returns an error if r is not a valid interval: both bounds must be
+// non-nil, neither may be NaN, and Lo must not be greater than Hi.
+func (r Range) Validate() error {
+	switch {
+	case r.Lo == nil || r.Hi == nil:
+		return errors.New("decimal: range has a nil bound")
+	case r.Lo.IsNaN(0) || r.Hi.IsNaN(0):
+		return errors.New("decimal: range has a NaN bound")
+	case r.Lo.Cmp(r.Hi) > 0:
+		return fmt.Errorf("decimal: range lower bound %s is greater than upper bound %s", r.Lo, r.Hi)
+	}
+	return nil
+}
+
+// valid is like Validate, but returns a bool.
+func (r Range) valid() bool {
+	return r.Lo != nil && r.Hi != nil &&
+		!r.Lo.IsNaN(0) && !r.Hi.IsNaN(0) && r.Lo.Cmp(r.Hi) <= 0
+}
+
+// IsEmpty reports whether r contains no values. An invalid Range is empty.
+func (r Range) IsEmpty() bool {
+	return !r.valid() || (r.Lo.Cmp(r.Hi) == 0 && !(r.LoIncl && r.HiIncl))
+}
+
+// Contains reports whether x is inside r. It is false if x is NaN or if r is
+// invalid.
+func (r Range) Contains(x *Big) bool {
+	mustNotNil("Contains", x, x)
+	if x.IsNaN(0) || !r.valid() {
+		return false
+	}
+	if c := x.Cmp(r.Lo); c < 0 || (c == 0 && !r.LoIncl) {
+		return false
+	}
+	if c := x.Cmp(r.Hi); c > 0 || (c == 0 && !r.HiIncl) {
+		return false
+	}
+	return true
+}
+
+// Overlaps reports whether r and o have at least one value in common.
+func (r Range) Overlaps(o Range) bool {
+	_, ok := r.Intersect(o)
+	return ok
+}
+
+// Intersect returns the interval of values contained in both r and o. The
+// returned boolean is false if the intersection is empty or either Range is
+// invalid.
+func (r Range) Intersect(o Range) (Range, bool) {
+	if !r.valid() || !o.valid() {
+		return Range{}, false
+	}
+
+	var z Range
+	switch c := r.Lo.Cmp(o.Lo); {
+	case c > 0:
+		z.Lo, z.LoIncl = r.Lo, r.LoIncl
+	case c < 0:
+		z.Lo, z.LoIncl = o.Lo, o.LoIncl
+	default:
+		z.Lo, z.LoIncl = r.Lo, r.LoIncl && o.LoIncl
+	}
+	switch c := r.Hi.Cmp(o.Hi); {
+	case c < 0:
+		z.Hi, z.HiIncl = r.Hi, r.HiIncl
+	case c > 0:
+		z.Hi, z.HiIncl = o.Hi, o.HiIncl
+	default:
+		z.Hi, z.HiIncl = r.Hi, r.HiIncl && o.HiIncl
+	}
+	if z.IsEmpty() {
+		return Range{}, false
+	}
+	return z, true
+}
+
+// String returns r in interval notation, e.g. "[1.5, 10)".
+func (r Range) String() string {
+	var b strings.Builder
+	if r.LoIncl {
+		b.WriteByte('[')
+	} else {
+		b.WriteByte('(')
+	}
+	b.WriteString(r.Lo.String())
+	b.WriteString(", ")
+	b.WriteString(r.Hi.String())
+	if r.HiIncl {
+		b.WriteByte(']')
+	} else {
+		b.WriteByte(')')
+	}
+	return b.String()
+}
+
+// MarshalJSON implements json.Marshaler. r is encoded as a JSON string in
+// interval notation; see String.
+func (r Range) MarshalJSON() ([]byte, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(r.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts a JSON string in the
+// interval notation produced by MarshalJSON. Each bound may be in any of the
+// formats accepted by SetString.
+func (r *Range) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	bad := func() error { return fmt.Errorf("decimal: invalid range %q", s) }
+
+	s = strings.TrimSpace(s)
+	if len(s) < 2 {
+		return bad()
+	}
+	var z Range
+	switch s[0] {
+	case '[':
+		z.LoIncl = true
+	case '(':
+	default:
+		return bad()
+	}
+	switch s[len(s)-1] {
+	case ']':
+		z.HiIncl = true
+	case ')':
+	default:
+		return bad()
+	}
+	parts := strings.Split(s[1:len(s)-1], ",")
+	if len(parts) != 2 {
+		return bad()
+	}
+	var ok bool
+	if z.Lo, ok = new(Big).SetString(strings.TrimSpace(parts[0])); !ok {
+		return bad()
+	}
+	if z.Hi, ok = new(Big).SetString(strings.TrimSpace(parts[1])); !ok {
+		return bad()
+	}
+	if err := z.Validate(); err != nil {
+		return err
+	}
+	*r = z
+	return nil
+}
@@ -0,0 +1,129 @@
+package decimal_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func mustRange(t *testing.T, s string) decimal.Range {
+	var r decimal.Range
+	if err := json.Unmarshal([]byte(`"`+s+`"`), &r); err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestRange_Contains(t *testing.T) {
+	for i, test := range [...]struct {
+		r  string
+		x  string
+		ok bool
+	}{
+		{"[1, 10]", "1", true},
+		{"[1, 10]", "10", true},
+		{"[1, 10]", "1.00", true},
+		{"(1, 10]", "1", false},
+		{"(1, 10]", "1.0000000000000000000001", true},
+		{"[1, 10)", "10", false},
+		{"[1, 10)", "9.999", true},
+		{"[1, 10]", "0.999", false},
+		{"[1, 10]", "NaN", false},
+		{"[1, 1]", "1", true},
+		{"(1, 1]", "1", false},
+		{"(-Infinity, 5]", "-1e1000", true},
+		{"(-Infinity, 5]", "-Infinity", false},
+		{"[-Infinity, 5]", "-Infinity", true},
+		{"[0, Infinity)", "1e1000", true},
+		{"[0, Infinity)", "-0", true},
+	} {
+		r := mustRange(t, test.r)
+		x, _ := new(decimal.Big).SetString(test.x)
+		if ok := r.Contains(x); ok != test.ok {
+			t.Fatalf("#%d: %s.Contains(%s): wanted %t, got %t", i, test.r, test.x, test.ok, ok)
+		}
+	}
+}
+
+func TestRange_Intersect(t *testing.T) {
+	for i, test := range [...]struct {
+		a, b string
+		r    string // empty if no intersection
+	}{
+		{"[1, 10]", "[5, 20]", "[5, 10]"},
+		{"[1, 10)", "[5, 20]", "[5, 10)"},
+		{"[1, 10]", "(10, 20]", ""},
+		{"[1, 10]", "[10, 20]", "[10, 10]"},
+		{"[1, 10)", "[10, 20]", ""},
+		{"(1, 10)", "[1, 10]", "(1, 10)"},
+		{"[1, 10]", "[2, 3]", "[2, 3]"},
+		{"(-Infinity, 0]", "[0, Infinity)", "[0, 0]"},
+		{"(-Infinity, Infinity)", "(5, 6]", "(5, 6]"},
+		{"[1, 2]", "[3, 4]", ""},
+	} {
+		a, b := mustRange(t, test.a), mustRange(t, test.b)
+		for _, p := range [...][2]decimal.Range{{a, b}, {b, a}} {
+			r, ok := p[0].Intersect(p[1])
+			if ok != (test.r != "") || p[0].Overlaps(p[1]) != ok {
+				t.Fatalf("#%d: %s ∩ %s: wanted %q, got (%s, %t)", i, p[0], p[1], test.r, r, ok)
+			}
+			if ok && r.String() != test.r {
+				t.Fatalf("#%d: %s ∩ %s: wanted %s, got %s", i, p[0], p[1], test.r, r)
+			}
+		}
+	}
+}
+
+func TestRange_Validate(t *testing.T) {
+	one, two := decimal.New(1, 0), decimal.New(2, 0)
+	nan := new(decimal.Big).SetNaN(false)
+	for i, r := range [...]decimal.Range{
+		{Lo: nil, Hi: one},
+		{Lo: one, Hi: nil},
+		{Lo: nan, Hi: one},
+		{Lo: one, Hi: nan},
+		{Lo: two, Hi: one},
+	} {
+		if r.Validate() == nil {
+			t.Fatalf("#%d: wanted an error", i)
+		}
+		if r.Contains(one) || r.Overlaps(decimal.Range{Lo: one, Hi: two, LoIncl: true, HiIncl: true}) {
+			t.Fatalf("#%d: invalid range must be empty", i)
+		}
+	}
+	if err := (decimal.Range{Lo: one, Hi: one}).Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRange_JSON(t *testing.T) {
+	for i, s := range []string{
+		`"[1.5, 10)"`,
+		`"(-Infinity, 0]"`,
+		`"(0.001, Infinity)"`,
+	} {
+		var r decimal.Range
+		if err := json.Unmarshal([]byte(s), &r); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		b, err := json.Marshal(r)
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if string(b) != s {
+			t.Fatalf("#%d: wanted %s, got %s", i, s, b)
+		}
+	}
+	for i, s := range []string{
+		`"1, 2"`, `"[1, 2"`, `"[1; 2]"`, `"[2, 1]"`, `"[a, 2]"`, `"[NaN, 2]"`, `"[1, 2, 3]"`, `12`,
+	} {
+		var r decimal.Range
+		if err := json.Unmarshal([]byte(s), &r); err == nil {
+			t.Fatalf("#%d: %s: wanted an error, got %s", i, s, r)
+		}
+	}
+	if _, err := json.Marshal(decimal.Range{Lo: decimal.New(2, 0), Hi: decimal.New(1, 0)}); err == nil {
+		t.Fatal("wanted an error marshaling an invalid range")
+	}
+}
@@ -0,0 +1,63 @@
+package decimal
+
+// pctChangeGuard is the number of guard digits PctChange uses for the
+// difference of its operands.
+const pctChangeGuard = 5
+
+// PctChange sets z to the relative change from old to new, (new - old) / old,
+// rounded using z's Context, and returns z. The result is a fraction: a
+// change from 80 to 100 is 0.25, i.e., 25%.
+//
+// The difference is computed with pctChangeGuard more digits than z's
+// Context, so it is exact unless the operands have many more digits than
+// the Context's precision; computing it with Sub and Quo rounds the
+// difference to the Context's precision first, which biases the result.
+//
+// If old is zero the result is that of Quo: ±Inf, raising DivisionByZero, if
+// new is not zero, and NaN, raising DivisionUndefined, if it is.
+func PctChange(z, old, new *Big) *Big {
+	ctx := z.context("PctChange")
+	if z.checkNil("PctChange", old, new) {
+		return z
+	}
+	prec := precision(ctx)
+	if prec <= MaxPrecision-pctChangeGuard {
+		prec += pctChangeGuard
+	}
+	var d Big
+	d.Context.OperatingMode = ctx.OperatingMode
+	Context{Precision: prec, OperatingMode: ctx.OperatingMode}.Sub(&d, new, old)
+	if d.IsNaN(0) {
+		z.Context.Conditions |= d.Context.Conditions
+		return z.Copy(&d)
+	}
+	if d.Context.Conditions&Inexact == 0 {
+		return ctx.Quo(z, &d, old)
+	}
+	if ctx.ExactOnly {
+		return ctx.setInexact(z, "PctChange", -1)
+	}
+	ctx.Quo(z, &d, old)
+	z.Context.Conditions |= Inexact | Rounded
+	return z
+}
+
+// Ratio sets z to part / whole, rounded using z's Context, and returns z. It
+// is identical to z.Quo(part, whole), including the handling of a zero whole.
+func Ratio(z, part, whole *Big) *Big {
+	return z.context("Ratio").Quo(z, part, whole)
+}
+
+// BasisPoints sets z to x × 10000, the number of basis points in the
+// fraction x, and returns z. The result is exact; it is not rounded to z's
+// Context.
+func BasisPoints(z, x *Big) *Big {
+	if z.checkNil("BasisPoints", x, x) {
+		return z
+	}
+	z.Copy(x)
+	if z.IsFinite() {
+		z.exp += 4
+	}
+	return z
+}
@@ -0,0 +1,116 @@
+package decimal_test
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestPctChange(t *testing.T) {
+	for i, test := range [...]struct {
+		old, new string
+		want     string
+		conds    decimal.Condition
+	}{
+		{"80", "100", "0.25", 0},
+		{"100", "80", "-0.2", 0},
+		{"3", "4", "0.3333333333333333", decimal.Inexact | decimal.Rounded},
+		{"1.00", "1.00", "0", 0},
+		{"0", "5", "Infinity", decimal.DivisionByZero},
+		{"0", "-5", "-Infinity", decimal.DivisionByZero},
+		{"0", "0", "NaN3", decimal.DivisionUndefined | decimal.InvalidOperation},
+		{"Infinity", "Infinity", "NaN8", decimal.InvalidOperation},
+		// Sub then Quo rounds the difference to 9.999999999999999 first.
+		{"1", "10.99999999999999949", "9.999999999999999", decimal.Inexact | decimal.Rounded},
+		{"3", "1E+10000000", "3.333333333333333E+9999999", decimal.Inexact | decimal.Rounded},
+		{"1E+10000000", "3", "-1.000000000000000", decimal.Inexact | decimal.Rounded},
+	} {
+		old, _ := new(decimal.Big).SetString(test.old)
+		nw, _ := new(decimal.Big).SetString(test.new)
+		z := decimal.PctChange(new(decimal.Big), old, nw)
+		if z.String() != test.want || z.Context.Conditions != test.conds {
+			t.Fatalf("#%d: PctChange(%s, %s): wanted %s (%s), got %s (%s)",
+				i, test.old, test.new, test.want, test.conds, z, z.Context.Conditions)
+		}
+	}
+}
+
+// TestPctChange_SingleRounding checks that PctChange is correctly rounded, and
+// that composing Sub and Quo is not.
+func TestPctChange_SingleRounding(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	ctx := decimal.Context64
+	ctx.Traps = 0
+
+	n := 20000
+	if testing.Short() {
+		n = 2000
+	}
+	naive := 0
+	for i := 0; i < n; i++ {
+		// Operands, and their difference, with more digits than the
+		// precision.
+		var a, b big.Int
+		a.Rand(rng, new(big.Int).Exp(big.NewInt(10), big.NewInt(20), nil))
+		b.Rand(rng, new(big.Int).Exp(big.NewInt(10), big.NewInt(19), nil))
+		old := new(decimal.Big).SetBigMantScale(&a, 10)
+		delta := new(decimal.Big).SetBigMantScale(&b, 10+rng.Intn(3))
+		nw := decimal.ContextUnlimited.Add(new(decimal.Big), old, delta)
+		if old.Sign() == 0 {
+			continue
+		}
+
+		exact := new(big.Rat).Sub(nw.Rat(nil), old.Rat(nil))
+		exact.Quo(exact, old.Rat(nil))
+		want := decimal.WithContext(ctx)
+		if exact.Sign() != 0 {
+			e := ilog10(exact) - ctx.Precision + 1
+			q := roundRat(new(big.Rat).Quo(exact, pow10Rat(e)), ctx.RoundingMode)
+			want.SetRat(new(big.Rat).Mul(new(big.Rat).SetInt(q), pow10Rat(e)))
+		}
+
+		got := decimal.PctChange(decimal.WithContext(ctx), old, nw)
+		if got.Cmp(want) != 0 {
+			t.Fatalf("#%d: PctChange(%s, %s): wanted %s, got %s", i, old, nw, want, got)
+		}
+		d := ctx.Sub(new(decimal.Big), nw, old)
+		if ctx.Quo(d, d, old).Cmp(want) != 0 {
+			naive++
+		}
+	}
+	if naive == 0 {
+		t.Fatal("Sub followed by Quo was always correctly rounded")
+	}
+	t.Logf("Sub followed by Quo was incorrectly rounded %d times in %d", naive, n)
+}
+
+func TestRatio(t *testing.T) {
+	z := decimal.Ratio(new(decimal.Big), decimal.New(1, 0), decimal.New(8, 0))
+	if z.String() != "0.125" {
+		t.Fatalf("Ratio(1, 8): wanted 0.125, got %s", z)
+	}
+	z = decimal.Ratio(new(decimal.Big), decimal.New(1, 0), decimal.New(0, 0))
+	if !z.IsInf(+1) || z.Context.Conditions != decimal.DivisionByZero {
+		t.Fatalf("Ratio(1, 0): wanted Infinity (division by zero), got %s (%s)", z, z.Context.Conditions)
+	}
+}
+
+func TestBasisPoints(t *testing.T) {
+	for i, test := range [...]struct {
+		x, want string
+	}{
+		{"0.0125", "125"},
+		{"1.23456789012345678901", "12345.6789012345678901"},
+		{"-0.00001", "-0.1"},
+		{"0", "0E+4"},
+		{"-Infinity", "-Infinity"},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		z := decimal.BasisPoints(decimal.WithPrecision(5), x)
+		if z.String() != test.want || z.Context.Conditions != 0 {
+			t.Fatalf("#%d: BasisPoints(%s): wanted %s, got %s (%s)", i, test.x, test.want, z, z.Context.Conditions)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package decimal
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/tabwriter"
+)
+
+var updateRoundingMatrix = flag.Bool("update-rounding-matrix", false,
+	"regenerate the golden rounding matrix")
+
+// roundingMatrixFile is the golden rounding matrix. It documents how each
+// value is quantized to each scale under each RoundingMode; a change in
+// rounding behavior shows up as a diff of this file.
+var roundingMatrixFile = filepath.Join("_testdata", "rounding-matrix.txt")
+
+// The rows of the rounding matrix: ties, values on either side of ties, values
+// that carry, and values that round to zero, of both signs.
+var (
+	roundingMatrixValues = []string{
+		"2.345", "-2.345", "2.355", "2.3451", "2.3449",
+		"2.5", "-2.5", "3.5", "0.5", "-0.5",
+		"1.005", "9.995", "-9.995", "0.004", "-0.004", "0.001",
+		"12345", "-0",
+	}
+	roundingMatrixScales = []int{2, 1, 0, -1}
+)
+
+// renderRoundingMatrix writes the result of quantizing each of values to each
+// of scales under each RoundingMode, along with the conditions raised.
+func renderRoundingMatrix(values []string, scales []int) string {
+	var b bytes.Buffer
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	for _, v := range values {
+		for _, n := range scales {
+			fmt.Fprintf(w, "%s at scale %d\n", v, n)
+			for m := ToNearestEven; m <= ToPositiveInf; m++ {
+				x, _ := new(Big).SetString(v)
+				Context{RoundingMode: m}.Quantize(x, n)
+				fmt.Fprintf(w, "\t%s\t%s\t%s\n", m, x, x.Context.Conditions)
+			}
+		}
+	}
+	w.Flush()
+	// Trim the padding tabwriter leaves before empty condition cells.
+	lines := strings.Split(b.String(), "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(l, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestRoundingMatrix_Golden(t *testing.T) {
+	got := renderRoundingMatrix(roundingMatrixValues, roundingMatrixScales)
+	if *updateRoundingMatrix {
+		if err := ioutil.WriteFile(roundingMatrixFile, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := ioutil.ReadFile(roundingMatrixFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == string(want) {
+		return
+	}
+	gl, wl := strings.Split(got, "\n"), strings.Split(string(want), "\n")
+	for i := 0; i < len(gl) || i < len(wl); i++ {
+		var g, w string
+		if i < len(gl) {
+			g = gl[i]
+		}
+		if i < len(wl) {
+			w = wl[i]
+		}
+		if g != w {
+			t.Fatalf(`%s:%d: rounding behavior changed (run with -update-rounding-matrix if intended)
+wanted: %s
+got   : %s`, roundingMatrixFile, i+1, w, g)
+		}
+	}
+}
+
+// This example shows how a tie is quantized under each RoundingMode. See
+// _testdata/rounding-matrix.txt for more values and scales.
+func ExampleContext_Quantize_roundingModes() {
+	fmt.Print(renderRoundingMatrix([]string{"2.345", "-2.345"}, []int{2}))
+	// Output:
+	// 2.345 at scale 2
+	//   ToNearestEven  2.34  inexact, rounded
+	//   ToNearestAway  2.35  inexact, rounded
+	//   ToZero         2.34  inexact, rounded
+	//   AwayFromZero   2.35  inexact, rounded
+	//   ToNegativeInf  2.34  inexact, rounded
+	//   ToPositiveInf  2.35  inexact, rounded
+	// -2.345 at scale 2
+	//   ToNearestEven  -2.34  inexact, rounded
+	//   ToNearestAway  -2.35  inexact, rounded
+	//   ToZero         -2.34  inexact, rounded
+	//   AwayFromZero   -2.35  inexact, rounded
+	//   ToNegativeInf  -2.35  inexact, rounded
+	//   ToPositiveInf  -2.34  inexact, rounded
+}
@@ -4,9 +4,9 @@ package decimal
 
 import "strconv"
 
-const _RoundingMode_name = "ToNearestEvenToNearestAwayToZeroAwayFromZeroToNegativeInfToPositiveInfunnecessary"
+const _RoundingMode_name = "DefaultRoundingToNearestEvenToNearestAwayToZeroAwayFromZeroToNegativeInfToPositiveInfunnecessary"
 
-var _RoundingMode_index = [...]uint8{0, 13, 26, 32, 44, 57, 70, 81}
+var _RoundingMode_index = [...]uint8{0, 15, 28, 41, 47, 59, 72, 85, 96}
 
 func (i RoundingMode) String() string {
 	if i >= RoundingMode(len(_RoundingMode_index)-1) {
@@ -0,0 +1,71 @@
+package decimal_test
+
+import (
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestBig_RoundSig(t *testing.T) {
+	const r = decimal.Inexact | decimal.Rounded
+	for i, test := range [...]struct {
+		x    string
+		sig  int
+		want [6]string // indexed by RoundingMode
+		c    decimal.Condition
+	}{
+		// Ties.
+		{"2.5", 1, [6]string{"2", "3", "2", "3", "2", "3"}, r},
+		{"-2.5", 1, [6]string{"-2", "-3", "-2", "-3", "-3", "-2"}, r},
+		{"3.5", 1, [6]string{"4", "4", "3", "4", "3", "4"}, r},
+		{"0.0125", 2, [6]string{"0.012", "0.013", "0.012", "0.013", "0.012", "0.013"}, r},
+		{"-12350", 3, [6]string{"-1.24E+4", "-1.24E+4", "-1.23E+4", "-1.24E+4", "-1.24E+4", "-1.23E+4"}, r},
+		// Either side of a tie.
+		{"2.51", 1, [6]string{"3", "3", "2", "3", "2", "3"}, r},
+		{"-2.49", 1, [6]string{"-2", "-2", "-2", "-3", "-3", "-2"}, r},
+		// Carries.
+		{"9.95", 2, [6]string{"10", "10", "9.9", "10", "9.9", "10"}, r},
+		{"99999", 2, [6]string{"1.0E+5", "1.0E+5", "9.9E+4", "1.0E+5", "9.9E+4", "1.0E+5"}, r},
+		// Trailing zeros count as significant digits.
+		{"12.300", 3, [6]string{"12.3", "12.3", "12.3", "12.3", "12.3", "12.3"}, decimal.Rounded},
+		// Nothing to round.
+		{"1.5", 4, [6]string{"1.5", "1.5", "1.5", "1.5", "1.5", "1.5"}, 0},
+		{"-0.00", 1, [6]string{"-0.00", "-0.00", "-0.00", "-0.00", "-0.00", "-0.00"}, 0},
+		{"Infinity", 1, [6]string{"Infinity", "Infinity", "Infinity", "Infinity", "Infinity", "Infinity"}, 0},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		for m, want := range test.want {
+			ctx := decimal.Context{Precision: 3, RoundingMode: decimal.ToNearestEven + decimal.RoundingMode(m)}
+			z := decimal.WithContext(ctx).RoundSig(x, test.sig)
+			if z.String() != want || z.Context.Conditions != test.c {
+				t.Fatalf("#%d: RoundSig(%s, %d) (%s): wanted %s (%s), got %s (%s)",
+					i, test.x, test.sig, decimal.ToNearestEven+decimal.RoundingMode(m), want, test.c, z, z.Context.Conditions)
+			}
+			if z.Context.Precision != 3 {
+				t.Fatalf("#%d: RoundSig changed the precision to %d", i, z.Context.Precision)
+			}
+		}
+	}
+}
+
+func TestBig_RoundSig_Invalid(t *testing.T) {
+	for i, test := range [...]struct {
+		x   string
+		sig int
+	}{
+		{"1.5", 0},
+		{"1.5", -3},
+		{"sNaN", 2},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		z := new(decimal.Big).RoundSig(x, test.sig)
+		if !z.IsNaN(0) || z.Context.Conditions != decimal.InvalidOperation {
+			t.Fatalf("#%d: RoundSig(%s, %d): wanted NaN (invalid operation), got %s (%s)",
+				i, test.x, test.sig, z, z.Context.Conditions)
+		}
+	}
+	z := new(decimal.Big).RoundSig(new(decimal.Big).SetNaN(false), 2)
+	if !z.IsNaN(0) || z.Context.Conditions != 0 {
+		t.Fatalf("RoundSig(NaN, 2): wanted NaN, got %s (%s)", z, z.Context.Conditions)
+	}
+}
@@ -0,0 +1,182 @@
+package decimal
+
+import (
+	"errors"
+	"strconv"
+)
+
+// RunningTotal is a sum of decimals to which values can be added and from
+// which they can be removed, such as the total of a shopping cart. The sum is
+// kept exactly, so removing a value exactly reverses adding it, and the only
+// rounding is performed by Total. (The value is restored exactly, but the
+// sum's exponent may keep trailing zeros; e.g., adding and removing 0.01 from
+// a total of 1 leaves 1.00.)
+//
+// Since the sum is exact, its digits span every digit of the values added to
+// it, starting from a sum of 0, which is limited to maxRunningDigits (10,000)
+// digits: e.g., 1E+5000 and 1E-5000 cannot both be added, and neither can
+// 1E+10000.
+//
+// Changes are recorded for Rollback from the first call to Snapshot until the
+// next call to Commit, so a RunningTotal that is not rolled back should be
+// committed periodically.
+//
+// The zero value is an empty RunningTotal ready to use. A RunningTotal must
+// not be copied after first use, and it is not safe for concurrent use.
+type RunningTotal struct {
+	sum     Big
+	count   map[Key]int     // number of times each value has been added
+	log     []runningChange // changes since the oldest snapshot
+	base    int             // number of changes made before log[0]
+	logging bool            // whether a snapshot may be rolled back to
+	baseSeq uint64          // sequence number of the change before log[0]
+	seq     uint64          // sequence number of the most recent change
+}
+
+// maxRunningDigits is the largest number of digits that the exact sum of a
+// RunningTotal may span.
+const maxRunningDigits = 10000
+
+// runningChange is a change to a RunningTotal.
+type runningChange struct {
+	key    Key
+	remove bool
+	seq    uint64
+}
+
+// RunningTotalSnapshot identifies a state of a RunningTotal. See Snapshot.
+type RunningTotalSnapshot struct {
+	t   *RunningTotal
+	n   int    // number of changes made before the snapshot
+	seq uint64 // sequence number of the last of those changes
+}
+
+// Add adds x to t. It returns an error, and t is unchanged, if x is nil or
+// not finite, or if the exact sum would span more than maxRunningDigits
+// digits.
+func (t *RunningTotal) Add(x *Big) error {
+	k, err := runningKey("Add", x)
+	if err != nil {
+		return err
+	}
+	// The exponents are within [MinScale - MaxPrecision, MaxScale], so their
+	// difference does not overflow.
+	lo, hi := x.exp, x.adjusted()
+	if t.sum.exp < lo {
+		lo = t.sum.exp
+	}
+	if adj := t.sum.adjusted(); adj > hi {
+		hi = adj
+	}
+	if hi-lo+1 > maxRunningDigits {
+		return errors.New("decimal: RunningTotal.Add: the sum with " + x.String() +
+			" would have more than " + strconv.Itoa(maxRunningDigits) + " digits")
+	}
+	t.apply(k, false)
+	return nil
+}
+
+// Remove removes x from t. It returns an error, and t is unchanged, if a value
+// equal to x has not been added to t more times than it has been removed.
+// Removal depends only on the value: a value of 2.50 can be removed after
+// adding 2.5.
+func (t *RunningTotal) Remove(x *Big) error {
+	k, err := runningKey("Remove", x)
+	if err != nil {
+		return err
+	}
+	if t.count[k] == 0 {
+		return errors.New("decimal: RunningTotal.Remove: " + x.String() + " was not added")
+	}
+	t.apply(k, true)
+	return nil
+}
+
+func runningKey(op string, x *Big) (Key, error) {
+	if x == nil {
+		return Key{}, ErrNilOperand{Op: "RunningTotal." + op}
+	}
+	if !x.IsFinite() {
+		return Key{}, errors.New("decimal: RunningTotal." + op + ": " + x.String() + " is not finite")
+	}
+	return NewKey(x)
+}
+
+// apply adds or removes k and, if a snapshot may be rolled back to, records
+// the change.
+func (t *RunningTotal) apply(k Key, remove bool) {
+	t.update(k, remove)
+	t.seq++
+	if !t.logging {
+		t.base++
+		t.baseSeq = t.seq
+		return
+	}
+	t.log = append(t.log, runningChange{key: k, remove: remove, seq: t.seq})
+}
+
+// update adds or removes k without recording the change.
+func (t *RunningTotal) update(k Key, remove bool) {
+	if t.count == nil {
+		t.count = make(map[Key]int)
+	}
+	var x Big
+	k.Big(&x)
+	ctx := Context{Precision: UnlimitedPrecision}
+	if remove {
+		ctx.Sub(&t.sum, &t.sum, &x)
+		if t.count[k]--; t.count[k] == 0 {
+			delete(t.count, k)
+		}
+	} else {
+		ctx.Add(&t.sum, &t.sum, &x)
+		t.count[k]++
+	}
+}
+
+// Snapshot returns the current state of t, to which Rollback can return t
+// until the next call to Commit.
+func (t *RunningTotal) Snapshot() RunningTotalSnapshot {
+	t.logging = true
+	s := RunningTotalSnapshot{t: t, n: t.base + len(t.log), seq: t.baseSeq}
+	if len(t.log) > 0 {
+		s.seq = t.log[len(t.log)-1].seq
+	}
+	return s
+}
+
+// Rollback returns t to the state identified by s by reversing, exactly, each
+// change made since s was taken. It returns an error, and t is unchanged, if s
+// was not taken from t, if t has since been rolled back to a state before s,
+// or if t has been committed since s was taken.
+func (t *RunningTotal) Rollback(s RunningTotalSnapshot) error {
+	n := s.n - t.base
+	if s.t != t || !t.logging || n < 0 || n > len(t.log) ||
+		(n == 0 && s.seq != t.baseSeq) || (n > 0 && t.log[n-1].seq != s.seq) {
+		return errors.New("decimal: RunningTotal.Rollback: invalid snapshot")
+	}
+	for i := len(t.log) - 1; i >= n; i-- {
+		t.update(t.log[i].key, !t.log[i].remove)
+	}
+	t.log = t.log[:n]
+	return nil
+}
+
+// Commit discards the record of the changes made to t, so that t can no longer
+// be rolled back to any snapshot taken before the call.
+func (t *RunningTotal) Commit() {
+	t.base += len(t.log)
+	t.log = nil
+	t.logging = false
+	// Give the committed state a new sequence number so that the snapshots
+	// taken before it are invalid.
+	t.seq++
+	t.baseSeq = t.seq
+}
+
+// Total sets z to the sum of the values in t, rounded once using z's Context,
+// and returns z. The sum of no values is 0.
+func (t *RunningTotal) Total(z *Big) *Big {
+	mustNotNil("Total", z, z)
+	return z.Context.Round(z.Copy(&t.sum))
+}
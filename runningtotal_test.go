@@ -0,0 +1,146 @@
+package decimal_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestRunningTotal(t *testing.T) {
+	var rt decimal.RunningTotal
+	total := func() string {
+		return rt.Total(decimal.WithPrecision(5)).String()
+	}
+	mustAdd := func(s string) {
+		t.Helper()
+		x, _ := new(decimal.Big).SetString(s)
+		if err := rt.Add(x); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := total(); got != "0" {
+		t.Fatalf("empty: wanted 0, got %s", got)
+	}
+	mustAdd("1.005")
+	mustAdd("2.5")
+	empty := rt.Snapshot()
+	mustAdd("12345.6")
+	if got := total(); got != "12349" {
+		t.Fatalf("wanted 12349, got %s", got)
+	}
+	snap := rt.Snapshot()
+	mustAdd("0.0001")
+
+	if err := rt.Remove(decimal.New(250, 2)); err != nil {
+		t.Fatal(err)
+	}
+	if err := rt.Remove(decimal.New(25, 1)); err == nil {
+		t.Fatal("removed 2.5 twice")
+	}
+	if err := rt.Remove(decimal.New(7, 0)); err == nil {
+		t.Fatal("removed a value that was never added")
+	}
+
+	if err := rt.Rollback(snap); err != nil {
+		t.Fatal(err)
+	}
+	if got := rt.Total(new(decimal.Big)); got.Cmp(decimal.New(12349105, 3)) != 0 {
+		t.Fatalf("after Rollback: wanted 12349.105, got %s", got)
+	}
+	if err := rt.Rollback(empty); err != nil {
+		t.Fatal(err)
+	}
+	if got := rt.Total(new(decimal.Big)); got.Cmp(decimal.New(3505, 3)) != 0 {
+		t.Fatalf("after Rollback: wanted 3.505, got %s", got)
+	}
+
+	// snap's state was discarded by the previous Rollback.
+	mustAdd("1")
+	if err := rt.Rollback(snap); err == nil {
+		t.Fatal("rolled back to a discarded snapshot")
+	}
+	var other decimal.RunningTotal
+	if err := other.Rollback(empty); err == nil {
+		t.Fatal("rolled back to another RunningTotal's snapshot")
+	}
+
+	for _, x := range []*decimal.Big{nil, new(decimal.Big).SetNaN(false), new(decimal.Big).SetInf(true)} {
+		if err := rt.Add(x); err == nil {
+			t.Fatalf("added %v", x)
+		}
+	}
+
+	// Commit discards every snapshot.
+	snap = rt.Snapshot()
+	mustAdd("2")
+	rt.Commit()
+	if err := rt.Rollback(snap); err == nil {
+		t.Fatal("rolled back to a committed snapshot")
+	}
+	snap = rt.Snapshot()
+	rt.Commit()
+	if err := rt.Rollback(snap); err == nil {
+		t.Fatal("rolled back to a committed snapshot without changes")
+	}
+	snap = rt.Snapshot()
+	mustAdd("3")
+	if err := rt.Rollback(snap); err != nil {
+		t.Fatal(err)
+	}
+	if got := rt.Total(new(decimal.Big)); got.Cmp(decimal.New(6505, 3)) != 0 {
+		t.Fatalf("after Commit and Rollback: wanted 6.505, got %s", got)
+	}
+}
+
+func TestRunningTotal_Digits(t *testing.T) {
+	var rt decimal.RunningTotal
+	if err := rt.Add(decimal.New(1, -5000)); err != nil {
+		t.Fatal(err)
+	}
+	if err := rt.Add(decimal.New(1, 4999)); err != nil {
+		t.Fatal(err)
+	}
+	if err := rt.Add(decimal.New(1, 5000)); err == nil {
+		t.Fatal("added a value to a sum with too many digits")
+	}
+	if got := rt.Total(decimal.WithPrecision(5)); got.String() != "1.0000E+5000" {
+		t.Fatalf("wanted 1.0000E+5000, got %s", got)
+	}
+
+	// The sum starts at 0.
+	var empty decimal.RunningTotal
+	if err := empty.Add(decimal.New(1, -10000000)); err == nil {
+		t.Fatal("added 1E+10000000 to 0")
+	}
+}
+
+// TestRunningTotal_Exact checks that adding and removing values, in any
+// order, gives the same total as summing the remaining values from scratch.
+func TestRunningTotal_Exact(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	var rt decimal.RunningTotal
+	var items []*decimal.Big
+	for i := 0; i < 2000; i++ {
+		if len(items) > 0 && rng.Intn(3) == 0 {
+			j := rng.Intn(len(items))
+			if err := rt.Remove(items[j]); err != nil {
+				t.Fatal(err)
+			}
+			items = append(items[:j], items[j+1:]...)
+		} else {
+			x := decimal.New(rng.Int63()-rng.Int63(), rng.Intn(30)-5)
+			if err := rt.Add(x); err != nil {
+				t.Fatal(err)
+			}
+			items = append(items, x)
+		}
+
+		want := decimal.ContextUnlimited.Sum(new(decimal.Big), items...)
+		got := rt.Total(decimal.WithPrecision(decimal.UnlimitedPrecision))
+		if got.Cmp(want) != 0 {
+			t.Fatalf("#%d: wanted %s, got %s", i, want, got)
+		}
+	}
+}
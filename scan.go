@@ -1,17 +1,158 @@
 package decimal
 
 import (
+	"bufio"
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
-	"math/bits"
+	"math"
 	"strconv"
+	"strings"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/ericlagergren/decimal/internal/arith"
 	"github.com/ericlagergren/decimal/internal/c"
 )
 
+// An ErrParseLimit is used when the input to a parsing method exceeds the
+// limits set by Context.MaxParseBytes or Context.MaxParseDigits.
+type ErrParseLimit struct {
+	Digits bool // true if the limit on digits, not bytes, was exceeded
+	Max    int  // the limit that was exceeded
+}
+
+func (e ErrParseLimit) Error() string {
+	if e.Digits {
+		return fmt.Sprintf("decimal: input exceeds maximum of %d digits", e.Max)
+	}
+	return fmt.Sprintf("decimal: input exceeds maximum of %d bytes", e.Max)
+}
+
+// Unwrap returns the Condition raised because of e.
+func (e ErrParseLimit) Unwrap() error { return e.cond() }
+
+func (e ErrParseLimit) cond() Condition {
+	if e.Digits {
+		return InsufficientStorage
+	}
+	return ConversionSyntax
+}
+
+var _ error = ErrParseLimit{}
+
+// setParseErr sets z to NaN, raises c, and returns c.
+func (z *Big) setParseErr(c Condition) error {
+	z.form = qnan
+	z.compact = 0
+	z.Context.Conditions |= c
+	return c
+}
+
+// setParseLimit sets z to NaN, raises the Condition for err, and returns err.
+func (z *Big) setParseLimit(err ErrParseLimit) error {
+	z.setParseErr(err.cond())
+	return err
+}
+
+// scanString parses s, which must be the entire input, after checking it
+// against c's parsing limits.
+func (z *Big) scanString(s string, c Context) error {
+	nb, nd := c.parseLimits()
+	if nb > 0 && len(s) > nb {
+		return z.setParseLimit(ErrParseLimit{Max: nb})
+	}
+	if nd > 0 && len(s) > nd {
+		n := 0
+		for i := 0; i < len(s) && s[i] != 'e' && s[i] != 'E'; i++ {
+			if s[i] >= '0' && s[i] <= '9' {
+				n++
+			}
+		}
+		if n > nd {
+			return z.setParseLimit(ErrParseLimit{Digits: true, Max: nd})
+		}
+	}
+	if c.Underscores {
+		return z.scan(&underscoreScanner{r: strings.NewReader(s)})
+	}
+	return z.scan(strings.NewReader(s))
+}
+
+// scanBytes is like scanString, but for a []byte. r is used to read b.
+func (z *Big) scanBytes(b []byte, c Context, r *bytes.Reader) error {
+	nb, nd := c.parseLimits()
+	if nb > 0 && len(b) > nb {
+		return z.setParseLimit(ErrParseLimit{Max: nb})
+	}
+	if nd > 0 && len(b) > nd {
+		n := 0
+		for i := 0; i < len(b) && b[i] != 'e' && b[i] != 'E'; i++ {
+			if b[i] >= '0' && b[i] <= '9' {
+				n++
+			}
+		}
+		if n > nd {
+			return z.setParseLimit(ErrParseLimit{Digits: true, Max: nd})
+		}
+	}
+	r.Reset(b)
+	if c.Underscores {
+		return z.scan(&underscoreScanner{r: r})
+	}
+	return z.scan(r)
+}
+
+// underscoreScanner is an io.ByteScanner that removes the underscores between
+// digits from the input read from r. Any other underscore is a syntax error,
+// which is returned by every later call to ReadByte; the next byte to read from
+// r is then the one following the underscore.
+type underscoreScanner struct {
+	r    io.ByteScanner
+	prev byte  // the byte read before last
+	last byte  // the last byte read
+	err  error // the syntax error, if any
+}
+
+func (s *underscoreScanner) ReadByte() (byte, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	ch, err := s.r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	if ch == '_' {
+		if s.last < '0' || s.last > '9' {
+			s.err = strconv.ErrSyntax
+			return 0, s.err
+		}
+		ch, err = s.r.ReadByte()
+		switch {
+		case err == io.EOF:
+			s.err = strconv.ErrSyntax
+			return 0, s.err
+		case err != nil:
+			return 0, err
+		case ch < '0' || ch > '9':
+			s.r.UnreadByte()
+			s.err = strconv.ErrSyntax
+			return 0, s.err
+		}
+	}
+	s.prev, s.last = s.last, ch
+	return ch, nil
+}
+
+func (s *underscoreScanner) UnreadByte() error {
+	if s.err != nil {
+		return s.err
+	}
+	s.last = s.prev
+	return s.r.UnreadByte()
+}
+
 func (z *Big) scan(r io.ByteScanner) error {
 	if debug {
 		defer func() { z.validate() }()
@@ -37,12 +178,26 @@ func (z *Big) scan(r io.ByteScanner) error {
 	// Sign
 	neg, err := scanSign(r)
 	if err != nil {
+		switch err {
+		case strconv.ErrSyntax:
+			z.form = qnan
+			z.Context.Conditions |= ConversionSyntax
+			return nil
+		case io.EOF:
+			// An empty string is not a number, and SetString reports
+			// that it failed.
+			z.form = qnan
+			z.compact = 0
+			z.Context.Conditions |= ConversionSyntax
+		}
 		return err
 	}
 
 	z.form, err = z.scanForm(r)
 	if err != nil {
 		if err == strconv.ErrSyntax {
+			z.form = qnan
+			z.compact = 0
 			z.Context.Conditions |= ConversionSyntax
 		}
 		return err
@@ -69,28 +224,45 @@ func (z *Big) scan(r io.ByteScanner) error {
 	}
 
 	// Exponent
-	if err := z.scanExponent(r); err != nil && err != io.EOF {
-		switch err {
-		case Underflow:
-			z.xflow(MinScale, false, neg)
-		case Overflow:
-			z.xflow(MinScale, true, neg)
-		case strconv.ErrSyntax:
+	exp, err := scanExponent(r)
+	if err != nil && err != io.EOF {
+		if err == strconv.ErrSyntax {
 			z.form = qnan
 			z.Context.Conditions |= ConversionSyntax
-		default:
-			return err
+			return nil
 		}
-		return nil
+		return err
 	}
 
 	// Adjust for negative values.
 	if neg {
 		z.form |= signbit
 	}
+	z.clampExp(int64(z.exp) + exp)
 	return nil
 }
 
+// clampExp sets z's exponent to exp. If exp is outside [etiny, MaxScale], where
+// etiny is the smallest exponent of a subnormal at MaxPrecision, and z's
+// coefficient is zero, the exponent is clamped to the nearest limit and Clamped
+// is raised. Otherwise, z overflows to ±Infinity or underflows to ±0.
+func (z *Big) clampExp(exp int64) {
+	const etiny = MinScale - (MaxPrecision - 1)
+	switch {
+	case exp >= etiny && exp <= MaxScale:
+		z.exp = int(exp)
+	case z.compact == 0:
+		if exp > 0 {
+			z.exp = MaxScale
+		} else {
+			z.exp = etiny
+		}
+		z.Context.Conditions |= Clamped
+	default:
+		z.xflow(MinScale, exp > 0, z.form&signbit != 0)
+	}
+}
+
 func scanSign(r io.ByteScanner) (bool, error) {
 	ch, err := r.ReadByte()
 	if err != nil {
@@ -130,7 +302,7 @@ func (z *Big) scanForm(r io.ByteScanner) (form, error) {
 					if i == len("inf") {
 						return inf, nil
 					}
-					return 0, io.ErrUnexpectedEOF
+					return 0, strconv.ErrSyntax
 				}
 				return 0, err
 			}
@@ -162,7 +334,7 @@ func (z *Big) scanForm(r io.ByteScanner) (form, error) {
 		ch, err = r.ReadByte()
 		if err != nil {
 			if err == io.EOF {
-				return 0, io.ErrUnexpectedEOF
+				return 0, strconv.ErrSyntax
 			}
 			return 0, err
 		}
@@ -181,9 +353,10 @@ func (z *Big) scanForm(r io.ByteScanner) (form, error) {
 			}
 			return 0, err
 		}
-		if ch >= '0' && ch <= '9' {
-			buf[i] = ch
+		if ch < '0' || ch > '9' {
+			return 0, strconv.ErrSyntax
 		}
+		buf[i] = ch
 	}
 	if i > 0 {
 		z.compact, err = strconv.ParseUint(string(buf[:i]), 10, 64)
@@ -410,74 +583,245 @@ Loop:
 	return err
 }
 
-func (z *Big) scanExponent(r io.ByteScanner) (err error) {
-	// TODO(eric): there's actually an issue here if somebody has leading zeros
-	// in their exponent. But, I don't really think I want to support that.
-
-	// N is the max length of a signed N-bit int, including sign plus a leading
-	// 'e' or 'E'.
-	const N = ((((bits.UintSize + 1) * 1233) >> 12) + 1) + (64 / bits.UintSize)
-
-	var buf [N]byte
-	var i int
-	for ; i < len(buf); i++ {
-		if buf[i], err = r.ReadByte(); err != nil {
-			if err == io.EOF {
-				if i == 0 {
-					return nil
-				}
-				break
-			}
-			return err
-		}
+// scanExponent scans an optional exponent, returning io.EOF if there is none.
+// The exponent may have any number of digits, including leading zeros. It is
+// accumulated in an int64 and saturates well beyond [MinScale, MaxScale], so
+// exponents too large for an int are still clamped correctly.
+func scanExponent(r io.ByteScanner) (int64, error) {
+	ch, err := r.ReadByte()
+	if err != nil {
+		return 0, err
 	}
-
-	if buf[0] != 'e' && buf[0] != 'E' {
-		return strconv.ErrSyntax
+	if ch != 'e' && ch != 'E' {
+		return 0, strconv.ErrSyntax
 	}
 
-	if ch, err := r.ReadByte(); err != io.EOF {
-		if ch < '0' || ch > '9' {
-			return err
+	next := func() (byte, error) {
+		ch, err := r.ReadByte()
+		if err == io.EOF {
+			return 0, strconv.ErrSyntax // missing digits
 		}
-		if buf[0] == '-' {
-			return Underflow
+		return ch, err
+	}
+	if ch, err = next(); err != nil {
+		return 0, err
+	}
+	neg := false
+	if ch == '+' || ch == '-' {
+		neg = ch == '-'
+		if ch, err = next(); err != nil {
+			return 0, err
 		}
-		return Overflow
 	}
 
-	exp, err := strconv.Atoi(string(buf[1:i]))
-	if err != nil {
-		if err = err.(*strconv.NumError).Err; err != strconv.ErrRange {
-			return err
+	const max = 1 << 62
+	var exp int64
+	for {
+		if ch < '0' || ch > '9' {
+			return 0, strconv.ErrSyntax
 		}
-		// Atoi sets exp < 0 on underflow.
-		if exp < 0 {
-			return Underflow
+		if exp <= (max-9)/10 {
+			exp = exp*10 + int64(ch-'0')
+		} else {
+			exp = max
+		}
+		if ch, err = r.ReadByte(); err != nil {
+			if err != io.EOF {
+				return 0, err
+			}
+			break
 		}
-		return Overflow
 	}
+	if neg {
+		exp = -exp
+	}
+	return exp, nil
+}
 
-	z.exp += exp
-	return nil
+// LineError records a line ParseLines could not parse.
+type LineError struct {
+	Line  int    // line number, starting at 1
+	Col   int    // byte offset within the line where parsing failed, starting at 1
+	Input string // the line, without its line ending
+	Err   error  // the reason parsing failed
+}
+
+func (e LineError) Error() string {
+	return fmt.Sprintf("decimal: line %d, column %d: parsing %q: %v",
+		e.Line, e.Col, e.Input, e.Err)
 }
 
-// byteReader implementation borrowed from math/big/intconv.go
+// ParseLines reads one decimal per line from r, returning each successfully
+// parsed value in order and a LineError for each line that could not be
+// parsed. Leading and trailing whitespace is ignored, as are blank lines and
+// lines whose first non-whitespace character is '#'. Each value is parsed like
+// SetString and then rounded using ctx.
+//
+// r is read incrementally, so ParseLines can be used on large inputs. If
+// reading from r fails, the error is recorded as a LineError for the line
+// being read and ParseLines returns.
+//
+// If ctx limits the length of parsed input (see Context.MaxParseBytes), so is
+// the length of each line, including its whitespace: only the first
+// MaxParseBytes+1 bytes of a longer line are read, and recorded as its Input,
+// and the rest of the line is skipped.
+func ParseLines(r io.Reader, ctx Context) ([]*Big, []LineError) {
+	var (
+		vals []*Big
+		errs []LineError
+		br   bytes.Reader
+		z    *Big
+		line int
+	)
+	s := bufio.NewScanner(r)
+	if max, _ := ctx.parseLimits(); max > 0 {
+		s.Buffer(nil, max+2)
+		s.Split(splitLines(max))
+	} else {
+		s.Buffer(nil, math.MaxInt32)
+	}
+	for s.Scan() {
+		line++
+		raw := bytes.TrimSuffix(s.Bytes(), []byte{'\r'})
+		text := bytes.TrimLeftFunc(raw, unicode.IsSpace)
+		start := len(raw) - len(text)
+		text = bytes.TrimRightFunc(text, unicode.IsSpace)
+		if len(text) == 0 || text[0] == '#' {
+			continue
+		}
 
-// byteReader is a local wrapper around fmt.ScanState; it implements the
-// io.ByteReader interface.
-type byteReader struct {
-	fmt.ScanState
+		if z == nil {
+			z = WithContext(ctx)
+		}
+		err := z.scanBytes(text, ctx, &br)
+		if _, ok := err.(ErrParseLimit); ok {
+			// Limits are checked before parsing, so the error applies to
+			// the entire line.
+			errs = append(errs, LineError{
+				Line:  line,
+				Col:   start + 1,
+				Input: string(raw),
+				Err:   err,
+			})
+			*z = Big{Context: ctx}
+			continue
+		}
+		if z.Context.Conditions&ConversionSyntax != 0 {
+			err = ConversionSyntax
+		}
+		if err != nil {
+			// The offending byte is the last one read, or one past the end if
+			// the input ended early.
+			col := len(text) - br.Len()
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				err = io.ErrUnexpectedEOF
+				col = len(text) + 1
+			}
+			if col == 0 {
+				col = 1
+			}
+			errs = append(errs, LineError{
+				Line:  line,
+				Col:   start + col,
+				Input: string(raw),
+				Err:   err,
+			})
+			// z is reused for the next line.
+			*z = Big{Context: ctx}
+			continue
+		}
+		vals = append(vals, ctx.Round(z))
+		z = nil
+	}
+	if err := s.Err(); err != nil {
+		errs = append(errs, LineError{Line: line + 1, Col: 1, Err: err})
+	}
+	return vals, errs
 }
 
-func (r byteReader) ReadByte() (byte, error) {
-	ch, size, err := r.ReadRune()
-	if size != 1 && err == nil {
-		err = fmt.Errorf("invalid rune %#U", ch)
+// splitLines returns a bufio.SplitFunc like bufio.ScanLines, except that a line
+// longer than max bytes, excluding its line ending, is truncated to its first
+// max+1 bytes, which is enough to exceed the limit, and the rest of it is
+// discarded. A Scanner using it never buffers more than max+2 bytes.
+func splitLines(max int) bufio.SplitFunc {
+	skip := false // discarding the rest of a long line
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		if skip {
+			i := bytes.IndexByte(data, '\n')
+			if i < 0 {
+				return len(data), nil, nil
+			}
+			skip = false
+			return i + 1, nil, nil
+		}
+		if len(data) > max+1 && bytes.IndexByte(data[:max+2], '\n') < 0 {
+			skip = true
+			return max + 1, data[:max+1], nil
+		}
+		return bufio.ScanLines(data, atEOF)
 	}
-	return byte(ch), err
 }
 
-func (r byteReader) UnreadByte() error {
-	return r.UnreadRune()
+// scanToken reads the longest prefix of the input in state that could begin a
+// decimal, after skipping leading space: an optional sign followed by digits,
+// a radix, and an exponent, or a prefix of "Infinity" or of a NaN and its
+// payload, ignoring case. It reads at most max+1 bytes if max > 0, so that
+// longer input fails the same limit when parsed.
+func scanToken(state fmt.ScanState, max int) ([]byte, error) {
+	state.SkipSpace()
+
+	var tok []byte
+	accept := func(ok func(ch rune) bool) bool {
+		if max > 0 && len(tok) > max {
+			return false
+		}
+		ch, _, err := state.ReadRune()
+		if err != nil {
+			return false
+		}
+		if ch >= utf8.RuneSelf || !ok(ch) {
+			state.UnreadRune()
+			return false
+		}
+		tok = append(tok, byte(ch))
+		return true
+	}
+	isDigit := func(ch rune) bool { return ch >= '0' && ch <= '9' }
+	digits := func() {
+		for accept(isDigit) {
+		}
+	}
+
+	accept(func(ch rune) bool { return ch == '+' || ch == '-' })
+	word := len(tok)
+	for accept(func(ch rune) bool {
+		w := strings.ToLower(string(tok[word:]) + string(ch))
+		return strings.HasPrefix("infinity", w) || strings.HasPrefix("nan", w) ||
+			strings.HasPrefix("snan", w) || strings.HasPrefix("qnan", w)
+	}) {
+	}
+	if len(tok) > word {
+		if w := strings.ToLower(string(tok[word:])); strings.HasSuffix(w, "nan") {
+			digits() // payload
+		}
+	} else {
+		digits()
+		if accept(func(ch rune) bool { return ch == '.' }) {
+			digits()
+		}
+		if accept(func(ch rune) bool { return ch == 'e' || ch == 'E' }) {
+			accept(func(ch rune) bool { return ch == '+' || ch == '-' })
+			digits()
+		}
+	}
+
+	if len(tok) > 0 {
+		return tok, nil
+	}
+	ch, _, err := state.ReadRune()
+	if err != nil {
+		return nil, err
+	}
+	state.UnreadRune()
+	return nil, ErrInput{Input: string(ch), Msg: fmt.Sprintf("unexpected %q", ch)}
 }
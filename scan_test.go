@@ -1,8 +1,14 @@
 package decimal
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
 	"math/rand"
+	"runtime"
 	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -51,3 +57,402 @@ func BenchmarkBig_SetString(b *testing.B) {
 	}
 	globOk = ok
 }
+
+func BenchmarkBig_SetBytes(b *testing.B) {
+	var input [len(benchInput)][]byte
+	for i := range input {
+		input[i] = []byte(benchInput[i].s)
+	}
+	b.ResetTimer()
+	var ok bool
+	for i := 0; i < b.N; i++ {
+		m := &benchInput[i%len(benchInput)]
+		_, ok = m.b.SetBytes(input[i%len(input)])
+	}
+	globOk = ok
+}
+
+// FuzzSetBytes checks that SetBytes and SetString agree on every input.
+func FuzzSetBytes(f *testing.F) {
+	for _, s := range [...]string{
+		"", "0", "-0", "1.50", "+.5", "5.", ".", "1e", "1E+3", "-1.2e-30",
+		"1.2.3", "12x4", " 1", "Inf", "-infinity", "NaN123", "sNaN", "qnan",
+		"1E999999999999999999999", "18446744073709551616",
+	} {
+		f.Add([]byte(s), false)
+	}
+	f.Add([]byte("1234567890123"), true)
+
+	f.Fuzz(func(t *testing.T, b []byte, limit bool) {
+		ctx := Context{OperatingMode: GDA}
+		if limit {
+			ctx.MaxParseBytes = 8
+			ctx.MaxParseDigits = 6
+		}
+		x := WithContext(ctx)
+		_, okx := x.SetString(string(b))
+		z := WithContext(ctx)
+		in := append([]byte(nil), b...)
+		_, okz := z.SetBytes(b)
+		if okx != okz || x.Context.Conditions != z.Context.Conditions {
+			t.Fatalf("%q: SetString: (%t, %s), SetBytes: (%t, %s)",
+				b, okx, x.Context.Conditions, okz, z.Context.Conditions)
+		}
+		if string(b) != string(in) {
+			t.Fatalf("%q: SetBytes modified its input: %q", in, b)
+		}
+		if !okx {
+			return
+		}
+		if x.String() != z.String() || x.Signbit() != z.Signbit() || x.Payload() != z.Payload() ||
+			x.IsFinite() && (x.Scale() != z.Scale() || x.Precision() != z.Precision()) {
+			t.Fatalf("%q: SetString: %s, SetBytes: %s", b, x, z)
+		}
+	})
+}
+
+func TestParseLines(t *testing.T) {
+	const input = "# rates\n" +
+		"1.5\n" +
+		"\n" +
+		"  -2e3 \r\n" +
+		"1x2\n" +
+		"-\n" +
+		"inf\n" +
+		"\t# indented comment\n" +
+		"1.2.3\n" +
+		"12345.6789\n" +
+		"NaN\n"
+	vals, errs := ParseLines(strings.NewReader(input), Context{Precision: 5})
+
+	wantVals := []string{"1.5", "-2E+3", "Infinity", "12346", "NaN"}
+	if len(vals) != len(wantVals) {
+		t.Fatalf("wanted %d values, got %d: %v", len(wantVals), len(vals), vals)
+	}
+	for i, v := range vals {
+		if v.String() != wantVals[i] {
+			t.Fatalf("#%d: wanted: %s, got: %s", i, wantVals[i], v)
+		}
+		if v.Context.Precision != 5 {
+			t.Fatalf("#%d: context not applied: %+v", i, v.Context)
+		}
+	}
+
+	wantErrs := []LineError{
+		{Line: 5, Col: 2, Input: "1x2", Err: ConversionSyntax},
+		{Line: 6, Col: 2, Input: "-", Err: io.ErrUnexpectedEOF},
+		{Line: 9, Col: 4, Input: "1.2.3", Err: ConversionSyntax},
+	}
+	if len(errs) != len(wantErrs) {
+		t.Fatalf("wanted %d errors, got %d: %v", len(wantErrs), len(errs), errs)
+	}
+	for i, e := range errs {
+		if e != wantErrs[i] {
+			t.Fatalf(`#%d:
+wanted: %v
+got   : %v
+`, i, wantErrs[i], e)
+		}
+	}
+}
+
+func TestParseLinesLongLine(t *testing.T) {
+	long := strings.Repeat("1", 100)
+	input := "1\n" + long + "\r\n" + strings.Repeat("2", 32) + "\r\n3"
+	vals, errs := ParseLines(strings.NewReader(input), Context{MaxParseBytes: 32})
+	if len(vals) != 3 || vals[0].String() != "1" || vals[2].String() != "3" {
+		t.Fatalf("wanted [1 %s 3], got %v", strings.Repeat("2", 32), vals)
+	}
+	want := LineError{Line: 2, Col: 1, Input: long[:33], Err: ErrParseLimit{Max: 32}}
+	if len(errs) != 1 || errs[0] != want {
+		t.Fatalf(`
+wanted: %v
+got   : %v
+`, want, errs)
+	}
+}
+
+type errReader struct{ err error }
+
+func (r errReader) Read(_ []byte) (int, error) { return 0, r.err }
+
+func TestParseLinesReadError(t *testing.T) {
+	errRead := errors.New("read failed")
+	r := io.MultiReader(strings.NewReader("1\n2\n"), errReader{errRead})
+	vals, errs := ParseLines(r, Context{})
+	if len(vals) != 2 {
+		t.Fatalf("wanted 2 values, got %v", vals)
+	}
+	want := LineError{Line: 3, Col: 1, Err: errRead}
+	if len(errs) != 1 || errs[0] != want {
+		t.Fatalf(`
+wanted: %v
+got   : %v
+`, want, errs)
+	}
+}
+
+func TestParseLimits(t *testing.T) {
+	ctx := Context{MaxParseBytes: 32, MaxParseDigits: 10}
+	byteErr := ErrParseLimit{Max: 32}
+	digitErr := ErrParseLimit{Digits: true, Max: 10}
+
+	parsers := [...]struct {
+		name string
+		fn   func(z *Big, s string) error
+	}{
+		{"SetString", func(z *Big, s string) error {
+			if _, ok := z.SetString(s); !ok {
+				return z.Context.Conditions
+			}
+			return nil
+		}},
+		{"Context.SetString", func(z *Big, s string) error {
+			if _, ok := z.Context.SetString(z, s); !ok {
+				return z.Context.Conditions
+			}
+			return nil
+		}},
+		{"SetBytes", func(z *Big, s string) error {
+			if _, ok := z.SetBytes([]byte(s)); !ok {
+				return z.Context.Conditions
+			}
+			return nil
+		}},
+		{"UnmarshalText", func(z *Big, s string) error {
+			err := z.UnmarshalText([]byte(s))
+			if e, ok := err.(ErrParseLimit); ok {
+				// UnmarshalText leaves z unchanged.
+				if z.Sign() != 0 || z.Context.Conditions != 0 {
+					return fmt.Errorf("modified z: %s (%s)", z, z.Context.Conditions)
+				}
+				z.Context.Conditions |= e.cond()
+				z.form = qnan
+			}
+			return err
+		}},
+		{"UnmarshalJSON", func(z *Big, s string) error { return z.UnmarshalJSON([]byte(s)) }},
+		{"Scan", func(z *Big, s string) error {
+			_, err := fmt.Sscan(s, z)
+			return err
+		}},
+		{"ParseLines", func(z *Big, s string) error {
+			vals, errs := ParseLines(strings.NewReader(s), z.Context)
+			if len(errs) > 0 {
+				z.Context.Conditions |= errs[0].Err.(ErrParseLimit).cond()
+				z.form = qnan
+				return errs[0].Err
+			}
+			z.Copy(vals[0])
+			return nil
+		}},
+	}
+	for i, test := range [...]struct {
+		s      string
+		digits int // overrides ctx.MaxParseDigits if non-zero
+		err    ErrParseLimit
+		cond   Condition
+	}{
+		{"1234567890", 0, ErrParseLimit{}, 0},
+		{"1234567890e+12345", 0, ErrParseLimit{}, 0},
+		{"12345.67890", 0, ErrParseLimit{}, 0},
+		{"12345678901", 0, digitErr, InsufficientStorage},
+		{"-0.12345678901", 0, digitErr, InsufficientStorage},
+		{"1" + strings.Repeat("0", 40), -1, byteErr, ConversionSyntax},
+	} {
+		for _, p := range parsers {
+			z := WithContext(ctx)
+			if test.digits != 0 {
+				z.Context.MaxParseDigits = test.digits
+			}
+			err := p.fn(z, test.s)
+			if test.cond == 0 {
+				if err != nil {
+					t.Fatalf("#%d: %s(%q): unexpected error: %v", i, p.name, test.s, err)
+				}
+				continue
+			}
+			if !z.IsNaN(0) || z.Context.Conditions&test.cond == 0 {
+				t.Fatalf("#%d: %s(%q): wanted NaN with %s, got %s (%s)",
+					i, p.name, test.s, test.cond, z, z.Context.Conditions)
+			}
+			if _, ok := err.(Condition); !ok && err != test.err {
+				t.Fatalf("#%d: %s(%q): wanted %v, got %v", i, p.name, test.s, test.err, err)
+			}
+		}
+	}
+
+	var b Builder
+	b.Digits([]byte("123456"))
+	b.Fraction([]byte("123456"))
+	if err := b.Build(WithContext(ctx)); err != digitErr {
+		t.Fatalf("Builder: wanted %v, got %v", digitErr, err)
+	}
+}
+
+func TestParseLimits_Defaults(t *testing.T) {
+	s := "1" + strings.Repeat("0", MaxParseDigits)
+	if _, ok := new(Big).SetString(s); ok {
+		t.Fatal("GDA: wanted default limit to apply")
+	}
+	if _, ok := WithContext(Context{OperatingMode: Go}).SetString(s); !ok {
+		t.Fatal("Go: wanted no default limit")
+	}
+	if _, ok := WithContext(Context{MaxParseDigits: -1}).SetString(s); !ok {
+		t.Fatal("negative limit: wanted no limit")
+	}
+}
+
+func TestParseLimits_NoAlloc(t *testing.T) {
+	huge := strings.Repeat("9", 10<<20)
+	hugeb := []byte(huge)
+	digits := []byte(strings.Repeat("9", MaxParseDigits+1))
+	for _, test := range [...]struct {
+		name string
+		fn   func()
+	}{
+		{"SetString", func() { new(Big).SetString(huge) }},
+		{"SetBytes", func() { new(Big).SetBytes(hugeb) }},
+		{"UnmarshalText", func() { new(Big).UnmarshalText(hugeb) }},
+		{"UnmarshalJSON", func() { new(Big).UnmarshalJSON(hugeb) }},
+		{"UnmarshalText/digits", func() { new(Big).UnmarshalText(digits) }},
+	} {
+		const runs = 10
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		for i := 0; i < runs; i++ {
+			test.fn()
+		}
+		runtime.ReadMemStats(&after)
+		if n := (after.TotalAlloc - before.TotalAlloc) / runs; n > 1024 {
+			t.Fatalf("%s: allocated %d bytes per call on oversized input", test.name, n)
+		}
+	}
+}
+
+// TestParseExponent covers exponent edge cases modeled on the dectest base
+// conversion vectors: exponents beyond the int32 and int64 range, signed zeros,
+// and leading zeros.
+func TestParseExponent(t *testing.T) {
+	type parseTest struct {
+		in    string
+		out   string
+		conds Condition
+	}
+	exp := func(n int) string {
+		if n < 0 {
+			return "E" + strconv.Itoa(n)
+		}
+		return "E+" + strconv.Itoa(n)
+	}
+	// The smallest exponent of a zero, Etiny with unlimited precision.
+	zeroTiny := MinScale - (MaxScale - 1)
+	tests := []parseTest{
+		{"1" + exp(MaxScale), "1" + exp(MaxScale), 0},
+		{"1" + exp(MinScale), "1" + exp(MinScale), 0},
+		{"0" + exp(MaxScale+1), "0" + exp(MaxScale), Clamped},
+		{"-0" + exp(zeroTiny-1), "-0" + exp(zeroTiny), Clamped},
+		{"1E+0000000000000000000000003", "1E+3", 0},
+		{"-0E-0000007", "-0E-7", 0},
+		{"-0.00E-3", "-0.00000", 0},
+		{"12.5E-0", "12.5", 0},
+		{"-0E+9223372036854775807", "-0" + exp(MaxScale), Clamped},
+		{"0E-9223372036854775808", "0" + exp(zeroTiny), Clamped},
+		{"1E999999999999999999999", "Infinity", Inexact | Overflow | Rounded},
+		{"-1E999999999999999999999", "-Infinity", Inexact | Overflow | Rounded},
+		{"1E-999999999999999999999", "0" + exp(MinScale), Inexact | Rounded | Subnormal | Underflow},
+		{"1E", "NaN", ConversionSyntax},
+		{"1E+", "NaN", ConversionSyntax},
+		{"1E-", "NaN", ConversionSyntax},
+		{"1Ex", "NaN", ConversionSyntax},
+		{"1E+-1", "NaN", ConversionSyntax},
+	}
+	if MaxScale >= math.MaxInt32 {
+		// Exponents at the edges of an int32 are within range.
+		tests = append(tests, []parseTest{
+			{"1E+2147483647", "1E+2147483647", 0},
+			{"1E-2147483648", "1E-2147483648", 0},
+			{"0E+2147483648", "0E+2147483648", 0},
+			{"-0E-2147483649", "-0E-2147483649", 0},
+		}...)
+	}
+	for i, test := range tests {
+		z, _ := new(Big).SetString(test.in)
+		out := z.String()
+		if z.IsNaN(0) {
+			out = "NaN" // ignore the diagnostic payload
+		}
+		if out != test.out || z.Context.Conditions != test.conds {
+			t.Fatalf("#%d: %q: wanted %s (%s), got %s (%s)",
+				i, test.in, test.out, test.conds, z, z.Context.Conditions)
+		}
+	}
+}
+
+func TestBig_SetString_Empty(t *testing.T) {
+	var z Big
+	if r, ok := z.SetString(""); r != nil || ok {
+		t.Fatalf(`SetString(""): wanted (nil, false), got (%v, %t)`, r, ok)
+	}
+	if !z.IsNaN(0) || z.Context.Conditions != ConversionSyntax {
+		t.Fatalf(`SetString(""): wanted NaN (%s), got %s (%s)`,
+			ConversionSyntax, &z, z.Context.Conditions)
+	}
+}
+
+func TestBig_SetString_Underscores(t *testing.T) {
+	ctx := Context{OperatingMode: GDA, Underscores: true}
+	for i, test := range [...]struct {
+		in, out string
+	}{
+		{"1_000_000.000_1", "1000000.0001"},
+		{"-1_2e1_0", "-1.2E+11"},
+		{"+0_0.0_0E-0_1", "0.000"},
+		{"123_456_789_012_345_678_901_234.5", "123456789012345678901234.5"},
+		{"Infinity", "Infinity"},
+		{"-Infinity", "-Infinity"},
+		{"NaN123", "NaN123"},
+		{"sNaN1_2", "sNaN12"},
+	} {
+		z, ok := WithContext(ctx).SetString(test.in)
+		if !ok || z.String() != test.out || z.Context.Conditions != 0 {
+			t.Fatalf("#%d: %q: wanted %s, got %v (%t)", i, test.in, test.out, z, ok)
+		}
+		z = WithContext(Context{OperatingMode: GDA})
+		z.SetString(test.in)
+		if got := z.Context.Conditions == ConversionSyntax; got != strings.Contains(test.in, "_") {
+			t.Fatalf("#%d: %q: strict parsing: got %s (%s)", i, test.in, z, z.Context.Conditions)
+		}
+	}
+
+	for i, test := range [...]struct {
+		in  string
+		off int
+	}{
+		{"_1", 0},
+		{"1_", 1},
+		{"1__0", 1},
+		{"1_.5", 1},
+		{"1._5", 2},
+		{"-_1", 1},
+		{"+_1", 1},
+		{"1_e5", 1},
+		{"1e_5", 2},
+		{"1e+_5", 3},
+		{"1e5_", 3},
+		{"_Infinity", 0},
+		{"NaN_1", 3},
+		{"NaN1x", 4},
+		{"123_456_789_012_345_678_901_.5", 27},
+	} {
+		z := WithContext(ctx)
+		if z.SetString(test.in); !z.IsNaN(0) || z.Context.Conditions != ConversionSyntax {
+			t.Fatalf("#%d: %q: wanted ConversionSyntax, got %s (%s)", i, test.in, z, z.Context.Conditions)
+		}
+		z = WithContext(ctx)
+		err := z.UnmarshalText([]byte(test.in))
+		if e, ok := err.(ErrInput); !ok || e.Offset != test.off || !errors.Is(err, ConversionSyntax) {
+			t.Fatalf("#%d: %q: wanted an ErrInput at offset %d, got %v", i, test.in, test.off, err)
+		}
+	}
+}
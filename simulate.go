@@ -0,0 +1,51 @@
+//go:build decimaltest
+// +build decimaltest
+
+package decimal
+
+// nanConditions are the Conditions raised by operations that set their results
+// to NaN.
+const nanConditions = ConversionSyntax | DivisionImpossible |
+	DivisionUndefined | InsufficientStorage | InvalidContext | InvalidOperation
+
+// ForceCondition arranges for the next arithmetic operation that stores its
+// result in z (e.g., z.Add(x, y) or ctx.Quo(z, x, y)) to raise c in addition
+// to any Conditions the operation raises itself. It is intended for tests of
+// code that handles Conditions, such as Overflow or InsufficientStorage, which
+// are difficult to raise with real inputs; see package decimaltest. The
+// Conditions are raised as usual, so they are reported by Context.Err if
+// trapped, collected by Context.Do, and so on.
+//
+// ForceCondition only exists in builds with the decimaltest build tag, such
+// as those of "go test -tags decimaltest", so production code cannot force a
+// Condition.
+//
+// The operation otherwise computes its result as usual, unless c contains a
+// Condition raised by operations that set their results to NaN (e.g.,
+// InvalidOperation or InsufficientStorage). Then, like such an operation, it
+// sets z to NaN with the payload "simulated condition", which panics if z's
+// OperatingMode is Go.
+//
+// The forced Condition is kept in z's Context, so it is copied along with the
+// Context, e.g. by WithContext(z.Context), but not by Clone. A call with c == 0
+// cancels any Condition forced on z.
+func (z *Big) ForceCondition(c Condition) {
+	mustNotNil("ForceCondition", z, z)
+	z.Context.hooks = z.Context.hooks.update(func(h *hooks) { h.forced = c })
+}
+
+// simulate raises the Condition forced on z by ForceCondition, if any, and
+// reports whether it set z to NaN.
+func (z *Big) simulate() bool {
+	c := z.Context.hooks.forced
+	if c == 0 {
+		return false
+	}
+	z.Context.hooks = z.Context.hooks.update(func(h *hooks) { h.forced = 0 })
+	if c&nanConditions != 0 {
+		z.setNaN(c, qnan, simulated)
+		return true
+	}
+	z.Context.Conditions |= c
+	return false
+}
@@ -0,0 +1,8 @@
+//go:build !decimaltest
+// +build !decimaltest
+
+package decimal
+
+// simulate reports whether it set z to NaN, which it never does without the
+// decimaltest build tag, since no Condition can be forced on z.
+func (z *Big) simulate() bool { return false }
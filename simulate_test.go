@@ -0,0 +1,107 @@
+//go:build decimaltest
+// +build decimaltest
+
+package decimal_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestBig_ForceCondition(t *testing.T) {
+	ctx := decimal.Context{Traps: decimal.Overflow}
+	z := decimal.WithContext(ctx)
+	x := decimal.New(15, 1)
+	z.ForceCondition(decimal.Overflow)
+	z.Add(x, x)
+	if z.Cmp(decimal.New(3, 0)) != 0 {
+		t.Fatalf("wanted 3.0, got %s", z)
+	}
+	if c := z.Context.Conditions; c != decimal.Overflow {
+		t.Fatalf("wanted %s, got %s", decimal.Overflow, c)
+	}
+	if err := z.Context.Err(); !errors.Is(err, decimal.Overflow) {
+		t.Fatalf("wanted %s error, got %v", decimal.Overflow, err)
+	}
+
+	// Only the next operation raises the Condition.
+	z.Context.Conditions = 0
+	z.Add(z, x)
+	if c := z.Context.Conditions; c != 0 {
+		t.Fatalf("second operation: wanted no conditions, got %s", c)
+	}
+
+	// Operations on other decimals are unaffected.
+	z.ForceCondition(decimal.Underflow)
+	y := new(decimal.Big).Mul(x, x)
+	if c := y.Context.Conditions; c != 0 {
+		t.Fatalf("other decimal: wanted no conditions, got %s", c)
+	}
+
+	// A zero Condition cancels the forced one.
+	z.ForceCondition(0)
+	z.Context.Conditions = 0
+	z.Mul(x, x)
+	if c := z.Context.Conditions; c != 0 {
+		t.Fatalf("canceled: wanted no conditions, got %s", c)
+	}
+}
+
+func TestBig_ForceCondition_Context(t *testing.T) {
+	ctx := decimal.Context{Precision: 5, Traps: decimal.Subnormal}
+	z := new(decimal.Big)
+	z.ForceCondition(decimal.Subnormal)
+	ctx.Quo(z, decimal.New(1, 0), decimal.New(3, 0))
+	want := decimal.Subnormal | decimal.Inexact | decimal.Rounded
+	if c := z.Context.Conditions; c != want {
+		t.Fatalf("wanted %s, got %s", want, c)
+	}
+	if z.String() != "0.33333" {
+		t.Fatalf("wanted 0.33333, got %s", z)
+	}
+
+	conds, err := ctx.Do(func(ctx *decimal.Context) {
+		z.ForceCondition(decimal.Clamped)
+		ctx.Sub(z, z, z)
+	})
+	if conds != decimal.Clamped || err != nil {
+		t.Fatalf("Do: wanted (%s, <nil>), got (%s, %v)", decimal.Clamped, conds, err)
+	}
+}
+
+func TestBig_ForceCondition_NaN(t *testing.T) {
+	z := decimal.WithContext(decimal.Context{Traps: decimal.InsufficientStorage})
+	z.ForceCondition(decimal.InsufficientStorage)
+	z.Mul(decimal.New(2, 0), decimal.New(3, 0))
+	if !z.IsNaN(0) || z.Payload().String() != "simulated condition" {
+		t.Fatalf("wanted NaN with a payload, got %s (%s)", z, z.Payload())
+	}
+	if err := z.Context.Err(); !errors.Is(err, decimal.InsufficientStorage) {
+		t.Fatalf("wanted %s error, got %v", decimal.InsufficientStorage, err)
+	}
+
+	z = decimal.WithContext(decimal.Context{OperatingMode: decimal.Go})
+	z.ForceCondition(decimal.InvalidOperation)
+	defer func() {
+		if _, ok := recover().(decimal.ErrNaN); !ok {
+			t.Fatal("wanted a panic with an ErrNaN")
+		}
+	}()
+	z.Mul(decimal.New(2, 0), decimal.New(3, 0))
+}
+
+func TestBig_ForceCondition_Clone(t *testing.T) {
+	z := new(decimal.Big)
+	z.ForceCondition(decimal.Overflow)
+	y := z.Clone()
+	y.Add(decimal.New(1, 0), decimal.New(2, 0))
+	if c := y.Context.Conditions; c != 0 {
+		t.Fatalf("clone: wanted no conditions, got %s", c)
+	}
+	z.Add(decimal.New(1, 0), decimal.New(2, 0))
+	if c := z.Context.Conditions; c != decimal.Overflow {
+		t.Fatalf("wanted %s, got %s", decimal.Overflow, c)
+	}
+}
@@ -0,0 +1,82 @@
+package decimal
+
+import (
+	"errors"
+	"strconv"
+)
+
+// SpecialsPolicy determines how NaN and infinite values are encoded by
+// MarshalText, MarshalJSON, and the SQL Valuer in package sql/postgres. It
+// does not affect String or Format. Decoding accepts every spelling produced
+// by any policy, regardless of the policy in use.
+type SpecialsPolicy uint8
+
+const (
+	// SpecialsDefault spells NaN and infinite values as implied by the
+	// OperatingMode: "NaN", "sNaN", "Infinity", and "-Infinity" in GDA mode,
+	// "NaN", "+Inf", and "-Inf" in Go mode, and "NaN", "Infinity", and
+	// "-Infinity" in JS mode. MarshalJSON encodes them as
+	// JSON strings.
+	SpecialsDefault SpecialsPolicy = iota
+	// SpecialsError refuses to encode NaN and infinite values; encoding one
+	// returns an error.
+	SpecialsError
+	// SpecialsNull encodes NaN and infinite values as JSON null and SQL NULL.
+	// MarshalText, which has no null, returns an error.
+	SpecialsNull
+	// SpecialsGDA spells NaN and infinite values as in GDA mode, including
+	// the sign and payload of a NaN (e.g., "-sNaN12"), regardless of the
+	// OperatingMode.
+	SpecialsGDA
+	// SpecialsJS spells NaN and infinite values like JavaScript's Number:
+	// "NaN", "Infinity", and "-Infinity". Signaling NaNs and payloads are
+	// encoded as "NaN".
+	SpecialsJS
+)
+
+//go:generate stringer -type SpecialsPolicy
+
+// marshalSpecial returns the encoding of the NaN or infinite value x according
+// to x's SpecialsPolicy. null reports whether x should be encoded as a null
+// value instead, in which case s is empty.
+func (x *Big) marshalSpecial(op string) (s string, null bool, err error) {
+	switch p := x.Context.Specials; p {
+	case SpecialsDefault:
+		switch x.Context.OperatingMode {
+		case Go:
+			switch {
+			case x.IsNaN(0):
+				return "NaN", false, nil
+			case x.IsInf(+1):
+				return "+Inf", false, nil
+			default:
+				return "-Inf", false, nil
+			}
+		case JS:
+			s, _ := x.jsSpecial()
+			return s, false, nil
+		}
+		fallthrough
+	case SpecialsGDA:
+		s = x.form.String()
+		if x.IsNaN(0) && x.compact != 0 {
+			s += strconv.FormatUint(x.compact, 10)
+		}
+		return s, false, nil
+	case SpecialsJS:
+		switch {
+		case x.IsNaN(0):
+			return "NaN", false, nil
+		case x.IsInf(+1):
+			return "Infinity", false, nil
+		default:
+			return "-Infinity", false, nil
+		}
+	case SpecialsNull:
+		return "", true, nil
+	case SpecialsError:
+		return "", false, errors.New("decimal: " + op + ": cannot encode " + x.form.String())
+	default:
+		return "", false, errors.New("decimal: " + op + ": invalid " + p.String())
+	}
+}
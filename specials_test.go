@@ -0,0 +1,110 @@
+package decimal_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestBig_MarshalSpecials(t *testing.T) {
+	type want struct{ text, json string }
+	var (
+		fail = want{"error", "error"}
+		null = want{"error", "null"}
+	)
+	for i, test := range [...]struct {
+		x      string
+		mode   decimal.OperatingMode
+		policy decimal.SpecialsPolicy
+		want   want
+	}{
+		{"1.50", decimal.GDA, decimal.SpecialsError, want{"1.50", "1.50"}},
+		{"1.50", decimal.GDA, decimal.SpecialsNull, want{"1.50", "1.50"}},
+
+		{"NaN", decimal.GDA, decimal.SpecialsDefault, want{"NaN", `"NaN"`}},
+		{"-sNaN12", decimal.GDA, decimal.SpecialsDefault, want{"-sNaN12", `"-sNaN12"`}},
+		{"-Infinity", decimal.GDA, decimal.SpecialsDefault, want{"-Infinity", `"-Infinity"`}},
+		{"sNaN", decimal.Go, decimal.SpecialsDefault, want{"NaN", `"NaN"`}},
+		{"Infinity", decimal.Go, decimal.SpecialsDefault, want{"+Inf", `"+Inf"`}},
+		{"-Infinity", decimal.Go, decimal.SpecialsDefault, want{"-Inf", `"-Inf"`}},
+
+		{"NaN", decimal.GDA, decimal.SpecialsError, fail},
+		{"Infinity", decimal.Go, decimal.SpecialsError, fail},
+
+		{"NaN", decimal.GDA, decimal.SpecialsNull, null},
+		{"-Infinity", decimal.Go, decimal.SpecialsNull, null},
+
+		{"-sNaN12", decimal.Go, decimal.SpecialsGDA, want{"-sNaN12", `"-sNaN12"`}},
+		{"Infinity", decimal.Go, decimal.SpecialsGDA, want{"Infinity", `"Infinity"`}},
+
+		{"-sNaN12", decimal.GDA, decimal.SpecialsJS, want{"NaN", `"NaN"`}},
+		{"Infinity", decimal.Go, decimal.SpecialsJS, want{"Infinity", `"Infinity"`}},
+		{"-Infinity", decimal.GDA, decimal.SpecialsJS, want{"-Infinity", `"-Infinity"`}},
+
+		{"NaN", decimal.GDA, decimal.SpecialsJS + 1, fail},
+	} {
+		x := decimal.WithContext(decimal.Context{Specials: test.policy})
+		x.SetString(test.x)
+		x.Context.OperatingMode = test.mode
+
+		var got want
+		if b, err := x.MarshalText(); err != nil {
+			got.text = "error"
+		} else {
+			got.text = string(b)
+		}
+		if b, err := json.Marshal(x); err != nil {
+			got.json = "error"
+		} else {
+			got.json = string(b)
+		}
+		if got != test.want {
+			t.Fatalf("#%d: %s (%s, %s): wanted %+v, got %+v",
+				i, test.x, test.mode, test.policy, test.want, got)
+		}
+	}
+}
+
+func TestBig_UnmarshalSpecials(t *testing.T) {
+	for _, test := range [...]struct {
+		in   string
+		want string
+	}{
+		{`"NaN"`, "NaN"},
+		{`"sNaN"`, "sNaN"},
+		{`"-sNaN12"`, "-sNaN12"},
+		{`"Infinity"`, "Infinity"},
+		{`"-Infinity"`, "-Infinity"},
+		{`"+Inf"`, "Infinity"},
+		{`"-Inf"`, "-Infinity"},
+	} {
+		for _, policy := range []decimal.SpecialsPolicy{
+			decimal.SpecialsDefault, decimal.SpecialsError, decimal.SpecialsNull,
+			decimal.SpecialsGDA, decimal.SpecialsJS,
+		} {
+			z := decimal.WithContext(decimal.Context{Specials: policy})
+			if err := json.Unmarshal([]byte(test.in), z); err != nil {
+				t.Fatalf("%s (%s): %v", test.in, policy, err)
+			}
+			if z.String() != test.want {
+				t.Fatalf("%s (%s): wanted %s, got %s", test.in, policy, test.want, z)
+			}
+			var tz decimal.Big
+			tz.Context.Specials = policy
+			if err := tz.UnmarshalText([]byte(test.in[1 : len(test.in)-1])); err != nil {
+				t.Fatalf("%s (%s): %v", test.in, policy, err)
+			}
+			if tz.String() != test.want {
+				t.Fatalf("%s (%s): wanted %s, got %s", test.in, policy, test.want, &tz)
+			}
+		}
+	}
+
+	// null leaves the decimal unchanged.
+	z := decimal.New(15, 1)
+	z.Context.Specials = decimal.SpecialsNull
+	if err := json.Unmarshal([]byte("null"), z); err != nil || z.String() != "1.5" {
+		t.Fatalf("null: wanted 1.5, got %s (%v)", z, err)
+	}
+}
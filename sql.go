@@ -0,0 +1,122 @@
+package decimal
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+)
+
+// Value implements driver.Valuer. A finite x is stored as a string in the
+// format of String; NaN and infinite values are stored according to x's
+// SpecialsPolicy, as by MarshalText, and as NULL under SpecialsNull.
+//
+// Package sql/postgres provides a wrapper that also enforces the limits of the
+// PostgreSQL DECIMAL type.
+func (x *Big) Value() (driver.Value, error) {
+	if x == nil {
+		return nil, ErrNilOperand{Op: "Value"}
+	}
+	if x.isSpecial() {
+		s, null, err := x.marshalSpecial("Value")
+		if err != nil || null {
+			return nil, err
+		}
+		return s, nil
+	}
+	return x.String(), nil
+}
+
+var _ driver.Valuer = (*Big)(nil)
+
+// ScanSQL sets z to the value of src, a value read from a database, and
+// returns an error if src cannot be converted. It is the sql.Scanner
+// counterpart of Value; since Scan implements fmt.Scanner, a Big cannot be
+// passed to sql.Rows.Scan directly. Use a wrapper whose Scan method calls
+// ScanSQL, such as NullBig or the Decimal type of package sql/postgres.
+//
+// src may be a string or []byte in any of the formats accepted by SetString,
+// an int64, or a float64, which is converted as by SetFloat64, so that a
+// float64 0.1 becomes 0.1 rather than its exact binary value. A string or
+// []byte is parsed as by UnmarshalText: if it is invalid, z is unchanged. z is
+// not rounded.
+//
+// SQL NULL (a nil src) cannot be represented by a Big, so ScanSQL returns an
+// error and leaves z unchanged; scan nullable columns into a wrapper that
+// records NULL instead, such as NullBig.
+func (z *Big) ScanSQL(src interface{}) error {
+	if z == nil {
+		return ErrNilOperand{Op: "ScanSQL"}
+	}
+	switch v := src.(type) {
+	case string:
+		return z.UnmarshalText([]byte(v))
+	case []byte:
+		return z.UnmarshalText(v)
+	case int64:
+		z.SetMantScale(v, 0)
+		return nil
+	case float64:
+		z.SetFloat64(v)
+		return nil
+	case nil:
+		return errors.New("decimal: ScanSQL: cannot scan NULL into a Big; use a nullable wrapper, such as NullBig")
+	default:
+		return fmt.Errorf("decimal: ScanSQL: cannot scan %T into a Big", src)
+	}
+}
+
+// NullBig is a Big that may be null, like sql.NullString. It implements
+// sql.Scanner and driver.Valuer, mapping SQL NULL to Valid == false, and
+// json.Marshaler and json.Unmarshaler, mapping JSON null likewise. Its zero
+// value is null.
+type NullBig struct {
+	Big   Big
+	Valid bool // Valid is true if Big is not NULL
+}
+
+// Scan implements sql.Scanner. A non-nil src is converted as by Big.ScanSQL.
+func (n *NullBig) Scan(src interface{}) error {
+	if src == nil {
+		n.Big.setZero(0, 0)
+		n.Valid = false
+		return nil
+	}
+	if err := n.Big.ScanSQL(src); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements driver.Valuer. A valid n is stored as by Big.Value.
+func (n NullBig) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Big.Value()
+}
+
+// MarshalJSON implements json.Marshaler. A valid n is encoded as by
+// Big.MarshalJSON; otherwise, n is encoded as null.
+func (n NullBig) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return n.Big.MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler. null sets Valid to false;
+// anything else is decoded as by Big.UnmarshalJSON.
+func (n *NullBig) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		n.Big.setZero(0, 0)
+		n.Valid = false
+		return nil
+	}
+	if err := n.Big.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
@@ -37,7 +37,10 @@ type Decimal struct {
 	Zero  bool // return "0" if V == nil
 }
 
-// Value implements driver.Valuer.
+// Value implements driver.Valuer. By default, NaN is stored as "NaN" and
+// infinite values are rejected; set V's Context.Specials to choose another
+// SpecialsPolicy. For example, SpecialsJS stores "Infinity" and "-Infinity",
+// which PostgreSQL 14 and later accept.
 func (d *Decimal) Value() (driver.Value, error) {
 	if d.V == nil {
 		if d.Zero {
@@ -46,11 +49,22 @@ func (d *Decimal) Value() (driver.Value, error) {
 		return nil, nil
 	}
 	v := d.V
-	if v.IsNaN(0) {
-		return "NaN", nil
-	}
-	if v.IsInf(0) {
-		return nil, errors.New("Decimal.Value: DECIMAL does not accept Infinities")
+	if v.IsNaN(0) || v.IsInf(0) {
+		switch v.Context.Specials {
+		case decimal.SpecialsDefault:
+			if v.IsInf(0) {
+				return nil, errors.New("Decimal.Value: DECIMAL does not accept Infinities")
+			}
+			return "NaN", nil
+		case decimal.SpecialsNull:
+			return nil, nil
+		default:
+			b, err := v.MarshalText()
+			if err != nil {
+				return nil, err
+			}
+			return string(b), nil
+		}
 	}
 
 	dl := v.Precision()  // length of d
@@ -73,23 +87,12 @@ func (d *Decimal) Value() (driver.Value, error) {
 	return v.String(), nil
 }
 
-// Scan implements sql.Scanner.
+// Scan implements sql.Scanner. It accepts the sources accepted by
+// decimal.Big.ScanSQL, which rejects SQL NULL; scan nullable columns into a
+// decimal.NullBig instead.
 func (d *Decimal) Scan(val interface{}) error {
 	if d.V == nil {
 		d.V = new(decimal.Big)
 	}
-	switch t := val.(type) {
-	case string:
-		if _, ok := d.V.SetString(t); !ok {
-			if err := d.V.Context.Err(); err != nil {
-				return err
-			}
-			return fmt.Errorf("Decimal.Scan: invalid syntax: %q", t)
-		}
-		return nil
-	case []byte:
-		return d.V.UnmarshalText(t)
-	default:
-		return fmt.Errorf("Decimal.Scan: unknown value: %#v", val)
-	}
+	return d.V.ScanSQL(val)
 }
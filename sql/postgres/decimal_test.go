@@ -74,3 +74,53 @@ func TestDecimal_Value(t *testing.T) {
 		}
 	}
 }
+
+func TestDecimal_ValueSpecials(t *testing.T) {
+	for i, test := range [...]struct {
+		x      string
+		policy decimal.SpecialsPolicy
+		want   interface{}
+		err    bool
+	}{
+		{"NaN", decimal.SpecialsDefault, "NaN", false},
+		{"Infinity", decimal.SpecialsDefault, nil, true},
+		{"NaN", decimal.SpecialsError, nil, true},
+		{"-Infinity", decimal.SpecialsNull, nil, false},
+		{"-Infinity", decimal.SpecialsJS, "-Infinity", false},
+		{"sNaN", decimal.SpecialsJS, "NaN", false},
+		{"1.5", decimal.SpecialsError, "1.5", false},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		x.Context.Specials = test.policy
+		v, err := (&Decimal{V: x}).Value()
+		if (err != nil) != test.err || v != test.want {
+			t.Fatalf("#%d: Value(%s, %s): wanted (%v, error: %t), got (%v, %v)",
+				i, test.x, test.policy, test.want, test.err, v, err)
+		}
+	}
+}
+
+func TestDecimal_Scan(t *testing.T) {
+	for i, test := range [...]struct {
+		src  interface{}
+		want string
+	}{
+		{"12.50", "12.50"},
+		{[]byte("-7"), "-7"},
+		{int64(3), "3"},
+		{0.1, "0.1"},
+	} {
+		var d Decimal
+		if err := d.Scan(test.src); err != nil || d.V.String() != test.want {
+			t.Fatalf("#%d: Scan(%#v): wanted %s, got %s (%v)", i, test.src, test.want, d.V, err)
+		}
+	}
+
+	d := Decimal{V: decimal.New(1, 0)}
+	if err := d.Scan(nil); err == nil || d.V.String() != "1" {
+		t.Fatalf("Scan(nil): wanted an error and an unchanged V, got %s (%v)", d.V, err)
+	}
+	if err := d.Scan(true); err == nil {
+		t.Fatal("Scan(true): wanted an error")
+	}
+}
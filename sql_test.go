@@ -0,0 +1,140 @@
+package decimal_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestBig_Value(t *testing.T) {
+	for i, test := range [...]struct {
+		x      string
+		policy decimal.SpecialsPolicy
+		want   driver.Value
+	}{
+		{"1.50", decimal.SpecialsDefault, "1.50"},
+		{"-0", decimal.SpecialsDefault, "-0"},
+		{"1.2E+7", decimal.SpecialsDefault, "1.2E+7"},
+		{"NaN", decimal.SpecialsDefault, "NaN"},
+		{"-Infinity", decimal.SpecialsJS, "-Infinity"},
+		{"NaN", decimal.SpecialsNull, nil},
+	} {
+		x := decimal.WithContext(decimal.Context{Specials: test.policy})
+		x.SetString(test.x)
+		v, err := x.Value()
+		if err != nil || v != test.want || !driver.IsValue(v) {
+			t.Fatalf("#%d: Value(%s): wanted %v, got %v (%v)", i, test.x, test.want, v, err)
+		}
+	}
+
+	x := decimal.WithContext(decimal.Context{Specials: decimal.SpecialsError})
+	if _, err := x.SetInf(false).Value(); err == nil {
+		t.Fatal("Value(Infinity) (SpecialsError): wanted an error")
+	}
+}
+
+func TestBig_ScanSQL(t *testing.T) {
+	for i, test := range [...]struct {
+		src  interface{}
+		want string
+	}{
+		{"12.50", "12.50"},
+		{[]byte("-1E+3"), "-1E+3"},
+		{"NaN", "NaN"},
+		{int64(-42), "-42"},
+		{0.1, "0.1"},
+		{1e21, "1E+21"},
+		{0.30000000000000004, "0.30000000000000004"},
+	} {
+		z := new(decimal.Big)
+		if err := z.ScanSQL(test.src); err != nil || z.String() != test.want {
+			t.Fatalf("#%d: ScanSQL(%#v): wanted %s, got %s (%v)", i, test.src, test.want, z, err)
+		}
+	}
+
+	for i, src := range [...]interface{}{
+		nil, []byte("1.2.3"), "", "12abc", true, time.Time{}, int32(1),
+	} {
+		z := decimal.New(125, 2)
+		err := z.ScanSQL(src)
+		if err == nil {
+			t.Fatalf("#%d: ScanSQL(%#v): wanted an error, got %s", i, src, z)
+		}
+		if z.String() != "1.25" || z.Context.Conditions != 0 {
+			t.Fatalf("#%d: ScanSQL(%#v): modified z: %s (%s)", i, src, z, z.Context.Conditions)
+		}
+		if src == nil && !strings.Contains(err.Error(), "nullable") {
+			t.Fatalf("ScanSQL(nil): wanted the error to suggest a nullable wrapper, got %v", err)
+		}
+	}
+}
+
+func TestNullBig(t *testing.T) {
+	var (
+		_ sql.Scanner      = (*decimal.NullBig)(nil)
+		_ driver.Valuer    = decimal.NullBig{}
+		_ json.Marshaler   = decimal.NullBig{}
+		_ json.Unmarshaler = (*decimal.NullBig)(nil)
+	)
+
+	for i, test := range [...]struct {
+		src   interface{}
+		valid bool
+		want  string
+	}{
+		{nil, false, "0"},
+		{int64(7), true, "7"},
+		{[]byte("-1.50"), true, "-1.50"},
+		{"2E+3", true, "2E+3"},
+		{0.1, true, "0.1"},
+	} {
+		n := decimal.NullBig{Valid: !test.valid}
+		n.Big.SetMantScale(99, 0)
+		if err := n.Scan(test.src); err != nil {
+			t.Fatalf("#%d: Scan(%#v): %v", i, test.src, err)
+		}
+		if n.Valid != test.valid || n.Big.String() != test.want {
+			t.Fatalf("#%d: Scan(%#v): wanted (%s, %t), got (%s, %t)",
+				i, test.src, test.want, test.valid, &n.Big, n.Valid)
+		}
+		v, err := n.Value()
+		if err != nil || (v == nil) == test.valid {
+			t.Fatalf("#%d: Value(): got %v (%v)", i, v, err)
+		}
+		if test.valid && v != test.want {
+			t.Fatalf("#%d: Value(): wanted %s, got %v", i, test.want, v)
+		}
+	}
+
+	n := decimal.NullBig{Valid: true}
+	n.Big.SetMantScale(5, 0)
+	if err := n.Scan("1.2.3"); err == nil || !n.Valid || n.Big.String() != "5" {
+		t.Fatalf("Scan(1.2.3): wanted an error and 5, got %s, %t (%v)", &n.Big, n.Valid, err)
+	}
+
+	type row struct {
+		A, B decimal.NullBig
+		C    *decimal.NullBig
+	}
+	const in = `{"A":null,"B":12.50,"C":null}`
+	var r row
+	r.A.Valid = true
+	if err := json.Unmarshal([]byte(in), &r); err != nil {
+		t.Fatal(err)
+	}
+	if r.A.Valid || !r.B.Valid || r.B.Big.String() != "12.50" || r.C != nil {
+		t.Fatalf("json.Unmarshal(%s): got %+v", in, r)
+	}
+	b, err := json.Marshal(r)
+	if err != nil || string(b) != in {
+		t.Fatalf("json.Marshal: wanted %s, got %s (%v)", in, b, err)
+	}
+	if err := json.Unmarshal([]byte(`{"B":"1.2.3"}`), &r); err == nil {
+		t.Fatal("json.Unmarshal: wanted an error")
+	}
+}
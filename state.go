@@ -0,0 +1,210 @@
+package decimal
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// State is a snapshot of the complete state of a Big: its value, including the
+// sign of zero and the payload of a NaN, and its Context. Unlike the string
+// forms of a Big, a State distinguishes every Big that behaves differently, so
+// it is suitable for golden test data and for attaching a failing value to a
+// bug report. States are comparable with ==, and encode to human-readable JSON.
+//
+// A State does not include x's tag, the Context's Tracer or TagPolicy, nor the
+// details of a failure caused by ExactOnly or of a trapped operation.
+type State struct {
+	// Form is one of "finite", "inf", "qnan", or "snan".
+	Form    string `json:"form"`
+	Signbit bool   `json:"signbit"`
+
+	// Coeff is the decimal coefficient. For a NaN, it is the payload.
+	Coeff string `json:"coeff"`
+	Exp   int    `json:"exp"`
+
+	Context ContextState `json:"context"`
+}
+
+// ContextState is the snapshot of a Context within a State. Conditions and
+// Traps are encoded with Condition.Code; bits without a code are appended as
+// a hexadecimal number, as in "inexact,rounded,0xffff0000".
+type ContextState struct {
+	Precision       int    `json:"precision"`
+	MaxScale        int    `json:"max_scale"`
+	MinScale        int    `json:"min_scale"`
+	Clamp           bool   `json:"clamp"`
+	RoundingMode    string `json:"rounding_mode"`
+	OperatingMode   string `json:"operating_mode"`
+	Traps           string `json:"traps"`
+	Conditions      string `json:"conditions"`
+	ExactOnly       bool   `json:"exact_only"`
+	MaxParseBytes   int    `json:"max_parse_bytes"`
+	MaxParseDigits  int    `json:"max_parse_digits"`
+	Underscores     bool   `json:"underscores"`
+	GuardDigits     int    `json:"guard_digits"`
+	CompatLevel     int    `json:"compat_level"`
+	Specials        string `json:"specials"`
+	QuoteUnsafeJSON bool   `json:"quote_unsafe_json"`
+}
+
+// Dump returns a snapshot of x's state. Load reconstructs x from it.
+func (x *Big) Dump() State {
+	mustNotNil("Dump", x, x)
+
+	s := State{
+		Signbit: x.form&signbit != 0,
+		Exp:     x.exp,
+		Context: ContextState{
+			Precision:       x.Context.Precision,
+			MaxScale:        x.Context.MaxScale,
+			MinScale:        x.Context.MinScale,
+			Clamp:           x.Context.Clamp,
+			RoundingMode:    x.Context.RoundingMode.String(),
+			OperatingMode:   x.Context.OperatingMode.String(),
+			Traps:           conditionState(x.Context.Traps),
+			Conditions:      conditionState(x.Context.Conditions),
+			ExactOnly:       x.Context.ExactOnly,
+			MaxParseBytes:   x.Context.MaxParseBytes,
+			MaxParseDigits:  x.Context.MaxParseDigits,
+			Underscores:     x.Context.Underscores,
+			GuardDigits:     x.Context.GuardDigits,
+			CompatLevel:     x.Context.CompatLevel,
+			Specials:        x.Context.Specials.String(),
+			QuoteUnsafeJSON: x.Context.QuoteUnsafeJSON,
+		},
+	}
+	switch x.form &^ signbit {
+	case finite:
+		s.Form = "finite"
+	case inf:
+		s.Form = "inf"
+	case qnan:
+		s.Form = "qnan"
+	case snan:
+		s.Form = "snan"
+	}
+	if x.isCompact() {
+		s.Coeff = strconv.FormatUint(x.compact, 10)
+	} else {
+		s.Coeff = x.unscaled.String()
+	}
+	return s
+}
+
+// Load returns a new Big with the state s, which is usually produced by Dump.
+// It returns an error if s is invalid.
+func Load(s State) (*Big, error) {
+	bad := func(field, v string) error {
+		return fmt.Errorf("decimal: invalid State %s %q", field, v)
+	}
+
+	var coeff big.Int
+	if strings.TrimLeft(s.Coeff, "0123456789") != "" {
+		return nil, bad("coefficient", s.Coeff)
+	}
+	if _, ok := coeff.SetString(s.Coeff, 10); !ok {
+		return nil, bad("coefficient", s.Coeff)
+	}
+
+	z := new(Big)
+	switch s.Form {
+	case "finite":
+		z.SetBigMantScale(&coeff, -s.Exp)
+	case "inf":
+		z.form = inf
+	case "qnan":
+		z.form = qnan
+	case "snan":
+		z.form = snan
+	default:
+		return nil, bad("form", s.Form)
+	}
+	if z.form != finite {
+		if !coeff.IsUint64() {
+			return nil, bad("coefficient", s.Coeff)
+		}
+		z.compact = coeff.Uint64()
+		z.exp = s.Exp
+	}
+	if s.Signbit {
+		z.form |= signbit
+	}
+
+	cs := s.Context
+	ctx := Context{
+		Precision:       cs.Precision,
+		MaxScale:        cs.MaxScale,
+		MinScale:        cs.MinScale,
+		Clamp:           cs.Clamp,
+		ExactOnly:       cs.ExactOnly,
+		MaxParseBytes:   cs.MaxParseBytes,
+		MaxParseDigits:  cs.MaxParseDigits,
+		Underscores:     cs.Underscores,
+		GuardDigits:     cs.GuardDigits,
+		CompatLevel:     cs.CompatLevel,
+		QuoteUnsafeJSON: cs.QuoteUnsafeJSON,
+	}
+	for ctx.RoundingMode < unnecessary && ctx.RoundingMode.String() != cs.RoundingMode {
+		ctx.RoundingMode++
+	}
+	if ctx.RoundingMode == unnecessary {
+		return nil, bad("rounding mode", cs.RoundingMode)
+	}
+	for ctx.OperatingMode <= JS && ctx.OperatingMode.String() != cs.OperatingMode {
+		ctx.OperatingMode++
+	}
+	if ctx.OperatingMode > JS {
+		return nil, bad("operating mode", cs.OperatingMode)
+	}
+	for ctx.Specials <= SpecialsJS && ctx.Specials.String() != cs.Specials {
+		ctx.Specials++
+	}
+	if ctx.Specials > SpecialsJS {
+		return nil, bad("specials policy", cs.Specials)
+	}
+	var ok bool
+	if ctx.Traps, ok = parseConditionState(cs.Traps); !ok {
+		return nil, bad("traps", cs.Traps)
+	}
+	if ctx.Conditions, ok = parseConditionState(cs.Conditions); !ok {
+		return nil, bad("conditions", cs.Conditions)
+	}
+	z.Context = ctx
+	return z, nil
+}
+
+// conditionState encodes x for a ContextState.
+func conditionState(x Condition) string {
+	known := x & (1<<uint(len(conditionCodes)) - 1)
+	s := known.Code()
+	if rest := x &^ known; rest != 0 {
+		if s != "" {
+			s += ","
+		}
+		s += "0x" + strconv.FormatUint(uint64(rest), 16)
+	}
+	return s
+}
+
+// parseConditionState is the inverse of conditionState.
+func parseConditionState(s string) (Condition, bool) {
+	if s == "" {
+		return 0, true
+	}
+	var x Condition
+	if i := strings.LastIndex(s, "0x"); i >= 0 && (i == 0 || s[i-1] == ',') {
+		n, err := strconv.ParseUint(s[i+2:], 16, 32)
+		if err != nil {
+			return 0, false
+		}
+		x = Condition(n)
+		s = strings.TrimSuffix(s[:i], ",")
+		if s == "" {
+			return x, true
+		}
+	}
+	known, err := ParseCondition(s)
+	return x | known, err == nil
+}
@@ -0,0 +1,97 @@
+package decimal_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestBig_DumpLoad(t *testing.T) {
+	mk := func(s string, ctx decimal.Context) *decimal.Big {
+		x, ok := decimal.WithContext(ctx).SetString(s)
+		if !ok {
+			t.Fatalf("invalid input %q", s)
+		}
+		return x
+	}
+	ctx := decimal.Context{
+		Precision:       7,
+		MaxScale:        96,
+		MinScale:        -95,
+		RoundingMode:    decimal.ToNegativeInf,
+		OperatingMode:   decimal.GDA,
+		ExactOnly:       true,
+		GuardDigits:     2,
+		CompatLevel:     1,
+		Specials:        decimal.SpecialsJS,
+		QuoteUnsafeJSON: true,
+	}
+	for i, x := range []*decimal.Big{
+		new(decimal.Big),
+		mk("-0", decimal.Context{}),
+		mk("-0E-12", decimal.Context{}),
+		mk("1.50", decimal.Context{}),
+		mk("-1E+5", ctx),
+		mk("123456789012345678901234567890E-10", decimal.Context{}),
+		new(decimal.Big).SetUint64(math.MaxUint64 - 1),
+		new(decimal.Big).SetUint64(math.MaxUint64),
+		mk("NaN", decimal.Context{}),
+		mk("-sNaN123", decimal.Context{}),
+		mk("-Infinity", decimal.Context{}),
+		mk("1.2345678", decimal.Context32),
+		mk("1.2345678", decimal.ContextUnlimited),
+		new(decimal.Big).Quo(decimal.New(1, 0), decimal.New(0, 0)),
+		decimal.WithContext(decimal.Context{OperatingMode: decimal.Go}).SetMantScale(-42, 3),
+		decimal.WithContext(decimal.Context{OperatingMode: decimal.JS}).SetMantScale(-42, 3),
+	} {
+		s := x.Dump()
+		y, err := decimal.Load(s)
+		if err != nil {
+			t.Fatalf("#%d: Load(%+v): %v", i, s, err)
+		}
+		if y.Dump() != s {
+			t.Fatalf("#%d: round trip: wanted %+v, got %+v", i, s, y.Dump())
+		}
+		if y.String() != x.String() || y.Signbit() != x.Signbit() ||
+			y.Scale() != x.Scale() || y.Context != x.Context {
+			t.Fatalf("#%d: wanted %s (%+v), got %s (%+v)", i, x, x.Context, y, y.Context)
+		}
+
+		b, err := json.Marshal(s)
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		var js decimal.State
+		if err := json.Unmarshal(b, &js); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if js != s {
+			t.Fatalf("#%d: JSON round trip: wanted %+v, got %+v", i, s, js)
+		}
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	valid := decimal.New(15, 1).Dump()
+	for i, fn := range []func(s *decimal.State){
+		func(s *decimal.State) { s.Form = "nan" },
+		func(s *decimal.State) { s.Coeff = "" },
+		func(s *decimal.State) { s.Coeff = "-15" },
+		func(s *decimal.State) { s.Coeff = "+15" },
+		func(s *decimal.State) { s.Coeff = "1.5" },
+		func(s *decimal.State) { s.Form, s.Coeff = "qnan", "18446744073709551616" },
+		func(s *decimal.State) { s.Context.RoundingMode = "unnecessary" },
+		func(s *decimal.State) { s.Context.OperatingMode = "Python" },
+		func(s *decimal.State) { s.Context.Traps = "inexact,bogus" },
+		func(s *decimal.State) { s.Context.Conditions = "0xzz" },
+		func(s *decimal.State) { s.Context.Specials = "SpecialsXML" },
+	} {
+		s := valid
+		fn(&s)
+		if _, err := decimal.Load(s); err == nil {
+			t.Fatalf("#%d: Load(%+v): wanted an error", i, s)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package decimal
+
+// SetTag sets z's tag to v and returns z. A tag is arbitrary metadata, such as
+// the provenance of a value, that is carried through arithmetic: Add, Sub, Mul,
+// Quo, QuoInt, Rem, QuoRem, FMA, Dot, Sum, Wrap, and the other operations that
+// compute a result from operands give their results a tag combined from the
+// tags of their operands according to the TagPolicy of the Context used for
+// the operation, or as by the zero TagPolicy, which keeps the tag of the
+// left-most operand, if it has none. Copy, Clone, and the methods built on
+// them, such as Set and Neg, copy the tag of their operand. Other methods that
+// set z's value, such as SetString and Quantize, leave its tag unchanged.
+//
+// The tag is kept in z's Context, out of line, so that decimals without tags
+// pay nothing for them. Thus assigning to z.Context replaces z's tag with that
+// of the assigned Context, though WithContext(z.Context) creates an untagged
+// decimal.
+//
+// A nil v removes z's tag. Tags are not otherwise interpreted by this package,
+// so two decimals that differ only in their tags are equal.
+func (z *Big) SetTag(v interface{}) *Big {
+	mustNotNil("SetTag", z, z)
+	z.setTag(v)
+	return z
+}
+
+// setTag sets z's tag to v.
+func (z *Big) setTag(v interface{}) {
+	if v == nil && z.tag() == nil {
+		return
+	}
+	z.Context.hooks = z.Context.hooks.update(func(h *hooks) { h.tag = v })
+}
+
+// Tag returns x's tag, or nil if x has none. See SetTag.
+func (x *Big) Tag() interface{} {
+	mustNotNil("Tag", x, x)
+	return x.tag()
+}
+
+// tag returns x's tag. A nil x has none.
+func (x *Big) tag() interface{} {
+	if x == nil || x.Context.hooks == nil {
+		return nil
+	}
+	return x.Context.hooks.tag
+}
+
+// SetTags sets c's TagPolicy. A nil p restores the default policy, the zero
+// TagPolicy. See Big.SetTag.
+func (c *Context) SetTags(p *TagPolicy) {
+	c.hooks = c.hooks.update(func(h *hooks) { h.tags = p })
+}
+
+// Tags returns c's TagPolicy, or nil if it has none. See SetTags.
+func (c Context) Tags() *TagPolicy {
+	if c.hooks == nil {
+		return nil
+	}
+	return c.hooks.tags
+}
+
+// TagMode determines how an operation combines the tags of its operands.
+type TagMode uint8
+
+const (
+	// TagsLeft gives the result the tag of its left-most operand, even if
+	// that operand has no tag.
+	TagsLeft TagMode = iota
+	// TagsRight gives the result the tag of its right-most operand, even if
+	// that operand has no tag.
+	TagsRight
+	// TagsMerge gives the result the tag returned by TagPolicy.Merge, called
+	// on the tags of the operands from left to right. If only one of a pair
+	// of operands has a tag, or Merge is nil, the result has the first
+	// non-nil tag instead.
+	TagsMerge
+	// TagsDrop gives the result no tag.
+	TagsDrop
+)
+
+//go:generate stringer -type TagMode
+
+// TagPolicy determines how the tags of decimals are propagated through
+// operations performed with a Context that uses it, and how they are encoded.
+// It does not store any tags; each decimal keeps its own. See Context.SetTags
+// and Big.SetTag.
+type TagPolicy struct {
+	// Mode determines how the tags of an operation's operands combine into
+	// the tag of its result.
+	Mode TagMode
+
+	// Merge combines two non-nil tags under TagsMerge. It must not modify
+	// either tag.
+	Merge func(x, y interface{}) interface{}
+
+	// JSON, if true, makes MarshalJSON encode a decimal that has a tag as the
+	// JSON object {"value": v, "tag": t}, where v is the decimal's usual
+	// encoding and t is its tag encoded with package encoding/json.
+	JSON bool
+}
+
+// combine returns the tag of the result of an operation on xs under p. A nil
+// p is the zero TagPolicy.
+func (p *TagPolicy) combine(xs ...*Big) interface{} {
+	var (
+		mode  TagMode
+		merge func(x, y interface{}) interface{}
+	)
+	if p != nil {
+		mode, merge = p.Mode, p.Merge
+	}
+	if mode == TagsDrop {
+		return nil
+	}
+	var tag interface{}
+	for i, x := range xs {
+		t := x.tag()
+		switch {
+		case i == 0 || mode == TagsRight:
+			tag = t
+		case mode != TagsMerge || t == nil:
+			// Keep tag.
+		case tag == nil || merge == nil:
+			if tag == nil {
+				tag = t
+			}
+		default:
+			tag = merge(tag, t)
+		}
+	}
+	return tag
+}
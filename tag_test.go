@@ -0,0 +1,188 @@
+package decimal_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestBig_Tag(t *testing.T) {
+	merge := func(x, y interface{}) interface{} { return x.(string) + "+" + y.(string) }
+	for i, test := range [...]struct {
+		p          *decimal.TagPolicy
+		x, y, want interface{}
+	}{
+		{&decimal.TagPolicy{Mode: decimal.TagsLeft}, "a", "b", "a"},
+		{&decimal.TagPolicy{Mode: decimal.TagsRight}, "a", "b", "b"},
+		{&decimal.TagPolicy{Mode: decimal.TagsRight}, "a", nil, nil},
+		{&decimal.TagPolicy{Mode: decimal.TagsMerge, Merge: merge}, "a", "b", "a+b"},
+		{&decimal.TagPolicy{Mode: decimal.TagsMerge, Merge: merge}, nil, "b", "b"},
+		{&decimal.TagPolicy{Mode: decimal.TagsMerge, Merge: merge}, "a", nil, "a"},
+		{&decimal.TagPolicy{Mode: decimal.TagsMerge}, "a", "b", "a"},
+		{&decimal.TagPolicy{Mode: decimal.TagsDrop}, "a", "b", nil},
+	} {
+		var ctx decimal.Context
+		ctx.SetTags(test.p)
+		tagged := func(v int64, tag interface{}) *decimal.Big {
+			return decimal.WithContext(ctx).SetMantScale(v, 0).SetTag(tag)
+		}
+		for _, op := range [...]struct {
+			name string
+			fn   func(z, x, y *decimal.Big) *decimal.Big
+		}{
+			{"Add", ctx.Add},
+			{"Sub", ctx.Sub},
+			{"Mul", ctx.Mul},
+			{"Quo", ctx.Quo},
+			{"QuoInt", ctx.QuoInt},
+			{"Rem", ctx.Rem},
+			{"Sum", func(z, x, y *decimal.Big) *decimal.Big { return ctx.Sum(z, x, y) }},
+			{"Dot", func(z, x, y *decimal.Big) *decimal.Big {
+				return ctx.Dot(z, []*decimal.Big{x}, []*decimal.Big{y})
+			}},
+			{"QuoRem", func(z, x, y *decimal.Big) *decimal.Big {
+				r := tagged(0, "stale")
+				ctx.QuoRem(z, x, y, r)
+				if r.Tag() != z.Tag() {
+					t.Fatalf("QuoRem: remainder has tag %v, quotient has %v", r.Tag(), z.Tag())
+				}
+				return z
+			}},
+		} {
+			x := tagged(7, test.x)
+			y := tagged(2, test.y)
+			z := op.fn(tagged(0, "stale"), x, y)
+			if z.Tag() != test.want {
+				t.Fatalf("#%d: %s: wanted tag %v, got %v", i, op.name, test.want, z.Tag())
+			}
+			// z may be an operand.
+			if z := op.fn(x, x, y); z.Tag() != test.want {
+				t.Fatalf("#%d: %s with z == x: wanted tag %v, got %v", i, op.name, test.want, z.Tag())
+			}
+		}
+	}
+}
+
+func TestBig_Tag_FMA(t *testing.T) {
+	var ctx decimal.Context
+	ctx.SetTags(&decimal.TagPolicy{
+		Mode:  decimal.TagsMerge,
+		Merge: func(x, y interface{}) interface{} { return x.(string) + "+" + y.(string) },
+	})
+	x := decimal.WithContext(ctx).SetMantScale(2, 0).SetTag("a")
+	y := decimal.WithContext(ctx).SetMantScale(3, 0).SetTag("b")
+	u := decimal.WithContext(ctx).SetMantScale(4, 0).SetTag("c")
+	z := ctx.FMA(u, x, y, u)
+	if z.String() != "10" || z.Tag() != "a+b+c" {
+		t.Fatalf("FMA: wanted 10 (a+b+c), got %s (%v)", z, z.Tag())
+	}
+}
+
+func TestBig_Tag_Copy(t *testing.T) {
+	var ctx decimal.Context
+	ctx.SetTags(new(decimal.TagPolicy))
+	x := decimal.WithContext(ctx).SetMantScale(15, 1).SetTag("src")
+	if tag := decimal.WithContext(ctx).Copy(x).Tag(); tag != "src" {
+		t.Fatalf("Copy: wanted tag src, got %v", tag)
+	}
+	if tag := x.Clone().Tag(); tag != "src" {
+		t.Fatalf("Clone: wanted tag src, got %v", tag)
+	}
+	if tag := decimal.WithContext(ctx).Set(x).Tag(); tag != "src" {
+		t.Fatalf("Set: wanted tag src, got %v", tag)
+	}
+	if tag := decimal.WithContext(ctx).Neg(x).Tag(); tag != "src" {
+		t.Fatalf("Neg: wanted tag src, got %v", tag)
+	}
+	z, _ := decimal.WithContext(ctx).SetTag("kept").SetString("2")
+	if z.Tag() != "kept" {
+		t.Fatalf("SetString: wanted tag kept, got %v", z.Tag())
+	}
+	if z.SetTag(nil).Tag() != nil {
+		t.Fatalf("SetTag(nil): wanted no tag, got %v", z.Tag())
+	}
+
+	// Tags are kept with the decimal, so a copy of the Big has them too.
+	y := *x
+	if tag := y.Tag(); tag != "src" {
+		t.Fatalf("copy by value: wanted tag src, got %v", tag)
+	}
+	if tag := x.CloneWithContext(decimal.Context128).Tag(); tag != "src" {
+		t.Fatalf("CloneWithContext: wanted tag src, got %v", tag)
+	}
+}
+
+func TestBig_Tag_NoPolicy(t *testing.T) {
+	// Without a TagPolicy, tags combine as by the zero TagPolicy.
+	x := decimal.New(1, 0).SetTag("x")
+	y := decimal.New(2, 0).SetTag("y")
+	if tag := new(decimal.Big).Add(x, y).Tag(); tag != "x" {
+		t.Fatalf("Add: wanted tag x, got %v", tag)
+	}
+	if tag := new(decimal.Big).Mul(y, x).Tag(); tag != "y" {
+		t.Fatalf("Mul: wanted tag y, got %v", tag)
+	}
+	if tag := decimal.Context128.Quo(new(decimal.Big), x, y).Tag(); tag != "x" {
+		t.Fatalf("Context128.Quo: wanted tag x, got %v", tag)
+	}
+	z := decimal.New(3, 0).SetTag("stale")
+	if tag := z.Sub(decimal.New(4, 0), y).Tag(); tag != nil {
+		t.Fatalf("Sub of an untagged operand: wanted no tag, got %v", tag)
+	}
+	if tag := z.Sqrt(y).Tag(); tag != "y" {
+		t.Fatalf("Sqrt: wanted tag y, got %v", tag)
+	}
+
+	// Untagged operands have no tags.
+	z = new(decimal.Big).Add(decimal.New(1, 0), decimal.New(2, 0))
+	if z.Tag() != nil {
+		t.Fatalf("Add: wanted no tag, got %v", z.Tag())
+	}
+}
+
+func TestBig_Tag_JSON(t *testing.T) {
+	type provenance struct {
+		Source string `json:"source"`
+		Bucket int    `json:"bucket"`
+	}
+	var ctx decimal.Context
+	ctx.SetTags(new(decimal.TagPolicy))
+	x := decimal.WithContext(ctx).SetMantScale(1250, 2).SetTag(provenance{"ledger", 42})
+	b, err := json.Marshal(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "12.50" {
+		t.Fatalf("wanted tags to be omitted by default, got %s", b)
+	}
+
+	x.Context.Tags().JSON = true
+	b, err = json.Marshal(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = `{"value":12.50,"tag":{"source":"ledger","bucket":42}}`
+	if string(b) != want {
+		t.Fatalf("wanted %s, got %s", want, b)
+	}
+	if b, _ := json.Marshal(decimal.WithContext(x.Context).SetMantScale(3, 0)); string(b) != "3" {
+		t.Fatalf("wanted an untagged decimal to be encoded as a number, got %s", b)
+	}
+
+	b, _ = json.Marshal(x)
+	z := decimal.WithContext(ctx)
+	if err := json.Unmarshal(b, z); err != nil {
+		t.Fatal(err)
+	}
+	tag, ok := z.Tag().(map[string]interface{})
+	if z.String() != "12.50" || !ok || tag["source"] != "ledger" || tag["bucket"] != 42.0 {
+		t.Fatalf("wanted 12.50 (%s), got %s (%#v)", want, z, z.Tag())
+	}
+
+	for _, s := range [...]string{`{"tag":1}`, `{"value":{"value":"1"}}`, `{"value":"1"`} {
+		if err := json.Unmarshal([]byte(s), new(decimal.Big)); err == nil {
+			t.Fatalf("Unmarshal(%s): wanted an error", s)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package decimal_test
+
+import (
+	"encoding/xml"
+	"errors"
+	"flag"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestBig_MarshalText(t *testing.T) {
+	for i, test := range [...]struct {
+		in   string
+		mode decimal.OperatingMode
+	}{
+		{"1.50", decimal.GDA},
+		{"-0", decimal.GDA},
+		{"1.2E+7", decimal.GDA},
+		{"1.2E-10", decimal.GDA},
+		{"12345678901234567890.123", decimal.GDA},
+		{"1.2E+7", decimal.Go},
+		{"1.2E-10", decimal.Go},
+	} {
+		x := decimal.WithContext(decimal.Context{OperatingMode: test.mode})
+		x.SetString(test.in)
+		b, err := x.MarshalText()
+		if err != nil || string(b) != x.String() {
+			t.Fatalf("#%d: MarshalText(%s): wanted %s, got %s (%v)", i, test.in, x, b, err)
+		}
+		z := decimal.WithContext(x.Context)
+		if err := z.UnmarshalText(b); err != nil || z.Cmp(x) != 0 || z.Scale() != x.Scale() {
+			t.Fatalf("#%d: UnmarshalText(%s): got %s (%v)", i, b, z, err)
+		}
+	}
+
+	var x *decimal.Big
+	if _, err := x.MarshalText(); err == nil {
+		t.Fatal("MarshalText: wanted an error for a nil receiver")
+	}
+	if err := x.UnmarshalText([]byte("1")); err == nil {
+		t.Fatal("UnmarshalText: wanted an error for a nil receiver")
+	}
+}
+
+func TestBig_UnmarshalText_Invalid(t *testing.T) {
+	for i, test := range [...]struct {
+		in     string
+		offset int
+		msg    string
+	}{
+		{"", 0, "empty input"},
+		{"1.2.3", 3, `unexpected '.'`},
+		{"12x4", 2, `unexpected 'x'`},
+		{" 1", 0, `unexpected ' '`},
+		{"-", 1, "unexpected end of input"},
+		{"NaNx", 3, `unexpected 'x'`},
+	} {
+		z := decimal.New(125, 2)
+		z.Context.Conditions = decimal.Inexact
+		err := z.UnmarshalText([]byte(test.in))
+		var e decimal.ErrInput
+		if !errors.As(err, &e) || !errors.Is(err, decimal.ConversionSyntax) {
+			t.Fatalf("#%d: UnmarshalText(%q): wanted ErrInput, got %v", i, test.in, err)
+		}
+		if e.Input != test.in || e.Offset != test.offset || e.Msg != test.msg {
+			t.Fatalf("#%d: UnmarshalText(%q): wanted (%d, %s), got (%d, %s)",
+				i, test.in, test.offset, test.msg, e.Offset, e.Msg)
+		}
+		if z.String() != "1.25" || z.Context.Conditions != decimal.Inexact {
+			t.Fatalf("#%d: UnmarshalText(%q): modified z: %s (%s)", i, test.in, z, z.Context.Conditions)
+		}
+	}
+
+	// A ConversionSyntax raised earlier does not cause an error.
+	z := new(decimal.Big)
+	z.Context.Conditions = decimal.ConversionSyntax
+	if err := z.UnmarshalText([]byte("1.5")); err != nil || z.String() != "1.5" {
+		t.Fatalf("wanted 1.5, got %s (%v)", z, err)
+	}
+	if z.Context.Conditions != decimal.ConversionSyntax {
+		t.Fatalf("wanted %s, got %s", decimal.ConversionSyntax, z.Context.Conditions)
+	}
+}
+
+func TestBig_Text_Packages(t *testing.T) {
+	type item struct {
+		Price decimal.Big  `xml:"price"`
+		Tax   *decimal.Big `xml:"tax,attr"`
+	}
+	in := item{Tax: decimal.New(7, 2)}
+	in.Price.SetString("1.2E+3")
+	b, err := xml.Marshal(&in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = `<item tax="0.07"><price>1.2E+3</price></item>`
+	if string(b) != want {
+		t.Fatalf("xml.Marshal: wanted %s, got %s", want, b)
+	}
+	var out item
+	if err := xml.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Price.String() != "1.2E+3" || out.Tax.String() != "0.07" {
+		t.Fatalf("xml.Unmarshal: got %s and %s", &out.Price, out.Tax)
+	}
+	if err := xml.Unmarshal([]byte(`<item><price>1.2.3</price></item>`), &out); err == nil {
+		t.Fatal("xml.Unmarshal: wanted an error")
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	var rate decimal.Big
+	fs.TextVar(&rate, "rate", decimal.New(5, 2), "interest rate")
+	if rate.String() != "0.05" {
+		t.Fatalf("flag default: wanted 0.05, got %s", &rate)
+	}
+	if err := fs.Parse([]string{"-rate", "0.0425"}); err != nil {
+		t.Fatal(err)
+	}
+	if rate.String() != "0.0425" {
+		t.Fatalf("flag: wanted 0.0425, got %s", &rate)
+	}
+}
@@ -0,0 +1,155 @@
+package decimal
+
+import (
+	"strings"
+	"sync"
+)
+
+// Tracer records the operations performed by a Context so that the steps
+// leading to a result can be explained later with RenderTrace. Each recorded
+// operation captures its operands, result, and the conditions it raised. The
+// zero value is ready to use, and a Tracer is safe for concurrent use. See
+// Context.SetTracer.
+//
+// Operations are recorded by result: the most recent operation whose result
+// was z is the one shown for z. Operands are copied before the operation is
+// performed, so z.Add(z, y) is recorded correctly.
+type Tracer struct {
+	mu    sync.Mutex
+	nodes map[*Big]*traceNode
+}
+
+// traceNode is a single recorded operation.
+type traceNode struct {
+	op     string       // function name or infix operator
+	infix  bool         // whether op is an infix operator
+	args   []*Big       // snapshots of the operands
+	inputs []*traceNode // operations that produced args, or nil
+	params string       // non-decimal parameters, e.g. the scale in Quantize
+	result *Big         // snapshot of the result
+	conds  Condition    // conditions raised by the operation
+}
+
+// SetTracer sets the Tracer that records the arithmetic operations performed
+// with c, or removes c's Tracer if t is nil. Decimals whose Context is a copy
+// of c share its Tracer.
+func (c *Context) SetTracer(t *Tracer) {
+	c.hooks = c.hooks.update(func(h *hooks) { h.tracer = t })
+}
+
+// Tracer returns c's Tracer, or nil if it has none. See SetTracer.
+func (c Context) Tracer() *Tracer {
+	if c.hooks == nil {
+		return nil
+	}
+	return c.hooks.tracer
+}
+
+// Reset discards all recorded operations.
+func (t *Tracer) Reset() {
+	t.mu.Lock()
+	t.nodes = nil
+	t.mu.Unlock()
+}
+
+// begin returns a node for op applied to args. It must be called before the
+// operation is performed.
+func (t *Tracer) begin(op string, infix bool, params string, args ...*Big) *traceNode {
+	n := &traceNode{
+		op:     op,
+		infix:  infix,
+		params: params,
+		args:   make([]*Big, len(args)),
+		inputs: make([]*traceNode, len(args)),
+	}
+	t.mu.Lock()
+	for i, x := range args {
+		n.args[i] = new(Big).Copy(x)
+		n.inputs[i] = t.nodes[x]
+	}
+	t.mu.Unlock()
+	return n
+}
+
+// end records n as the operation that produced z, raising conds.
+func (t *Tracer) end(n *traceNode, z *Big, conds Condition) {
+	n.result = new(Big).Copy(z)
+	n.conds = conds
+	t.mu.Lock()
+	if t.nodes == nil {
+		t.nodes = make(map[*Big]*traceNode)
+	}
+	t.nodes[z] = n
+	t.mu.Unlock()
+}
+
+// RenderTrace returns a human-readable explanation of how x was computed, using
+// the Tracer in x's Context. Each line shows a result and the operation that
+// produced it; the operations that produced its operands are indented below
+// it. For example:
+//
+//	12.47 = quantize(12.4680, 2, ToNearestEven) [inexact, rounded]
+//	  ← 12.4680 = 103.90 × 0.12
+//
+// If x's Context has no Tracer, or no operation was recorded for x,
+// RenderTrace returns x's string form.
+func RenderTrace(x *Big) string {
+	if x == nil {
+		return "<nil>"
+	}
+	t := x.Context.Tracer()
+	if t == nil {
+		return x.String()
+	}
+	t.mu.Lock()
+	n := t.nodes[x]
+	t.mu.Unlock()
+	if n == nil {
+		return x.String()
+	}
+	var b strings.Builder
+	n.render(&b, 0)
+	return b.String()
+}
+
+func (n *traceNode) render(b *strings.Builder, depth int) {
+	if depth > 0 {
+		b.WriteString(strings.Repeat("  ", depth))
+		b.WriteString("← ")
+	}
+	b.WriteString(n.result.String())
+	b.WriteString(" = ")
+	if n.infix {
+		for i, x := range n.args {
+			if i > 0 {
+				b.WriteString(" " + n.op + " ")
+			}
+			b.WriteString(x.String())
+		}
+	} else {
+		b.WriteString(n.op)
+		b.WriteByte('(')
+		for i, x := range n.args {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(x.String())
+		}
+		if n.params != "" {
+			b.WriteString(", ")
+			b.WriteString(n.params)
+		}
+		b.WriteByte(')')
+	}
+	if n.conds != 0 {
+		b.WriteString(" [")
+		b.WriteString(n.conds.String())
+		b.WriteByte(']')
+	}
+	b.WriteByte('\n')
+	for _, in := range n.inputs {
+		if in != nil {
+			in.render(b, depth+1)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package decimal_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestRenderTrace(t *testing.T) {
+	var ctx decimal.Context
+	ctx.SetTracer(new(decimal.Tracer))
+
+	price, _ := decimal.WithContext(ctx).SetString("103.90")
+	rate, _ := decimal.WithContext(ctx).SetString("0.12")
+	tax := decimal.WithContext(ctx).Mul(price, rate)
+	tax.Quantize(2)
+
+	const want = "12.47 = quantize(12.4680, 2, ToNearestEven) [inexact, rounded]\n" +
+		"  ← 12.4680 = 103.90 × 0.12\n"
+	if got := decimal.RenderTrace(tax); got != want {
+		t.Fatalf(`
+wanted: %q
+got   : %q
+`, want, got)
+	}
+
+	// Operands are captured before the receiver is overwritten.
+	total := decimal.WithContext(ctx).Add(price, tax)
+	total.Add(total, decimal.New(1, 0))
+	const want2 = "117.37 = 116.37 + 1\n" +
+		"  ← 116.37 = 103.90 + 12.47\n" +
+		"    ← 12.47 = quantize(12.4680, 2, ToNearestEven) [inexact, rounded]\n" +
+		"      ← 12.4680 = 103.90 × 0.12\n"
+	if got := decimal.RenderTrace(total); got != want2 {
+		t.Fatalf(`
+wanted: %q
+got   : %q
+`, want2, got)
+	}
+
+	ctx.Tracer().Reset()
+	if got := decimal.RenderTrace(total); got != "117.37" {
+		t.Fatalf("after Reset: wanted %q, got %q", "117.37", got)
+	}
+	if got := decimal.RenderTrace(decimal.New(5, 1)); got != "0.5" {
+		t.Fatalf("without Tracer: wanted %q, got %q", "0.5", got)
+	}
+}
+
+func TestRenderTrace_Round(t *testing.T) {
+	ctx := decimal.Context{Precision: 3}
+	ctx.SetTracer(new(decimal.Tracer))
+
+	x := decimal.WithContext(ctx)
+	x.Quo(decimal.New(2, 0), decimal.New(3, 0))
+	y := decimal.WithContext(ctx).Set(decimal.New(12345, 2))
+	z, r := decimal.WithContext(ctx).QuoRem(decimal.New(17, 0), decimal.New(5, 0), decimal.WithContext(ctx))
+
+	for i, test := range [...]struct {
+		x    *decimal.Big
+		want string
+	}{
+		{x, "0.667 = 2 ÷ 3 [inexact, rounded]\n"},
+		{y, "123 = round(123.45, 3 digits, ToNearestEven) [inexact, rounded]\n"},
+		{z, "3 = quoint(17, 5)\n"},
+		{r, "2 = rem(17, 5)\n"},
+	} {
+		if got := decimal.RenderTrace(test.x); got != test.want {
+			t.Fatalf("#%d: wanted %q, got %q", i, test.want, got)
+		}
+	}
+}
+
+func BenchmarkBig_Add_NoTracer(b *testing.B) {
+	b.ReportAllocs()
+	x, y := decimal.New(12345, 2), decimal.New(678, 1)
+	z := new(decimal.Big)
+	for i := 0; i < b.N; i++ {
+		z.Add(x, y)
+	}
+}
+
+func ExampleRenderTrace() {
+	var ctx decimal.Context
+	ctx.SetTracer(new(decimal.Tracer))
+
+	price, _ := decimal.WithContext(ctx).SetString("103.90")
+	rate, _ := decimal.WithContext(ctx).SetString("0.12")
+	tax := decimal.WithContext(ctx).Mul(price, rate)
+	tax.Quantize(2)
+	fmt.Print(decimal.RenderTrace(tax))
+	// Output:
+	// 12.47 = quantize(12.4680, 2, ToNearestEven) [inexact, rounded]
+	//   ← 12.4680 = 103.90 × 0.12
+}
@@ -0,0 +1,520 @@
+package decimal
+
+import (
+	"math/big"
+
+	"github.com/ericlagergren/decimal/internal/arith"
+	cst "github.com/ericlagergren/decimal/internal/c"
+)
+
+// trigFunc is one of the trigonometric functions.
+type trigFunc uint8
+
+const (
+	sinFunc trigFunc = iota
+	cosFunc
+	tanFunc
+)
+
+// Sin sets z to the sine of x, in radians, correctly rounded using c's
+// precision and RoundingMode, and returns z. Sin(±0) is ±0 and is exact;
+// otherwise, Inexact and Rounded are always raised.
+//
+// x is reduced modulo π/2 using as many digits of π as necessary. If
+// |x| >= 10**p, where p is c's precision, the digits of x that determine the
+// result are not significant, so z is set to NaN and InvalidOperation is
+// raised. Sin(±Inf) is also NaN and raises InvalidOperation, as are inexact
+// results if c.Precision is UnlimitedPrecision.
+func (c Context) Sin(z, x *Big) *Big {
+	defer c.adopt(z)
+	if c.hookedOp(z, x, nil) {
+		return c.hooked(z, hookOp{name: "sin", tagged: true}, func(c Context) *Big {
+			return c.Sin(z, x)
+		}, x)
+	}
+	if c.Traps != 0 && z != nil {
+		return c.trapped(z, "Sin", func(c Context) *Big {
+			return c.Sin(z, x)
+		}, x)
+	}
+	return c.trig(z, x, sinFunc)
+}
+
+// Cos sets z to the cosine of x, in radians, correctly rounded using c's
+// precision and RoundingMode, and returns z. Cos(±0) is exactly 1; otherwise,
+// Inexact and Rounded are always raised. Huge, infinite, and NaN values of x
+// are handled as by Sin.
+func (c Context) Cos(z, x *Big) *Big {
+	defer c.adopt(z)
+	if c.hookedOp(z, x, nil) {
+		return c.hooked(z, hookOp{name: "cos", tagged: true}, func(c Context) *Big {
+			return c.Cos(z, x)
+		}, x)
+	}
+	if c.Traps != 0 && z != nil {
+		return c.trapped(z, "Cos", func(c Context) *Big {
+			return c.Cos(z, x)
+		}, x)
+	}
+	return c.trig(z, x, cosFunc)
+}
+
+// Tan sets z to the tangent of x, in radians, correctly rounded using c's
+// precision and RoundingMode, and returns z. Tan(±0) is ±0 and is exact;
+// otherwise, Inexact and Rounded are always raised. Since x is never an odd
+// multiple of π/2, the result is always finite. Huge, infinite, and NaN values
+// of x are handled as by Sin.
+func (c Context) Tan(z, x *Big) *Big {
+	defer c.adopt(z)
+	if c.hookedOp(z, x, nil) {
+		return c.hooked(z, hookOp{name: "tan", tagged: true}, func(c Context) *Big {
+			return c.Tan(z, x)
+		}, x)
+	}
+	if c.Traps != 0 && z != nil {
+		return c.trapped(z, "Tan", func(c Context) *Big {
+			return c.Tan(z, x)
+		}, x)
+	}
+	return c.trig(z, x, tanFunc)
+}
+
+// trig is the implementation of Sin, Cos, and Tan.
+func (c Context) trig(z, x *Big, fn trigFunc) *Big {
+	op := [...]string{"Sin", "Cos", "Tan"}[fn]
+	if z.checkNil(op, x, x) {
+		return z
+	}
+	if z.invalidContext(c) || z.mixedModes(c, x) {
+		return z
+	}
+	if z.checkNaNs(x, x, trignan) {
+		return z
+	}
+	switch {
+	case x.IsInf(0):
+		return z.setNaN(InvalidOperation, qnan, triginf)
+	case x.compact == 0:
+		if fn == cosFunc {
+			return z.SetMantScale(1, 0)
+		}
+		return c.fix(z.setZero(x.form&signbit, x.exp))
+	}
+
+	prec := precision(c)
+	if prec == UnlimitedPrecision {
+		return z.setNaN(InvalidOperation, qnan, trigunlim)
+	}
+	adj := x.adjusted()
+	if adj >= prec {
+		return z.setNaN(InvalidOperation, qnan, trighuge)
+	}
+
+	if v, e, ok := trigTiny(x, prec, fn); ok {
+		// The result is within a rounding interval too narrow to contain a
+		// rounding boundary, so any value in it rounds the same way.
+		c.Round(z.SetBigMantScale(v, -e))
+		z.Context.Conditions |= Inexact | Rounded
+		return z
+	}
+
+	return c.ziv(z, func(wp int) (y, e *big.Int, f int) {
+		s, co, d, k, f := sinCosApprox(x, wp)
+		switch fn {
+		case sinFunc, cosFunc:
+			// cos(x) = sin(x + π/2), so cos advances one quadrant.
+			q := (k + int(fn)) & 3
+			if y, e = s, d; q&1 != 0 {
+				y = co
+			}
+			if q >= 2 {
+				y.Neg(y)
+			}
+		case tanFunc:
+			one := arith.BigPow10(uint64(f))
+			if k&1 == 0 {
+				y, e = quoApprox(s, co, d, one)
+			} else if y, e = quoApprox(co, s, d, one); y != nil {
+				y.Neg(y)
+			}
+		}
+		return y, e, f
+	})
+}
+
+// ziv sets z to a transcendental value correctly rounded using c, and returns
+// z. approx(wp) returns y, e, and f such that the value is within
+// e × 10**-f of y × 10**-f, using about wp digits of working precision, or a
+// nil y if wp digits are not enough to bound the value.
+//
+// ziv calls approx with increasing precision until both ends of the interval
+// round to the same result. Since the value is not a rounding boundary, this
+// always terminates. Inexact and Rounded are always raised.
+func (c Context) ziv(z *Big, approx func(wp int) (y, e *big.Int, f int)) *Big {
+	var lo, hi Big
+	for wp := precision(c) + 5; ; wp += wp / 2 {
+		y, e, f := approx(wp)
+		if y == nil {
+			continue
+		}
+		lo.Context.Conditions = 0
+		c.Round(lo.SetBigMantScale(new(big.Int).Sub(y, e), f))
+		c.Round(hi.SetBigMantScale(y.Add(y, e), f))
+		if lo.Cmp(&hi) == 0 {
+			break
+		}
+	}
+	z.Copy(&lo)
+	z.Context.Conditions |= lo.Context.Conditions | Inexact | Rounded
+	return z
+}
+
+// trigTiny returns v and e such that fn(x) rounds to prec digits as
+// v × 10**e does, and true, if x is close enough to zero for the first term of
+// its series, plus or minus a tiny amount, to determine the result.
+func trigTiny(x *Big, prec int, fn trigFunc) (v *big.Int, e int, ok bool) {
+	adj := x.adjusted()
+	if fn == cosFunc {
+		// cos(x) is in (1 - x**2/2, 1), which is within (1 - 10**-(prec+4),
+		// 1) if x**2/2 < 10**(2×adj+2) <= 10**-(prec+4).
+		if 2*int64(adj)+2 > -int64(prec+4) {
+			return nil, 0, false
+		}
+		v = new(big.Int).Set(arith.BigPow10(uint64(prec + 4)))
+		return v.Sub(v, cst.OneInt), -(prec + 4), true
+	}
+
+	// sin(x) is between x - x**3/6 and x, and tan(x) between x and
+	// x + x**3/2, so both are within 10**(3×(adj+1)) of x.
+	return tinyNudge(x, prec, 3, (fn == sinFunc) == x.Signbit())
+}
+
+// sinCosApprox approximates sin(r) and cos(r), where x = k×π/2 + r and
+// |r| <= π/4, for a finite, non-zero x using wp digits of working precision.
+// It returns s, c, d, k mod 4, and f such that sin(r) and cos(r) are within
+// d × 10**-f of s × 10**-f and c × 10**-f, respectively.
+func sinCosApprox(x *Big, wp int) (s, c, d *big.Int, k, f int) {
+	// Each step truncates to f fractional digits. r inherits an error of a
+	// few units from x and one unit from each multiple of π/2, and the Taylor
+	// series add one unit per term. Since sin and cos have slopes of at most
+	// one, the error in r carries over to s and c unamplified.
+	adj := x.adjusted()
+	if adj < 0 {
+		adj = -adj
+	}
+	f = wp + adj + arith.Length(uint64(wp)) + 5
+	one := arith.BigPow10(uint64(f))
+
+	r := scaledMod(x, x.exp, nil)
+	if sh := x.exp + f; sh >= 0 {
+		r.Mul(r, arith.BigPow10(uint64(sh)))
+	} else {
+		r.Quo(r, arith.BigPow10(uint64(-sh)))
+	}
+	pi := piFixed(f)
+	// n = round(x / (π/2)) = floor((4x + π) / 2π)
+	n := new(big.Int).Lsh(r, 2)
+	n.Add(n, pi)
+	n.Div(n, new(big.Int).Lsh(pi, 1))
+	t := new(big.Int).Mul(n, pi)
+	r.Sub(r, t.Quo(t, cst.TwoInt))
+	k = int(t.And(n, big.NewInt(3)).Int64())
+
+	r2 := new(big.Int).Mul(r, r)
+	r2.Quo(r2, one)
+	s = new(big.Int).Set(r)
+	c = new(big.Int).Set(one)
+	ts := new(big.Int).Set(r)
+	tc := new(big.Int).Set(one)
+	var q big.Int
+	i := int64(1)
+	for ; ts.Sign() != 0 || tc.Sign() != 0; i++ {
+		tc.Mul(tc, r2)
+		tc.Quo(tc, q.Mul(one, q.SetInt64((2*i-1)*(2*i))))
+		c.Sub(c, tc)
+		tc.Neg(tc)
+		ts.Mul(ts, r2)
+		ts.Quo(ts, q.Mul(one, q.SetInt64((2*i)*(2*i+1))))
+		s.Sub(s, ts)
+		ts.Neg(ts)
+	}
+	d = new(big.Int).Abs(n)
+	d.Mul(d, big.NewInt(3))
+	return s, c, d.Add(d, big.NewInt(2*i+20)), k, f
+}
+
+// quoApprox returns q and e such that the quotient of a and b, which are
+// within d of the true numerator and denominator, is within e of q, all in
+// fixed point with the unit one. It returns nil if |b| <= d.
+func quoApprox(a, b, d, one *big.Int) (q, e *big.Int) {
+	den := new(big.Int).Abs(b)
+	if den.Sub(den, d).Sign() <= 0 {
+		return nil, nil
+	}
+	q = new(big.Int).Mul(a, one)
+	q.Quo(q, b)
+	// |a/b - A/B| <= (d + |A/B|×d) / (|B| - d), plus one for truncating q.
+	e = new(big.Int).Abs(q)
+	e.Add(e, cst.OneInt)
+	e.Mul(e, d)
+	e.Add(e, new(big.Int).Mul(d, one))
+	e.Quo(e, den)
+	return q, e.Add(e, cst.TwoInt)
+}
+
+// Atan sets z to the arctangent of x, in radians, correctly rounded using c's
+// precision and RoundingMode, and returns z. The result is in [-π/2, π/2].
+// Atan(±0) is ±0 and is exact; otherwise, Inexact and Rounded are always
+// raised. Atan(±Inf) is ±π/2. If c.Precision is UnlimitedPrecision, inexact
+// results are NaN and raise InvalidOperation.
+func (c Context) Atan(z, x *Big) *Big {
+	defer c.adopt(z)
+	if c.hookedOp(z, x, nil) {
+		return c.hooked(z, hookOp{name: "atan", tagged: true}, func(c Context) *Big {
+			return c.Atan(z, x)
+		}, x)
+	}
+	if c.Traps != 0 && z != nil {
+		return c.trapped(z, "Atan", func(c Context) *Big {
+			return c.Atan(z, x)
+		}, x)
+	}
+	if z.checkNil("Atan", x, x) {
+		return z
+	}
+	if z.invalidContext(c) || z.mixedModes(c, x) {
+		return z
+	}
+	if z.checkNaNs(x, x, atannan) {
+		return z
+	}
+	switch {
+	case x.IsInf(0):
+		return c.piFrac(z, x.Signbit(), 1, 2)
+	case x.compact == 0:
+		return c.fix(z.setZero(x.form&signbit, x.exp))
+	}
+	return c.atan(z, x, New(1, 0))
+}
+
+// Atan2 sets z to the arctangent of y/x, in radians, using the signs of y and
+// x to determine the quadrant of the result, and returns z. The result is
+// correctly rounded using c's precision and RoundingMode, and is in [-π, π].
+//
+// Zeros and infinities are handled like math.Atan2:
+//
+//	Atan2(±0, x >= +0)      = ±0 (exact)
+//	Atan2(±0, x <= -0)      = ±π
+//	Atan2(y > 0, ±0)        = +π/2
+//	Atan2(y < 0, ±0)        = -π/2
+//	Atan2(±Inf, +Inf)       = ±π/4
+//	Atan2(±Inf, -Inf)       = ±3π/4
+//	Atan2(y, +Inf)          = ±0 (exact) for finite y
+//	Atan2(y, -Inf)          = ±π for finite y
+//	Atan2(±Inf, x)          = ±π/2 for finite x
+//
+// where the sign of each result is the sign of y. Otherwise, Inexact and
+// Rounded are always raised. If either operand is NaN, or if c.Precision is
+// UnlimitedPrecision and the result is inexact, the result is NaN.
+func (c Context) Atan2(z, y, x *Big) *Big {
+	defer c.adopt(z)
+	if c.hookedOp(z, y, x) {
+		return c.hooked(z, hookOp{name: "atan2", tagged: true}, func(c Context) *Big {
+			return c.Atan2(z, y, x)
+		}, y, x)
+	}
+	if c.Traps != 0 && z != nil {
+		return c.trapped(z, "Atan2", func(c Context) *Big {
+			return c.Atan2(z, y, x)
+		}, y, x)
+	}
+	if z.checkNil("Atan2", y, x) {
+		return z
+	}
+	if z.invalidContext(c) || z.mixedModes(c, y, x) {
+		return z
+	}
+	if z.checkNaNs(y, x, atannan) {
+		return z
+	}
+
+	neg := y.Signbit()
+	switch {
+	case y.IsInf(0):
+		switch {
+		case x.IsInf(+1):
+			return c.piFrac(z, neg, 1, 4)
+		case x.IsInf(-1):
+			return c.piFrac(z, neg, 3, 4)
+		}
+		return c.piFrac(z, neg, 1, 2)
+	case x.IsInf(+1):
+		return c.fix(z.setZero(y.form&signbit, 0))
+	case x.IsInf(-1):
+		return c.piFrac(z, neg, 1, 1)
+	case y.compact == 0:
+		if x.Signbit() {
+			return c.piFrac(z, neg, 1, 1)
+		}
+		return c.fix(z.setZero(y.form&signbit, y.exp))
+	case x.compact == 0:
+		return c.piFrac(z, neg, 1, 2)
+	}
+	return c.atan(z, y, x)
+}
+
+// atan sets z to the arctangent of y/x, where y and x are finite and non-zero,
+// in the quadrant determined by their signs, and returns z.
+func (c Context) atan(z, y, x *Big) *Big {
+	if precision(c) == UnlimitedPrecision {
+		return z.setNaN(InvalidOperation, qnan, atanunlim)
+	}
+	return c.ziv(z, func(wp int) (*big.Int, *big.Int, int) {
+		return atanApprox(y, x, wp)
+	})
+}
+
+// piFrac sets z to n×π/d, negated if neg is true, correctly rounded using c,
+// and returns z.
+func (c Context) piFrac(z *Big, neg bool, n, d int64) *Big {
+	if precision(c) == UnlimitedPrecision {
+		return z.setNaN(InvalidOperation, qnan, atanunlim)
+	}
+	return c.ziv(z, func(wp int) (y, e *big.Int, f int) {
+		f = wp + 3
+		y = piFixed(f)
+		y.Mul(y, big.NewInt(n))
+		y.Quo(y, big.NewInt(d))
+		if neg {
+			y.Neg(y)
+		}
+		return y, big.NewInt(n + 1), f
+	})
+}
+
+// atanApprox approximates the arctangent of y/x, where y and x are finite and
+// non-zero, in the quadrant determined by their signs, using wp digits of
+// working precision. It returns a, d, and f such that the arctangent is within
+// d × 10**-f of a × 10**-f.
+func atanApprox(y, x *Big, wp int) (a, d *big.Int, f int) {
+	// Let v = min(|y|, |x|) / max(|y|, |x|), so that the result is ±atan(v),
+	// ±(π/2 - atan(v)), ±(π/2 + atan(v)), or ±(π - atan(v)).
+	num, den := y, x
+	swap := y.CmpAbs(x) > 0
+	if swap {
+		num, den = x, y
+	}
+	// Only if the result is ±atan(v) can it be small, in which case f must
+	// grow with its magnitude, about 10**g, to keep wp significant digits.
+	f = wp + arith.Length(uint64(wp)) + 5
+	if g := int64(num.adjusted()) - int64(den.adjusted()); !swap && !x.Signbit() && g < 0 {
+		f -= int(g)
+	}
+	v, exact := fixedQuo(num, den, f)
+
+	// If v**3/3 is less than one unit divided by den's coefficient, which is
+	// less than the fractional part of the quotient if it has one, the
+	// series for atan(v) only needs its first term.
+	tiny := 3*int64(arith.BigLength(v))+int64(den.Precision()) <= 2*int64(f)-1
+	switch {
+	case tiny && (swap || x.Signbit()):
+		a, d = v, big.NewInt(2)
+	case tiny:
+		// The result itself is tiny. It is in (v - 1, v) if the quotient is
+		// exact and (v, v + 1) otherwise, so approximate it with the middle of
+		// that interval lest it straddle a rounding boundary at v forever.
+		a = v.Mul(v, cst.TenInt)
+		if exact {
+			a.Sub(a, cst.FiveInt)
+		} else {
+			a.Add(a, cst.FiveInt)
+		}
+		if y.Signbit() {
+			a.Neg(a)
+		}
+		return a, big.NewInt(4), f + 1
+	default:
+		a, d = atanFixed(v, arith.BigPow10(uint64(f)))
+	}
+
+	if swap || x.Signbit() {
+		pi := piFixed(f)
+		if swap {
+			// atan(1/v) = π/2 - atan(v)
+			a.Sub(new(big.Int).Rsh(pi, 1), a)
+		}
+		if x.Signbit() {
+			// The result is in the second or third quadrant.
+			a.Sub(pi, a)
+		}
+		d.Add(d, cst.TwoInt)
+	}
+	if y.Signbit() {
+		a.Neg(a)
+	}
+	return a, d, f
+}
+
+// atanFixed returns atan(v/one) × one, truncated, where 0 <= v <= one, and a
+// bound on its error in units.
+func atanFixed(v, one *big.Int) (a, d *big.Int) {
+	// Halve the argument until it is at most 1/10 using
+	//
+	//	atan(v) = 2×atan(v / (1 + sqrt(1 + v**2)))
+	//
+	// Each step contributes a few units of error, which do not grow in later
+	// steps since they at most halve the argument.
+	v = new(big.Int).Set(v)
+	tenth := new(big.Int).Quo(one, cst.TenInt)
+	var k uint
+	var t big.Int
+	for ; v.Cmp(tenth) > 0; k++ {
+		t.Mul(v, v)
+		t.Add(&t, new(big.Int).Mul(one, one))
+		t.Sqrt(&t)
+		t.Add(&t, one)
+		v.Mul(v, one)
+		v.Quo(v, &t)
+	}
+
+	// atan(v) = Σ (-1)**i × v**(2i+1) / (2i+1)
+	v2 := new(big.Int).Mul(v, v)
+	v2.Quo(v2, one)
+	a = new(big.Int)
+	p := new(big.Int).Set(v)
+	var q big.Int
+	n := int64(0)
+	for i := int64(1); p.Sign() != 0; i += 2 {
+		if t.Quo(p, q.SetInt64(i)); i&2 != 0 {
+			t.Neg(&t)
+		}
+		a.Add(a, &t)
+		p.Mul(p, v2)
+		p.Quo(p, one)
+		n++
+	}
+	a.Lsh(a, k)
+	d = big.NewInt(n + 3*int64(k) + 3)
+	return a, d.Lsh(d, k)
+}
+
+// fixedQuo returns |num| / |den| × 10**f, truncated, where num and den are
+// finite and non-zero and |num| <= |den|, and whether it is exact.
+func fixedQuo(num, den *Big, f int) (*big.Int, bool) {
+	n := scaledMod(num, num.exp, nil)
+	n.Abs(n)
+	m := scaledMod(den, den.exp, nil)
+	m.Abs(m)
+	sh := int64(num.exp) - int64(den.exp) + int64(f)
+	if sh < 0 {
+		if -sh > int64(num.Precision())+1 {
+			// |num| / |den| × 10**f < 10**(num.Precision() + sh) < 1
+			return n.SetUint64(0), false
+		}
+		m.Mul(m, arith.BigPow10(uint64(-sh)))
+	} else {
+		n.Mul(n, arith.BigPow10(uint64(sh)))
+	}
+	n.QuoRem(n, m, m)
+	return n, m.Sign() == 0
+}
@@ -25,32 +25,33 @@ func (c Context) fix(z *Big) *Big {
 	adj := z.adjusted()
 
 	if adj > c.maxScale() {
-		prec := precision(c)
-
 		if z.compact == 0 {
-			z.exp = c.maxScale()
+			z.exp = c.etop()
 			z.Context.Conditions |= Clamped
 			return z
 		}
 
-		switch m := c.RoundingMode; m {
-		case ToNearestAway, ToNearestEven:
+		// Rounding toward zero overflows to the largest finite magnitude, and
+		// every other rounding mode to the infinity with z's sign.
+		switch m := c.roundingMode(); {
+		case c.Compat() < 2:
+			c.overflow1(z)
+		case m == ToZero,
+			m == ToPositiveInf && z.Signbit(),
+			m == ToNegativeInf && !z.Signbit():
+			c.setMaxFinite(z)
+		default:
 			z.SetInf(z.Signbit())
-		case AwayFromZero:
-			// OK
-		case ToZero:
-			z.exp = c.maxScale() - prec + 1
-		case ToPositiveInf, ToNegativeInf:
-			if m == ToPositiveInf == z.Signbit() {
-				z.exp = c.maxScale() - prec + 1
-			} else {
-				z.SetInf(false)
-			}
 		}
 		z.Context.Conditions |= Overflow | Inexact | Rounded
 		return z
 	}
 
+	if top := c.etop(); z.exp > top {
+		c.foldDown(z, top)
+		return z
+	}
+
 	if adj < c.minScale() {
 		tiny := c.etiny()
 
@@ -64,19 +65,134 @@ func (c Context) fix(z *Big) *Big {
 
 		z.Context.Conditions |= Subnormal
 		if z.exp < tiny {
-			if c.shiftr(z, uint64(tiny-z.exp)) {
-				z.compact = 1
-			}
-			z.Context.Conditions |= Underflow
-			z.exp = tiny
-			if z.compact == 0 {
-				z.Context.Conditions |= Clamped
+			if c.Compat() < 2 {
+				c.subnormal1(z, tiny)
+			} else {
+				c.roundSubnormal(z, tiny)
 			}
 		}
 	}
 	return z
 }
 
+// etop returns the largest exponent of a finite result in c: MaxScale -
+// (Precision - 1) if c.Clamp is set and c's precision is limited, and
+// otherwise MaxScale.
+func (c Context) etop() int {
+	if p := precision(c); c.Clamp && p != UnlimitedPrecision {
+		return c.maxScale() - p + 1
+	}
+	return c.maxScale()
+}
+
+// foldDown pads the coefficient of z, which does not overflow, with zeros to
+// reduce its exponent to top and raises Clamped.
+func (c Context) foldDown(z *Big, top int) {
+	shift := uint64(z.exp - top)
+	z.exp = top
+	z.Context.Conditions |= Clamped
+	if z.compact == 0 {
+		return
+	}
+	if z.isCompact() {
+		if zc, ok := checked.MulPow10(z.compact, shift); ok {
+			z.setTriple(zc, z.form&signbit, top)
+			return
+		}
+		z.unscaled.SetUint64(z.compact)
+	}
+	checked.MulBigPow10(&z.unscaled, &z.unscaled, shift)
+	z.norm()
+}
+
+// roundSubnormal rounds the subnormal z to the exponent tiny, which is greater
+// than z's. Rounded is raised, as are Inexact and Underflow if any non-zero
+// digits were discarded, and Clamped if z rounds to zero.
+func (c Context) roundSubnormal(z *Big, tiny int) {
+	// Only whether the discarded digits are zero, less than half, half, or more
+	// than half matters, so a z of less than a tenth of 10**tiny may be
+	// replaced by any other, which bounds the shift.
+	shift := tiny - z.exp
+	if zp := z.Precision(); shift > zp+1 {
+		shift = zp + 1
+	}
+	conds := z.Context.Conditions
+	z.Context.Conditions &^= Inexact
+	z.exp = tiny
+
+	m := c.roundingMode()
+	neg := z.form & signbit
+	if y, ok := arith.Pow10(uint64(shift)); ok && z.isCompact() {
+		z.quo(m, z.compact, neg, y, 0)
+	} else {
+		if z.isCompact() {
+			z.unscaled.SetUint64(z.compact)
+		}
+		var r big.Int
+		z.quoBig(m, &z.unscaled, neg, arith.BigPow10(uint64(shift)), 0, &r)
+	}
+	c.quantCarry(z, tiny)
+
+	if z.Context.Conditions&Inexact != 0 {
+		z.Context.Conditions |= Underflow
+	}
+	z.Context.Conditions |= conds | Rounded
+	if z.compact == 0 {
+		z.Context.Conditions |= Clamped
+	}
+}
+
+// overflow1 is the compatibility level 1 behavior of fix for a z that
+// overflows, which ignores z's sign when rounding toward negative infinity and
+// keeps z's digits when rounding away from or toward zero.
+func (c Context) overflow1(z *Big) {
+	switch m := c.roundingMode(); m {
+	case ToNearestAway, ToNearestEven:
+		z.SetInf(z.Signbit())
+	case AwayFromZero:
+		// OK
+	case ToZero:
+		z.exp = c.maxScale() - precision(c) + 1
+	case ToPositiveInf, ToNegativeInf:
+		if m == ToPositiveInf == z.Signbit() {
+			z.exp = c.maxScale() - precision(c) + 1
+		} else {
+			z.SetInf(false)
+		}
+	}
+}
+
+// subnormal1 is the compatibility level 1 behavior of roundSubnormal.
+func (c Context) subnormal1(z *Big, tiny int) {
+	if c.shiftr(z, uint64(tiny-z.exp)) {
+		z.compact = 1
+	}
+	z.Context.Conditions |= Underflow
+	z.exp = tiny
+	if z.compact == 0 {
+		z.Context.Conditions |= Clamped
+	}
+}
+
+// setMaxFinite sets z to the largest finite magnitude representable by c with
+// z's sign: p nines times 10**(Emax-p+1), where p is c's precision, or z's
+// precision if c's precision is unlimited.
+func (c Context) setMaxFinite(z *Big) *Big {
+	p := precision(c)
+	if p == UnlimitedPrecision {
+		p = z.Precision()
+	}
+	sign := z.form & signbit
+	exp := c.maxScale() - p + 1
+	if v, ok := arith.Pow10(uint64(p)); ok {
+		return z.setTriple(v-1, sign, exp)
+	}
+	z.unscaled.Sub(arith.BigPow10(uint64(p)), cst.OneInt)
+	z.form = finite | sign
+	z.exp = exp
+	return z.norm()
+}
+
 // alias returns z if z != x, otherwise a newly-allocated big.Int.
 func alias(z, x *big.Int) *big.Int {
 	if z != x {
@@ -90,24 +206,75 @@ func alias(z, x *big.Int) *big.Int {
 	return new(big.Int)
 }
 
+// invalidContext reports whether c is invalid, in which case z is set to NaN
+// and InvalidContext is raised. In Go mode, it panics with an
+// ErrInvalidSetting instead.
 func (z *Big) invalidContext(c Context) bool {
+	p := c.invalid()
+	if p == 0 {
+		return z.Context.hooks != nil && z.simulate()
+	}
+	if z.Context.OperatingMode == Go {
+		panic(c.Validate())
+	}
+	z.setNaN(InvalidContext, qnan, p)
+	return true
+}
+
+// invalid returns the payload describing the first invalid setting of c, or
+// zero if c is valid.
+func (c Context) invalid() Payload {
 	switch {
 	case c.Precision < 0:
-		z.setNaN(InvalidContext, qnan, invctxpltz)
+		return invctxpltz
 	case c.Precision > UnlimitedPrecision:
-		z.setNaN(InvalidContext, qnan, invctxpgtu)
+		return invctxpgtu
+	case c.Precision > MaxPracticalPrecision && c.Precision != UnlimitedPrecision:
+		return invctxpgtp
 	case c.RoundingMode >= unnecessary:
-		z.setNaN(InvalidContext, qnan, invctxrmode)
-	case c.OperatingMode > Go:
-		z.setNaN(InvalidContext, qnan, invctxomode)
+		return invctxrmode
+	case c.OperatingMode > JS:
+		return invctxomode
 	case c.MaxScale > MaxScale:
-		z.setNaN(InvalidContext, qnan, invctxsgtu)
+		return invctxsgtu
+	case c.MaxScale < 0:
+		return invctxsltz
 	case c.MinScale < MinScale:
-		z.setNaN(InvalidContext, qnan, invctxsltu)
+		return invctxsltu
+	case c.MinScale > 0:
+		return invctxsgtz
+	case c.CompatLevel < 0 || c.CompatLevel > LatestCompatLevel:
+		return invctxcompat
+	case c.GuardDigits < 0:
+		return invctxguard
 	default:
+		return 0
+	}
+}
+
+// mixedModes reports whether CheckModeMix is set and the OperatingMode of any
+// of xs, other than z itself, differs from c's. If so, z is set to NaN and
+// InvalidContext is raised.
+func (z *Big) mixedModes(c Context, xs ...*Big) bool {
+	if !CheckModeMix {
 		return false
 	}
-	return true
+	for _, x := range xs {
+		if x != nil && x != z && x.Context.OperatingMode != c.OperatingMode {
+			z.setNaN(InvalidContext, qnan, modemix)
+			return true
+		}
+	}
+	return false
+}
+
+// mustNotMix panics with an ErrModeMix if CheckModeMix is set and x and y have
+// different OperatingModes.
+func mustNotMix(op string, x, y *Big) {
+	if CheckModeMix && x != nil && y != nil &&
+		x.Context.OperatingMode != y.Context.OperatingMode {
+		panic(ErrModeMix{Op: op})
+	}
 }
 
 func precision(c Context) (p int) {
@@ -133,7 +300,7 @@ func copybits(x []big.Word) []big.Word {
 }
 
 // cmpNorm compares x and y in the range [0.1, 0.999...] and returns true if x
-// > y.
+// >= y.
 func cmpNorm(x uint64, xs int, y uint64, ys int) (ok bool) {
 	goodx, goody := true, true
 
@@ -147,7 +314,7 @@ func cmpNorm(x uint64, xs int, y uint64, ys int) (ok bool) {
 	}
 	if goodx {
 		if goody {
-			return arith.Cmp(x, y) > 0
+			return arith.Cmp(x, y) >= 0
 		}
 		return false
 	}
@@ -155,7 +322,7 @@ func cmpNorm(x uint64, xs int, y uint64, ys int) (ok bool) {
 }
 
 // cmpNormBig compares x and y in the range [0.1, 0.999...] and returns true if
-// x > y. It uses z as backing storage, provided it does not alias x or y.
+// x >= y. It uses z as backing storage, provided it does not alias x or y.
 func cmpNormBig(z, x *big.Int, xs int, y *big.Int, ys int) (ok bool) {
 	if xs != ys {
 		z = alias(alias(z, x), y)
@@ -166,7 +333,7 @@ func cmpNormBig(z, x *big.Int, xs int, y *big.Int, ys int) (ok bool) {
 		}
 	}
 	// x and y are non-negative
-	return x.Cmp(y) > 0
+	return x.Cmp(y) >= 0
 }
 
 // scalex adjusts x by scale. If scale > 0, x = x * 10^scale, otherwise
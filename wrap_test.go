@@ -0,0 +1,181 @@
+package decimal_test
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestBig_Wrap(t *testing.T) {
+	for i, test := range [...]struct {
+		x, lo, hi string
+		want      string
+	}{
+		{"370", "0", "360", "10"},
+		{"-1", "0", "24", "23"},
+		{"-360", "0", "360", "0"},
+		{"360", "0", "360", "0"},
+		{"0", "0", "360", "0"},
+		{"720.5", "0", "360", "0.5"},
+		{"-720.25", "0", "360", "359.75"},
+		{"190", "-180", "180", "-170"},
+		{"-180", "-180", "180", "-180"},
+		{"180", "-180", "180", "-180"},
+		{"1E+30", "0", "360", "280"},
+		{"-1E+30", "0", "360", "80"},
+		{"1E+999999", "0", "7", "6"},
+		{"12345678901234567890123.5", "0.5", "7.25", "4.25"},
+		{"0.1", "0.3", "0.7", "0.5"},
+		{"3.14159", "-3.14159", "3.14159", "-3.14159"},
+		{"1.00000000000000000001", "0", "1", "1E-20"},
+		{"25", "1", "13", "1"},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		lo, _ := new(decimal.Big).SetString(test.lo)
+		hi, _ := new(decimal.Big).SetString(test.hi)
+		want, _ := new(decimal.Big).SetString(test.want)
+		z := decimal.WithContext(decimal.Context128).Wrap(x, lo, hi)
+		if z.Cmp(want) != 0 || z.Context.Conditions&decimal.Inexact != 0 {
+			t.Fatalf("#%d: Wrap(%s, %s, %s): wanted %s, got %s (%s)",
+				i, test.x, test.lo, test.hi, test.want, z, z.Context.Conditions)
+		}
+	}
+}
+
+func TestBig_Wrap_Invalid(t *testing.T) {
+	for i, test := range [...]struct {
+		x, lo, hi string
+	}{
+		{"1", "5", "5"},
+		{"1", "5", "4"},
+		{"1", "-Infinity", "5"},
+		{"1", "0", "Infinity"},
+		{"Infinity", "0", "5"},
+		{"1", "sNaN", "5"},
+	} {
+		x, _ := new(decimal.Big).SetString(test.x)
+		lo, _ := new(decimal.Big).SetString(test.lo)
+		hi, _ := new(decimal.Big).SetString(test.hi)
+		z := new(decimal.Big).Wrap(x, lo, hi)
+		if !z.IsNaN(0) || z.Context.Conditions != decimal.InvalidOperation {
+			t.Fatalf("#%d: Wrap(%s, %s, %s): wanted NaN (invalid operation), got %s (%s)",
+				i, test.x, test.lo, test.hi, z, z.Context.Conditions)
+		}
+	}
+	z := new(decimal.Big).Wrap(new(decimal.Big).SetNaN(false), decimal.New(0, 0), decimal.New(1, 0))
+	if !z.IsNaN(0) || z.Context.Conditions != 0 {
+		t.Fatalf("Wrap(NaN, 0, 1): wanted NaN, got %s (%s)", z, z.Context.Conditions)
+	}
+}
+
+// TestBig_Wrap_Naive shows the double rounding of wrapping with Rem and Add.
+func TestBig_Wrap_Naive(t *testing.T) {
+	ctx := decimal.Context{Precision: 5}
+	x, lo, hi := decimal.New(-1, 9), decimal.New(0, 0), decimal.New(360, 0)
+
+	naive := ctx.Rem(new(decimal.Big), x, hi)
+	ctx.Add(naive, naive, hi)
+	if naive.Cmp(hi) != 0 {
+		t.Fatalf("Rem then Add: wanted 360 (out of range), got %s", naive)
+	}
+	if z := decimal.WithContext(ctx).Wrap(x, lo, hi); z.Sign() != 0 {
+		t.Fatalf("Wrap(%s, %s, %s): wanted 0, got %s", x, lo, hi, z)
+	}
+}
+
+// oracleWrap returns x wrapped into [lo, hi) and rounded using ctx, computed
+// with big.Rat.
+func oracleWrap(x, lo, hi *decimal.Big, ctx decimal.Context) *big.Rat {
+	xr, lr, hr := x.Rat(nil), lo.Rat(nil), hi.Rat(nil)
+	w := new(big.Rat).Sub(hr, lr)
+	q := new(big.Rat).Quo(new(big.Rat).Sub(xr, lr), w)
+	n := new(big.Int).Div(q.Num(), q.Denom()) // floor
+	exact := new(big.Rat).Sub(xr, new(big.Rat).Mul(w, new(big.Rat).SetInt(n)))
+	r, _ := oracleRound(exact, -1000, ctx)
+	if r.Cmp(hr) >= 0 {
+		r, _ = oracleRound(lr, -1000, ctx)
+	}
+	return r
+}
+
+// TestBig_Wrap_Boundaries checks values within an ulp of each boundary and
+// values many widths from the range, under every rounding mode.
+func TestBig_Wrap_Boundaries(t *testing.T) {
+	ranges := [...][2]string{
+		{"0", "360"}, {"-180", "180"}, {"0", "24"}, {"0.5", "7.25"}, {"-1", "0"},
+		{"0", "0.001"}, {"12.34", "98.765"},
+	}
+	offsets := [...]string{
+		"0", "1E-9", "-1E-9", "1E-20", "-1E-20", "5E-7", "-5E-7", "0.00049",
+	}
+	widths := [...]string{"0", "1", "-1", "3", "-7", "1E+6", "-1E+15", "123456789012345678901234567890"}
+	ctx := decimal.ContextUnlimited
+	for _, rg := range ranges {
+		lo, _ := new(decimal.Big).SetString(rg[0])
+		hi, _ := new(decimal.Big).SetString(rg[1])
+		w := ctx.Sub(new(decimal.Big), hi, lo)
+		for _, b := range [...]*decimal.Big{lo, hi} {
+			for _, off := range offsets {
+				for _, k := range widths {
+					d, _ := new(decimal.Big).SetString(off)
+					n, _ := new(decimal.Big).SetString(k)
+					x := ctx.Add(new(decimal.Big), b, d)
+					x = ctx.Add(x, x, ctx.Mul(new(decimal.Big), n, w))
+					for m := decimal.ToNearestEven; m <= decimal.ToPositiveInf; m++ {
+						c := decimal.Context{Precision: 5, RoundingMode: m}
+						checkWrap(t, x, lo, hi, c)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestBig_Wrap_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	n := 20000
+	if testing.Short() {
+		n = 2000
+	}
+	for i := 0; i < n; i++ {
+		var a, b, c big.Int
+		a.Rand(rng, big.NewInt(1e12))
+		b.Rand(rng, big.NewInt(1e12))
+		c.Rand(rng, new(big.Int).Exp(big.NewInt(10), big.NewInt(40), nil))
+		if rng.Intn(2) == 0 {
+			a.Neg(&a)
+		}
+		if rng.Intn(2) == 0 {
+			c.Neg(&c)
+		}
+		lo := new(decimal.Big).SetBigMantScale(&a, rng.Intn(6))
+		hi := decimal.ContextUnlimited.Add(new(decimal.Big), lo,
+			new(decimal.Big).SetBigMantScale(b.Add(&b, big.NewInt(1)), rng.Intn(6)))
+		x := new(decimal.Big).SetBigMantScale(&c, rng.Intn(30)-10)
+		ctx := decimal.Context{
+			Precision:    1 + rng.Intn(20),
+			RoundingMode: decimal.RoundingMode(rng.Intn(int(decimal.ToPositiveInf) + 1)),
+		}
+		checkWrap(t, x, lo, hi, ctx)
+	}
+}
+
+func checkWrap(t *testing.T, x, lo, hi *decimal.Big, ctx decimal.Context) {
+	t.Helper()
+	want := oracleWrap(x, lo, hi, ctx)
+	z := decimal.WithContext(ctx).Wrap(x, lo, hi)
+	if z.Rat(nil).Cmp(want) != 0 {
+		t.Fatalf("Wrap(%s, %s, %s) (%d digits, %s): wanted %s, got %s (%s)",
+			x, lo, hi, ctx.Precision, ctx.RoundingMode, new(decimal.Big).SetRat(want), z, z.Context.Conditions)
+	}
+	// If lo and hi have at most ctx.Precision digits, the result is in the
+	// range.
+	exact := decimal.WithContext(ctx).Set(lo).Cmp(lo) == 0 &&
+		decimal.WithContext(ctx).Set(hi).Cmp(hi) == 0
+	if exact && (z.Cmp(lo) < 0 || z.Cmp(hi) >= 0) {
+		t.Fatalf("Wrap(%s, %s, %s) (%d digits, %s): %s is not in the range",
+			x, lo, hi, ctx.Precision, ctx.RoundingMode, z)
+	}
+}